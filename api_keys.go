@@ -0,0 +1,415 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	restProxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// HeaderAPIKey is the HTTP header field name that is used to send an
+	// API key.
+	HeaderAPIKey = "X-Api-Key"
+
+	// apiKeyMetadataKey is the gRPC metadata key of the API key.
+	apiKeyMetadataKey = "x-api-key"
+
+	// apiKeySecretLen is the length of the secret part of an API key.
+	apiKeySecretLen = 32
+)
+
+var (
+	// errInvalidAPIKey is returned if a request carries an API key that
+	// doesn't exist or was revoked.
+	errInvalidAPIKey = errors.New("invalid API key")
+)
+
+// apiKeyStore keeps the API keys in memory, so they can be checked without a
+// database lookup on every request, and keeps them in sync with the session
+// store.
+type apiKeyStore struct {
+	db session.Store
+
+	keys map[session.APIKeyID]*session.APIKey
+
+	// macaroons are the super macaroons that were baked for the keys.
+	macaroons map[session.APIKeyID]string
+
+	mu sync.RWMutex
+}
+
+// newAPIKeyStore creates a new, empty API key store.
+func newAPIKeyStore() *apiKeyStore {
+	return &apiKeyStore{
+		keys:      make(map[session.APIKeyID]*session.APIKey),
+		macaroons: make(map[session.APIKeyID]string),
+	}
+}
+
+// load reads all API keys from the given session store.
+func (s *apiKeyStore) load(db session.Store) error {
+	keys, err := db.ListAPIKeys()
+	if err != nil {
+		return fmt.Errorf("unable to load API keys: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.db = db
+	for _, key := range keys {
+		s.keys[key.ID] = key
+	}
+
+	return nil
+}
+
+// create stores a new API key with the given label and scope and returns the
+// key together with the token that must be sent with requests.
+func (s *apiKeyStore) create(label string, readOnly bool,
+	perms []bakery.Op) (*session.APIKey, string, error) {
+
+	var token [len(session.APIKeyID{}) + apiKeySecretLen]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return nil, "", err
+	}
+
+	key := &session.APIKey{
+		Label:       label,
+		SecretHash:  sha256.Sum256(token[len(session.APIKeyID{}):]),
+		CreatedAt:   time.Now(),
+		ReadOnly:    readOnly,
+		Permissions: perms,
+	}
+	copy(key.ID[:], token[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.AddAPIKey(key); err != nil {
+		return nil, "", err
+	}
+	s.keys[key.ID] = key
+
+	return key, hex.EncodeToString(token[:]), nil
+}
+
+// list returns all API keys.
+func (s *apiKeyStore) list() []*session.APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]*session.APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// revoke removes the API key with the given ID. The key can't be used anymore
+// once this returns.
+func (s *apiKeyStore) revoke(id session.APIKeyID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.DeleteAPIKey(id); err != nil {
+		return err
+	}
+
+	delete(s.keys, id)
+	delete(s.macaroons, id)
+
+	return nil
+}
+
+// verify returns the API key that the given token belongs to.
+// errInvalidAPIKey is returned if the token doesn't belong to any key.
+func (s *apiKeyStore) verify(token string) (*session.APIKey, error) {
+	tokenBytes, err := hex.DecodeString(token)
+	if err != nil ||
+		len(tokenBytes) != len(session.APIKeyID{})+apiKeySecretLen {
+
+		return nil, errInvalidAPIKey
+	}
+
+	var id session.APIKeyID
+	copy(id[:], tokenBytes)
+
+	s.mu.RLock()
+	key, ok := s.keys[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errInvalidAPIKey
+	}
+
+	hash := sha256.Sum256(tokenBytes[len(id):])
+	if subtle.ConstantTimeCompare(hash[:], key.SecretHash[:]) != 1 {
+		return nil, errInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+// resetMacaroons forgets the macaroons that were baked for the keys, so they
+// are baked again with the current root key.
+func (s *apiKeyStore) resetMacaroons() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.macaroons = make(map[session.APIKeyID]string)
+}
+
+// apiKeyMacaroon returns the hex encoded super macaroon that grants the
+// permissions of the given API key.
+func (p *rpcProxy) apiKeyMacaroon(ctx context.Context,
+	key *session.APIKey) (string, error) {
+
+	p.apiKeys.mu.Lock()
+	defer p.apiKeys.mu.Unlock()
+
+	// A key that was revoked while the request was checked must not get
+	// a new macaroon.
+	if _, ok := p.apiKeys.keys[key.ID]; !ok {
+		return "", errInvalidAPIKey
+	}
+
+	if mac, ok := p.apiKeys.macaroons[key.ID]; ok {
+		return mac, nil
+	}
+
+	var perms []bakery.Op
+	switch {
+	case key.ReadOnly:
+		perms = p.permsMgr.ActivePermissions(true)
+
+	case len(key.Permissions) > 0:
+		perms = key.Permissions
+
+	default:
+		perms = p.permsMgr.ActivePermissions(false)
+	}
+
+	mac, err := p.bakeSuperMac(ctx, 0, perms, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to bake API key macaroon: %v",
+			err)
+	}
+	p.apiKeys.macaroons[key.ID] = mac
+
+	return mac, nil
+}
+
+// requestAPIKey returns the API key that the request in the given context
+// carries. errNoCredential is returned if there is none.
+func (p *rpcProxy) requestAPIKey(ctx context.Context) (*session.APIKey,
+	error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errNoCredential
+	}
+
+	tokens := md.Get(apiKeyMetadataKey)
+	if len(tokens) == 0 {
+		return nil, errNoCredential
+	}
+
+	return p.apiKeys.verify(tokens[0])
+}
+
+// apiKeyAuthBackend authenticates requests with an API key.
+type apiKeyAuthBackend struct {
+	p *rpcProxy
+}
+
+// name returns the name of the backend.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *apiKeyAuthBackend) name() string {
+	return AuthBackendAPIKey
+}
+
+// authenticate checks the API key of the request and, if it is valid,
+// attaches the super macaroon that grants the key's permissions.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *apiKeyAuthBackend) authenticate(ctx context.Context,
+	requestURI string, requiredPermissions []bakery.Op) (context.Context,
+	error) {
+
+	if !b.p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	key, err := b.p.requestAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := b.p.apiKeyMacaroon(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(HeaderMacaroon, mac)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	err = b.p.macValidator.ValidateMacaroon(
+		ctx, requiredPermissions, requestURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// restHeaderMatcher forwards the API key, request ID and trace context headers
+// of REST requests to the gRPC server, in addition to the headers that are
+// forwarded by default.
+func restHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case HeaderAPIKey:
+		return apiKeyMetadataKey, true
+
+	case restHeaderRequestID:
+		return HeaderRequestID, true
+
+	// The W3C trace context of a REST request is passed on, so the span
+	// of the request continues the client's trace.
+	case "Traceparent", "Tracestate":
+		return strings.ToLower(key), true
+	}
+
+	return restProxy.DefaultHeaderMatcher(key)
+}
+
+// marshalAPIKey converts an API key into its RPC representation.
+func marshalAPIKey(key *session.APIKey) *litrpc.ApiKey {
+	perms := make([]*litrpc.MacaroonPermission, len(key.Permissions))
+	for i, op := range key.Permissions {
+		perms[i] = &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		}
+	}
+
+	return &litrpc.ApiKey{
+		Id:          hex.EncodeToString(key.ID[:]),
+		Label:       key.Label,
+		CreatedAt:   uint64(key.CreatedAt.Unix()),
+		ReadOnly:    key.ReadOnly,
+		Permissions: perms,
+	}
+}
+
+// CreateApiKey creates a new API key that grants the given permissions.
+//
+// NOTE: this is part of the litrpc.ApiKeysServer interface.
+func (p *rpcProxy) CreateApiKey(_ context.Context,
+	req *litrpc.CreateApiKeyRequest) (*litrpc.CreateApiKeyResponse,
+	error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	// Only explicitly requested permissions are stored, the permissions
+	// of the other scopes are determined when the key is used, so they
+	// include the daemons that are active at that time.
+	var perms []bakery.Op
+	if len(req.Permissions) > 0 {
+		var err error
+		perms, err = p.superMacaroonPermissions(
+			req.ReadOnly, req.Permissions,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, token, err := p.apiKeys.create(req.Label, req.ReadOnly, perms)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Created API key %x (label=%q)", key.ID[:], key.Label)
+
+	return &litrpc.CreateApiKeyResponse{
+		ApiKey: token,
+		Key:    marshalAPIKey(key),
+	}, nil
+}
+
+// ListApiKeys returns all API keys that were created and not yet revoked.
+//
+// NOTE: this is part of the litrpc.ApiKeysServer interface.
+func (p *rpcProxy) ListApiKeys(_ context.Context,
+	_ *litrpc.ListApiKeysRequest) (*litrpc.ListApiKeysResponse, error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	keys := p.apiKeys.list()
+	resp := &litrpc.ListApiKeysResponse{
+		Keys: make([]*litrpc.ApiKey, len(keys)),
+	}
+	for i, key := range keys {
+		resp.Keys[i] = marshalAPIKey(key)
+	}
+
+	return resp, nil
+}
+
+// RevokeApiKey revokes the API key with the given ID.
+//
+// NOTE: this is part of the litrpc.ApiKeysServer interface.
+func (p *rpcProxy) RevokeApiKey(_ context.Context,
+	req *litrpc.RevokeApiKeyRequest) (*litrpc.RevokeApiKeyResponse,
+	error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	idBytes, err := hex.DecodeString(req.Id)
+	if err != nil || len(idBytes) != len(session.APIKeyID{}) {
+		return nil, status.Error(codes.InvalidArgument, "invalid API "+
+			"key ID")
+	}
+
+	var id session.APIKeyID
+	copy(id[:], idBytes)
+
+	err = p.apiKeys.revoke(id)
+	if errors.Is(err, session.ErrAPIKeyNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Revoked API key %x", id[:])
+
+	return &litrpc.RevokeApiKeyResponse{}, nil
+}
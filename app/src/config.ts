@@ -16,7 +16,11 @@ export const LNC_APP_BASE_URL = IS_DEV
 
 // detect the host currently serving the app files
 const { protocol, hostname, port } = window.location;
-const host = `${protocol}//${hostname}:${port}`;
+// the app may be served under a path prefix, the GRPC server then is too
+const pathPrefix = (PUBLIC_URL || '').startsWith('/')
+  ? (PUBLIC_URL || '').replace(/\/+$/, '')
+  : '';
+const host = `${protocol}//${hostname}:${port}${pathPrefix}`;
 // the GRPC server to make requests to
 export const DEV_HOST = process.env.REACT_APP_DEV_HOST || host;
 
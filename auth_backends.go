@@ -0,0 +1,479 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// defaultTLSClientScope is the default scope that TLS client
+	// certificates are mapped to.
+	defaultTLSClientScope = jwtScopeReadOnly
+
+	// AuthBackendMTLS authenticates requests that were made over a TLS
+	// connection with a client certificate that is signed by one of the
+	// configured client CAs. The certificate is only used if the request
+	// carries no other credential and grants the permissions of the
+	// configured client certificate scope.
+	AuthBackendMTLS = "mtls"
+
+	// AuthBackendMacaroon authenticates requests with the macaroon in the
+	// macaroon header. The access is limited to the permissions of the
+	// macaroon.
+	AuthBackendMacaroon = "macaroon"
+
+	// AuthBackendPassword authenticates requests with the UI password that
+	// is sent as basic auth in the authorization header. The password
+	// grants full access.
+	AuthBackendPassword = "password"
+
+	// AuthBackendAPIKey authenticates requests with an API key that is
+	// sent in the X-Api-Key header. The access is limited to the
+	// permissions of the key.
+	AuthBackendAPIKey = "apikey"
+
+	// AuthBackendJWT authenticates requests with a JWT that is sent as
+	// bearer token in the authorization header. The access is limited to
+	// the permissions that the token's scope claim is mapped to.
+	AuthBackendJWT = "jwt"
+)
+
+var (
+	// defaultAuthBackends is the default order in which the
+	// authentication backends are tried.
+	defaultAuthBackends = []string{
+		AuthBackendMacaroon, AuthBackendMTLS, AuthBackendAPIKey,
+		AuthBackendJWT, AuthBackendPassword,
+	}
+
+	// errNoCredential is returned by an authentication backend if the
+	// request doesn't carry the backend's credential.
+	errNoCredential = errors.New("no credential provided")
+
+	// errWrongUIPassword is returned by the password backend if the
+	// request carries an incorrect UI password. It is handled like a
+	// missing credential, so the error the client sees doesn't reveal
+	// that basic auth is allowed at all.
+	errWrongUIPassword = fmt.Errorf("%w: wrong UI password",
+		errNoCredential)
+)
+
+// validateAuthBackends makes sure the given list of authentication backends
+// is a valid order to try the backends in.
+func validateAuthBackends(backends []string) error {
+	seen := make(map[string]struct{}, len(backends))
+	for _, backend := range backends {
+		switch backend {
+		case AuthBackendMTLS, AuthBackendMacaroon, AuthBackendAPIKey,
+			AuthBackendJWT, AuthBackendPassword:
+
+		default:
+			return fmt.Errorf("unknown authentication backend %s",
+				backend)
+		}
+
+		if _, ok := seen[backend]; ok {
+			return fmt.Errorf("duplicate authentication backend %s",
+				backend)
+		}
+		seen[backend] = struct{}{}
+	}
+
+	// The macaroon backend is also responsible for the whitelisted
+	// methods that don't need any credential, so it can't be left out.
+	if _, ok := seen[AuthBackendMacaroon]; !ok {
+		return fmt.Errorf("the %s authentication backend is required",
+			AuthBackendMacaroon)
+	}
+
+	return nil
+}
+
+// authBackend is a way of authenticating a request to LiT's RPC proxy.
+type authBackend interface {
+	// name returns the name of the backend.
+	name() string
+
+	// authenticate checks the backend's credential of the request. If the
+	// request is authenticated, the context the request should continue
+	// with is returned. errNoCredential is returned if the request doesn't
+	// carry the backend's credential at all.
+	authenticate(ctx context.Context, requestURI string,
+		requiredPermissions []bakery.Op) (context.Context, error)
+}
+
+// newAuthBackends creates the authentication backends with the given names
+// in the same order. Backends that aren't configured are left out.
+func newAuthBackends(cfg *Config, p *rpcProxy) []authBackend {
+	backends := make([]authBackend, 0, len(cfg.AuthBackends))
+	for _, name := range cfg.AuthBackends {
+		switch name {
+		case AuthBackendMTLS:
+			// Certificates that are required by lit-clientcapath
+			// only grant access to the port, they never
+			// authenticate requests.
+			if cfg.TLSClientCAPath == "" {
+				continue
+			}
+			backends = append(backends, &mtlsAuthBackend{p: p})
+
+		case AuthBackendMacaroon:
+			backends = append(backends, &macaroonAuthBackend{p: p})
+
+		case AuthBackendAPIKey:
+			backends = append(backends, &apiKeyAuthBackend{p: p})
+
+		case AuthBackendJWT:
+			if !cfg.JWT.enabled() {
+				continue
+			}
+			backends = append(backends, &jwtAuthBackend{p: p})
+
+		case AuthBackendPassword:
+			if cfg.DisableUI {
+				continue
+			}
+			backends = append(backends, &passwordAuthBackend{p: p})
+		}
+	}
+
+	return backends
+}
+
+// authBackendKey is the context key under which the name of the backend that
+// authenticated a request is stored.
+type authBackendKey struct{}
+
+// authBackendFromContext returns the name of the authentication backend that
+// authenticated the request of the given context, if any.
+func authBackendFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(authBackendKey{}).(string)
+
+	return name
+}
+
+// authenticate tries the configured authentication backends in order until one
+// of them authenticates the request. Two contexts are returned, both marked
+// with the name of the backend: The first one carries the credential that was
+// validated (for example the macaroon that the UI password was converted to),
+// the second one is the request's original context which the request should
+// be handled with. If no backend authenticates the request, an error that
+// combines the errors of all backends that were tried is returned.
+func (p *rpcProxy) authenticate(ctx context.Context, requestURI string,
+	requiredPermissions []bakery.Op) (context.Context, context.Context,
+	error) {
+
+	var (
+		failures      []string
+		lastErr       error
+		lockoutErr    error
+		wrongPassword bool
+		otherFailed   bool
+	)
+	for _, backend := range p.authBackends {
+		authCtx, err := backend.authenticate(
+			ctx, requestURI, requiredPermissions,
+		)
+		if err == nil {
+			recordAuthDecision(ctx, backend.name(), "")
+
+			authCtx = context.WithValue(
+				authCtx, authBackendKey{}, backend.name(),
+			)
+			origCtx := context.WithValue(
+				ctx, authBackendKey{}, backend.name(),
+			)
+
+			return authCtx, origCtx, nil
+		}
+
+		if errors.Is(err, errWrongUIPassword) {
+			wrongPassword = true
+		}
+		if errors.Is(err, errNoCredential) {
+			continue
+		}
+
+		// Make sure we handle the case where the super macaroon is
+		// still empty on startup.
+		if pErr, ok := err.(*proxyErr); ok &&
+			pErr.proxyContext == "supermacaroon" {
+
+			return nil, nil, fmt.Errorf("super macaroon error: %v",
+				pErr)
+		}
+
+		// A client that is locked out of a backend should learn why,
+		// even if other backends failed as well.
+		if status.Code(err) == codes.ResourceExhausted {
+			lockoutErr = err
+		}
+
+		failures = append(
+			failures, fmt.Sprintf("%s: %v", backend.name(), err),
+		)
+		lastErr = err
+
+		if backend.name() != AuthBackendMacaroon {
+			otherFailed = true
+		}
+	}
+
+	// If only a single backend was tried, we return its error as is, so
+	// the most common case of a single credential gives the same error as
+	// if there was no chain of backends.
+	p.observeDenial(ctx, requestURI)
+	reason := authFailureReason(
+		ctx, lockoutErr != nil, wrongPassword, otherFailed,
+	)
+	p.observeAuthFailure(reason)
+	recordAuthDecision(ctx, "", reason)
+	p.requestLog(ctx, requestURI).Debugf("Authentication failed for %s: "+
+		"%d backend(s) rejected the request", requestURI, len(failures))
+
+	switch {
+	case lockoutErr != nil:
+		return nil, nil, lockoutErr
+
+	case len(failures) == 0:
+		return nil, nil, permissionDeniedError(errNoCredential)
+
+	case len(failures) == 1:
+		return nil, nil, permissionDeniedError(lastErr)
+	}
+
+	return nil, nil, permissionDeniedError(fmt.Errorf("all authentication "+
+		"backends failed: %s", strings.Join(failures, "; ")))
+}
+
+// mtlsAuthBackend authenticates requests with a verified TLS client
+// certificate.
+type mtlsAuthBackend struct {
+	p *rpcProxy
+}
+
+// name returns the name of the backend.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *mtlsAuthBackend) name() string {
+	return AuthBackendMTLS
+}
+
+// authenticate accepts the request if the client presented a certificate that
+// was verified against the configured client CAs during the TLS handshake and
+// attaches the macaroon of the client certificate scope.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *mtlsAuthBackend) authenticate(ctx context.Context,
+	requestURI string, requiredPermissions []bakery.Op) (context.Context,
+	error) {
+
+	// A request that carries another credential, for example the
+	// macaroon of a session with firewall rules, must never get more
+	// access just because its connection has a client certificate.
+	if hasOtherCredential(ctx) {
+		return nil, errNoCredential
+	}
+
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errNoCredential
+	}
+
+	tlsInfo, ok := pr.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return nil, errNoCredential
+	}
+
+	macBytes, err := b.p.mtlsMacaroon(requestURI)
+	if err != nil {
+		return nil, err
+	}
+	if len(macBytes) == 0 {
+		return nil, errors.New("no macaroon available for client " +
+			"certificate")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	err = b.p.macValidator.ValidateMacaroon(
+		ctx, requiredPermissions, requestURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// hasOtherCredential returns true if the request of the given context carries
+// a credential other than a TLS client certificate.
+func hasOtherCredential(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, key := range []string{
+		HeaderMacaroon, "authorization", apiKeyMetadataKey,
+	} {
+		if len(md.Get(key)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mtlsMacaroon returns the macaroon that is attached to requests that were
+// authenticated with a TLS client certificate.
+func (p *rpcProxy) mtlsMacaroon(requestURI string) ([]byte, error) {
+	return p.scopePermsMacaroon("mtls:", p.cfg.tlsClientScope, requestURI)
+}
+
+// macaroonAuthBackend authenticates requests with the macaroon in the
+// macaroon header.
+type macaroonAuthBackend struct {
+	p *rpcProxy
+}
+
+// name returns the name of the backend.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *macaroonAuthBackend) name() string {
+	return AuthBackendMacaroon
+}
+
+// authenticate validates the macaroon of the request. Requests without a
+// macaroon are validated too, since whitelisted methods don't need one.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *macaroonAuthBackend) authenticate(ctx context.Context,
+	requestURI string, requiredPermissions []bakery.Op) (context.Context,
+	error) {
+
+	err := b.p.macValidator.ValidateMacaroon(
+		ctx, requiredPermissions, requestURI,
+	)
+	if err != nil {
+		b.p.recordAuthFailure(ctx, err)
+
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// passwordAuthBackend authenticates requests with the UI password that is sent
+// as basic auth.
+type passwordAuthBackend struct {
+	p *rpcProxy
+}
+
+// name returns the name of the backend.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *passwordAuthBackend) name() string {
+	return AuthBackendPassword
+}
+
+// authenticate checks the UI password of the request and, if it is correct,
+// attaches the macaroon that grants access to the requested method.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *passwordAuthBackend) authenticate(ctx context.Context,
+	requestURI string, requiredPermissions []bakery.Op) (context.Context,
+	error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errNoCredential
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return nil, errNoCredential
+	}
+
+	// A bearer token is the credential of the JWT backend, so it must
+	// neither be checked as a password nor count as a failed attempt.
+	if _, ok := bearerToken(authHeaders[0]); ok {
+		return nil, errNoCredential
+	}
+
+	// Clients that failed too often are locked out before their password
+	// is even checked, so they can't keep guessing.
+	client, limited := b.p.passwordClient(ctx)
+	if limited {
+		err := b.p.passwordLimiter.check(client, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	macBytes, err := b.p.basicAuthToMacaroon(
+		authHeaders[0], requestURI, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// We treat an incorrect password as if there was none. That way an
+	// attacker doesn't learn that basic auth is even allowed, as the
+	// error will only be the one of the other backends.
+	if len(macBytes) == 0 {
+		if limited {
+			b.p.passwordLimiter.fail(client, time.Now())
+		}
+
+		return nil, errWrongUIPassword
+	}
+
+	if limited {
+		b.p.passwordLimiter.succeed(client)
+	}
+
+	md = md.Copy()
+	md.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	err = b.p.macValidator.ValidateMacaroon(
+		ctx, requiredPermissions, requestURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
+
+// authenticatedServerStream wraps a grpc.ServerStream so that its context
+// carries the name of the backend that authenticated the request.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context of the stream, including the name of the
+// authentication backend.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
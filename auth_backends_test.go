@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TestMTLSAuthBackendOtherCredential makes sure that a client certificate is
+// never used to authenticate a request that carries another credential, so
+// it can't widen the permissions of that credential.
+func TestMTLSAuthBackendOtherCredential(t *testing.T) {
+	require.Equal(t, AuthBackendMacaroon, defaultAuthBackends[0])
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{
+					{&x509.Certificate{}},
+				},
+			},
+		},
+	})
+
+	const uri = "/lnrpc.Lightning/GetInfo"
+	backend := &mtlsAuthBackend{p: &rpcProxy{}}
+	for _, md := range []metadata.MD{
+		metadata.Pairs(HeaderMacaroon, "0201"),
+		metadata.Pairs("authorization", "Basic Zm9vOmJhcg=="),
+		metadata.Pairs(apiKeyMetadataKey, "key"),
+	} {
+		_, err := backend.authenticate(
+			metadata.NewIncomingContext(ctx, md), uri, nil,
+		)
+		require.ErrorIs(t, err, errNoCredential)
+	}
+
+	// Without a client certificate, the backend has no credential either.
+	_, err := backend.authenticate(context.Background(), uri, nil)
+	require.ErrorIs(t, err, errNoCredential)
+}
@@ -0,0 +1,137 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultAutoRevokeWindow is the default time window in which the
+	// authentication failures of a session are counted.
+	defaultAutoRevokeWindow = 10 * time.Minute
+)
+
+// revokeSessionFn is a function that revokes the session with the given local
+// public key and records the reason for the revocation.
+type revokeSessionFn func(ctx context.Context, pubKey *btcec.PublicKey,
+	reason string) error
+
+// authFailureTracker keeps track of the recent authentication failures of
+// each session.
+type authFailureTracker struct {
+	failures map[session.ID][]time.Time
+	mu       sync.Mutex
+}
+
+// newAuthFailureTracker creates a new, empty authFailureTracker.
+func newAuthFailureTracker() *authFailureTracker {
+	return &authFailureTracker{
+		failures: make(map[session.ID][]time.Time),
+	}
+}
+
+// record registers a new authentication failure for the given session and
+// returns the number of failures of the session that happened within the
+// given window.
+func (t *authFailureTracker) record(id session.ID, now time.Time,
+	window time.Duration) int {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Drop all failures that are outside the window.
+	recent := t.failures[id][:0]
+	for _, ts := range t.failures[id] {
+		if now.Sub(ts) < window {
+			recent = append(recent, ts)
+		}
+	}
+
+	t.failures[id] = append(recent, now)
+
+	return len(t.failures[id])
+}
+
+// reset forgets all authentication failures of the given session.
+func (t *authFailureTracker) reset(id session.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, id)
+}
+
+// isTransientAuthError returns true if the given authentication error is
+// likely caused by a temporary condition rather than by an invalid macaroon.
+// Such errors never count towards the automatic revocation of a session.
+func isTransientAuthError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled,
+		codes.ResourceExhausted:
+
+		return true
+	}
+
+	return false
+}
+
+// recordAuthFailure registers a failed authentication of a request. If the
+// request was made with the macaroon of a session and the session has failed
+// authentication too often within the configured window, the session is
+// revoked automatically.
+func (p *rpcProxy) recordAuthFailure(ctx context.Context, authErr error) {
+	cfg := p.cfg.AutoRevoke
+	if cfg.MaxFailures == 0 || p.revokeSession == nil {
+		return
+	}
+
+	if isTransientAuthError(authErr) || ctx.Err() != nil {
+		return
+	}
+
+	sess, ok := p.sessionFromContext(ctx)
+	if !ok || sess.State == session.StateRevoked ||
+		sess.State == session.StateExpired {
+
+		return
+	}
+
+	sessLog := logWithFields(log, logFields{
+		"session_pubkey": hex.EncodeToString(
+			sess.LocalPublicKey.SerializeCompressed(),
+		),
+	})
+
+	count := p.authFailures.record(sess.ID, time.Now(), cfg.Window)
+	if count < int(cfg.MaxFailures) {
+		sessLog.Debugf("Authentication failure %d of %d for session "+
+			"%x: %v", count, cfg.MaxFailures, sess.ID[:], authErr)
+
+		return
+	}
+
+	p.authFailures.reset(sess.ID)
+
+	reason := fmt.Sprintf("%d authentication failures within %v, last "+
+		"error: %v", count, cfg.Window, authErr)
+
+	sessLog.Errorf("Automatically revoking session %x (label=%s): %s",
+		sess.ID[:], sess.Label, reason)
+
+	// The request context is cancelled as soon as the request returns, so
+	// we don't use it for the revocation.
+	err := p.revokeSession(
+		context.Background(), sess.LocalPublicKey, reason,
+	)
+	if err != nil {
+		sessLog.Errorf("Unable to automatically revoke session %x: %v",
+			sess.ID[:], err)
+	}
+}
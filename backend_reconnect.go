@@ -0,0 +1,126 @@
+package terminal
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeaderBackendReconnected is the trailer that is set on a stream that
+	// was dropped because LiT lost the connection to the backend daemon,
+	// once the connection is re-established. Its value is the name of the
+	// daemon. Clients should re-subscribe when they see it.
+	HeaderBackendReconnected = "lit-backend-reconnected"
+
+	// defaultBackendReconnectWait is the default duration for which a
+	// dropped stream waits for the backend to reconnect.
+	defaultBackendReconnectWait = 30 * time.Second
+)
+
+// backendConnForURI returns the connection to the backend daemon that the
+// director forwards streaming calls for the given URI to. Nil is returned if
+// the daemon runs integrated in LiT or isn't connected.
+func (p *rpcProxy) backendConnForURI(requestURI string) *grpc.ClientConn {
+	if p.permsMgr.IsSubServerURI(subservers.LIT, requestURI) {
+		return nil
+	}
+
+	handled, conn, err := p.subServerMgr.GetRemoteConn(requestURI)
+	switch {
+	case err != nil:
+		return nil
+
+	case handled:
+		return conn
+	}
+
+	return p.lndConn
+}
+
+// waitForReady waits until the given connection is ready or the context is
+// done. True is returned if the connection is ready.
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) bool {
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return true
+
+		case connectivity.Shutdown:
+			return false
+
+		// An idle connection only reconnects once it's used, so we
+		// need to trigger it ourselves.
+		case connectivity.Idle:
+			conn.Connect()
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// backendReconnectStreamInterceptor is a gRPC interceptor that notices
+// server-streaming calls that were dropped because the connection to the
+// backend daemon was lost. Instead of ending the stream right away, it waits
+// for the backend to reconnect and then ends the stream with an Unavailable
+// status and the HeaderBackendReconnected trailer, so the client knows it can
+// re-subscribe now.
+func (p *rpcProxy) backendReconnectStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if !isServerStreamingMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	conn := p.backendConnForURI(info.FullMethod)
+	if conn == nil {
+		return handler(srv, ss)
+	}
+
+	// Only streams that were started while the backend was connected can
+	// be dropped by a disconnect.
+	wasReady := conn.GetState() == connectivity.Ready
+
+	err := handler(srv, ss)
+	if !wasReady || status.Code(err) != codes.Unavailable {
+		return err
+	}
+
+	// If the client went away, there's nobody left to notify.
+	ctx := ss.Context()
+	if ctx.Err() != nil {
+		return err
+	}
+
+	daemon, daemonErr := p.subSystemForURI(info.FullMethod)
+	if daemonErr != nil {
+		daemon = "unknown"
+	}
+
+	log.Debugf("Stream %s dropped by %s, waiting for it to reconnect: %v",
+		info.FullMethod, daemon, err)
+
+	waitCtx, cancel := context.WithTimeout(
+		ctx, p.cfg.BackendReconnect.Wait,
+	)
+	defer cancel()
+
+	if !waitForReady(waitCtx, conn) {
+		return err
+	}
+
+	ss.SetTrailer(metadata.Pairs(HeaderBackendReconnected, daemon))
+
+	return status.Errorf(codes.Unavailable, "stream dropped by %s which "+
+		"is reconnected now, re-subscribe to continue", daemon)
+}
@@ -0,0 +1,213 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// maxBatchCalls is the maximum number of calls a single BatchCall
+	// request may contain.
+	maxBatchCalls = 100
+
+	// loopbackBufferSize is the size of the in-memory connection buffer
+	// that is used to execute the calls of a batch.
+	loopbackBufferSize = 1 << 20
+
+	// batchCallURI is the URI of the BatchCall RPC itself.
+	batchCallURI = "/litrpc.Proxy/BatchCall"
+)
+
+// batchForwardedHeaders are the headers of a BatchCall request that are
+// forwarded to each of its calls. That way each call is authenticated with the
+// credential of the batch, including the discharges of its macaroon's third
+// party caveats. The step-up token is forwarded as well, so a batch can
+// contain a call to a sensitive method.
+var batchForwardedHeaders = []string{
+	HeaderMacaroon, HeaderDischarge, "authorization", apiKeyMetadataKey,
+	HeaderRequestID, HeaderStepUpToken,
+}
+
+// startLoopback starts serving the proxy's gRPC server on an in-memory
+// listener and connects to it. The connection is used to execute the calls of
+// a batch as if they were made by the client directly, so they go through
+// the same authentication and routing as any other call.
+func (p *rpcProxy) startLoopback() error {
+	p.loopbackListener = bufconn.Listen(loopbackBufferSize)
+
+	go func() {
+		err := p.grpcServer.Serve(p.loopbackListener)
+		if err != nil && err != grpc.ErrServerStopped {
+			log.Errorf("Loopback gRPC server error: %v", err)
+		}
+	}()
+
+	conn, err := grpc.Dial(
+		"loopback",
+		grpc.WithContextDialer(func(ctx context.Context,
+			_ string) (net.Conn, error) {
+
+			return p.loopbackListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(lnrpc.MaxGrpcMsgSize),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to connect to loopback gRPC "+
+			"server: %v", err)
+	}
+	p.loopbackConn = conn
+
+	return nil
+}
+
+// BatchCall executes multiple unary calls in one request. Each call is
+// authorized independently with the credential of the batch request and the
+// calls are executed concurrently. The results are returned in the order of
+// the calls, a failed call doesn't fail the batch.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) BatchCall(ctx context.Context,
+	req *litrpc.BatchCallRequest) (*litrpc.BatchCallResponse, error) {
+
+	if p.loopbackConn == nil {
+		return nil, ErrWaitingToStart
+	}
+
+	if len(req.Calls) > maxBatchCalls {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch can "+
+			"contain at most %d calls", maxBatchCalls)
+	}
+
+	// A step-up token only allows a single call, so only one call of the
+	// batch could use it. The batch is rejected before any of its calls
+	// are made, instead of letting all but one of them fail.
+	var stepUpCalls int
+	for _, call := range req.Calls {
+		if _, ok := p.cfg.StepUp.methods[call.Method]; ok {
+			stepUpCalls++
+		}
+	}
+	if stepUpCalls > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch can "+
+			"contain at most one call to a method that requires "+
+			"step-up authentication, found %d", stepUpCalls)
+	}
+
+	// Only the credentials of the batch request are passed on to the
+	// calls, all other headers are specific to the batch request itself.
+	outCtx := metadata.NewOutgoingContext(ctx, p.forwardedCredentials(ctx))
+
+	results := make([]*litrpc.BatchCallResult, len(req.Calls))
+
+	var wg sync.WaitGroup
+	for idx, call := range req.Calls {
+		wg.Add(1)
+		go func(idx int, call *litrpc.BatchCallItem) {
+			defer wg.Done()
+
+			results[idx] = p.batchSubCall(outCtx, call)
+		}(idx, call)
+	}
+	wg.Wait()
+
+	return &litrpc.BatchCallResponse{
+		Results: results,
+	}, nil
+}
+
+// batchSubCall executes a single call of a batch over the loopback connection
+// and converts its outcome into a result.
+func (p *rpcProxy) batchSubCall(ctx context.Context,
+	call *litrpc.BatchCallItem) *litrpc.BatchCallResult {
+
+	result := func(err error) *litrpc.BatchCallResult {
+		s := status.Convert(err)
+
+		return &litrpc.BatchCallResult{
+			Code:  uint32(s.Code()),
+			Error: s.Message(),
+		}
+	}
+
+	if call.Method == batchCallURI {
+		return result(status.Error(
+			codes.InvalidArgument, "batch calls can't be nested",
+		))
+	}
+
+	// Streaming calls can't be answered with a single response. Methods
+	// we don't know are passed on, so the error is the same as if the
+	// method was called directly.
+	if isStreamingMethod(call.Method) {
+		return result(status.Errorf(
+			codes.InvalidArgument, "streaming method %s can't be "+
+				"part of a batch", call.Method,
+		))
+	}
+
+	resp, err := p.invokeLoopback(ctx, call.Method, call.Request)
+	if err != nil {
+		return result(err)
+	}
+
+	return &litrpc.BatchCallResult{
+		Response: resp,
+		Code:     uint32(codes.OK),
+	}
+}
+
+// forwardedCredentials returns the headers of the incoming request in the
+// given context that authenticate calls made on its behalf over the loopback
+// connection. The IP of the client is passed on as well, so the calls are
+// subject to the same IP restrictions.
+func (p *rpcProxy) forwardedCredentials(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	outMD := metadata.MD{}
+	for _, header := range batchForwardedHeaders {
+		if values := md.Get(header); len(values) > 0 {
+			outMD.Set(header, values...)
+		}
+	}
+
+	if ip, err := p.clientAddrs.clientIP(ctx); err == nil {
+		outMD = metadata.Join(outMD, p.clientAddrs.forward(ip))
+	}
+
+	return outMD
+}
+
+// invokeLoopback calls the given unary method with the serialized request over
+// the loopback connection and returns the serialized response.
+func (p *rpcProxy) invokeLoopback(ctx context.Context, method string,
+	req []byte) ([]byte, error) {
+
+	// We don't know the request and response types of the call, so we
+	// pass on the raw bytes as unknown fields of an empty message.
+	rawReq := &emptypb.Empty{}
+	rawReq.ProtoReflect().SetUnknown(protoreflect.RawFields(req))
+
+	rawResp := &emptypb.Empty{}
+	err := p.loopbackConn.Invoke(ctx, method, rawReq, rawResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawResp.ProtoReflect().GetUnknown(), nil
+}
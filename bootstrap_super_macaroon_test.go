@@ -0,0 +1,173 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestSuperMacaroonConfig tests that the super macaroon bootstrap options are
+// validated and parsed.
+func TestSuperMacaroonConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "super.macaroon")
+
+	// Without a path, nothing is baked, so nothing is checked either.
+	cfg := &SuperMacaroonConfig{
+		ReadOnly:    true,
+		Permissions: []string{"info:read"},
+	}
+	require.NoError(t, cfg.validate())
+
+	cfg = &SuperMacaroonConfig{
+		Path:          path,
+		RootKeySuffix: "01020304",
+		Permissions:   []string{"info:read", "offchain:write"},
+	}
+	require.NoError(t, cfg.validate())
+	require.Equal(t, [4]byte{1, 2, 3, 4}, cfg.rootKeySuffix)
+	require.Equal(t, []bakery.Op{
+		{Entity: "info", Action: "read"},
+		{Entity: "offchain", Action: "write"},
+	}, cfg.permissions)
+
+	// The suffix becomes the last 4 bytes of the root key ID.
+	require.Equal(
+		t, uint64(0xffeeddcc01020304),
+		session.NewSuperMacaroonRootKeyID(cfg.rootKeySuffix),
+	)
+
+	// Without a suffix, a random one is used.
+	cfg = &SuperMacaroonConfig{Path: path}
+	require.NoError(t, cfg.validate())
+	require.NotEqual(t, [4]byte{}, cfg.rootKeySuffix)
+
+	testCases := []struct {
+		name string
+		cfg  *SuperMacaroonConfig
+		err  string
+	}{{
+		name: "readonly with permissions",
+		cfg: &SuperMacaroonConfig{
+			Path:        path,
+			ReadOnly:    true,
+			Permissions: []string{"info:read"},
+		},
+		err: "readonly and permission cannot be set",
+	}, {
+		name: "suffix not hex",
+		cfg: &SuperMacaroonConfig{
+			Path:          path,
+			RootKeySuffix: "xyz",
+		},
+		err: "invalid root key suffix",
+	}, {
+		name: "suffix too short",
+		cfg: &SuperMacaroonConfig{
+			Path:          path,
+			RootKeySuffix: "010203",
+		},
+		err: "root key suffix must be 4 bytes",
+	}, {
+		name: "suffix too long",
+		cfg: &SuperMacaroonConfig{
+			Path:          path,
+			RootKeySuffix: "0102030405",
+		},
+		err: "root key suffix must be 4 bytes",
+	}, {
+		name: "invalid permission",
+		cfg: &SuperMacaroonConfig{
+			Path:        path,
+			Permissions: []string{"info"},
+		},
+		err: "must be in the form entity:action",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorContains(t, tc.cfg.validate(), tc.err)
+		})
+	}
+}
+
+// TestBakeBootstrapSuperMacaroon tests that the bootstrap super macaroon is
+// only baked if it doesn't exist yet, and never with a root key that was
+// rotated out.
+func TestBakeBootstrapSuperMacaroon(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	superMacKeys, err := newSuperMacRootKeys(db)
+	require.NoError(t, err)
+
+	accountService, err := accounts.NewService(
+		t.TempDir(), func(error) {},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = accountService.Stop()
+	})
+
+	lnd := newMockRootKeyLnd()
+	path := filepath.Join(t.TempDir(), "macaroons", "super.macaroon")
+	cfg := &SuperMacaroonConfig{
+		Path:          path,
+		RootKeySuffix: "01020304",
+		Permissions:   []string{"info:read"},
+	}
+	require.NoError(t, cfg.validate())
+
+	g := &LightningTerminal{
+		cfg:            &Config{SuperMacaroon: cfg},
+		basicClient:    lnd,
+		accountService: accountService,
+		superMacKeys:   superMacKeys,
+	}
+	ctx := context.Background()
+	require.NoError(t, g.bakeBootstrapSuperMacaroon(ctx))
+
+	macBytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	rootKeyID := session.NewSuperMacaroonRootKeyID([4]byte{1, 2, 3, 4})
+	mac, err := session.ParseMacaroon(hex.EncodeToString(macBytes))
+	require.NoError(t, err)
+	macRootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	require.NoError(t, err)
+	require.Equal(t, rootKeyID, macRootKeyID)
+
+	// Baking again doesn't replace the existing super macaroon.
+	require.NoError(t, g.bakeBootstrapSuperMacaroon(ctx))
+	newBytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, macBytes, newBytes)
+	require.Len(t, lnd.rootKeys, 1)
+
+	// A root key that was rotated out can't be used for a new bootstrap
+	// super macaroon, since LiT would reject it.
+	require.NoError(t, os.Remove(path))
+	err = superMacKeys.rotate(
+		session.NewSuperMacaroonRootKeyID([4]byte{9}),
+		[]uint64{rootKeyID},
+	)
+	require.NoError(t, err)
+	err = g.bakeBootstrapSuperMacaroon(ctx)
+	require.ErrorContains(t, err, "choose a different root key suffix")
+	require.NoFileExists(t, path)
+
+	// Without a path, nothing is baked.
+	g.cfg.SuperMacaroon = &SuperMacaroonConfig{}
+	require.NoError(t, g.bakeBootstrapSuperMacaroon(ctx))
+}
@@ -153,6 +153,60 @@ func listActions(ctx *cli.Context) error {
 	return nil
 }
 
+var subscribeActionsCommand = cli.Command{
+	Name:     "subscribeactions",
+	Usage:    "Stream actions performed on the Litd server",
+	Category: "Firewall",
+	Description: "Stream the actions that are added to the action log " +
+		"as well as the actions whose state changes. Past actions " +
+		"are not included, use the `actions` command to query them.",
+	Action: subscribeActions,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "session_id",
+			Usage: "The hex encoded session ID to filter the " +
+				"actions by. If left empty, then the actions " +
+				"of all sessions will be streamed.",
+		},
+	},
+}
+
+func subscribeActions(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	var sessionID []byte
+	if ctx.String("session_id") != "" {
+		sessionID, err = hex.DecodeString(ctx.String("session_id"))
+		if err != nil {
+			return err
+		}
+	}
+
+	stream, err := client.SubscribeActions(
+		ctxb, &litrpc.SubscribeActionsRequest{
+			SessionId: sessionID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		action, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(action)
+	}
+}
+
 func parseActionState(actionStr string) (litrpc.ActionState, error) {
 	switch actionStr {
 	case "":
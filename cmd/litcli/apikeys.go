@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var apiKeysCommands = []cli.Command{
+	{
+		Name:     "apikeys",
+		Usage:    "Manage API keys",
+		Category: "LiT",
+		Subcommands: []cli.Command{
+			createAPIKeyCommand,
+			listAPIKeysCommand,
+			revokeAPIKeyCommand,
+		},
+		Description: "Manage the API keys that can be used " +
+			"instead of a macaroon.",
+	},
+}
+
+var createAPIKeyCommand = cli.Command{
+	Name:      "create",
+	ShortName: "c",
+	Usage:     "Create a new API key.",
+	Description: `Creates a new API key. The key must be sent in the
+X-Api-Key header of REST requests or in the x-api-key metadata of gRPC
+requests. The key is only printed once, LiT only stores a hash of it.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  labelName,
+			Usage: "(optional) A label to identify the key.",
+		},
+		cli.BoolFlag{
+			Name: "read_only",
+			Usage: "Only grant the read-only permissions of all " +
+				"active daemons.",
+		},
+		cli.StringSliceFlag{
+			Name: "permission",
+			Usage: "A permission in the form entity:action, for " +
+				"example info:read, to grant instead of all " +
+				"active permissions. This flag can be " +
+				"specified multiple times.",
+		},
+	},
+	Action: createAPIKey,
+}
+
+func createAPIKey(ctx *cli.Context) error {
+	macPerms, err := parsePermissions(ctx.StringSlice("permission"))
+	if err != nil {
+		return err
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	resp, err := client.CreateApiKey(
+		context.Background(), &litrpc.CreateApiKeyRequest{
+			Label:       ctx.String(labelName),
+			ReadOnly:    ctx.Bool("read_only"),
+			Permissions: macPerms,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listAPIKeysCommand = cli.Command{
+	Name:        "list",
+	ShortName:   "l",
+	Usage:       "List all API keys.",
+	Description: "Returns all API keys that were not revoked.",
+	Action:      listAPIKeys,
+}
+
+func listAPIKeys(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	resp, err := client.ListApiKeys(
+		context.Background(), &litrpc.ListApiKeysRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var revokeAPIKeyCommand = cli.Command{
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "Revoke an API key.",
+	ArgsUsage: "id",
+	Description: "Revokes the API key with the given ID. Requests that " +
+		"use the key are rejected immediately.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  idName,
+			Usage: "The ID of the API key to revoke.",
+		},
+	},
+	Action: revokeAPIKey,
+}
+
+func revokeAPIKey(ctx *cli.Context) error {
+	id := ctx.String(idName)
+	if id == "" && ctx.Args().Present() {
+		id = ctx.Args().First()
+	}
+	if id == "" {
+		return fmt.Errorf("the ID of the API key is required")
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	resp, err := client.RevokeApiKey(
+		context.Background(), &litrpc.RevokeApiKeyRequest{Id: id},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
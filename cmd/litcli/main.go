@@ -73,9 +73,11 @@ func main() {
 	app.Commands = append(app.Commands, sessionCommands...)
 	app.Commands = append(app.Commands, accountsCommands...)
 	app.Commands = append(app.Commands, listActionsCommand)
+	app.Commands = append(app.Commands, subscribeActionsCommand)
 	app.Commands = append(app.Commands, privacyMapCommands)
 	app.Commands = append(app.Commands, autopilotCommands)
 	app.Commands = append(app.Commands, litCommands...)
+	app.Commands = append(app.Commands, apiKeysCommands...)
 	app.Commands = append(app.Commands, helperCommands)
 	app.Commands = append(app.Commands, statusCommands...)
 
@@ -9,17 +9,22 @@ import (
 	"os"
 
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/urfave/cli"
 )
 
 var litCommands = []cli.Command{
 	{
-		Name: "bakesupermacaroon",
+		Name:    "bakesupermacaroon",
+		Aliases: []string{"bake-supermacaroon"},
 		Usage: "Bake a new super macaroon with all of LiT's active " +
 			"permissions",
 		Description: "Bake a new super macaroon with all of LiT's active " +
-			"permissions.",
+			"permissions. Use --read_only or --permission to " +
+			"only include some of them. The hex encoded macaroon " +
+			"is printed and, if --save_to is set, the raw " +
+			"macaroon is also written to that file.",
 		Category: "LiT",
 		Action:   bakeSuperMacaroon,
 		Flags: []cli.Flag{
@@ -32,13 +37,47 @@ var litCommands = []cli.Command{
 					"specified as a hex string using a " +
 					"maximum of 8 characters.",
 			},
+			cli.Uint64Flag{
+				Name: "root_key_id",
+				Usage: "The full ID of the super macaroon " +
+					"root key to use, as returned by " +
+					"rotatesupermacaroonkey. Can't be " +
+					"combined with --root_key_suffix.",
+			},
 			cli.StringFlag{
 				Name: "save_to",
 				Usage: "Save returned admin macaroon to " +
 					"this file.",
 			},
+			cli.BoolFlag{
+				Name: "read_only",
+				Usage: "Only include the read-only " +
+					"permissions of all active daemons.",
+			},
+			cli.StringSliceFlag{
+				Name: "permission",
+				Usage: "A permission in the form " +
+					"entity:action, for example " +
+					"info:read, to include instead of " +
+					"all active permissions. This flag " +
+					"can be specified multiple times.",
+			},
+			allowedIPRangeFlag,
+			thirdPartyCaveatFlag,
 		},
 	},
+	{
+		Name: "rotatesupermacaroonkey",
+		Usage: "Invalidate all super macaroons by rotating their " +
+			"root key",
+		Description: "Invalidate all super macaroons that were baked " +
+			"so far, including the macaroons of sessions, and " +
+			"make a new root key the current super macaroon " +
+			"root key. Sessions stay unusable until their key " +
+			"is rotated with 'sessions rotatekey'.",
+		Category: "LiT",
+		Action:   rotateSuperMacaroonKey,
+	},
 	{
 		Name: "getinfo",
 		Usage: "Returns basic information related to the active " +
@@ -48,6 +87,56 @@ var litCommands = []cli.Command{
 		Category: "LiT",
 		Action:   getInfo,
 	},
+	{
+		Name:  "listeners",
+		Usage: "List all listeners of the LiT daemon",
+		Description: "List every listener LiT serves on with its " +
+			"address, protocols, TLS status and accepted " +
+			"credentials, and test whether it can be connected " +
+			"to.",
+		Category: "LiT",
+		Action:   listListeners,
+	},
+	{
+		Name:  "routes",
+		Usage: "List the routing table of the LiT proxy",
+		Description: "List every method URI LiT knows with the " +
+			"daemon it is routed to and whether the daemon can " +
+			"currently handle calls to it.",
+		Category: "LiT",
+		Action:   listRoutes,
+	},
+	{
+		Name:  "listpermissions",
+		Usage: "List the permissions of all active daemons",
+		Description: "List every permission that the methods of the " +
+			"active daemons require, grouped by daemon. These are " +
+			"the permissions a session or super macaroon can be " +
+			"scoped to.",
+		Category: "LiT",
+		Action:   listMacaroonPermissions,
+	},
+	{
+		Name:  "reloadtls",
+		Usage: "Reload the TLS certificate of the LiT daemon",
+		Description: "Re-read the TLS certificate and key of LiT's " +
+			"main HTTPS listener from the configured files, for " +
+			"example after the certificate was renewed. New " +
+			"connections use the new certificate, existing ones " +
+			"are not affected.",
+		Category: "LiT",
+		Action:   reloadTLS,
+	},
+	{
+		Name:  "whoami",
+		Usage: "Show what the macaroon of the call is allowed to do",
+		Description: "Show the session, caveats, expiry and callable " +
+			"methods of the super macaroon that is used for the " +
+			"call. Use the global --macaroonpath flag to select " +
+			"the macaroon.",
+		Category: "LiT",
+		Action:   whoAmI,
+	},
 	{
 		Name:        "stop",
 		Usage:       "Shutdown the LiT daemon",
@@ -57,6 +146,27 @@ var litCommands = []cli.Command{
 	},
 }
 
+func rotateSuperMacaroonKey(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.RotateSuperMacaroonRootKey(
+		ctxb, &litrpc.RotateSuperMacaroonRootKeyRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 func getInfo(ctx *cli.Context) error {
 	clientConn, cleanup, err := connectClient(ctx, false)
 	if err != nil {
@@ -76,6 +186,105 @@ func getInfo(ctx *cli.Context) error {
 	return nil
 }
 
+func listListeners(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ListListeners(
+		ctxb, &litrpc.ListListenersRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func listRoutes(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ListRoutes(ctxb, &litrpc.ListRoutesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func listMacaroonPermissions(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ListMacaroonPermissions(
+		ctxb, &litrpc.ListMacaroonPermissionsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func whoAmI(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.WhoAmI(ctxb, &litrpc.WhoAmIRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func reloadTLS(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ReloadTLS(ctxb, &litrpc.ReloadTLSRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 func shutdownLit(ctx *cli.Context) error {
 	clientConn, cleanup, err := connectClient(ctx, false)
 	if err != nil {
@@ -97,7 +306,23 @@ func shutdownLit(ctx *cli.Context) error {
 
 func bakeSuperMacaroon(ctx *cli.Context) error {
 	var suffixBytes [4]byte
-	if ctx.IsSet("root_key_suffix") {
+	switch {
+	case ctx.IsSet("root_key_id") && ctx.IsSet("root_key_suffix"):
+		return fmt.Errorf("only one of --root_key_id and " +
+			"--root_key_suffix can be set")
+
+	// A full root key ID consists of the super macaroon prefix and the
+	// suffix, so only the suffix must be sent.
+	case ctx.IsSet("root_key_id"):
+		rootKeyID := ctx.Uint64("root_key_id")
+		if !session.IsSuperMacaroonRootKeyID(rootKeyID) {
+			return fmt.Errorf("root key ID %d is not a super "+
+				"macaroon root key ID", rootKeyID)
+		}
+
+		binary.BigEndian.PutUint32(suffixBytes[:], uint32(rootKeyID))
+
+	case ctx.IsSet("root_key_suffix"):
 		suffixHex, err := hex.DecodeString(
 			ctx.String("root_key_suffix"),
 		)
@@ -106,7 +331,8 @@ func bakeSuperMacaroon(ctx *cli.Context) error {
 		}
 
 		copy(suffixBytes[:], suffixHex)
-	} else {
+
+	default:
 		_, err := rand.Read(suffixBytes[:])
 		if err != nil {
 			return err
@@ -114,6 +340,18 @@ func bakeSuperMacaroon(ctx *cli.Context) error {
 	}
 	suffix := binary.BigEndian.Uint32(suffixBytes[:])
 
+	macPerms, err := parsePermissions(ctx.StringSlice("permission"))
+	if err != nil {
+		return err
+	}
+
+	thirdPartyCaveats, err := parseThirdPartyCaveats(
+		ctx.StringSlice("third_party_caveat"),
+	)
+	if err != nil {
+		return err
+	}
+
 	clientConn, cleanup, err := connectClient(ctx, false)
 	if err != nil {
 		return err
@@ -124,7 +362,11 @@ func bakeSuperMacaroon(ctx *cli.Context) error {
 	ctxb := context.Background()
 	resp, err := client.BakeSuperMacaroon(
 		ctxb, &litrpc.BakeSuperMacaroonRequest{
-			RootKeyIdSuffix: suffix,
+			RootKeyIdSuffix:   suffix,
+			AllowedIpRanges:   ctx.StringSlice("allowed_ip_range"),
+			ReadOnly:          ctx.Bool("read_only"),
+			Permissions:       macPerms,
+			ThirdPartyCaveats: thirdPartyCaveats,
 		},
 	)
 	if err != nil {
@@ -146,8 +388,6 @@ func bakeSuperMacaroon(ctx *cli.Context) error {
 			return err
 		}
 		fmt.Printf("Super macaroon saved to %s\n", macSavePath)
-
-		return nil
 	}
 
 	printRespJSON(resp)
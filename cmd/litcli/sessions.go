@@ -2,11 +2,18 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/hex"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	litperms "github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/urfave/cli"
 )
@@ -37,8 +44,28 @@ var (
 		Usage: "Set to true to skip verification of the " +
 			"server's tls cert.",
 	}
+	allowedIPRangeFlag = cli.StringSliceFlag{
+		Name: "allowed_ip_range",
+		Usage: "A CIDR range, for example 192.168.1.0/24, that " +
+			"the macaroon may only be used from. This flag can " +
+			"be specified multiple times. Requires the " +
+			"firewall to be enabled.",
+	}
+	thirdPartyCaveatFlag = cli.StringSliceFlag{
+		Name: "third_party_caveat",
+		Usage: "A third party caveat in the form " +
+			"'<location> <predicate>' that the external " +
+			"authorization server at the location must " +
+			"discharge before the macaroon can be used. This " +
+			"flag can be specified multiple times.",
+	}
 )
 
+var humanFlag = cli.BoolFlag{
+	Name:  "human",
+	Usage: "Print the result as a table instead of JSON.",
+}
+
 var sessionCommands = []cli.Command{
 	{
 		Name:      "sessions",
@@ -49,6 +76,17 @@ var sessionCommands = []cli.Command{
 			addSessionCommand,
 			listSessionCommand,
 			revokeSessionCommand,
+			rotateSessionKeyCommand,
+			renewSessionCommand,
+			importSessionCommand,
+			exportSessionCommand,
+			checkSessionPermissionsCommand,
+			previewMethodPolicyCommand,
+			sessionUsageCommand,
+			sessionEventsCommand,
+			attenuateMacaroonCommand,
+			checkSessionStoreCommand,
+			testWebhookCommand,
 		},
 		Description: "Manage Lightning Node Connect sessions.",
 	},
@@ -57,14 +95,29 @@ var sessionCommands = []cli.Command{
 var addSessionCommand = cli.Command{
 	Name:        "add",
 	ShortName:   "a",
+	Aliases:     []string{"create"},
 	Usage:       "Create a new Lightning Node Connect session.",
 	Description: "Add a new active session.",
 	Action:      addSession,
 	Flags: []cli.Flag{
+		humanFlag,
 		labelFlag,
 		expiryFlag,
 		mailboxServerAddrFlag,
 		devserver,
+		cli.Uint64Flag{
+			Name: "mac_expiry",
+			Usage: "If set, the number of seconds after which " +
+				"the session's macaroon stops working, even " +
+				"if the session itself is still active.",
+		},
+		cli.Uint64Flag{
+			Name: "idle_timeout",
+			Usage: "If set, the number of seconds after which " +
+				"the session is revoked if it wasn't used in " +
+				"the meantime. Overrides the idle timeout " +
+				"configured for litd.",
+		},
 		cli.StringFlag{
 			Name: "type",
 			Usage: "The session type to be created which will " +
@@ -86,6 +139,38 @@ var addSessionCommand = cli.Command{
 				"For example, '/lnrpc\\..*' will result in " +
 				"all `lnrpc` permissions being included.",
 		},
+		cli.StringSliceFlag{
+			Name: "permission",
+			Usage: "A permission in the form entity:action, for " +
+				"example info:read, that should be included " +
+				"in the macaroon of a custom session. Note " +
+				"that this flag will only be used if the " +
+				"'type' flag is set to 'custom'. This flag " +
+				"can be specified multiple times and can be " +
+				"combined with the 'uri' flag.",
+		},
+		cli.StringSliceFlag{
+			Name: "allowedop",
+			Usage: "A loop or pool operation that the custom " +
+				"session may execute; options include " +
+				"loop-out|loop-in|loop-instant-out|pool-ask|" +
+				"pool-bid. If set, all other of these " +
+				"operations are rejected. This flag can be " +
+				"specified multiple times.",
+		},
+		cli.UintFlag{
+			Name: "maxstreams",
+			Usage: "The maximum number of streams that may be " +
+				"active at the same time for the session. If " +
+				"not set, the limit configured in litd applies.",
+		},
+		cli.StringSliceFlag{
+			Name: "metadata",
+			Usage: "A key=value pair to attach to the session, " +
+				"for example to correlate it with external " +
+				"systems. This flag can be specified " +
+				"multiple times.",
+		},
 		cli.StringFlag{
 			Name: "account_id",
 			Usage: "The account id that should be used for " +
@@ -93,6 +178,23 @@ var addSessionCommand = cli.Command{
 				"will only be used if the 'type' flag is " +
 				"set to 'account'.",
 		},
+		allowedIPRangeFlag,
+		thirdPartyCaveatFlag,
+		cli.StringSliceFlag{
+			Name: "scope",
+			Usage: "A sub-server the session can reach; options " +
+				"include lnd|loop|pool|faraday|litrpc. If " +
+				"set, calls to all other sub-servers are " +
+				"rejected. This flag can be specified " +
+				"multiple times. Requires the firewall to be " +
+				"enabled.",
+		},
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "Don't create the session, only show the " +
+				"permissions and caveats its macaroon would " +
+				"be baked with.",
+		},
 	},
 }
 
@@ -110,6 +212,11 @@ func addSession(ctx *cli.Context) error {
 		return err
 	}
 
+	metadata, err := parseMetadata(ctx.StringSlice("metadata"))
+	if err != nil {
+		return err
+	}
+
 	var macPerms []*litrpc.MacaroonPermission
 	for _, uri := range ctx.StringSlice("uri") {
 		macPerms = append(macPerms, &litrpc.MacaroonPermission{
@@ -117,10 +224,30 @@ func addSession(ctx *cli.Context) error {
 			Action: uri,
 		})
 	}
+	perms, err := parsePermissions(ctx.StringSlice("permission"))
+	if err != nil {
+		return err
+	}
+	macPerms = append(macPerms, perms...)
+
+	thirdPartyCaveats, err := parseThirdPartyCaveats(
+		ctx.StringSlice("third_party_caveat"),
+	)
+	if err != nil {
+		return err
+	}
 
 	sessionLength := time.Second * time.Duration(ctx.Uint64("expiry"))
 	sessionExpiry := time.Now().Add(sessionLength).Unix()
 
+	var macExpiry uint64
+	if ctx.IsSet("mac_expiry") {
+		macLength := time.Second * time.Duration(
+			ctx.Uint64("mac_expiry"),
+		)
+		macExpiry = uint64(time.Now().Add(macLength).Unix())
+	}
+
 	ctxb := context.Background()
 	resp, err := client.AddSession(
 		ctxb, &litrpc.AddSessionRequest{
@@ -130,18 +257,94 @@ func addSession(ctx *cli.Context) error {
 			MailboxServerAddr:         ctx.String("mailboxserveraddr"),
 			DevServer:                 ctx.Bool("devserver"),
 			MacaroonCustomPermissions: macPerms,
+			AllowedOperations:         ctx.StringSlice("allowedop"),
 			AccountId:                 ctx.String("account_id"),
+			MaxStreams:                uint32(ctx.Uint("maxstreams")),
+			Metadata:                  metadata,
+			MacExpiry:                 macExpiry,
+			AllowedIpRanges: ctx.StringSlice(
+				"allowed_ip_range",
+			),
+			SessionScope:       ctx.StringSlice("scope"),
+			DryRun:             ctx.Bool("dry_run"),
+			IdleTimeoutSeconds: ctx.Uint64("idle_timeout"),
+			ThirdPartyCaveats:  thirdPartyCaveats,
 		},
 	)
 	if err != nil {
 		return err
 	}
 
+	if ctx.Bool("human") && resp.MacaroonRecipe != nil {
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ENTITY\tACTION")
+		for _, perm := range resp.MacaroonRecipe.Permissions {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", perm.Entity,
+				perm.Action)
+		}
+		_ = w.Flush()
+
+		fmt.Println("\nCaveats:")
+		for _, caveat := range resp.MacaroonRecipe.Caveats {
+			fmt.Printf("  %s\n", caveat)
+		}
+
+		return nil
+	}
+
+	if ctx.Bool("human") {
+		fmt.Printf("Pairing phrase: %s\nMailbox server: %s\n\n",
+			resp.Session.PairingSecretMnemonic,
+			resp.Session.MailboxServerAddr)
+		printSessionsTable([]*litrpc.Session{resp.Session})
+
+		return nil
+	}
+
 	printRespJSON(resp)
 
 	return nil
 }
 
+// parsePermissions parses permissions in the form entity:action.
+func parsePermissions(perms []string) ([]*litrpc.MacaroonPermission, error) {
+	ops, err := litperms.ParsePermissions(perms)
+	if err != nil {
+		return nil, err
+	}
+
+	macPerms := make([]*litrpc.MacaroonPermission, 0, len(ops))
+	for _, op := range ops {
+		macPerms = append(macPerms, &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		})
+	}
+
+	return macPerms, nil
+}
+
+func parseThirdPartyCaveats(caveats []string) ([]*litrpc.ThirdPartyCaveat,
+	error) {
+
+	rpcCaveats := make([]*litrpc.ThirdPartyCaveat, 0, len(caveats))
+	for _, caveat := range caveats {
+		location, predicate, ok := strings.Cut(caveat, " ")
+		if !ok || location == "" || predicate == "" {
+			return nil, fmt.Errorf("invalid third party caveat %s, "+
+				"must be in the form '<location> <predicate>'",
+				caveat)
+		}
+
+		rpcCaveats = append(rpcCaveats, &litrpc.ThirdPartyCaveat{
+			Location:  location,
+			Predicate: predicate,
+		})
+	}
+
+	return rpcCaveats, nil
+}
+
 func parseSessionType(sessionType string) (litrpc.SessionType, error) {
 	switch sessionType {
 	case "admin":
@@ -158,10 +361,13 @@ func parseSessionType(sessionType string) (litrpc.SessionType, error) {
 }
 
 var listSessionCommand = cli.Command{
-	Name:        "list",
-	ShortName:   "l",
-	Usage:       "List Lightning Node Connect sessions.",
-	Description: "List sessions.",
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List Lightning Node Connect sessions.",
+	Description: "List sessions. Without a subcommand, all sessions " +
+		"are listed.",
+	Action: listSessions(sessionFilterAll),
+	Flags:  listSessionsFlags,
 	Subcommands: []cli.Command{
 		listAllSessionsCommand,
 		listRevokedSessions,
@@ -177,6 +383,7 @@ var listAllSessionsCommand = cli.Command{
 	Usage:       "List all Lightning Node Connect sessions.",
 	Description: "List all sessions.\n",
 	Action:      listSessions(sessionFilterAll),
+	Flags:       listSessionsFlags,
 }
 
 var listRevokedSessions = cli.Command{
@@ -185,6 +392,7 @@ var listRevokedSessions = cli.Command{
 	Usage:       "List revoked Lightning Node Connect sessions.",
 	Description: "List revoked sessions.\n",
 	Action:      listSessions(sessionFilterRevoked),
+	Flags:       listSessionsFlags,
 }
 
 var listInUseSessions = cli.Command{
@@ -193,6 +401,7 @@ var listInUseSessions = cli.Command{
 	Usage:       "List in-use Lightning Node Connect sessions.",
 	Description: "List in-use sessions.\n",
 	Action:      listSessions(sessionFilterInUse),
+	Flags:       listSessionsFlags,
 }
 
 var listExpiredSessions = cli.Command{
@@ -201,6 +410,7 @@ var listExpiredSessions = cli.Command{
 	Usage:       "List expired Lightning Node Connect sessions.",
 	Description: "List expired sessions.\n",
 	Action:      listSessions(sessionFilterExpired),
+	Flags:       listSessionsFlags,
 }
 
 var listCreatedSessions = cli.Command{
@@ -209,6 +419,58 @@ var listCreatedSessions = cli.Command{
 	Usage:       "List created Lightning Node Connect sessions.",
 	Description: "List created sessions.\n",
 	Action:      listSessions(sessionFilterCreated),
+	Flags:       listSessionsFlags,
+}
+
+var listSessionsFlags = []cli.Flag{
+	humanFlag,
+	cli.StringSliceFlag{
+		Name: "metadata",
+		Usage: "Only list sessions that have the given key=value " +
+			"pair in their metadata. This flag can be " +
+			"specified multiple times to require multiple pairs.",
+	},
+	cli.Uint64Flag{
+		Name: "expires_before",
+		Usage: "Only list sessions that expire before the given Unix " +
+			"timestamp in seconds.",
+	},
+	cli.Uint64Flag{
+		Name: "created_after",
+		Usage: "Only list sessions that were created after the given " +
+			"Unix timestamp in seconds.",
+	},
+	cli.UintFlag{
+		Name: "page_size",
+		Usage: "The maximum number of sessions to list. If set, the " +
+			"response contains a token to list the next page " +
+			"with, if there is one.",
+	},
+	cli.StringFlag{
+		Name: "page_token",
+		Usage: "The next_page_token of the previous page to continue " +
+			"listing after it.",
+	},
+}
+
+// parseMetadata parses a list of key=value pairs into a map.
+func parseMetadata(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %s, must be in "+
+				"the format key=value", pair)
+		}
+
+		metadata[key] = value
+	}
+
+	return metadata, nil
 }
 
 type sessionFilter uint32
@@ -221,11 +483,11 @@ const (
 	sessionFilterCreated
 )
 
-var sessionStateMap = map[litrpc.SessionState]sessionFilter{
-	litrpc.SessionState_STATE_CREATED: sessionFilterCreated,
-	litrpc.SessionState_STATE_EXPIRED: sessionFilterExpired,
-	litrpc.SessionState_STATE_IN_USE:  sessionFilterInUse,
-	litrpc.SessionState_STATE_REVOKED: sessionFilterRevoked,
+var sessionStateMap = map[sessionFilter]litrpc.SessionState{
+	sessionFilterCreated: litrpc.SessionState_STATE_CREATED,
+	sessionFilterExpired: litrpc.SessionState_STATE_EXPIRED,
+	sessionFilterInUse:   litrpc.SessionState_STATE_IN_USE,
+	sessionFilterRevoked: litrpc.SessionState_STATE_REVOKED,
 }
 
 func listSessions(filter sessionFilter) func(ctx *cli.Context) error {
@@ -237,49 +499,210 @@ func listSessions(filter sessionFilter) func(ctx *cli.Context) error {
 		defer cleanup()
 		client := litrpc.NewSessionsClient(clientConn)
 
-		ctxb := context.Background()
-		resp, err := client.ListSessions(
-			ctxb, &litrpc.ListSessionsRequest{},
+		metadataFilter, err := parseMetadata(
+			ctx.StringSlice("metadata"),
 		)
 		if err != nil {
 			return err
 		}
 
-		if filter == sessionFilterAll {
-			printRespJSON(resp)
-			return nil
+		req := &litrpc.ListSessionsRequest{
+			MetadataFilter: metadataFilter,
+			ExpiresBefore:  ctx.Uint64("expires_before"),
+			CreatedAfter:   ctx.Uint64("created_after"),
+			PageSize:       uint32(ctx.Uint("page_size")),
+			PageToken:      ctx.String("page_token"),
+		}
+		if filter != sessionFilterAll {
+			req.StateFilter = []litrpc.SessionState{
+				sessionStateMap[filter],
+			}
+		}
+
+		ctxb := context.Background()
+		resp, err := client.ListSessions(ctxb, req)
+		if err != nil {
+			return err
 		}
 
-		var sessions []*litrpc.Session
-		for _, session := range resp.Sessions {
-			if sessionStateMap[session.SessionState] != filter {
-				continue
+		if ctx.Bool("human") {
+			printSessionsTable(resp.Sessions)
+			if resp.NextPageToken != "" {
+				fmt.Printf("\nNext page token: %s\n",
+					resp.NextPageToken)
 			}
 
-			sessions = append(sessions, session)
+			return nil
 		}
 
-		printRespJSON(&litrpc.ListSessionsResponse{Sessions: sessions})
+		printRespJSON(resp)
 		return nil
 	}
 }
 
+// printSessionsTable prints the given sessions as a table with one row per
+// session.
+func printSessionsTable(sessions []*litrpc.Session) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(
+		w, "LABEL\tSTATE\tTYPE\tLOCAL PUBKEY\tCREATED\tEXPIRES",
+	)
+	for _, sess := range sessions {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%x\t%s\t%s\n", sess.Label,
+			strings.TrimPrefix(sess.SessionState.String(), "STATE_"),
+			strings.TrimPrefix(sess.SessionType.String(), "TYPE_"),
+			sess.LocalPublicKey, formatTimestamp(sess.CreatedAt),
+			formatTimestamp(sess.ExpiryTimestampSeconds))
+	}
+	_ = w.Flush()
+}
+
+// formatTimestamp formats the given Unix timestamp in seconds for a table.
+func formatTimestamp(timestamp uint64) string {
+	if timestamp == 0 {
+		return "-"
+	}
+
+	return time.Unix(int64(timestamp), 0).Format(time.RFC3339)
+}
+
 var revokeSessionCommand = cli.Command{
-	Name:        "revoke",
-	ShortName:   "r",
-	Usage:       "Revoke a Lightning Node Connect session.",
-	Description: "Revoke an active session.",
-	Action:      revokeSession,
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "Revoke a Lightning Node Connect session.",
+	Description: "Revoke an active session, identified either by its " +
+		"local pubkey or by its label.",
+	Action: revokeSession,
+	Flags: []cli.Flag{
+		humanFlag,
+		cli.StringFlag{
+			Name:  "localpubkey",
+			Usage: "The local pubkey of the session to revoke.",
+		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "The label of the session to revoke. Fails if " +
+				"more than one session that isn't revoked " +
+				"has the label.",
+		},
+	},
+}
+
+func revokeSession(ctx *cli.Context) error {
+	if ctx.IsSet("localpubkey") == ctx.IsSet("label") {
+		return fmt.Errorf("exactly one of --localpubkey or --label " +
+			"must be set")
+	}
+
+	var (
+		pubkey []byte
+		err    error
+	)
+	if ctx.IsSet("localpubkey") {
+		pubkey, err = hex.DecodeString(ctx.String("localpubkey"))
+		if err != nil {
+			return err
+		}
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.RevokeSession(
+		ctxb, &litrpc.RevokeSessionRequest{
+			LocalPublicKey: pubkey,
+			Label:          ctx.String("label"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("human") {
+		fmt.Println("Session revoked")
+
+		return nil
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var rotateSessionKeyCommand = cli.Command{
+	Name:  "rotatekey",
+	Usage: "Rotate the macaroon root key of a session.",
+	Description: "Replace the macaroon root key of a session with a new " +
+		"one and print the session's new macaroon. All macaroons " +
+		"baked with the old root key stop working. The session " +
+		"keeps its ID, label, permissions and metadata.",
+	Action: rotateSessionKey,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "localpubkey",
+			Usage: "The local pubkey of the session to rotate the " +
+				"root key of.",
+			Required: true,
+		},
+	},
+}
+
+func rotateSessionKey(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.RotateSessionKey(
+		ctxb, &litrpc.RotateSessionKeyRequest{
+			LocalPublicKey: pubkey,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var renewSessionCommand = cli.Command{
+	Name:  "renew",
+	Usage: "Extend the expiry of a session.",
+	Description: "Set a new expiry for a session that is neither " +
+		"revoked nor expired yet. The session keeps its pairing, so " +
+		"the remote client doesn't need to pair again.",
+	Action: renewSession,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:     "localpubkey",
-			Usage:    "The local pubkey of the session to revoke.",
+			Usage:    "The local pubkey of the session to renew.",
+			Required: true,
+		},
+		cli.Uint64Flag{
+			Name: "expiry",
+			Usage: "The number of seconds from now that the " +
+				"session should remain active.",
 			Required: true,
 		},
 	},
 }
 
-func revokeSession(ctx *cli.Context) error {
+func renewSession(ctx *cli.Context) error {
 	clientConn, cleanup, err := connectClient(ctx, false)
 	if err != nil {
 		return err
@@ -292,10 +715,581 @@ func revokeSession(ctx *cli.Context) error {
 		return err
 	}
 
+	sessionLength := time.Second * time.Duration(ctx.Uint64("expiry"))
+	sessionExpiry := time.Now().Add(sessionLength).Unix()
+
 	ctxb := context.Background()
-	resp, err := client.RevokeSession(
-		ctxb, &litrpc.RevokeSessionRequest{
+	resp, err := client.RenewSession(
+		ctxb, &litrpc.RenewSessionRequest{
+			LocalPublicKey:         pubkey,
+			ExpiryTimestampSeconds: uint64(sessionExpiry),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var importSessionCommand = cli.Command{
+	Name:  "import",
+	Usage: "Import a session that was exported from another node.",
+	Description: "Add a session that was exported from another litd " +
+		"node to the session store. The session keeps its pairing, " +
+		"so the remote client doesn't need to pair again.",
+	Action: importSession,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "file",
+			Usage:    "The file that contains the exported session.",
+			Required: true,
+		},
+		cli.BoolFlag{
+			Name: "overwrite",
+			Usage: "Replace an existing session with the same " +
+				"local pubkey.",
+		},
+		cli.StringFlag{
+			Name: "passphrase",
+			Usage: "The passphrase the session was exported " +
+				"with, if it is encrypted.",
+		},
+	},
+}
+
+func importSession(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	fileName := lncfg.CleanAndExpandPath(ctx.String("file"))
+	export, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("error reading exported session from %s: %v",
+			fileName, err)
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ImportSession(
+		ctxb, &litrpc.ImportSessionRequest{
+			Session:    export,
+			Overwrite:  ctx.Bool("overwrite"),
+			Passphrase: ctx.String("passphrase"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var exportSessionCommand = cli.Command{
+	Name:  "export",
+	Usage: "Export a session to import it on another node.",
+	Description: "Write a session, including its private key, to a " +
+		"file so that it can be imported on another litd node. If a " +
+		"passphrase is given, the file is encrypted with it, " +
+		"otherwise the file must be kept secret.",
+	Action: exportSession,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "The local pubkey of the session to export.",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "file",
+			Usage:    "The file to write the exported session to.",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "passphrase",
+			Usage: "The passphrase to encrypt the export with.",
+		},
+	},
+}
+
+func exportSession(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ExportSession(
+		ctxb, &litrpc.ExportSessionRequest{
 			LocalPublicKey: pubkey,
+			Passphrase:     ctx.String("passphrase"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fileName := lncfg.CleanAndExpandPath(ctx.String("file"))
+	if err := os.WriteFile(fileName, resp.Session, 0600); err != nil {
+		return fmt.Errorf("error writing exported session to %s: %v",
+			fileName, err)
+	}
+
+	fmt.Printf("Session exported to %s\n", fileName)
+
+	return nil
+}
+
+var checkSessionPermissionsCommand = cli.Command{
+	Name:  "checkperms",
+	Usage: "Check which permissions a session lacks for a method.",
+	Description: "Check whether the macaroon of a session grants the " +
+		"permissions the given method requires and list the " +
+		"missing ones. Caveats of the session are not checked.",
+	Action: checkSessionPermissions,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "The local pubkey of the session to check.",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name: "method",
+			Usage: "The full gRPC URI of the method, for example " +
+				"/lnrpc.Lightning/GetInfo.",
+			Required: true,
+		},
+	},
+}
+
+func checkSessionPermissions(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.CheckSessionPermissions(
+		ctxb, &litrpc.CheckSessionPermissionsRequest{
+			LocalPublicKey: pubkey,
+			Method:         ctx.String("method"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var previewMethodPolicyCommand = cli.Command{
+	Name:  "previewpolicy",
+	Usage: "Preview which sessions a method policy change affects.",
+	Description: "List the active sessions that would lose access to " +
+		"the denied methods and the ones that would gain access to " +
+		"the allowed methods. Nothing is changed.",
+	Action: previewMethodPolicy,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "deny",
+			Usage: "A full gRPC URI or a regular expression of the " +
+				"methods to deny. This flag can be specified " +
+				"multiple times.",
+		},
+		cli.StringSliceFlag{
+			Name: "allow",
+			Usage: "A full gRPC URI or a regular expression of the " +
+				"methods to allow. This flag can be specified " +
+				"multiple times.",
+		},
+	},
+}
+
+func previewMethodPolicy(ctx *cli.Context) error {
+	deny, allow := ctx.StringSlice("deny"), ctx.StringSlice("allow")
+	if len(deny) == 0 && len(allow) == 0 {
+		return fmt.Errorf("at least one of --deny or --allow must " +
+			"be set")
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.PreviewMethodPolicy(
+		ctxb, &litrpc.PreviewMethodPolicyRequest{
+			DenyMethods:  deny,
+			AllowMethods: allow,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var sessionEventsCommand = cli.Command{
+	Name:  "events",
+	Usage: "Stream the events of Lightning Node Connect sessions.",
+	Description: "Stream an event whenever a session is created, " +
+		"revoked or expires. Only new events are streamed unless " +
+		"from_event_id is set.",
+	Action: sessionEvents,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "from_event_id",
+			Usage: "Replay the stored events starting at this " +
+				"event ID before streaming new ones. To " +
+				"resume, set it to one more than the ID of " +
+				"the last event received.",
+		},
+	},
+}
+
+func sessionEvents(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	stream, err := client.SubscribeSessionEvents(
+		ctxb, &litrpc.SubscribeSessionEventsRequest{
+			FromEventId: ctx.Uint64("from_event_id"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(event)
+	}
+}
+
+var sessionUsageCommand = cli.Command{
+	Name:  "usage",
+	Usage: "Show the number of requests sessions made per period.",
+	Description: "Show the number of requests each session made per " +
+		"day or per week. Sessions that made no requests within " +
+		"the retention have no buckets, which helps to find unused " +
+		"sessions.",
+	Action: sessionUsage,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "localpubkey",
+			Usage: "The local pubkey of the session to show the " +
+				"usage of. If not set, all sessions are shown.",
+		},
+		cli.StringFlag{
+			Name:  "period",
+			Usage: "The period of each bucket, either day or week.",
+			Value: "day",
+		},
+		cli.Uint64Flag{
+			Name: "start_time",
+			Usage: "Only show buckets that end after this unix " +
+				"timestamp.",
+		},
+		cli.Uint64Flag{
+			Name: "end_time",
+			Usage: "Only show buckets that start before this unix " +
+				"timestamp.",
+		},
+		cli.BoolFlag{
+			Name: "csv",
+			Usage: "Print one CSV line per session and bucket " +
+				"instead of JSON.",
+		},
+	},
+}
+
+func sessionUsage(ctx *cli.Context) error {
+	var period litrpc.UsagePeriod
+	switch ctx.String("period") {
+	case "day":
+		period = litrpc.UsagePeriod_USAGE_PERIOD_DAY
+	case "week":
+		period = litrpc.UsagePeriod_USAGE_PERIOD_WEEK
+	default:
+		return fmt.Errorf("unsupported period %s", ctx.String("period"))
+	}
+
+	var (
+		pubkey []byte
+		err    error
+	)
+	if ctx.IsSet("localpubkey") {
+		pubkey, err = hex.DecodeString(ctx.String("localpubkey"))
+		if err != nil {
+			return err
+		}
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.GetSessionUsage(
+		ctxb, &litrpc.GetSessionUsageRequest{
+			LocalPublicKey: pubkey,
+			Period:         period,
+			StartTimestamp: ctx.Uint64("start_time"),
+			EndTimestamp:   ctx.Uint64("end_time"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool("csv") {
+		printRespJSON(resp)
+
+		return nil
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	err = w.Write([]string{
+		"local_public_key", "label", "state", "bucket_start",
+		"requests",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range resp.Sessions {
+		record := []string{
+			hex.EncodeToString(sess.LocalPublicKey), sess.Label,
+			sess.SessionState.String(), "", "0",
+		}
+
+		// Sessions without requests get a single line, so they show
+		// up as unused.
+		if len(sess.Buckets) == 0 {
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+
+		for _, bucket := range sess.Buckets {
+			record[3] = time.Unix(
+				int64(bucket.StartTimestamp), 0,
+			).UTC().Format(time.RFC3339)
+			record[4] = strconv.FormatUint(bucket.Requests, 10)
+
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+var attenuateMacaroonCommand = cli.Command{
+	Name:  "attenuate",
+	Usage: "Add caveats to a macaroon to restrict it further.",
+	Description: "Derive a more restricted macaroon from the given one " +
+		"by adding caveats. The added caveats can only narrow what " +
+		"the macaroon allows, never broaden it.",
+	Action: attenuateMacaroon,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "macaroon",
+			Usage:    "The hex encoded macaroon to restrict.",
+			Required: true,
+		},
+		cli.StringSliceFlag{
+			Name: "caveat",
+			Usage: "A first party caveat to add as it is, for " +
+				"example \"ipaddr 192.168.1.1\". This flag " +
+				"can be specified multiple times.",
+		},
+		cli.Uint64Flag{
+			Name: "timeout",
+			Usage: "The number of seconds after which the " +
+				"macaroon expires.",
+		},
+		cli.StringFlag{
+			Name: "ip_address",
+			Usage: "The IP address the macaroon can only be used " +
+				"from.",
+		},
+		cli.StringSliceFlag{
+			Name: "allowedop",
+			Usage: "A loop or pool operation the macaroon may " +
+				"execute. This flag can be specified " +
+				"multiple times.",
+		},
+	},
+}
+
+func attenuateMacaroon(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.AttenuateMacaroon(
+		ctxb, &litrpc.AttenuateMacaroonRequest{
+			Macaroon:          ctx.String("macaroon"),
+			Caveats:           ctx.StringSlice("caveat"),
+			TimeoutSeconds:    ctx.Uint64("timeout"),
+			IpAddress:         ctx.String("ip_address"),
+			AllowedOperations: ctx.StringSlice("allowedop"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var checkSessionStoreCommand = cli.Command{
+	Name:  "checkstore",
+	Usage: "Check the session store for inconsistencies.",
+	Description: "Audit the session store for index entries that have " +
+		"no session and sessions that are missing from the index, " +
+		"for example after an unclean shutdown. Use the --repair " +
+		"flag to also fix them. The super macaroon root keys in " +
+		"lnd are compared with the root keys of the active " +
+		"sessions as well, which reports root keys without a " +
+		"session and sessions whose root key is missing.",
+	Action: checkSessionStore,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "repair",
+			Usage: "Remove orphaned index entries and re-index " +
+				"sessions that are missing from the index.",
+		},
+		cli.BoolFlag{
+			Name: "delete_orphaned_root_keys",
+			Usage: "Delete the root keys without a session from " +
+				"lnd. The root keys of super macaroons baked " +
+				"by LiT are kept.",
+		},
+	},
+}
+
+func checkSessionStore(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.CheckSessionStore(
+		ctxb, &litrpc.CheckSessionStoreRequest{
+			Repair: ctx.Bool("repair"),
+			DeleteOrphanedRootKeys: ctx.Bool(
+				"delete_orphaned_root_keys",
+			),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var testWebhookCommand = cli.Command{
+	Name:  "testwebhook",
+	Usage: "Send a signed test event to a webhook endpoint.",
+	Description: "Send a synthetic test event, signed with the given " +
+		"secret, to the webhook URL and report the HTTP status code " +
+		"and the round trip time.",
+	Action: testWebhook,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "url",
+			Usage:    "The http or https URL of the webhook.",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name: "secret",
+			Usage: "The hex encoded secret that is used to sign " +
+				"the test event.",
+			Required: true,
+		},
+		cli.Uint64Flag{
+			Name: "timeout",
+			Usage: "The maximum number of seconds to wait for the " +
+				"endpoint to respond.",
+		},
+	},
+}
+
+func testWebhook(ctx *cli.Context) error {
+	secret, err := hex.DecodeString(ctx.String("secret"))
+	if err != nil {
+		return fmt.Errorf("invalid secret: %v", err)
+	}
+
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.TestWebhook(
+		ctxb, &litrpc.TestWebhookRequest{
+			Url:            ctx.String("url"),
+			Secret:         secret,
+			TimeoutSeconds: uint32(ctx.Uint64("timeout")),
 		},
 	)
 	if err != nil {
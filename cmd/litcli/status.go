@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
 
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/urfave/cli"
 )
@@ -15,6 +19,14 @@ var statusCommands = []cli.Command{
 		Description: "View info about litd status.\n",
 		Category:    "LiT",
 		Action:      getStatus,
+		Subcommands: []cli.Command{
+			simulateAuthCommand,
+			tlsCertChainCommand,
+			stepUpCommand,
+			healthCommand,
+			subServerStateCommand,
+			litStatusCommand,
+		},
 	},
 }
 
@@ -48,3 +60,240 @@ func getStatus(ctx *cli.Context) error {
 
 	return nil
 }
+
+var simulateAuthCommand = cli.Command{
+	Name:      "simulateauth",
+	ShortName: "sa",
+	Usage:     "Find out why a credential is rejected",
+	Description: "Run LiT's authentication pipeline for the given " +
+		"macaroon or UI password and method and show which checks " +
+		"passed and which failed. The method itself is not called.",
+	Action: simulateAuth,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "method",
+			Usage: "the full gRPC URI of the method, for example " +
+				"/lnrpc.Lightning/GetInfo",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name: "authmacaroonpath",
+			Usage: "the path to the macaroon to simulate the " +
+				"authentication for",
+		},
+		cli.StringFlag{
+			Name: "authpassword",
+			Usage: "the UI password to simulate the " +
+				"authentication for",
+		},
+	},
+}
+
+func simulateAuth(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	req := &litrpc.SimulateAuthRequest{
+		Method:   ctx.String("method"),
+		Password: ctx.String("authpassword"),
+	}
+
+	if ctx.IsSet("authmacaroonpath") {
+		macBytes, err := os.ReadFile(lncfg.CleanAndExpandPath(
+			ctx.String("authmacaroonpath"),
+		))
+		if err != nil {
+			return fmt.Errorf("unable to read macaroon: %v", err)
+		}
+		req.Macaroon = hex.EncodeToString(macBytes)
+	}
+
+	resp, err := client.SimulateAuth(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var tlsCertChainCommand = cli.Command{
+	Name:  "tlscertchain",
+	Usage: "Fetch the full TLS cert chain presented by litd",
+	Description: "Fetch the full TLS certificate chain, including any " +
+		"intermediate certificates, that litd presents on its main " +
+		"HTTPS listener.",
+	Action: getTLSCertChain,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "pem",
+			Usage: "only print the PEM encoded chain, for example " +
+				"to redirect it into a file",
+		},
+	},
+}
+
+func getTLSCertChain(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.GetTLSCertChain(
+		context.Background(), &litrpc.GetTLSCertChainRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("pem") {
+		fmt.Print(resp.PemChain)
+
+		return nil
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var stepUpCommand = cli.Command{
+	Name:  "stepup",
+	Usage: "Get a one-time token for a sensitive method",
+	Description: "Exchange the UI password for a one-time token that " +
+		"allows a single call to a method that requires step-up " +
+		"authentication. Send the token in the lit-stepup-token " +
+		"header of the call.",
+	Action: stepUp,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "method",
+			Usage: "the full gRPC URI of the sensitive method, for " +
+				"example /lnrpc.Lightning/CloseChannel",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "authpassword",
+			Usage:    "the UI password",
+			Required: true,
+		},
+	},
+}
+
+func stepUp(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.StepUpAuth(
+		context.Background(), &litrpc.StepUpAuthRequest{
+			Password: ctx.String("authpassword"),
+			Method:   ctx.String("method"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var healthCommand = cli.Command{
+	Name:  "health",
+	Usage: "Check the health of lnd, litd and all enabled daemons",
+	Description: "Probe lnd, litd and each enabled daemon with a cheap " +
+		"call and report whether each of them responded and how " +
+		"long it took.",
+	Action: checkHealth,
+}
+
+func checkHealth(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.CheckHealth(
+		context.Background(), &litrpc.CheckHealthRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var subServerStateCommand = cli.Command{
+	Name:  "subservers",
+	Usage: "Show the state of lnd and all daemons managed by litd",
+	Description: "Show for lnd and each daemon whether it runs in " +
+		"integrated or remote mode or is disabled, whether it is " +
+		"currently running and the error of the last failed " +
+		"attempt to start or connect to it.",
+	Action: getSubServerState,
+}
+
+func getSubServerState(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.GetSubServerState(
+		context.Background(), &litrpc.GetSubServerStateRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var litStatusCommand = cli.Command{
+	Name:  "lit",
+	Usage: "Show the startup state of lnd as seen by litd",
+	Description: "Show whether lnd can't be reached yet, is waiting for " +
+		"its wallet to be unlocked, is syncing to the chain or is " +
+		"ready.",
+	Action: getLitStatus,
+}
+
+func getLitStatus(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.GetLitStatus(
+		context.Background(), &litrpc.GetLitStatusRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package terminal
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// minCompressSize is the minimum size of a response body in bytes for
+	// it to be compressed. Smaller responses don't get much smaller, so
+	// compressing them isn't worth the overhead.
+	minCompressSize = 1024
+
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var (
+	// incompressibleTypes are the content types that are already
+	// compressed, so compressing them again would only cost CPU time.
+	incompressibleTypes = map[string]struct{}{
+		"application/gzip":         {},
+		"application/zip":          {},
+		"application/x-gzip":       {},
+		"application/octet-stream": {},
+		"application/wasm":         {},
+		"font/woff":                {},
+		"font/woff2":               {},
+		"image/gif":                {},
+		"image/jpeg":               {},
+		"image/png":                {},
+		"image/webp":               {},
+		"image/x-icon":             {},
+		"image/vnd.microsoft.icon": {},
+		"video/mp4":                {},
+		"video/webm":               {},
+	}
+)
+
+// acceptedEncoding returns the compression encoding that should be used for
+// the response to the given request, or an empty string if the response
+// shouldn't be compressed. Gzip is preferred over deflate.
+func acceptedEncoding(req *http.Request) string {
+	// Partial content can't be compressed, since the range refers to the
+	// uncompressed body. A connection upgrade must reach the handler
+	// unmodified, so it can take over the connection.
+	if req.Header.Get("Range") != "" || req.Header.Get("Upgrade") != "" {
+		return ""
+	}
+
+	var gzipOk, deflateOk bool
+	accepted := strings.Split(req.Header.Get("Accept-Encoding"), ",")
+	for _, part := range accepted {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		// An encoding with a quality of 0 is explicitly not
+		// acceptable.
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(k, "q") {
+				continue
+			}
+
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case encodingGzip, "x-gzip", "*":
+			gzipOk = true
+		case encodingDeflate:
+			deflateOk = true
+		}
+	}
+
+	switch {
+	case gzipOk:
+		return encodingGzip
+	case deflateOk:
+		return encodingDeflate
+	default:
+		return ""
+	}
+}
+
+// compressResponseWriter compresses the response body with the given encoding
+// if it is worth it. The decision is made once the status and headers are
+// known and either the first minCompressSize bytes of the body were written
+// or the handler finished. Until then, the body is buffered.
+type compressResponseWriter struct {
+	http.ResponseWriter
+
+	encoding string
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	compressor  io.WriteCloser
+}
+
+// WriteHeader records the status code. It is only written once it is decided
+// whether the response is compressed, since that changes the headers.
+//
+// NOTE: this is part of the http.ResponseWriter interface.
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	// Informational responses are sent as they are and don't count as
+	// the final status.
+	if status >= 100 && status < 200 {
+		w.wroteHeader = false
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Write buffers or compresses the given part of the body.
+//
+// NOTE: this is part of the http.ResponseWriter interface.
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < minCompressSize && !w.knownLarge() {
+			return len(b), nil
+		}
+
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+
+		return len(b), nil
+	}
+
+	if w.compressor != nil {
+		return w.compressor.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// knownLarge returns true if the handler announced a body that is large
+// enough to be compressed.
+func (w *compressResponseWriter) knownLarge() bool {
+	length, err := strconv.Atoi(w.Header().Get("Content-Length"))
+	return err == nil && length >= minCompressSize
+}
+
+// shouldCompress returns true if the response should be compressed, given
+// whether its body is large enough.
+func (w *compressResponseWriter) shouldCompress(large bool) bool {
+	header := w.Header()
+
+	switch {
+	case !large:
+		return false
+
+	// Only complete, successful responses are compressed.
+	case w.status != http.StatusOK:
+		return false
+
+	// The handler compressed the body itself already.
+	case header.Get("Content-Encoding") != "":
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		// Without a content type, the body is sniffed by the HTTP
+		// server, which works better uncompressed.
+		return false
+	}
+	_, incompressible := incompressibleTypes[mediaType]
+
+	return !incompressible
+}
+
+// decide determines whether the response is compressed, writes the headers
+// and flushes the buffered part of the body.
+func (w *compressResponseWriter) decide(large bool) error {
+	w.decided = true
+
+	header := w.Header()
+	if w.shouldCompress(large) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+
+		if w.encoding == encodingGzip {
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		} else {
+			// Only invalid levels return an error.
+			w.compressor, _ = flate.NewWriter(
+				w.ResponseWriter, flate.DefaultCompression,
+			)
+		}
+	}
+
+	// Caches must not serve a compressed response to a client that
+	// doesn't accept it or the other way around.
+	header.Add("Vary", "Accept-Encoding")
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.compressor != nil {
+		_, err = w.compressor.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+
+	return err
+}
+
+// Flush sends the data written so far to the client. A response that is
+// flushed before it is decided whether it is compressed is sent uncompressed,
+// since streaming responses consist of small messages that don't compress
+// well.
+//
+// NOTE: this is part of the http.Flusher interface.
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		if err := w.decide(false); err != nil {
+			return
+		}
+	}
+
+	if f, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection.
+//
+// NOTE: this is part of the http.Hijacker interface.
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter,
+	error) {
+
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking not supported")
+	}
+
+	return h.Hijack()
+}
+
+// close finishes the response once the handler returned.
+func (w *compressResponseWriter) close() error {
+	if !w.decided {
+		// A handler that didn't write anything might still have set a
+		// status.
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		if err := w.decide(len(w.buf) >= minCompressSize); err != nil {
+			return err
+		}
+	}
+
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+
+	return nil
+}
+
+// makeCompressionHandler compresses the responses of the given handler with
+// gzip or deflate if the client accepts it. Responses that are too small or
+// that are already compressed are sent as they are.
+func makeCompressionHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter,
+		req *http.Request) {
+
+		encoding := acceptedEncoding(req)
+		if encoding == "" {
+			handler.ServeHTTP(resp, req)
+			return
+		}
+
+		w := &compressResponseWriter{
+			ResponseWriter: resp,
+			encoding:       encoding,
+		}
+		defer func() {
+			if err := w.close(); err != nil {
+				log.Debugf("Error finishing compressed "+
+					"response: %v", err)
+			}
+		}()
+
+		handler.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,119 @@
+package terminal
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompressionHandler tests that responses are only compressed if the
+// client accepts it and if it is worth it.
+func TestCompressionHandler(t *testing.T) {
+	large := bytes.Repeat([]byte("lightning "), minCompressSize)
+	small := []byte("{}")
+
+	handler := func(body []byte, contentType string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter,
+			_ *http.Request) {
+
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set(
+				"Content-Length", strconv.Itoa(len(body)),
+			)
+			_, _ = w.Write(body)
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+		body           []byte
+		contentType    string
+		expectEncoding string
+	}{{
+		name:           "gzip",
+		acceptEncoding: "gzip, deflate, br",
+		body:           large,
+		contentType:    "application/json",
+		expectEncoding: encodingGzip,
+	}, {
+		name:           "deflate",
+		acceptEncoding: "gzip;q=0, deflate",
+		body:           large,
+		contentType:    "text/html; charset=utf-8",
+		expectEncoding: encodingDeflate,
+	}, {
+		name:        "not accepted",
+		body:        large,
+		contentType: "application/json",
+	}, {
+		name:           "too small",
+		acceptEncoding: "gzip",
+		body:           small,
+		contentType:    "application/json",
+	}, {
+		name:           "already compressed",
+		acceptEncoding: "gzip",
+		body:           large,
+		contentType:    "image/png",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding",
+					tc.acceptEncoding)
+			}
+
+			rec := httptest.NewRecorder()
+			makeCompressionHandler(
+				handler(tc.body, tc.contentType),
+			).ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(
+				t, tc.expectEncoding,
+				resp.Header.Get("Content-Encoding"),
+			)
+
+			var body io.Reader = resp.Body
+			switch tc.expectEncoding {
+			case encodingGzip:
+				gzipReader, err := gzip.NewReader(resp.Body)
+				require.NoError(t, err)
+				body = gzipReader
+
+				require.Empty(
+					t, resp.Header.Get("Content-Length"),
+				)
+
+			case encodingDeflate:
+				body = flate.NewReader(resp.Body)
+
+				require.Empty(
+					t, resp.Header.Get("Content-Length"),
+				)
+
+			default:
+				require.Equal(
+					t, strconv.Itoa(len(tc.body)),
+					resp.Header.Get("Content-Length"),
+				)
+			}
+
+			decoded, err := io.ReadAll(body)
+			require.NoError(t, err)
+			require.Equal(t, tc.body, decoded)
+		})
+	}
+}
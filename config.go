@@ -1,14 +1,19 @@
 package terminal
 
 import (
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +25,7 @@ import (
 	"github.com/lightninglabs/lightning-terminal/accounts"
 	"github.com/lightninglabs/lightning-terminal/autopilotserver"
 	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/perms"
 	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
 	"github.com/lightninglabs/lightning-terminal/subservers"
 	"github.com/lightninglabs/lndclient"
@@ -34,6 +40,9 @@ import (
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/mwitkow/go-conntrack/connhelpers"
 	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
 const (
@@ -88,6 +97,14 @@ const (
 	DefaultMacaroonFilename = "lit.macaroon"
 
 	defaultFirstLNCConnTimeout = 10 * time.Minute
+
+	// defaultSessionCreateRate is the default maximum number of sessions
+	// that can be created per minute.
+	defaultSessionCreateRate = 30
+
+	// defaultSubServerRetryInterval is the default interval at which
+	// sub-servers that failed to start are retried.
+	defaultSubServerRetryInterval = time.Minute
 )
 
 var (
@@ -149,30 +166,73 @@ var (
 type Config struct {
 	HTTPSListen    string   `long:"httpslisten" description:"The host:port to listen for incoming HTTP/2 connections on for the web UI only."`
 	HTTPListen     string   `long:"insecure-httplisten" description:"The host:port to listen on with TLS disabled. This is dangerous to enable as credentials will be submitted without encryption. Should only be used in combination with Tor hidden services or other external encryption."`
+	HTTPPathPrefix string   `long:"httppathprefix" description:"Serve the UI, the gRPC web and the REST interface under this URL path prefix, for example /lightning, instead of at the root. This allows hosting LiT behind a reverse proxy under a sub path. Requests outside the prefix are answered with 404, except native gRPC requests, which can't carry a path prefix. The UI must be built with PUBLIC_URL set to the same prefix, so it loads its assets and makes its gRPC web calls under the prefix."`
 	EnableREST     bool     `long:"enablerest" description:"Also allow REST requests to be made to the main HTTP(s) port(s) configured above."`
-	RestCORS       []string `long:"restcors" description:"Add an ip:port/hostname to allow cross origin access from. To allow all origins, set as \"*\"."`
+	RestCORS       []string `long:"restcors" description:"Add an origin (for example https://app.example.com) to allow cross origin access from, to both the REST and the gRPC web interface. Browsers then get the CORS headers and pre-flight requests are answered. Specify this option multiple times to allow multiple origins. To allow all origins, set as \"*\". If no origin is set, only same origin requests are allowed. Only add trusted origins, gRPC web calls from them may carry the browser's cookies."`
 	UIPassword     string   `long:"uipassword" description:"The password that must be entered when using the UI. Use a strong password to protect your node from unauthorized access through the web UI."`
 	UIPasswordFile string   `long:"uipassword_file" description:"Same as uipassword but instead of passing in the value directly, read the password from the specified file."`
 	UIPasswordEnv  string   `long:"uipassword_env" description:"Same as uipassword but instead of passing in the value directly, read the password from the specified environment variable."`
+	UIPasswordHash string   `long:"uipassword_hash" description:"The bcrypt hash of the password that must be entered when using the UI, for example the part after the colon of the output of 'htpasswd -nbBC 12 lit <password>'. Can be used instead of uipassword, uipassword_file or uipassword_env, so the password itself doesn't need to be stored in the configuration."`
+	UIUsers        []string `long:"uiuser" description:"Add a named UI user in the form name:scope:hash, where scope is either admin for full access or readonly for the read permissions of all active daemons, and hash is the bcrypt hash of the user's password. Users authenticate with basic auth using their name and password, in addition to the uipassword. Specify this option multiple times to add multiple users."`
 	DisableUI      bool     `long:"disableui" description:"If set to true, no web UI will be served and so the uipassword will also not need to be set."`
 
+	RestMaxRequestSize int64 `long:"restmaxrequestsize" description:"The maximum size in bytes of the body of a REST or gRPC web request on the main HTTP(s) port(s). Larger requests are answered with 413 Request Entity Too Large before their body is passed on. Native gRPC requests are not affected."`
+
+	UIPasswordMinLength        int  `long:"lit-uipassword-minlength" description:"The minimum number of characters the UI password must have. Can't be lower than 8."`
+	UIPasswordRequireMixedCase bool `long:"lit-uipassword-requiremixedcase" description:"Require the UI password to contain both upper and lower case letters."`
+	UIPasswordRequireDigit     bool `long:"lit-uipassword-requiredigit" description:"Require the UI password to contain at least one digit."`
+	UIPasswordRequireSymbol    bool `long:"lit-uipassword-requiresymbol" description:"Require the UI password to contain at least one character that is neither a letter nor a digit."`
+
+	UIPasswordLimit *UIPasswordLimitConfig `group:"UI password rate limit options" namespace:"uipasswordlimit"`
+
+	JWT *JWTConfig `group:"JWT authentication options" namespace:"jwt"`
+
 	LetsEncrypt       bool   `long:"letsencrypt" description:"Use Let's Encrypt to create a TLS certificate for the UI instead of using lnd's TLS certificate. Port 80 must be free to listen on and must be reachable from the internet for this to work."`
 	LetsEncryptHost   string `long:"letsencrypthost" description:"The host name to create a Let's Encrypt certificate for."`
 	LetsEncryptDir    string `long:"letsencryptdir" description:"The directory where the Let's Encrypt library will store its key and certificate."`
 	LetsEncryptListen string `long:"letsencryptlisten" description:"The IP:port on which LiT will listen for Let's Encrypt challenges. Let's Encrypt will always try to contact on port 80. Often non-root processes are not allowed to bind to ports lower than 1024. This configuration option allows a different port to be used, but must be used in combination with port forwarding from port 80. This configuration can also be used to specify another IP address to listen on, for example an IPv6 address."`
 
-	TLSCertPath     string   `long:"tlscertpath" description:"Path to write the self signed TLS certificate for LiT's RPC and REST proxy service (if Let's Encrypt is not used). This only applies to the HTTPSListen port."`
-	TLSKeyPath      string   `long:"tlskeypath" description:"Path to write the self signed TLS private key for LiT's RPC and REST proxy service (if Let's Encrypt is not used). This only applies to the HTTPSListen port."`
+	TLSCertPath     string   `long:"tlscertpath" description:"Path to the TLS certificate for LiT's RPC and REST proxy service (if Let's Encrypt is not used). This only applies to the HTTPSListen port, lnd's RPC port always uses lnd's own certificate. If neither the certificate nor the key exists, a self signed certificate is written to this path, otherwise an existing certificate, for example a CA-signed one including its intermediate certificates, is used as is."`
+	TLSKeyPath      string   `long:"tlskeypath" description:"Path to the TLS private key for LiT's RPC and REST proxy service (if Let's Encrypt is not used). This only applies to the HTTPSListen port. If neither the certificate nor the key exists, a self signed key is written to this path, otherwise the existing key is used."`
 	TLSExtraIPs     []string `long:"tlsextraip" description:"Adds an extra ip to the generated LiT TLS certificate (if Let's Encrypt is not used)"`
 	TLSExtraDomains []string `long:"tlsextradomain" description:"Adds an extra domain to the generated LiT TLS certificate (if Let's Encrypt is not used)"`
+	TLSClientCAPath string   `long:"tlsclientcapath" description:"Path to a PEM file with the CA certificates that sign TLS client certificates. If set, clients of the HTTPSListen port can authenticate with a client certificate signed by one of these CAs (mTLS), but aren't required to present one. A client certificate is only used if the request carries no other credential and then grants the permissions of tlsclientscope. Can't be combined with lit-clientcapath."`
+	TLSClientScope  string   `long:"tlsclientscope" description:"The permissions a TLS client certificate signed by one of the CAs in tlsclientcapath grants. Either admin for full access, readonly for the read permissions of all active daemons or a comma separated list of entity:action permissions."`
+	LitClientCAPath string   `long:"lit-clientcapath" description:"Path to a PEM file with the CA certificates that sign TLS client certificates. If set, every client of the HTTPSListen port must present a client certificate signed by one of these CAs. Connections without one are rejected during the TLS handshake, before any request is handled. The certificate only grants access to the port and doesn't authenticate requests, so requests still need a macaroon or the UI password. Browsers accessing the UI must present a client certificate as well. Can't be combined with tlsclientcapath."`
+
+	AuthBackends []string `long:"authbackend" description:"The authentication backends that LiT's RPC proxy tries, in the given order, until one of them authenticates the request. Options are 'mtls' (a TLS client certificate, only if tlsclientcapath is set and the request carries no other credential), 'macaroon' (the macaroon header, this backend is required), 'apikey' (an API key in the X-Api-Key header), 'jwt' (a JWT bearer token, only if jwt.jwksurl or jwt.publickeypath is set) and 'password' (the UI password as basic auth, only if the UI is enabled). Specify this option multiple times to set the order. If all backends fail, the request is rejected with the errors of all backends that were tried." choice:"mtls" choice:"macaroon" choice:"apikey" choice:"jwt" choice:"password"`
 
 	LitDir     string `long:"lit-dir" description:"The main directory where LiT looks for its configuration file. If LiT is running in 'remote' lnd mode, this is also the directory where the TLS certificates and log files are stored by default."`
 	ConfigFile string `long:"configfile" description:"Path to LiT's configuration file."`
 
 	MacaroonPath string `long:"macaroonpath" description:"Path to write the macaroon for litd's RPC and REST services if it doesn't exist."`
 
+	MacaroonCookie string `long:"macaroon-cookie" description:"The name of an HTTP cookie that gRPC web and REST requests may use to send their hex encoded macaroon instead of a header. The cookie is only accepted over HTTPS and is ignored if the request carries a macaroon or basic auth header. Clients should set it with the Secure, HttpOnly and SameSite=Strict flags so it is never sent over plain HTTP, can't be read by JavaScript and isn't attached to cross-site requests."`
+
+	MaxActiveSessions uint32 `long:"lit-max-active-sessions" description:"The maximum number of sessions that may be active at the same time. New sessions are rejected once the limit is reached. Revoked and expired sessions don't count towards the limit. Set to 0 for no limit."`
+
+	SessionCreateRate uint32 `long:"lit-session-create-rate" description:"The maximum number of sessions that may be created per minute through AddSession and AddAutopilotSession combined. Requests beyond the limit are rejected with RESOURCE_EXHAUSTED. Set to 0 for no limit."`
+
+	MaxConnsPerIP  uint32   `long:"lit-max-conns-per-ip" description:"The maximum number of concurrent connections a single IP address may have open to the HTTPS and HTTP listeners. New connections beyond the limit are closed before the TLS handshake. Set to 0 for no limit."`
+	TrustedProxies []string `long:"lit-trusted-proxy" description:"The IP address or CIDR range of a reverse proxy in front of LiT. Connections from trusted proxies carry the requests of many clients and are therefore not limited by lit-max-conns-per-ip, the proxy should limit the connections of its clients itself. Can be specified multiple times."`
+
+	SessionUsageRetention time.Duration `long:"lit-session-usage-retention" description:"The duration for which the number of requests each session made per day is kept. The usage can be queried with the GetSessionUsage RPC, for example to find sessions that are no longer used. Set to 0 to not keep the daily usage. The total request count and the last use of each session are always shown in ListSessions."`
+
+	WebsocketPingInterval time.Duration `long:"lit-websocket-ping-interval" description:"The interval at which pings are sent to clients that stream gRPC web calls over a WebSocket. A stream is cancelled as soon as its WebSocket breaks, the pings make sure a client that silently went away is noticed as well. Must be at least 1s, set to 0 to disable the pings."`
+
+	MaxSessionStreams uint32 `long:"maxsessionstreams" description:"The maximum number of streams that may be active at the same time for a single session. This applies to all sessions that don't have their own limit set. Set to 0 for no limit."`
+
 	FirstLNCConnDeadline time.Duration `long:"firstlncconndeadline" description:"The duration after a new LNC session will be revoked if no connection is made with it. This only applies for the first connection which is made using the pairing phrase. "`
 
+	LogUnknownMethods bool `long:"logunknownmethods" description:"Log a warning with the peer address for every call to a method that LiT doesn't know. Such calls might indicate that someone is probing the RPC interface."`
+
+	SlowRequestThreshold time.Duration `long:"lit-slowrequest-threshold" description:"Requests handled by LiT's RPC proxy that take longer than this are logged with a warning. Streaming calls are not included. Set to 0 to disable."`
+
+	ReportJobTTL time.Duration `long:"lit-reportjob-ttl" description:"The duration for which the result of a completed faraday report job is kept before it is removed."`
+
+	OtelEndpoint string `long:"lit-otel-endpoint" description:"The host:port of an OpenTelemetry collector that accepts OTLP over gRPC. If set, a span is created for each request handled by LiT's RPC proxy and exported to this collector."`
+	OtelInsecure bool   `long:"lit-otel-insecure" description:"Don't use TLS when connecting to the OpenTelemetry collector."`
+
 	// Network is the Bitcoin network we're running on. This will be parsed
 	// before the configuration is loaded and will set the correct flag on
 	// `lnd.bitcoin.mainnet|testnet|regtest` and also for the other daemons.
@@ -190,6 +250,8 @@ type Config struct {
 	LndMode string      `long:"lnd-mode" description:"The mode to run lnd in, either 'remote' (default) or 'integrated'. 'integrated' means lnd is started alongside the UI and everything is stored in lnd's main data directory, configure everything by using the --lnd.* flags. 'remote' means the UI connects to an existing lnd node and acts as a proxy for gRPC calls to it. In the remote node LiT creates its own directory for log and configuration files, configure everything using the --remote.* flags." choice:"integrated" choice:"remote"`
 	Lnd     *lnd.Config `group:"Integrated lnd (use when lnd-mode=integrated)" namespace:"lnd"`
 
+	LitWalletUnlockPasswordFile string `long:"lit-wallet-unlock-password-file" description:"Path to a file that contains the password LiT unlocks the wallet of the integrated lnd with once lnd waits to be unlocked. If unlocking fails, lnd keeps waiting to be unlocked and the error is reported by the GetLitStatus call. The file must only be accessible by its owner. Only used in integrated lnd mode, can't be combined with lnd.wallet-unlock-password-file."`
+
 	FaradayMode string          `long:"faraday-mode" description:"The mode to run faraday in, either 'integrated' (default), 'remote' or 'disable'. 'integrated' means faraday is started alongside the UI and everything is stored in faraday's main data directory, configure everything by using the --faraday.* flags. 'remote' means the UI connects to an existing faraday node and acts as a proxy for gRPC calls to it. 'disable' means that LiT is started without faraday." choice:"integrated" choice:"remote" choice:"disable"`
 	Faraday     *faraday.Config `group:"Integrated faraday options (use when faraday-mode=integrated)" namespace:"faraday"`
 
@@ -210,6 +272,48 @@ type Config struct {
 
 	Accounts *accounts.Config `group:"Accounts options" namespace:"accounts"`
 
+	SuperMacaroon *SuperMacaroonConfig `group:"Super macaroon bootstrap options" namespace:"supermacaroon"`
+
+	AutoRevoke *AutoRevokeConfig `group:"Session auto revocation options" namespace:"autorevoke"`
+
+	RESTJSON *RESTJSONConfig `group:"REST JSON options" namespace:"restjson"`
+
+	UIFallback *UIFallbackConfig `group:"UI fallback options" namespace:"uifallback"`
+
+	UICSP *UICSPConfig `group:"UI Content-Security-Policy options" namespace:"uicsp"`
+
+	DefaultSession *DefaultSessionConfig `group:"Default session options" namespace:"defaultsession"`
+
+	Prometheus *PrometheusConfig `group:"Prometheus options" namespace:"prometheus"`
+
+	LndReplicas *LndReplicasConfig `group:"Remote lnd read replica options (use when lnd-mode=remote)" namespace:"lndreplicas"`
+
+	StreamLimits *StreamLimitsConfig `group:"Stream limit options" namespace:"streamlimits"`
+
+	HTTP2 *HTTP2Config `group:"HTTP/2 options" namespace:"http2"`
+
+	Keepalive *KeepaliveConfig `group:"gRPC keepalive options" namespace:"keepalive"`
+
+	Shutdown *ShutdownConfig `group:"Graceful shutdown options" namespace:"shutdown"`
+
+	BackendReconnect *BackendReconnectConfig `group:"Backend reconnect options" namespace:"backendreconnect"`
+
+	StepUp *StepUpConfig `group:"Step-up authentication options" namespace:"stepup"`
+
+	StaleOnError *StaleOnErrorConfig `group:"Stale response options" namespace:"staleonerror"`
+
+	SubServerStartup *SubServerStartupConfig `group:"Sub-server startup options" namespace:"subserverstartup"`
+
+	LndRecovery *LndRecoveryConfig `group:"lnd recovery options" namespace:"lndrecovery"`
+
+	SessionExpiryWarning *SessionExpiryWarningConfig `group:"Session expiry warning options" namespace:"sessionexpirywarning"`
+
+	Session *SessionCleanupConfig `group:"Session cleanup options" namespace:"session"`
+
+	ThirdPartyAuth *ThirdPartyAuthConfig `group:"Third party authorization options" namespace:"thirdpartyauth"`
+
+	Logging *LoggingConfig `group:"Logging options" namespace:"logging"`
+
 	// faradayRpcConfig is a subset of faraday's full configuration that is
 	// passed into faraday's RPC server.
 	faradayRpcConfig *frdrpcserver.Config
@@ -222,23 +326,503 @@ type Config struct {
 	poolRemote    bool
 	tapRemote     bool
 
+	// uiUsers is the parsed version of UIUsers.
+	uiUsers map[string]*uiUser
+
+	// trustedProxies is the parsed version of TrustedProxies.
+	trustedProxies []*net.IPNet
+
+	// tlsClientScope is the parsed version of TLSClientScope.
+	tlsClientScope *jwtScope
+
 	// lndAdminMacaroon is the admin macaroon that is given to us by lnd
 	// over an in-memory connection on startup. This is only set in
 	// integrated lnd mode.
 	lndAdminMacaroon []byte
 }
 
+// LndReplicasConfig holds the options for routing read-only lnd requests to
+// read replicas of the remote lnd.
+//
+// NOTE: The replicas are not guaranteed to be in sync with the primary. A read
+// that follows a write might therefore not yet see the write's effect.
+type LndReplicasConfig struct {
+	Replicas      []string `long:"replica" description:"The host:port of an lnd read replica, optionally followed by @weight (default 1) to control the share of read requests it receives. Can be specified multiple times. Read-only, non-streaming lnd requests are distributed across the healthy replicas, all other requests go to the primary remote lnd. A replica that fails a request because it is unavailable is skipped for 30 seconds. The replicas must accept the same macaroons as the primary, for example by sharing its macaroon database. Replicas might lag behind the primary, so a read that directly follows a write might not see its effect yet."`
+	TLSCertPath   string   `long:"tlscertpath" description:"The full path to the TLS cert of the replicas. If not set, the TLS cert of the primary remote lnd is used."`
+	PrimaryWeight uint32   `long:"primaryweight" description:"The weight of the primary remote lnd when distributing read requests. Set to 0 to only send reads to the primary if no replica is healthy."`
+
+	// replicas is the parsed version of Replicas.
+	replicas []lndReplicaAddr
+}
+
+// lndReplicaAddr is the address and weight of an lnd read replica.
+type lndReplicaAddr struct {
+	addr   string
+	weight uint32
+}
+
+// validate checks and parses the lnd read replica options.
+func (c *LndReplicasConfig) validate(lndRemote bool) error {
+	if len(c.Replicas) == 0 {
+		return nil
+	}
+
+	if !lndRemote {
+		return fmt.Errorf("replicas can only be used in remote lnd " +
+			"mode")
+	}
+
+	c.replicas = make([]lndReplicaAddr, 0, len(c.Replicas))
+	for _, replica := range c.Replicas {
+		addr, weightStr, hasWeight := strings.Cut(replica, "@")
+		if addr == "" {
+			return fmt.Errorf("invalid replica %s", replica)
+		}
+
+		weight := uint64(1)
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseUint(weightStr, 10, 32)
+			if err != nil || weight == 0 {
+				return fmt.Errorf("invalid weight of replica "+
+					"%s, must be a positive integer",
+					replica)
+			}
+		}
+
+		c.replicas = append(c.replicas, lndReplicaAddr{
+			addr:   addr,
+			weight: uint32(weight),
+		})
+	}
+
+	if c.TLSCertPath != "" {
+		c.TLSCertPath = lncfg.CleanAndExpandPath(c.TLSCertPath)
+	}
+
+	return nil
+}
+
+// HTTP2Config holds the limits that protect the main HTTPS listener against
+// HTTP/2 clients that flood it with new streams, stream resets or pings, like
+// in the Rapid Reset attack. Bursts of twice the configured rates are allowed.
+type HTTP2Config struct {
+	StreamRate uint32 `long:"streamrate" description:"The maximum number of new streams per second a single HTTP/2 connection may open. Connections that open streams faster are closed with a GOAWAY frame. Set to 0 for no limit."`
+	ResetRate  uint32 `long:"resetrate" description:"The maximum number of streams per second a single HTTP/2 client may reset. Connections that reset streams faster are closed with a GOAWAY frame. Set to 0 for no limit."`
+	PingRate   uint32 `long:"pingrate" description:"The maximum number of pings per second a single HTTP/2 client may send. Connections that send pings faster are closed with a GOAWAY frame. Set to 0 for no limit."`
+}
+
+// KeepaliveConfig holds the gRPC keepalive settings of LiT's RPC listener and
+// of the connections to remote daemons.
+type KeepaliveConfig struct {
+	Time    time.Duration `long:"time" description:"How long a gRPC connection may be idle before a keepalive ping is sent. Applies to the pings LiT sends to its clients as well as to the connections to a remote lnd and its read replicas, which must allow pings at this interval. Connections to remote sub-servers (loop, pool, faraday, taproot-assets) don't send keepalive pings, since those daemons only allow a ping every 5 minutes and disconnect clients that ping more often."`
+	Timeout time.Duration `long:"timeout" description:"How long to wait for the response to a keepalive ping before the connection is closed."`
+	MinPing time.Duration `long:"minping" description:"The minimum amount of time a client must wait between two keepalive pings. Clients that ping more often are disconnected with a GOAWAY frame."`
+}
+
+// SubServerStartupConfig holds the settings for handling sub-servers that
+// fail to start.
+type SubServerStartupConfig struct {
+	Strict        bool          `long:"strict" description:"Abort the startup of LiT if any of the enabled sub-servers (loop, pool, faraday, taproot-assets) fails to start or can't be connected to. By default LiT starts anyway, serves the healthy sub-servers and reports the failed ones as errored in the status."`
+	RetryInterval time.Duration `long:"retryinterval" description:"The interval at which starting or connecting to a sub-server that failed to do so at startup is retried. Calls to the sub-server fail with UNAVAILABLE until it is running. Set to 0 to not retry."`
+}
+
+// validate checks the sub-server startup options.
+func (c *SubServerStartupConfig) validate() error {
+	if c.RetryInterval < 0 {
+		return fmt.Errorf("retryinterval must not be negative")
+	}
+
+	return nil
+}
+
+// LndRecoveryConfig holds the settings for handling calls to lnd while lnd is
+// recovering its wallet.
+type LndRecoveryConfig struct {
+	AllowSafeCalls bool          `long:"allowsafecalls" description:"While lnd is recovering its wallet, forward the calls to lnd that don't depend on the wallet being synced, like GetInfo and GetRecoveryInfo, and reject all other calls to lnd with UNAVAILABLE: lnd in recovery. By default all calls to lnd except for the status are rejected until lnd is ready."`
+	PollInterval   time.Duration `long:"pollinterval" description:"The interval at which lnd is asked for the progress of its wallet recovery while LiT waits for lnd to be ready."`
+}
+
+// validate checks the lnd recovery options.
+func (c *LndRecoveryConfig) validate() error {
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("pollinterval must be positive")
+	}
+
+	return nil
+}
+
+// SessionExpiryWarningConfig holds the settings for warning about sessions
+// whose macaroon is about to expire.
+type SessionExpiryWarningConfig struct {
+	LeadTime      time.Duration `long:"leadtime" description:"The time before a session's macaroon expires at which a SESSION_EVENT_EXPIRING event is emitted on the session event stream, for example 24h. The warning is emitted once per session and again after the session was renewed. Set to 0 to disable expiry warnings."`
+	WebhookURL    string        `long:"webhookurl" description:"If set, each expiry warning is also sent as a signed session_expiring event to this http or https URL."`
+	WebhookSecret string        `long:"webhooksecret" description:"The secret used to sign the expiry warnings sent to the webhook. Must be set if webhookurl is set."`
+}
+
+// validate checks the session expiry warning options.
+func (c *SessionExpiryWarningConfig) validate() error {
+	if c.LeadTime < 0 {
+		return fmt.Errorf("leadtime must not be negative")
+	}
+
+	if c.WebhookURL == "" {
+		return nil
+	}
+
+	if c.LeadTime == 0 {
+		return fmt.Errorf("webhookurl requires leadtime to be set")
+	}
+
+	u, err := url.Parse(c.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhookurl: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhookurl must be an absolute http or " +
+			"https URL")
+	}
+
+	if c.WebhookSecret == "" {
+		return fmt.Errorf("webhooksecret must be set if webhookurl " +
+			"is set")
+	}
+
+	return nil
+}
+
+// StaleOnErrorConfig holds the settings for serving cached responses of
+// read-only calls if the backend daemon is unavailable.
+type StaleOnErrorConfig struct {
+	Enable       bool          `long:"enable" description:"Keep the last successful response of read-only unary calls that are forwarded to a backend daemon and return it instead of an UNAVAILABLE error if the daemon is down. Served responses carry the lit-stale-response header with the unix timestamp at which they were received."`
+	MaxStaleness time.Duration `long:"maxstaleness" description:"The maximum age of a cached response that is still served if the backend daemon is unavailable."`
+}
+
+// validate checks the stale response options.
+func (c *StaleOnErrorConfig) validate() error {
+	if c.Enable && c.MaxStaleness <= 0 {
+		return fmt.Errorf("maxstaleness must be positive")
+	}
+
+	return nil
+}
+
+// StepUpConfig holds the sensitive methods that require a fresh step-up token
+// for every call, on top of the regular authentication.
+type StepUpConfig struct {
+	Methods  []string      `long:"method" description:"The full gRPC URI of a sensitive method, for example /lnrpc.Lightning/CloseChannel, that requires a one-time step-up token in the lit-stepup-token header for every call, even if the request is otherwise authenticated. Tokens are issued by litrpc.Status/StepUpAuth in exchange for the UI password. Can be specified multiple times."`
+	TokenTTL time.Duration `long:"tokenttl" description:"The duration for which a step-up token is valid after it was issued."`
+
+	// methods is the parsed version of Methods.
+	methods map[string]struct{}
+}
+
+// validate checks the step-up options and parses the sensitive methods.
+func (c *StepUpConfig) validate(disableUI bool) error {
+	c.methods = make(map[string]struct{}, len(c.Methods))
+	for _, method := range c.Methods {
+		if !strings.HasPrefix(method, "/") {
+			return fmt.Errorf("invalid method %s, must be a full "+
+				"gRPC URI", method)
+		}
+
+		c.methods[method] = struct{}{}
+	}
+
+	if len(c.methods) == 0 {
+		return nil
+	}
+
+	if disableUI {
+		return fmt.Errorf("step-up tokens are issued for the UI " +
+			"password, which isn't available if the UI is " +
+			"disabled")
+	}
+
+	if c.TokenTTL <= 0 {
+		return fmt.Errorf("tokenttl must be positive")
+	}
+
+	return nil
+}
+
+// BackendReconnectConfig holds the settings for notifying streaming clients
+// once a backend daemon that dropped their stream is reachable again.
+type BackendReconnectConfig struct {
+	Notify bool          `long:"notify" description:"If a server-streaming call is dropped because the connection to the backend daemon was lost, wait for the connection to be re-established and then end the stream with a status that tells the client to re-subscribe."`
+	Wait   time.Duration `long:"wait" description:"The maximum duration a dropped stream waits for the backend daemon to reconnect. If the daemon doesn't reconnect in time, the stream ends with the original error."`
+}
+
+// validate checks that the backend reconnect config is sane.
+func (c *BackendReconnectConfig) validate() error {
+	if c.Notify && c.Wait <= 0 {
+		return fmt.Errorf("wait must be positive")
+	}
+
+	return nil
+}
+
+// StreamLimitsConfig holds the limits for the responses that LiT's RPC proxy
+// sends to the client of a single server-streaming call.
+//
+// NOTE: Slow clients are already covered by HTTP/2 flow control. If a client
+// doesn't read its responses fast enough, the proxy stops reading from the
+// backend instead of buffering the responses. These limits protect against
+// streams that send more data than a client should ever receive.
+type StreamLimitsConfig struct {
+	MaxMessages uint64   `long:"maxmessages" description:"The maximum number of messages that are sent to the client of a single server-streaming call. A stream that exceeds the limit is terminated with a ResourceExhausted error. Set to 0 for no limit."`
+	MaxBytes    uint64   `long:"maxbytes" description:"The maximum number of bytes that are sent to the client of a single server-streaming call. A stream that exceeds the limit is terminated with a ResourceExhausted error. Set to 0 for no limit."`
+	Methods     []string `long:"method" description:"Overrides the limits for a single method, in the format <uri>:<maxmessages>:<maxbytes>, for example /lnrpc.Lightning/SubscribeChannelGraph:0:1073741824. Set a limit to 0 to not limit the method in that regard. Can be specified multiple times."`
+
+	// methods is the parsed version of Methods.
+	methods map[string]streamLimit
+}
+
+// validate checks and parses the stream limit options.
+func (c *StreamLimitsConfig) validate() error {
+	c.methods = make(map[string]streamLimit, len(c.Methods))
+	for _, method := range c.Methods {
+		parts := strings.Split(method, ":")
+		if len(parts) != 3 || !strings.HasPrefix(parts[0], "/") {
+			return fmt.Errorf("invalid method limit %s, must be in "+
+				"the format <uri>:<maxmessages>:<maxbytes>",
+				method)
+		}
+
+		maxMessages, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max messages of method "+
+				"limit %s: %v", method, err)
+		}
+
+		maxBytes, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max bytes of method limit "+
+				"%s: %v", method, err)
+		}
+
+		if _, ok := c.methods[parts[0]]; ok {
+			return fmt.Errorf("duplicate method limit for %s",
+				parts[0])
+		}
+
+		c.methods[parts[0]] = streamLimit{
+			maxMessages: maxMessages,
+			maxBytes:    maxBytes,
+		}
+	}
+
+	return nil
+}
+
+// PrometheusConfig holds the options for exporting the metrics of LiT's RPC
+// proxy to Prometheus.
+type PrometheusConfig struct {
+	Listen       string   `long:"listen" description:"The host:port to serve the Prometheus metrics on, under the /metrics path, for example 127.0.0.1:9090. The metrics are served on their own listener and aren't protected by a macaroon, so the listener should only be reachable from localhost or a trusted network. If not set, no metrics are exported."`
+	MethodLabels string   `long:"methodlabels" description:"Controls the cardinality of the RPC metrics. 'none' aggregates the metrics by daemon only, which results in a small, fixed number of time series. 'allowlist' only adds a method label for the methods set with prometheus.method, all other methods are aggregated as 'other'. 'all' adds a method label for every method, which multiplies the number of time series by the number of called methods (several hundred) and can overload Prometheus on a busy node." choice:"none" choice:"allowlist" choice:"all"`
+	Methods      []string `long:"method" description:"The full gRPC URI of a method, for example /lnrpc.Lightning/GetInfo, that gets its own method label if prometheus.methodlabels=allowlist. Can be specified multiple times."`
+
+	PermissionDenials bool `long:"permissiondenials" description:"Also export a counter of the requests that were denied because of missing or insufficient credentials. The counter is labeled by daemon, by method as configured with prometheus.methodlabels and by the type of session the credential belongs to ('none' for credentials that don't belong to a session)."`
+}
+
+// validate checks the Prometheus options.
+func (c *PrometheusConfig) validate() error {
+	if c.MethodLabels == MethodLabelsAllowlist && len(c.Methods) == 0 {
+		return fmt.Errorf("at least one method must be set if "+
+			"methodlabels is %s", MethodLabelsAllowlist)
+	}
+
+	if c.MethodLabels != MethodLabelsAllowlist && len(c.Methods) > 0 {
+		return fmt.Errorf("method can only be set if methodlabels is "+
+			"%s", MethodLabelsAllowlist)
+	}
+
+	return nil
+}
+
+// DefaultSessionConfig holds the options for creating a default session on
+// the first startup of LiT.
+type DefaultSessionConfig struct {
+	Create            bool          `long:"create" description:"Create a default admin session on startup if no sessions exist yet. The session is only created once, it is not re-created on subsequent startups, even if it was revoked or expired. Note that the session is revoked if no LNC connection is made with it within firstlncconndeadline."`
+	MacaroonPath      string        `long:"macaroonpath" description:"The path the macaroon of the default session is written to. Required if defaultsession.create is set."`
+	Label             string        `long:"label" description:"The label of the default session."`
+	Expiry            time.Duration `long:"expiry" description:"The duration after which the default session expires."`
+	MailboxServerAddr string        `long:"mailboxserveraddr" description:"The address of the mailbox server the default session uses."`
+}
+
+// validate checks the default session options.
+func (c *DefaultSessionConfig) validate() error {
+	if !c.Create {
+		return nil
+	}
+
+	if c.MacaroonPath == "" {
+		return fmt.Errorf("macaroonpath must be set")
+	}
+	c.MacaroonPath = lncfg.CleanAndExpandPath(c.MacaroonPath)
+
+	if c.Label == "" {
+		return fmt.Errorf("label must be set")
+	}
+
+	if c.Expiry <= 0 {
+		return fmt.Errorf("expiry must be positive")
+	}
+
+	if c.MailboxServerAddr == "" {
+		return fmt.Errorf("mailboxserveraddr must be set")
+	}
+
+	return nil
+}
+
+// RESTJSONConfig holds the options that control how the REST proxy encodes
+// responses as JSON. By default, the field names from the proto files are used
+// and all fields are emitted, even if they have their default value.
+type RESTJSONConfig struct {
+	CamelCase bool `long:"camelcase" description:"Use lowerCamelCase JSON field names instead of the field names defined in the proto files. This is the equivalent of setting use_proto_names to false."`
+	OmitEmpty bool `long:"omitempty" description:"Omit fields that have their default value from JSON responses. This is the equivalent of setting emit_unpopulated (or emit_defaults) to false."`
+}
+
+// marshalOptions returns the proto JSON marshal options the REST proxy should
+// use.
+func (c *RESTJSONConfig) marshalOptions() protojson.MarshalOptions {
+	return protojson.MarshalOptions{
+		UseProtoNames:   !c.CamelCase,
+		EmitUnpopulated: !c.OmitEmpty,
+	}
+}
+
+// UIFallbackConfig holds the options that control how requests for unknown
+// paths of the web UI are answered. Since the UI is a single page app, its
+// client side routes are unknown to the file server and are answered with
+// index.html by default.
+type UIFallbackConfig struct {
+	Status   int      `long:"status" description:"The HTTP status code of the responses that serve index.html for a client side route. The default of 200 is needed for browsers to open client side routes directly, 404 can be used to still serve the UI but signal the missing resource to crawlers and monitoring."`
+	Prefixes []string `long:"prefix" description:"A path prefix, for example /loop, of the client side routes. If set, only unknown paths without a file extension that start with one of the prefixes are answered with index.html, all other unknown paths are answered with a plain 404. If not set, all unknown paths without a file extension are answered with index.html. Can be specified multiple times."`
+}
+
+// validate checks the UI fallback options.
+func (c *UIFallbackConfig) validate() error {
+	if http.StatusText(c.Status) == "" || c.Status < http.StatusOK {
+		return fmt.Errorf("invalid status code %d", c.Status)
+	}
+
+	for _, prefix := range c.Prefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("prefix %s must start with /", prefix)
+		}
+	}
+
+	return nil
+}
+
+// UICSPConfig holds the options for serving the web UI with a strict
+// Content-Security-Policy that only allows the scripts carrying a nonce that
+// is generated for each response.
+type UICSPConfig struct {
+	Enable bool   `long:"enable" description:"Serve index.html with a Content-Security-Policy header that contains a fresh random nonce for each response and add the nonce to the script and style tags of index.html. This allows the UI to run under a strict policy without 'unsafe-inline'."`
+	Policy string `long:"policy" description:"The Content-Security-Policy that is sent with index.html. Each {nonce} in the policy is replaced with the nonce of the response, so it must contain at least one."`
+}
+
+// validate checks the UI Content-Security-Policy options.
+func (c *UICSPConfig) validate() error {
+	if c.Enable && !strings.Contains(c.Policy, cspNoncePlaceholder) {
+		return fmt.Errorf("policy must contain %s", cspNoncePlaceholder)
+	}
+
+	return nil
+}
+
+// AutoRevokeConfig holds the options for automatically revoking sessions
+// whose macaroons repeatedly fail authentication, which might indicate that a
+// leaked credential is being probed.
+type AutoRevokeConfig struct {
+	MaxFailures uint32        `long:"maxfailures" description:"The number of authentication failures of a session's macaroon within the window after which the session is revoked automatically. Failures caused by temporary conditions, like a backend being unavailable, are not counted. Set to 0 to disable automatic revocation."`
+	Window      time.Duration `long:"window" description:"The time window in which the authentication failures of a session are counted."`
+}
+
+// validate checks the automatic session revocation options.
+func (c *AutoRevokeConfig) validate() error {
+	if c.MaxFailures != 0 && c.Window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+
+	return nil
+}
+
+// SuperMacaroonConfig holds the options for baking a super macaroon on
+// startup. This allows the macaroon to be provisioned without having to make
+// a BakeSuperMacaroon RPC call first.
+type SuperMacaroonConfig struct {
+	Path          string   `long:"path" description:"If set, a super macaroon is baked on startup and written to this path. Nothing is baked if the file already exists."`
+	ReadOnly      bool     `long:"readonly" description:"Only include the read permissions of all active daemons in the super macaroon. Cannot be combined with supermacaroon.permission."`
+	RootKeySuffix string   `long:"rootkeysuffix" description:"A 4-byte suffix, hex encoded as 8 characters, that makes up the last 4 bytes of the root key ID of the super macaroon. The first 4 bytes are always the super macaroon prefix ffeeddcc, so the suffix 01020304 results in the root key ID 0xffeeddcc01020304. This is the same suffix as the root_key_id_suffix of BakeSuperMacaroon. If not set, a random one is generated."`
+	Permissions   []string `long:"permission" description:"A permission in the form entity:action that should be included in the super macaroon. Can be specified multiple times. If not set, all permissions of all active daemons are included."`
+
+	// rootKeySuffix is the parsed version of RootKeySuffix.
+	rootKeySuffix [4]byte
+
+	// permissions is the parsed version of Permissions.
+	permissions []bakery.Op
+}
+
+// validate checks and parses the super macaroon bootstrap options.
+func (c *SuperMacaroonConfig) validate() error {
+	if c.Path == "" {
+		return nil
+	}
+
+	c.Path = lncfg.CleanAndExpandPath(c.Path)
+
+	if c.ReadOnly && len(c.Permissions) > 0 {
+		return fmt.Errorf("readonly and permission cannot be set at " +
+			"the same time")
+	}
+
+	if c.RootKeySuffix != "" {
+		suffix, err := hex.DecodeString(c.RootKeySuffix)
+		if err != nil {
+			return fmt.Errorf("invalid root key suffix: %v", err)
+		}
+		if len(suffix) != len(c.rootKeySuffix) {
+			return fmt.Errorf("root key suffix must be %d bytes",
+				len(c.rootKeySuffix))
+		}
+		copy(c.rootKeySuffix[:], suffix)
+	} else {
+		if _, err := rand.Read(c.rootKeySuffix[:]); err != nil {
+			return err
+		}
+	}
+
+	permissions, err := perms.ParsePermissions(c.Permissions)
+	if err != nil {
+		return err
+	}
+	c.permissions = permissions
+
+	return nil
+}
+
 // lndConnectParams returns the connection parameters to connect to the local
 // lnd instance.
 func (c *Config) lndConnectParams() (string, lndclient.Network, string,
 	string, []byte) {
 
 	// In remote lnd mode, we just pass along what was configured in the
-	// remote section of the lnd config.
+	// remote section of the lnd config. If lnd is verified against a CA
+	// bundle, the bundle takes the place of the cert since the lnd clients
+	// trust all certs in the file they are given.
 	if c.LndMode == ModeRemote {
+		tlsPath := c.Remote.Lnd.TLSCertPath
+		if c.Remote.Lnd.TLSVerifyMode() == subservers.TLSVerifyCA {
+			tlsPath = c.Remote.Lnd.TLSCAPath
+		}
+
 		return c.Remote.Lnd.RPCServer,
 			lndclient.Network(c.Network),
-			lncfg.CleanAndExpandPath(c.Remote.Lnd.TLSCertPath),
+			lncfg.CleanAndExpandPath(tlsPath),
 			lncfg.CleanAndExpandPath(c.Remote.Lnd.MacaroonPath),
 			nil
 	}
@@ -247,9 +831,14 @@ func (c *Config) lndConnectParams() (string, lndclient.Network, string,
 	// the client dial address. But with TLS enabled by default, we
 	// cannot call 0.0.0.0 internally when dialing lnd as that IP
 	// address isn't in the cert. We need to rewrite it to the
-	// loopback address.
+	// loopback address. A Unix domain socket listener only has a path as
+	// its address, so we need to add the scheme for gRPC to dial it as a
+	// socket.
 	lndDialAddr := c.Lnd.RPCListeners[0].String()
 	switch {
+	case c.Lnd.RPCListeners[0].Network() == "unix":
+		lndDialAddr = subservers.UnixSocketScheme + lndDialAddr
+
 	case strings.Contains(lndDialAddr, "0.0.0.0"):
 		lndDialAddr = strings.Replace(
 			lndDialAddr, "0.0.0.0", "127.0.0.1", 1,
@@ -268,9 +857,10 @@ func (c *Config) lndConnectParams() (string, lndclient.Network, string,
 // defaultConfig returns a configuration struct with all default values set.
 func defaultConfig() *Config {
 	return &Config{
-		HTTPSListen: defaultHTTPSListen,
-		TLSCertPath: DefaultTLSCertPath,
-		TLSKeyPath:  defaultTLSKeyPath,
+		HTTPSListen:        defaultHTTPSListen,
+		RestMaxRequestSize: defaultRestMaxRequestSize,
+		TLSCertPath:        DefaultTLSCertPath,
+		TLSKeyPath:         defaultTLSKeyPath,
 		Remote: &subservers.RemoteConfig{
 			LitDebugLevel:     defaultLogLevel,
 			LitLogDir:         defaultLogDir,
@@ -280,52 +870,134 @@ func defaultConfig() *Config {
 				RPCServer:    defaultRemoteLndRpcServer,
 				MacaroonPath: DefaultRemoteLndMacaroonPath,
 				TLSCertPath:  lndDefaultConfig.TLSCertPath,
+				TLSVerify:    subservers.TLSVerifyCert,
 			},
 			Faraday: &subservers.RemoteDaemonConfig{
 				RPCServer:    defaultRemoteFaradayRpcServer,
 				MacaroonPath: faradayDefaultConfig.MacaroonPath,
 				TLSCertPath:  faradayDefaultConfig.TLSCertPath,
+				TLSVerify:    subservers.TLSVerifyCert,
 			},
 			Loop: &subservers.RemoteDaemonConfig{
 				RPCServer:    defaultRemoteLoopRpcServer,
 				MacaroonPath: loopDefaultConfig.MacaroonPath,
 				TLSCertPath:  loopDefaultConfig.TLSCertPath,
+				TLSVerify:    subservers.TLSVerifyCert,
 			},
 			Pool: &subservers.RemoteDaemonConfig{
 				RPCServer:    defaultRemotePoolRpcServer,
 				MacaroonPath: poolDefaultConfig.MacaroonPath,
 				TLSCertPath:  poolDefaultConfig.TLSCertPath,
+				TLSVerify:    subservers.TLSVerifyCert,
 			},
 			TaprootAssets: &subservers.RemoteDaemonConfig{
 				RPCServer:    defaultRemoteTapRpcServer,
 				MacaroonPath: tapDefaultConfig.RpcConf.MacaroonPath,
 				TLSCertPath:  tapDefaultConfig.RpcConf.TLSCertPath,
+				TLSVerify:    subservers.TLSVerifyCert,
 			},
 		},
-		Network:              DefaultNetwork,
-		LndMode:              DefaultLndMode,
-		Lnd:                  &lndDefaultConfig,
-		LitDir:               DefaultLitDir,
-		LetsEncryptListen:    defaultLetsEncryptListen,
-		LetsEncryptDir:       defaultLetsEncryptDir,
-		MacaroonPath:         DefaultMacaroonPath,
-		ConfigFile:           defaultConfigFile,
-		FaradayMode:          defaultFaradayMode,
-		Faraday:              &faradayDefaultConfig,
-		faradayRpcConfig:     &frdrpcserver.Config{},
-		LoopMode:             defaultLoopMode,
-		Loop:                 &loopDefaultConfig,
-		PoolMode:             defaultPoolMode,
-		Pool:                 &poolDefaultConfig,
-		TaprootAssetsMode:    defaultTapMode,
-		TaprootAssets:        &tapDefaultConfig,
-		RPCMiddleware:        mid.DefaultConfig(),
-		FirstLNCConnDeadline: defaultFirstLNCConnTimeout,
+		Network:               DefaultNetwork,
+		LndMode:               DefaultLndMode,
+		Lnd:                   &lndDefaultConfig,
+		LitDir:                DefaultLitDir,
+		LetsEncryptListen:     defaultLetsEncryptListen,
+		LetsEncryptDir:        defaultLetsEncryptDir,
+		MacaroonPath:          DefaultMacaroonPath,
+		ConfigFile:            defaultConfigFile,
+		FaradayMode:           defaultFaradayMode,
+		Faraday:               &faradayDefaultConfig,
+		faradayRpcConfig:      &frdrpcserver.Config{},
+		LoopMode:              defaultLoopMode,
+		Loop:                  &loopDefaultConfig,
+		PoolMode:              defaultPoolMode,
+		Pool:                  &poolDefaultConfig,
+		TaprootAssetsMode:     defaultTapMode,
+		TaprootAssets:         &tapDefaultConfig,
+		RPCMiddleware:         mid.DefaultConfig(),
+		FirstLNCConnDeadline:  defaultFirstLNCConnTimeout,
+		SlowRequestThreshold:  defaultSlowRequestThreshold,
+		UIPasswordMinLength:   uiPasswordMinLength,
+		SessionCreateRate:     defaultSessionCreateRate,
+		ReportJobTTL:          defaultReportJobTTL,
+		SessionUsageRetention: defaultSessionUsageRetention,
+		WebsocketPingInterval: defaultWebsocketPingInterval,
 		Autopilot: &autopilotserver.Config{
 			PingCadence: time.Hour,
 		},
-		Firewall: firewall.DefaultConfig(),
-		Accounts: &accounts.Config{},
+		Firewall:      firewall.DefaultConfig(),
+		Accounts:      &accounts.Config{},
+		SuperMacaroon: &SuperMacaroonConfig{},
+		AutoRevoke: &AutoRevokeConfig{
+			Window: defaultAutoRevokeWindow,
+		},
+		RESTJSON: &RESTJSONConfig{},
+		UIFallback: &UIFallbackConfig{
+			Status: http.StatusOK,
+		},
+		UICSP: &UICSPConfig{
+			Policy: defaultUICSPPolicy,
+		},
+		DefaultSession: &DefaultSessionConfig{
+			Label:             defaultSessionLabel,
+			Expiry:            defaultSessionExpiry,
+			MailboxServerAddr: defaultMailboxServerAddr,
+		},
+		Prometheus: &PrometheusConfig{
+			MethodLabels: MethodLabelsNone,
+		},
+		LndReplicas:    &LndReplicasConfig{},
+		AuthBackends:   append([]string(nil), defaultAuthBackends...),
+		TLSClientScope: defaultTLSClientScope,
+		StreamLimits: &StreamLimitsConfig{
+			MaxMessages: defaultStreamMaxMessages,
+			MaxBytes:    defaultStreamMaxBytes,
+		},
+		HTTP2: &HTTP2Config{
+			StreamRate: defaultHTTP2StreamRate,
+			ResetRate:  defaultHTTP2ResetRate,
+			PingRate:   defaultHTTP2PingRate,
+		},
+		Keepalive: &KeepaliveConfig{
+			Time:    defaultKeepaliveTime,
+			Timeout: defaultKeepaliveTimeout,
+			MinPing: defaultKeepaliveMinPing,
+		},
+		Shutdown: &ShutdownConfig{
+			Timeout: defaultShutdownTimeout,
+		},
+		BackendReconnect: &BackendReconnectConfig{
+			Wait: defaultBackendReconnectWait,
+		},
+		StepUp: &StepUpConfig{
+			TokenTTL: defaultStepUpTokenTTL,
+		},
+		StaleOnError: &StaleOnErrorConfig{
+			MaxStaleness: defaultMaxStaleness,
+		},
+		SubServerStartup: &SubServerStartupConfig{
+			RetryInterval: defaultSubServerRetryInterval,
+		},
+		LndRecovery: &LndRecoveryConfig{
+			PollInterval: defaultLndRecoveryPollInterval,
+		},
+		SessionExpiryWarning: &SessionExpiryWarningConfig{},
+		Session: &SessionCleanupConfig{
+			CleanupInterval: defaultSessionCleanupInterval,
+		},
+		ThirdPartyAuth: &ThirdPartyAuthConfig{},
+		Logging: &LoggingConfig{
+			Format: LogFormatConsole,
+		},
+		UIPasswordLimit: &UIPasswordLimitConfig{
+			MaxFailures: defaultPasswordMaxFailures,
+			Window:      defaultPasswordFailureWindow,
+			Lockout:     defaultPasswordLockout,
+		},
+		JWT: &JWTConfig{
+			JWKSRefresh: defaultJWKSRefreshInterval,
+			ScopeClaim:  defaultJWTScopeClaim,
+		},
 	}
 }
 
@@ -372,6 +1044,22 @@ func loadAndValidateConfig(interceptor signal.Interceptor) (*Config, error) {
 	cfg.poolRemote = cfg.PoolMode == ModeRemote
 	cfg.tapRemote = cfg.TaprootAssetsMode == ModeRemote
 
+	if err := validateRemoteTLSConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	// The JSON loggers replace some of the loggers we've set up above, so
+	// they need to be in place before the debug levels are set.
+	if err := cfg.Logging.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Logging.Format == LogFormatJSON {
+		err := setupJSONLogging(cfg, cfg.Lnd.LogWriter, interceptor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Now that we've registered all loggers, let's parse, validate, and set
 	// the debug log level(s). In remote lnd mode we have a global log level
 	// that overwrites all others. In integrated mode we use the lnd log
@@ -426,10 +1114,17 @@ func loadAndValidateConfig(interceptor signal.Interceptor) (*Config, error) {
 			return nil, fmt.Errorf("could not read UI password: %v",
 				err)
 		}
-		if len(cfg.UIPassword) < uiPasswordMinLength {
-			return nil, fmt.Errorf("please set a strong "+
-				"password for the UI, at least %d characters "+
-				"long", uiPasswordMinLength)
+
+		// The strength of a hashed password can't be checked.
+		if cfg.UIPassword != "" {
+			if err := cfg.validateUIPassword(); err != nil {
+				return nil, err
+			}
+		}
+
+		cfg.uiUsers, err = parseUIUsers(cfg.UIUsers)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -439,6 +1134,147 @@ func loadAndValidateConfig(interceptor signal.Interceptor) (*Config, error) {
 		)
 	}
 
+	if err := cfg.SuperMacaroon.validate(); err != nil {
+		return nil, fmt.Errorf("invalid super macaroon config: %v", err)
+	}
+
+	if err := cfg.AutoRevoke.validate(); err != nil {
+		return nil, fmt.Errorf("invalid auto revoke config: %v", err)
+	}
+
+	if err := cfg.UIFallback.validate(); err != nil {
+		return nil, fmt.Errorf("invalid UI fallback config: %v", err)
+	}
+
+	if err := cfg.UICSP.validate(); err != nil {
+		return nil, fmt.Errorf("invalid UI CSP config: %v", err)
+	}
+
+	if err := cfg.DefaultSession.validate(); err != nil {
+		return nil, fmt.Errorf("invalid default session config: %v",
+			err)
+	}
+
+	if err := cfg.Prometheus.validate(); err != nil {
+		return nil, fmt.Errorf("invalid prometheus config: %v", err)
+	}
+
+	if err := cfg.LndReplicas.validate(cfg.lndRemote); err != nil {
+		return nil, fmt.Errorf("invalid lnd replicas config: %v", err)
+	}
+
+	if err := cfg.StreamLimits.validate(); err != nil {
+		return nil, fmt.Errorf("invalid stream limits config: %v", err)
+	}
+
+	cfg.trustedProxies, err = parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.StepUp.validate(cfg.DisableUI); err != nil {
+		return nil, fmt.Errorf("invalid step-up config: %v", err)
+	}
+
+	if err := cfg.SubServerStartup.validate(); err != nil {
+		return nil, fmt.Errorf("invalid sub-server startup config: %v",
+			err)
+	}
+
+	if err := cfg.LndRecovery.validate(); err != nil {
+		return nil, fmt.Errorf("invalid lnd recovery config: %v", err)
+	}
+
+	if err := cfg.UIPasswordLimit.validate(); err != nil {
+		return nil, fmt.Errorf("invalid UI password limit config: %v",
+			err)
+	}
+
+	if err := cfg.SessionExpiryWarning.validate(); err != nil {
+		return nil, fmt.Errorf("invalid session expiry warning config: "+
+			"%v", err)
+	}
+
+	if err := cfg.Session.validate(); err != nil {
+		return nil, fmt.Errorf("invalid session cleanup config: %v",
+			err)
+	}
+
+	if err := cfg.ThirdPartyAuth.validate(); err != nil {
+		return nil, fmt.Errorf("invalid third party auth config: %v",
+			err)
+	}
+
+	if err := cfg.StaleOnError.validate(); err != nil {
+		return nil, fmt.Errorf("invalid stale on error config: %v", err)
+	}
+
+	if err := cfg.Keepalive.validate(); err != nil {
+		return nil, fmt.Errorf("invalid keepalive config: %v", err)
+	}
+
+	if err := cfg.Shutdown.validate(); err != nil {
+		return nil, fmt.Errorf("invalid shutdown config: %v", err)
+	}
+
+	if err := cfg.BackendReconnect.validate(); err != nil {
+		return nil, fmt.Errorf("invalid backend reconnect config: %v",
+			err)
+	}
+
+	cfg.HTTPPathPrefix, err = validateHTTPPathPrefix(cfg.HTTPPathPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid httppathprefix: %v", err)
+	}
+
+	if cfg.RestMaxRequestSize <= 0 {
+		return nil, fmt.Errorf("restmaxrequestsize must be positive")
+	}
+
+	if err := validateAuthBackends(cfg.AuthBackends); err != nil {
+		return nil, fmt.Errorf("invalid authbackend: %v", err)
+	}
+
+	if err := cfg.JWT.validate(); err != nil {
+		return nil, fmt.Errorf("invalid JWT config: %v", err)
+	}
+	if cfg.TLSClientCAPath != "" {
+		cfg.TLSClientCAPath = lncfg.CleanAndExpandPath(
+			cfg.TLSClientCAPath,
+		)
+	}
+	if cfg.LitClientCAPath != "" {
+		cfg.LitClientCAPath = lncfg.CleanAndExpandPath(
+			cfg.LitClientCAPath,
+		)
+	}
+	if cfg.TLSClientCAPath != "" && cfg.LitClientCAPath != "" {
+		return nil, fmt.Errorf("tlsclientcapath and lit-clientcapath " +
+			"can't be used together")
+	}
+	cfg.tlsClientScope, err = parseScope(
+		AuthBackendMTLS, cfg.TLSClientScope,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tlsclientscope: %v", err)
+	}
+
+	if cfg.ReportJobTTL <= 0 {
+		return nil, fmt.Errorf("lit-reportjob-ttl must be positive")
+	}
+
+	if cfg.WebsocketPingInterval != 0 &&
+		cfg.WebsocketPingInterval < time.Second {
+
+		return nil, fmt.Errorf("lit-websocket-ping-interval must be " +
+			"at least 1s or 0")
+	}
+
+	if cfg.SessionUsageRetention < 0 {
+		return nil, fmt.Errorf("lit-session-usage-retention must not " +
+			"be negative")
+	}
+
 	// Initiate our listeners. For now, we only support listening on one
 	// port at a time because we can only pass in one pre-configured RPC
 	// listener into lnd.
@@ -612,6 +1448,26 @@ func loadConfigFile(preCfg *Config, interceptor signal.Interceptor) (*Config,
 			return nil, err
 		}
 
+		// With our own password file, we unlock lnd ourselves, so a
+		// wrong password can be reported instead of lnd shutting down.
+		// lnd's own password file would unlock the wallet before we
+		// get the chance to, so only one of them can be used.
+		if cfg.LitWalletUnlockPasswordFile != "" {
+			if cfg.Lnd.WalletUnlockPasswordFile != "" {
+				return nil, fmt.Errorf("lit-wallet-unlock-" +
+					"password-file can't be combined " +
+					"with lnd.wallet-unlock-password-file")
+			}
+
+			path := lncfg.CleanAndExpandPath(
+				cfg.LitWalletUnlockPasswordFile,
+			)
+			if err := checkUnlockPasswordFile(path); err != nil {
+				return nil, err
+			}
+			cfg.LitWalletUnlockPasswordFile = path
+		}
+
 	// In remote lnd mode we skip the validation of the lnd configuration
 	// and instead just set up the logging (that would be done by lnd if it
 	// were running in the same process).
@@ -620,6 +1476,11 @@ func loadConfigFile(preCfg *Config, interceptor signal.Interceptor) (*Config,
 			return nil, err
 		}
 
+		if cfg.LitWalletUnlockPasswordFile != "" {
+			return nil, fmt.Errorf("lit-wallet-unlock-password-" +
+				"file is only supported in integrated lnd mode")
+		}
+
 	default:
 		return nil, fmt.Errorf("invalid lnd mode %v", cfg.LndMode)
 	}
@@ -699,6 +1560,12 @@ func validateRemoteModeConfig(cfg *Config) error {
 
 	r.LitLogDir = lncfg.CleanAndExpandPath(r.LitLogDir)
 
+	// lnd can be reached over a Unix domain socket. gRPC dials those
+	// natively, but only with an absolute path.
+	if err := r.Lnd.ValidateUnixSocket("remote.lnd"); err != nil {
+		return err
+	}
+
 	// In remote mode, we don't call lnd's ValidateConfig that sets up a
 	// logging backend for us. We need to manually create and start one. The
 	// root logger should've already been created as part of the default
@@ -717,6 +1584,45 @@ func validateRemoteModeConfig(cfg *Config) error {
 	return nil
 }
 
+// validateRemoteTLSConfig makes sure the TLS verification of each daemon that
+// LiT connects to in remote mode is configured consistently.
+func validateRemoteTLSConfig(cfg *Config) error {
+	remoteDaemons := []struct {
+		name   string
+		remote bool
+		cfg    *subservers.RemoteDaemonConfig
+	}{
+		{"remote.lnd", cfg.lndRemote, cfg.Remote.Lnd},
+		{"remote.faraday", cfg.faradayRemote, cfg.Remote.Faraday},
+		{"remote.loop", cfg.loopRemote, cfg.Remote.Loop},
+		{"remote.pool", cfg.poolRemote, cfg.Remote.Pool},
+		{
+			"remote.taproot-assets", cfg.tapRemote,
+			cfg.Remote.TaprootAssets,
+		},
+	}
+	for _, daemon := range remoteDaemons {
+		if !daemon.remote {
+			continue
+		}
+
+		if err := daemon.cfg.ValidateTLS(daemon.name); err != nil {
+			return err
+		}
+	}
+
+	// The lnd clients can only verify lnd against a cert or a CA bundle
+	// or not at all, so lnd's cert can't be pinned.
+	if cfg.lndRemote &&
+		cfg.Remote.Lnd.TLSVerify == subservers.TLSVerifyPinned {
+
+		return fmt.Errorf("remote.lnd: tlsverify=%s is not supported "+
+			"for lnd", subservers.TLSVerifyPinned)
+	}
+
+	return nil
+}
+
 // setNetwork parses the top-level network config options and, if valid, sets it
 // in all sub configuration structs. We also set the Bitcoin chain to active by
 // default as LiT won't support Litecoin in the foreseeable future.
@@ -750,9 +1656,36 @@ func setNetwork(cfg *Config) error {
 // readUIPassword reads the password for the UI either from the command line
 // flag, a file specified or an environment variable.
 func readUIPassword(config *Config) error {
+	// Only the hash of the password is configured. The password is
+	// checked against it when it is sent.
+	config.UIPasswordHash = strings.TrimSpace(config.UIPasswordHash)
+	if len(config.UIPasswordHash) > 0 {
+		if len(strings.TrimSpace(config.UIPassword)) > 0 ||
+			len(strings.TrimSpace(config.UIPasswordFile)) > 0 ||
+			len(strings.TrimSpace(config.UIPasswordEnv)) > 0 {
+
+			return fmt.Errorf("uipassword_hash can't be combined " +
+				"with uipassword, uipassword_file or " +
+				"uipassword_env")
+		}
+
+		_, err := bcrypt.Cost([]byte(config.UIPasswordHash))
+		if err != nil {
+			return fmt.Errorf("invalid uipassword_hash, must be a "+
+				"bcrypt hash: %v", err)
+		}
+
+		config.UIPassword = ""
+		return nil
+	}
+
 	// A password is passed in as a command line flag (or config file
 	// variable) directly.
 	if len(strings.TrimSpace(config.UIPassword)) > 0 {
+		log.Warnf("Storing the UI password in plaintext with " +
+			"uipassword is deprecated, use uipassword_hash, " +
+			"uipassword_file or uipassword_env instead")
+
 		config.UIPassword = strings.TrimSpace(config.UIPassword)
 		return nil
 	}
@@ -782,17 +1715,26 @@ func readUIPassword(config *Config) error {
 	}
 
 	return fmt.Errorf("mandatory password for UI not configured. specify " +
-		"either a password directly or a file or environment " +
-		"variable that contains the password")
+		"either a password directly, its hash or a file or " +
+		"environment variable that contains the password")
 }
 
-func buildTLSConfigForHttp2(config *Config) (*tls.Config, error) {
-	var tlsConfig *tls.Config
+// buildTLSConfigForHttp2 creates the TLS config of the main HTTPS listener.
+// If the certificate is loaded from files, the reloader that serves it is
+// returned as well. It is nil for Let's Encrypt certificates, which are
+// renewed automatically.
+func buildTLSConfigForHttp2(config *Config) (*tls.Config, *tlsCertReloader,
+	error) {
+
+	var (
+		tlsConfig *tls.Config
+		reloader  *tlsCertReloader
+	)
 
 	if config.LetsEncrypt {
 		serverName := config.LetsEncryptHost
 		if serverName == "" {
-			return nil, errors.New("let's encrypt host name " +
+			return nil, nil, errors.New("let's encrypt host name " +
 				"option is required for using let's encrypt")
 		}
 
@@ -838,7 +1780,7 @@ func buildTLSConfigForHttp2(config *Config) (*tls.Config, error) {
 				false, DefaultAutogenValidity,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("failed creating "+
+				return nil, nil, fmt.Errorf("failed creating "+
 					"self-signed cert: %v", err)
 			}
 
@@ -848,17 +1790,53 @@ func buildTLSConfigForHttp2(config *Config) (*tls.Config, error) {
 				tlsCertPath, tlsKeyPath, certBytes, keyBytes,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("failed storing "+
+				return nil, nil, fmt.Errorf("failed storing "+
 					"self-signed cert: %v", err)
 			}
 		}
 
-		tlsCert, _, err := cert.LoadCert(tlsCertPath, tlsKeyPath)
+		// The certificate is served through the reloader, so it can
+		// be replaced without restarting the listener.
+		var err error
+		reloader, err = newTLSCertReloader(tlsCertPath, tlsKeyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsCert, _ := reloader.getCertificate(nil)
+		tlsConfig = cert.TLSConfFromCert(*tlsCert)
+
+		// GetCertificate is only used if there's no static
+		// certificate.
+		tlsConfig.Certificates = nil
+		tlsConfig.GetCertificate = reloader.getCertificate
+	}
+
+	// If configured, clients can authenticate with a certificate that is
+	// signed by one of the client CAs. We don't require one, since the
+	// other authentication backends remain available. If lit-clientcapath
+	// is set instead, connections without a trusted client certificate
+	// are rejected during the handshake already.
+	clientCAPath, clientAuth := config.TLSClientCAPath,
+		tls.VerifyClientCertIfGiven
+	if config.LitClientCAPath != "" {
+		clientCAPath, clientAuth = config.LitClientCAPath,
+			tls.RequireAndVerifyClientCert
+	}
+	if clientCAPath != "" {
+		caBytes, err := os.ReadFile(clientCAPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed reading TLS server "+
-				"keys: %v", err)
+			return nil, nil, fmt.Errorf("unable to read TLS client CA "+
+				"file: %v", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caBytes) {
+			return nil, nil, fmt.Errorf("no valid certificates "+
+				"found in TLS client CA file %s", clientCAPath)
 		}
-		tlsConfig = cert.TLSConfFromCert(tlsCert)
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = clientAuth
 	}
 
 	// lnd's cipher suites are too restrictive for HTTP/2, we need to add
@@ -870,9 +1848,10 @@ func buildTLSConfigForHttp2(config *Config) (*tls.Config, error) {
 	)
 	tlsConfig, err := connhelpers.TlsConfigWithHttp2Enabled(tlsConfig)
 	if err != nil {
-		return nil, fmt.Errorf("can't configure h2 handling: %v", err)
+		return nil, nil, fmt.Errorf("can't configure h2 handling: %v",
+			err)
 	}
-	return tlsConfig, nil
+	return tlsConfig, reloader, nil
 }
 
 // makeDirectories creates the directory given and if necessary any parent
@@ -0,0 +1,192 @@
+package terminal
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// parseTrustedProxies parses the given IP addresses and CIDR ranges of
+// trusted proxies.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted "+
+					"proxy %s", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxy = fmt.Sprintf("%s/%d", ip, bits)
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %v",
+				proxy, err)
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// connCounter keeps track of the open connections of each IP address. It is
+// shared by all listeners, so the limit applies to the sum of a client's
+// connections.
+type connCounter struct {
+	maxConns       uint32
+	trustedProxies []*net.IPNet
+
+	mu sync.Mutex
+
+	// conns is the number of open connections of each IP.
+	conns map[string]uint32
+
+	// capped is the set of IPs that hit the limit since they last had
+	// fewer connections than allowed. It is used to only log the first
+	// rejected connection of a burst.
+	capped map[string]struct{}
+}
+
+// newConnCounter creates a new connection counter that allows the given number
+// of connections per IP. Connections of the given trusted proxies are not
+// limited.
+func newConnCounter(maxConns uint32,
+	trustedProxies []*net.IPNet) *connCounter {
+
+	return &connCounter{
+		maxConns:       maxConns,
+		trustedProxies: trustedProxies,
+		conns:          make(map[string]uint32),
+		capped:         make(map[string]struct{}),
+	}
+}
+
+// isTrustedProxy returns true if the given IP belongs to a trusted proxy.
+func (c *connCounter) isTrustedProxy(ip net.IP) bool {
+	for _, ipNet := range c.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acquire counts a new connection from the given IP. False is returned if the
+// IP already has the maximum number of connections open, in which case the
+// connection must be rejected.
+func (c *connCounter) acquire(ip string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conns[ip] >= c.maxConns {
+		if _, ok := c.capped[ip]; !ok {
+			c.capped[ip] = struct{}{}
+			log.Warnf("Connection limit of %d reached for %s, "+
+				"rejecting new connections", c.maxConns, ip)
+		}
+
+		return false
+	}
+
+	c.conns[ip]++
+
+	return true
+}
+
+// release counts a closed connection of the given IP.
+func (c *connCounter) release(ip string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conns[ip]--
+	if c.conns[ip] == 0 {
+		delete(c.conns, ip)
+	}
+
+	if _, ok := c.capped[ip]; ok {
+		delete(c.capped, ip)
+		log.Infof("Connection limit no longer reached for %s", ip)
+	}
+}
+
+// connLimitListener is a listener that closes new connections of IPs that
+// already have the maximum number of connections open. The connections are
+// closed right after they are accepted, so before a TLS listener that wraps
+// this listener starts the handshake.
+type connLimitListener struct {
+	net.Listener
+
+	counter *connCounter
+}
+
+// newConnLimitListener wraps the given listener with the limits of the given
+// counter.
+func newConnLimitListener(lis net.Listener,
+	counter *connCounter) *connLimitListener {
+
+	return &connLimitListener{
+		Listener: lis,
+		counter:  counter,
+	}
+}
+
+// Accept waits for and returns the next connection that is within the limits.
+//
+// NOTE: this is part of the net.Listener interface.
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		// We only limit TCP connections of clients. Connections of
+		// trusted proxies carry the requests of many clients.
+		addr, ok := conn.RemoteAddr().(*net.TCPAddr)
+		if !ok || l.counter.isTrustedProxy(addr.IP) {
+			return conn, nil
+		}
+
+		ip := addr.IP.String()
+		if !l.counter.acquire(ip) {
+			_ = conn.Close()
+			continue
+		}
+
+		return &limitedConn{
+			Conn: conn,
+			release: func() {
+				l.counter.release(ip)
+			},
+		}, nil
+	}
+}
+
+// limitedConn is a connection that is counted towards the limit of its IP
+// until it is closed.
+type limitedConn struct {
+	net.Conn
+
+	release   func()
+	closeOnce sync.Once
+}
+
+// Close closes the connection and releases its slot.
+//
+// NOTE: this is part of the net.Conn interface.
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(c.release)
+
+	return err
+}
@@ -0,0 +1,148 @@
+package terminal
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConnLimitListener starts a listener on localhost that is limited by
+// the given counter. The connections it accepts are sent on the returned
+// channel.
+func newTestConnLimitListener(t *testing.T,
+	counter *connCounter) (net.Listener, chan net.Conn) {
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	limited := newConnLimitListener(lis, counter)
+	t.Cleanup(func() {
+		_ = limited.Close()
+	})
+
+	accepted := make(chan net.Conn, 10)
+	go func() {
+		for {
+			conn, err := limited.Accept()
+			if err != nil {
+				return
+			}
+
+			accepted <- conn
+		}
+	}()
+
+	return limited, accepted
+}
+
+// dialConn connects to the given listener.
+func dialConn(t *testing.T, lis net.Listener) net.Conn {
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// requireAccepted makes sure a connection is accepted and returns it.
+func requireAccepted(t *testing.T, accepted chan net.Conn) net.Conn {
+	select {
+	case conn := <-accepted:
+		return conn
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not accepted")
+		return nil
+	}
+}
+
+// requireRejected makes sure the given client connection is closed by the
+// server without being accepted.
+func requireRejected(t *testing.T, conn net.Conn, accepted chan net.Conn) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err := conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	require.False(t, isTimeout(err), "connection not closed")
+
+	select {
+	case <-accepted:
+		t.Fatal("connection accepted")
+	default:
+	}
+}
+
+// isTimeout returns true if the given error is a network timeout.
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// openConns returns the number of open connections the given counter tracks
+// for each IP.
+func openConns(counter *connCounter) map[string]uint32 {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	conns := make(map[string]uint32, len(counter.conns))
+	for ip, n := range counter.conns {
+		conns[ip] = n
+	}
+
+	return conns
+}
+
+// TestConnLimitListener tests that the number of connections of an IP is
+// capped, that a closed connection frees its slot only once and that the cap
+// is shared by all listeners with the same counter.
+func TestConnLimitListener(t *testing.T) {
+	counter := newConnCounter(2, nil)
+	https, httpsAccepted := newTestConnLimitListener(t, counter)
+	plain, plainAccepted := newTestConnLimitListener(t, counter)
+
+	// The two connections allowed are spread over both listeners, so a
+	// third one is rejected by either of them.
+	_ = dialConn(t, https)
+	first := requireAccepted(t, httpsAccepted)
+	_ = dialConn(t, plain)
+	second := requireAccepted(t, plainAccepted)
+
+	requireRejected(t, dialConn(t, https), httpsAccepted)
+	requireRejected(t, dialConn(t, plain), plainAccepted)
+
+	// Closing a connection twice only frees one slot.
+	require.NoError(t, first.Close())
+	_ = first.Close()
+	require.Equal(t, map[string]uint32{"127.0.0.1": 1}, openConns(counter))
+
+	_ = dialConn(t, https)
+	third := requireAccepted(t, httpsAccepted)
+	requireRejected(t, dialConn(t, plain), plainAccepted)
+
+	// Once all connections are closed, the IP isn't tracked anymore.
+	require.NoError(t, second.Close())
+	require.NoError(t, third.Close())
+	require.Empty(t, openConns(counter))
+}
+
+// TestConnLimitTrustedProxies tests that the connections of trusted proxies
+// aren't limited.
+func TestConnLimitTrustedProxies(t *testing.T) {
+	trusted, err := parseTrustedProxies([]string{"10.0.0.1", "127.0.0.0/8"})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1/32", trusted[0].String())
+
+	_, err = parseTrustedProxies([]string{"not an ip"})
+	require.ErrorContains(t, err, "invalid trusted proxy")
+
+	counter := newConnCounter(1, trusted)
+	lis, accepted := newTestConnLimitListener(t, counter)
+	for i := 0; i < 3; i++ {
+		_ = dialConn(t, lis)
+		requireAccepted(t, accepted)
+	}
+	require.Empty(t, openConns(counter))
+}
@@ -0,0 +1,110 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// defaultSessionLabel is the default label of the session that is
+	// created on first startup.
+	defaultSessionLabel = "default"
+
+	// defaultSessionExpiry is the default duration after which the session
+	// that is created on first startup expires.
+	defaultSessionExpiry = 90 * 24 * time.Hour
+
+	// defaultMailboxServerAddr is the address of the default mailbox server
+	// that is used by LNC sessions.
+	defaultMailboxServerAddr = "mailbox.terminal.lightning.today:443"
+)
+
+// createDefaultSession creates an admin session and writes its macaroon to
+// the configured path if this was enabled and no sessions exist yet. Since the
+// session store is never empty after the default session was created once, the
+// session isn't re-created on subsequent startups.
+func (g *LightningTerminal) createDefaultSession(ctx context.Context) error {
+	cfg := g.cfg.DefaultSession
+	if !cfg.Create {
+		return nil
+	}
+
+	sessions, err := g.sessionDB.ListSessions(nil)
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) > 0 {
+		log.Debugf("Sessions already exist, not creating a default " +
+			"session")
+
+		return nil
+	}
+
+	// We never want to overwrite an existing macaroon, for example one
+	// that belongs to a session of a previous LiT data directory.
+	if lnrpc.FileExists(cfg.MacaroonPath) {
+		log.Infof("Default session macaroon already exists at %v, not "+
+			"creating a default session", cfg.MacaroonPath)
+
+		return nil
+	}
+
+	expiry := time.Now().Add(cfg.Expiry)
+	resp, err := g.sessionRpcServer.AddSession(
+		ctx, &litrpc.AddSessionRequest{
+			Label:                  cfg.Label,
+			SessionType:            litrpc.SessionType_TYPE_MACAROON_ADMIN,
+			ExpiryTimestampSeconds: uint64(expiry.Unix()),
+			MailboxServerAddr:      cfg.MailboxServerAddr,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error adding session: %v", err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(resp.Session.LocalPublicKey)
+	if err != nil {
+		return err
+	}
+
+	sess, err := g.sessionDB.GetSession(pubKey)
+	if err != nil {
+		return err
+	}
+
+	recipe, err := g.sessionRpcServer.sessionMacaroonRecipe(sess)
+	if err != nil {
+		return err
+	}
+
+	mac, err := g.sessionRpcServer.cfg.superMacBaker(
+		ctx, sess.MacaroonRootKey, recipe,
+	)
+	if err != nil {
+		return fmt.Errorf("error baking session macaroon: %v", err)
+	}
+
+	macBytes, err := hex.DecodeString(mac)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(cfg.MacaroonPath), 0700)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Created default session %x (label=%s), writing its "+
+		"macaroon to %v", sess.ID[:], sess.Label, cfg.MacaroonPath)
+
+	return os.WriteFile(cfg.MacaroonPath, macBytes, 0600)
+}
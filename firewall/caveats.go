@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/lightningnetwork/lnd/macaroons"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
 )
 
@@ -22,10 +23,18 @@ const (
 	// to mark the beginning of the rules list JSON data.
 	MetaRulesValuePrefix = "rules"
 
+	// MetaOperationsValuePrefix is the static prefix a macaroon caveat
+	// value has to mark the beginning of the allowed operations JSON data.
+	MetaOperationsValuePrefix = "ops"
+
 	// CondPrivacy is the name of the custom caveat that will
 	// instruct lnd to send all requests with this caveat to this
 	// interceptor.
 	CondPrivacy = "privacy"
+
+	// redactedValue replaces the value of a caveat that could be
+	// sensitive.
+	redactedValue = "<redacted>"
 )
 
 var (
@@ -41,6 +50,12 @@ var (
 		macaroons.CondLndCustom, RuleEnforcerCaveat,
 		MetaRulesValuePrefix)
 
+	// MetaOperationsFullCaveatPrefix is the full prefix a caveat needs to
+	// have to be recognized as an allowed operations caveat.
+	MetaOperationsFullCaveatPrefix = fmt.Sprintf("%s %s %s",
+		macaroons.CondLndCustom, RuleEnforcerCaveat,
+		MetaOperationsValuePrefix)
+
 	// MetaPrivacyCaveatPrefix is the caveat prefix that will be used to
 	// identify the privacy mapper caveat.
 	MetaPrivacyCaveatPrefix = fmt.Sprintf("%s %s", macaroons.CondLndCustom,
@@ -57,6 +72,11 @@ var (
 	// ErrNoRulesCaveat is the error that is returned if a caveat doesn't
 	// have the prefix to be recognized as a rules list caveat.
 	ErrNoRulesCaveat = fmt.Errorf("not a rules list caveat")
+
+	// ErrNoOperationsCaveat is the error that is returned if a caveat
+	// doesn't have the prefix to be recognized as an allowed operations
+	// caveat.
+	ErrNoOperationsCaveat = fmt.Errorf("not an allowed operations caveat")
 )
 
 // InterceptMetaInfo is the JSON serializable struct containing meta information
@@ -168,8 +188,92 @@ func ParseRuleCaveat(caveat string) (*InterceptRules, error) {
 	return &rules, nil
 }
 
+// OperationsToCaveat encodes a list of allowed operations as a full custom
+// caveat string representation in this format:
+//
+//	lnd-custom lit-mac-fw ops:[<array_of_JSON_encoded_operations>]
+func OperationsToCaveat(ops []Operation) (string, error) {
+	jsonBytes, err := json.Marshal(ops)
+	if err != nil {
+		return "", fmt.Errorf("error JSON marshaling: %v", err)
+	}
+
+	return fmt.Sprintf("%s:%s", MetaOperationsFullCaveatPrefix, jsonBytes),
+		nil
+}
+
+// ParseOperationsCaveat tries to parse the given caveat string as a list of
+// allowed operations.
+func ParseOperationsCaveat(caveat string) ([]Operation, error) {
+	if !strings.HasPrefix(caveat, MetaOperationsFullCaveatPrefix) {
+		return nil, ErrNoOperationsCaveat
+	}
+
+	// Only the prefix isn't enough.
+	if len(caveat) <= len(MetaOperationsFullCaveatPrefix)+1 {
+		return nil, ErrNoOperationsCaveat
+	}
+
+	// There's a colon after the prefix that we need to skip as well.
+	jsonData := caveat[len(MetaOperationsFullCaveatPrefix)+1:]
+	var ops []Operation
+
+	if err := json.Unmarshal([]byte(jsonData), &ops); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
+	}
+
+	return ops, nil
+}
+
 // IsPrivacyCaveat returns true if the given caveat string is a privacy mapper
 // caveat.
 func IsPrivacyCaveat(caveat string) bool {
 	return strings.Contains(caveat, MetaPrivacyCaveatPrefix)
 }
+
+// RedactCaveat returns the condition of the given caveat with any values that
+// could be sensitive replaced by a placeholder. The name of the condition is
+// always kept. Only the values of the expiry and of the caveats that describe
+// the rules, allowed operations and allowed IP ranges LiT enforces are kept,
+// since they are the constraints of the credential and contain no secrets.
+// Third party caveats are redacted completely.
+func RedactCaveat(caveat macaroon.Caveat) string {
+	if caveat.VerificationId != nil {
+		return fmt.Sprintf("third-party %s", redactedValue)
+	}
+
+	condition := string(caveat.Id)
+	for _, prefix := range nonSensitiveCaveatPrefixes() {
+		if strings.HasPrefix(condition, prefix) {
+			return condition
+		}
+	}
+
+	// Custom caveats carry the name of the custom condition as the first
+	// word of their value, which we keep as well.
+	numNameFields := 1
+	if strings.HasPrefix(condition, macaroons.CondLndCustom+" ") {
+		numNameFields = 2
+	}
+
+	fields := strings.SplitN(condition, " ", numNameFields+1)
+	if len(fields) <= numNameFields {
+		return condition
+	}
+
+	return fmt.Sprintf("%s %s", strings.Join(fields[:numNameFields], " "),
+		redactedValue)
+}
+
+// nonSensitiveCaveatPrefixes returns the prefixes of the caveats that are
+// kept in full by RedactCaveat.
+func nonSensitiveCaveatPrefixes() []string {
+	return []string{
+		checkers.CondTimeBefore + " ",
+		MetaRulesFullCaveatPrefix + ":",
+		MetaOperationsFullCaveatPrefix + ":",
+		MetaIPRangesFullCaveatPrefix + ":",
+		MetaScopeFullCaveatPrefix + ":",
+		SessionMetadataCaveatPrefix + " ",
+	}
+}
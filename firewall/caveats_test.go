@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon.v2"
 )
 
 const (
@@ -181,3 +182,62 @@ func TestParseRulesCaveat(t *testing.T) {
 		})
 	}
 }
+
+// TestRedactCaveat makes sure only the values of non-sensitive caveats are
+// kept.
+func TestRedactCaveat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		caveat macaroon.Caveat
+		result string
+	}{{
+		name: "expiry",
+		caveat: macaroon.Caveat{
+			Id: []byte("time-before 2030-01-01T00:00:00Z"),
+		},
+		result: "time-before 2030-01-01T00:00:00Z",
+	}, {
+		name:   "rules",
+		caveat: macaroon.Caveat{Id: []byte(testRulesCaveat)},
+		result: testRulesCaveat,
+	}, {
+		name: "session metadata",
+		caveat: macaroon.Caveat{
+			Id: []byte("lnd-custom lit-session-meta team=payments"),
+		},
+		result: "lnd-custom lit-session-meta team=payments",
+	}, {
+		name:   "meta info",
+		caveat: macaroon.Caveat{Id: []byte(testMetaCaveat)},
+		result: "lnd-custom lit-mac-fw <redacted>",
+	}, {
+		name: "account",
+		caveat: macaroon.Caveat{
+			Id: []byte("lnd-custom account 0011223344556677"),
+		},
+		result: "lnd-custom account <redacted>",
+	}, {
+		name:   "privacy without value",
+		caveat: macaroon.Caveat{Id: []byte("lnd-custom privacy")},
+		result: "lnd-custom privacy",
+	}, {
+		name:   "ip address",
+		caveat: macaroon.Caveat{Id: []byte("ipaddr 127.0.0.1")},
+		result: "ipaddr <redacted>",
+	}, {
+		name: "third party",
+		caveat: macaroon.Caveat{
+			Id:             []byte("secret"),
+			VerificationId: []byte("secret"),
+		},
+		result: "third-party <redacted>",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(tt *testing.T) {
+			require.Equal(tt, tc.result, RedactCaveat(tc.caveat))
+		})
+	}
+}
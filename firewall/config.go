@@ -8,6 +8,10 @@ type Config struct {
 // RequestLoggerConfig holds all the config options for the request logger.
 type RequestLoggerConfig struct {
 	RequestLoggerLevel RequestLoggerLevel `long:"level" description:"Set the request logger level. Options include 'all', 'full' and 'interceptor''"`
+	MacaroonCaveats    bool               `long:"macaroon-caveats" description:"Record the root key ID and the caveat conditions of the macaroon each logged request was made with. Caveat values that could be sensitive are redacted."`
+	SampleRate         uint32             `long:"sample-rate" description:"Only log one in every N of the requests that the level selects, for example 100 to log 1% of them. Requests that match a sample-method or sample-session are always logged. Set to 0 to log all requests, or only the ones that match a sample-method or sample-session if any are set."`
+	SampleMethods      []string           `long:"sample-method" description:"The full gRPC URI of a method, for example /lnrpc.Lightning/SendPaymentSync, whose requests are always logged regardless of the sample-rate, if the level selects them. Can be specified multiple times."`
+	SampleSessions     []string           `long:"sample-session" description:"The hex encoded ID of a session whose requests are always logged regardless of the sample-rate, if the level selects them. Can be specified multiple times."`
 }
 
 // DefaultConfig constructs the default firewall Config struct.
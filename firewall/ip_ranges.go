@@ -0,0 +1,91 @@
+package firewall
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/macaroons"
+)
+
+const (
+	// MetaIPRangesValuePrefix is the static prefix a macaroon caveat value
+	// has to mark the beginning of the allowed IP ranges.
+	MetaIPRangesValuePrefix = "ips"
+)
+
+var (
+	// MetaIPRangesFullCaveatPrefix is the full prefix a caveat needs to
+	// have to be recognized as an allowed IP ranges caveat. The caveat is
+	// a custom caveat of the rule enforcer so lnd accepts it, but it is
+	// enforced by LiT itself since only LiT knows the address of the
+	// client.
+	MetaIPRangesFullCaveatPrefix = fmt.Sprintf("%s %s %s",
+		macaroons.CondLndCustom, RuleEnforcerCaveat,
+		MetaIPRangesValuePrefix)
+
+	// ErrNoIPRangesCaveat is the error that is returned if a caveat
+	// doesn't have the prefix to be recognized as an allowed IP ranges
+	// caveat.
+	ErrNoIPRangesCaveat = errors.New("not an allowed IP ranges caveat")
+)
+
+// ParseIPRanges parses the given list of CIDR ranges, for example
+// 192.168.1.0/24 or 2001:db8::/32.
+func ParseIPRanges(cidrs []string) ([]*net.IPNet, error) {
+	ranges := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %s: %v", cidr,
+				err)
+		}
+
+		ranges = append(ranges, ipNet)
+	}
+
+	return ranges, nil
+}
+
+// IPRangesToCaveat encodes a list of allowed IP ranges as a full custom caveat
+// string representation in this format:
+//
+//	lnd-custom lit-mac-fw ips:<comma_separated_CIDR_ranges>
+func IPRangesToCaveat(ranges []*net.IPNet) string {
+	cidrs := make([]string, len(ranges))
+	for idx, ipNet := range ranges {
+		cidrs[idx] = ipNet.String()
+	}
+
+	return fmt.Sprintf("%s:%s", MetaIPRangesFullCaveatPrefix,
+		strings.Join(cidrs, ","))
+}
+
+// ParseIPRangesCaveat tries to parse the given caveat string as a list of
+// allowed IP ranges.
+func ParseIPRangesCaveat(caveat string) ([]*net.IPNet, error) {
+	if !strings.HasPrefix(caveat, MetaIPRangesFullCaveatPrefix+":") {
+		return nil, ErrNoIPRangesCaveat
+	}
+
+	// Only the prefix isn't enough.
+	cidrs := caveat[len(MetaIPRangesFullCaveatPrefix)+1:]
+	if cidrs == "" {
+		return nil, ErrNoIPRangesCaveat
+	}
+
+	return ParseIPRanges(strings.Split(cidrs, ","))
+}
+
+// CheckIPRanges makes sure the given client IP is within one of the allowed
+// ranges.
+func CheckIPRanges(allowed []*net.IPNet, ip net.IP) error {
+	for _, ipNet := range allowed {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("client IP %v is not in an allowed IP range", ip)
+}
@@ -0,0 +1,58 @@
+package firewall
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestIPRangesCaveat makes sure that a list of allowed IP ranges can be
+// formatted as a caveat and then parsed again successfully.
+func TestIPRangesCaveat(t *testing.T) {
+	ranges, err := ParseIPRanges([]string{
+		"192.168.1.7/24", " 2001:db8::/32",
+	})
+	require.NoError(t, err)
+
+	caveat := IPRangesToCaveat(ranges)
+	require.Equal(
+		t, "lnd-custom lit-mac-fw ips:192.168.1.0/24,2001:db8::/32",
+		caveat,
+	)
+
+	parsedRanges, err := ParseIPRangesCaveat(caveat)
+	require.NoError(t, err)
+	require.Equal(t, ranges, parsedRanges)
+
+	// Other caveats of the firewall are not mistaken for an IP ranges
+	// caveat.
+	_, err = ParseIPRangesCaveat(testRulesCaveat)
+	require.ErrorIs(t, err, ErrNoIPRangesCaveat)
+
+	_, err = ParseIPRangesCaveat(MetaIPRangesFullCaveatPrefix + ":")
+	require.ErrorIs(t, err, ErrNoIPRangesCaveat)
+
+	_, err = ParseIPRanges([]string{"192.168.1.7"})
+	require.ErrorContains(t, err, "invalid IP range 192.168.1.7")
+}
+
+// TestCheckIPRanges tests that only client IPs within one of the allowed
+// ranges are accepted.
+func TestCheckIPRanges(t *testing.T) {
+	ranges, err := ParseIPRanges([]string{"10.0.0.0/8", "2001:db8::/32"})
+	require.NoError(t, err)
+
+	require.NoError(t, CheckIPRanges(ranges, net.ParseIP("10.1.2.3")))
+	require.NoError(t, CheckIPRanges(ranges, net.ParseIP("2001:db8::1")))
+
+	// An IPv4 address in its IPv6 form is matched as well.
+	mappedIP := net.ParseIP("::ffff:10.1.2.3")
+	require.NoError(t, CheckIPRanges(ranges, mappedIP))
+
+	require.ErrorContains(
+		t, CheckIPRanges(ranges, net.ParseIP("11.0.0.1")),
+		"client IP 11.0.0.1 is not in an allowed IP range",
+	)
+	require.Error(t, CheckIPRanges(nil, net.ParseIP("10.1.2.3")))
+}
@@ -0,0 +1,133 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/pool/poolrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Operation is the name of an operation that is executed by a loop or pool
+// RPC. Some RPCs execute different operations depending on the content of the
+// request, so operations allow a finer delegation than URI permissions.
+type Operation string
+
+const (
+	// OpLoopOut is the operation of initiating a loop out swap.
+	OpLoopOut Operation = "loop-out"
+
+	// OpLoopIn is the operation of initiating a loop in swap.
+	OpLoopIn Operation = "loop-in"
+
+	// OpLoopInstantOut is the operation of initiating an instant loop out
+	// swap.
+	OpLoopInstantOut Operation = "loop-instant-out"
+
+	// OpPoolAsk is the operation of submitting an ask order to pool.
+	OpPoolAsk Operation = "pool-ask"
+
+	// OpPoolBid is the operation of submitting a bid order to pool.
+	OpPoolBid Operation = "pool-bid"
+)
+
+// operationParser returns the operation that is executed by a serialized
+// request.
+type operationParser func(rawReq []byte) (Operation, error)
+
+// operationParsers maps the URIs of all RPCs that execute distinguishable
+// operations to the parser for their requests. Requests to any other URI are
+// not restricted by an allowed operations caveat.
+var operationParsers = map[string]operationParser{
+	"/looprpc.SwapClient/LoopOut":    fixedOperation(OpLoopOut),
+	"/looprpc.SwapClient/LoopIn":     fixedOperation(OpLoopIn),
+	"/looprpc.SwapClient/InstantOut": fixedOperation(OpLoopInstantOut),
+	"/poolrpc.Trader/SubmitOrder": func(rawReq []byte) (Operation,
+		error) {
+
+		req := &poolrpc.SubmitOrderRequest{}
+		if err := proto.Unmarshal(rawReq, req); err != nil {
+			return "", err
+		}
+
+		switch req.Details.(type) {
+		case *poolrpc.SubmitOrderRequest_Ask:
+			return OpPoolAsk, nil
+
+		case *poolrpc.SubmitOrderRequest_Bid:
+			return OpPoolBid, nil
+
+		default:
+			return "", fmt.Errorf("order is neither an ask nor " +
+				"a bid")
+		}
+	},
+}
+
+// fixedOperation returns a parser for an RPC that always executes the same
+// operation.
+func fixedOperation(op Operation) operationParser {
+	return func([]byte) (Operation, error) {
+		return op, nil
+	}
+}
+
+// ParseOperations parses the given operation names and makes sure they are
+// all known.
+func ParseOperations(names []string) ([]Operation, error) {
+	known := make(map[Operation]bool)
+	for _, op := range AllOperations() {
+		known[op] = true
+	}
+
+	ops := make([]Operation, 0, len(names))
+	for _, name := range names {
+		op := Operation(strings.ToLower(strings.TrimSpace(name)))
+		if !known[op] {
+			return nil, fmt.Errorf("unknown operation %s, must be "+
+				"one of %v", name, AllOperations())
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// AllOperations returns all operations that can be distinguished.
+func AllOperations() []Operation {
+	return []Operation{
+		OpLoopOut, OpLoopIn, OpLoopInstantOut, OpPoolAsk, OpPoolBid,
+	}
+}
+
+// ExecutesOperation returns true if requests to the given URI execute a
+// distinguishable operation.
+func ExecutesOperation(uri string) bool {
+	_, ok := operationParsers[uri]
+	return ok
+}
+
+// CheckOperation returns an error if the serialized request to the given URI
+// executes an operation that is not in the list of allowed operations. Requests
+// to URIs that don't execute a distinguishable operation are always allowed.
+func CheckOperation(allowed []Operation, uri string, rawReq []byte) error {
+	parser, ok := operationParsers[uri]
+	if !ok {
+		return nil
+	}
+
+	op, err := parser(rawReq)
+	if err != nil {
+		return fmt.Errorf("unable to determine operation of %s "+
+			"request: %v", uri, err)
+	}
+
+	for _, allowedOp := range allowed {
+		if op == allowedOp {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("operation %s is not allowed by the macaroon", op)
+}
@@ -0,0 +1,108 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestOperationsCaveat makes sure that a list of allowed operations can be
+// formatted as a caveat and then parsed again successfully.
+func TestOperationsCaveat(t *testing.T) {
+	ops := []Operation{OpLoopOut, OpPoolBid}
+
+	caveat, err := OperationsToCaveat(ops)
+	require.NoError(t, err)
+	require.Equal(
+		t, "lnd-custom lit-mac-fw ops:[\"loop-out\",\"pool-bid\"]",
+		caveat,
+	)
+
+	parsedOps, err := ParseOperationsCaveat(caveat)
+	require.NoError(t, err)
+	require.Equal(t, ops, parsedOps)
+
+	// Other caveats of the firewall are not mistaken for an operations
+	// caveat.
+	_, err = ParseOperationsCaveat(testRulesCaveat)
+	require.ErrorIs(t, err, ErrNoOperationsCaveat)
+
+	_, err = ParseOperationsCaveat(MetaOperationsFullCaveatPrefix)
+	require.ErrorIs(t, err, ErrNoOperationsCaveat)
+}
+
+// TestParseOperations tests that only known operations can be parsed.
+func TestParseOperations(t *testing.T) {
+	ops, err := ParseOperations([]string{"loop-out", " Pool-Ask "})
+	require.NoError(t, err)
+	require.Equal(t, []Operation{OpLoopOut, OpPoolAsk}, ops)
+
+	_, err = ParseOperations([]string{"loop-sideways"})
+	require.ErrorContains(t, err, "unknown operation loop-sideways")
+}
+
+// TestCheckOperation tests that requests are only allowed if the operation
+// they execute is in the list of allowed operations.
+func TestCheckOperation(t *testing.T) {
+	askReq, err := proto.Marshal(&poolrpc.SubmitOrderRequest{
+		Details: &poolrpc.SubmitOrderRequest_Ask{
+			Ask: &poolrpc.Ask{},
+		},
+	})
+	require.NoError(t, err)
+
+	bidReq, err := proto.Marshal(&poolrpc.SubmitOrderRequest{
+		Details: &poolrpc.SubmitOrderRequest_Bid{
+			Bid: &poolrpc.Bid{},
+		},
+	})
+	require.NoError(t, err)
+
+	allowed := []Operation{OpLoopOut, OpPoolBid}
+
+	tests := []struct {
+		name    string
+		uri     string
+		req     []byte
+		wantErr string
+	}{{
+		name: "allowed loop out",
+		uri:  "/looprpc.SwapClient/LoopOut",
+	}, {
+		name:    "disallowed loop in",
+		uri:     "/looprpc.SwapClient/LoopIn",
+		wantErr: "operation loop-in is not allowed",
+	}, {
+		name: "allowed bid",
+		uri:  "/poolrpc.Trader/SubmitOrder",
+		req:  bidReq,
+	}, {
+		name:    "disallowed ask",
+		uri:     "/poolrpc.Trader/SubmitOrder",
+		req:     askReq,
+		wantErr: "operation pool-ask is not allowed",
+	}, {
+		name:    "order without details",
+		uri:     "/poolrpc.Trader/SubmitOrder",
+		wantErr: "neither an ask nor a bid",
+	}, {
+		name: "unrestricted method",
+		uri:  "/looprpc.SwapClient/ListSwaps",
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckOperation(allowed, test.uri, test.req)
+			if test.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/lightninglabs/lightning-terminal/session"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"gopkg.in/macaroon.v2"
 )
 
 const (
@@ -49,6 +50,14 @@ type RequestLogger struct {
 
 	shouldLogAction func(ri *RequestInfo) (bool, bool)
 
+	// sampler decides which of the requests that should be logged
+	// according to the level are actually logged.
+	sampler *requestSampler
+
+	// withMacaroonInfo is true if the root key ID and caveats of the
+	// request's macaroon should be recorded with each action.
+	withMacaroonInfo bool
+
 	// reqIDToAction is a map from request ID to an ActionLocator that can
 	// be used to find the corresponding action. This is used so that
 	// requests and responses can be easily linked. The mu mutex must be
@@ -102,10 +111,17 @@ func NewRequestLogger(cfg *RequestLoggerConfig,
 			cfg.RequestLoggerLevel)
 	}
 
+	sampler, err := newRequestSampler(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RequestLogger{
-		shouldLogAction: shouldLogAction,
-		actionsDB:       actionsDB,
-		reqIDToAction:   make(map[uint64]*firewalldb.ActionLocator),
+		shouldLogAction:  shouldLogAction,
+		sampler:          sampler,
+		withMacaroonInfo: cfg.MacaroonCaveats,
+		actionsDB:        actionsDB,
+		reqIDToAction:    make(map[uint64]*firewalldb.ActionLocator),
 	}, nil
 }
 
@@ -154,9 +170,22 @@ func (r *RequestLogger) Intercept(_ context.Context,
 	case MWRequestTypeStreamAuth:
 		return mid.RPCOk(req)
 
-	// Parse incoming requests and act on them.
+	// Parse incoming requests and act on them. Responses are only
+	// recorded for requests that were logged, so they follow the
+	// sampling of their request.
 	case MWRequestTypeRequest:
-		return mid.RPCErr(req, r.addNewAction(ri, withPayloadData))
+		sessionID, err := requestSessionID(ri)
+		if err != nil {
+			return mid.RPCErr(req, err)
+		}
+
+		if !r.sampler.sample(ri.URI, sessionID) {
+			return mid.RPCOk(req)
+		}
+
+		return mid.RPCErr(
+			req, r.addNewAction(ri, sessionID, withPayloadData),
+		)
 
 	// Parse and possibly manipulate outgoing responses.
 	case MWRequestTypeResponse:
@@ -178,27 +207,43 @@ func (r *RequestLogger) Intercept(_ context.Context,
 	}
 }
 
-// addNewAction persists the new action to the db.
-func (r *RequestLogger) addNewAction(ri *RequestInfo,
-	withPayloadData bool) error {
-
+// requestSessionID returns the ID of the session that the request was made
+// with.
+func requestSessionID(ri *RequestInfo) (session.ID, error) {
 	// If no macaroon is provided, then an empty 4-byte array is used as the
 	// session ID. Otherwise, the macaroon is used to derive a session ID.
-	var sessionID [4]byte
+	var sessionID session.ID
 	if ri.Macaroon != nil {
 		var err error
 		sessionID, err = session.IDFromMacaroon(ri.Macaroon)
 		if err != nil {
-			return fmt.Errorf("could not extract ID from macaroon")
+			return sessionID, fmt.Errorf("could not extract ID " +
+				"from macaroon")
 		}
 	}
 
+	return sessionID, nil
+}
+
+// addNewAction persists the new action to the db.
+func (r *RequestLogger) addNewAction(ri *RequestInfo, sessionID session.ID,
+	withPayloadData bool) error {
+
 	action := &firewalldb.Action{
 		RPCMethod:   ri.URI,
 		AttemptedAt: time.Now(),
 		State:       firewalldb.ActionStateInit,
 	}
 
+	if r.withMacaroonInfo && ri.Macaroon != nil {
+		info, err := macaroonInfo(ri.Macaroon)
+		if err != nil {
+			return err
+		}
+
+		action.MacaroonInfo = info
+	}
+
 	if withPayloadData {
 		msg, err := mid.ParseProtobuf(ri.GRPCMessageType, ri.Serialized)
 		if err != nil {
@@ -237,6 +282,28 @@ func (r *RequestLogger) addNewAction(ri *RequestInfo,
 	return nil
 }
 
+// macaroonInfo returns the root key ID and the redacted caveat conditions of
+// the given macaroon.
+func macaroonInfo(mac *macaroon.Macaroon) (*firewalldb.ActionMacaroonInfo,
+	error) {
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract root key ID from "+
+			"macaroon: %v", err)
+	}
+
+	caveats := make([]string, len(mac.Caveats()))
+	for idx, caveat := range mac.Caveats() {
+		caveats[idx] = RedactCaveat(caveat)
+	}
+
+	return &firewalldb.ActionMacaroonInfo{
+		RootKeyID: rootKeyID,
+		Caveats:   caveats,
+	}, nil
+}
+
 // MarkAction can be used to set the state of an action identified by the given
 // requestID.
 func (r *RequestLogger) MarkAction(reqID uint64,
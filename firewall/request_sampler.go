@@ -0,0 +1,92 @@
+package firewall
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+)
+
+// requestSampler decides which of the requests that the request logger would
+// log are actually logged. Requests to one of the configured methods or from
+// one of the configured sessions are always logged. Of all other requests,
+// one in every sampleRate is logged.
+type requestSampler struct {
+	// sampleRate is the N in "log one in N requests". If it is zero, only
+	// the requests that match a filter are logged, unless no filter is
+	// set, in which case all requests are logged.
+	sampleRate uint32
+
+	methods  map[string]struct{}
+	sessions map[session.ID]struct{}
+
+	// count is the number of requests that didn't match any filter. It
+	// must only be used atomically.
+	count atomic.Uint64
+}
+
+// newRequestSampler creates a new sampler from the given request logger
+// config.
+func newRequestSampler(cfg *RequestLoggerConfig) (*requestSampler, error) {
+	s := &requestSampler{
+		sampleRate: cfg.SampleRate,
+		methods:    make(map[string]struct{}),
+		sessions:   make(map[session.ID]struct{}),
+	}
+
+	for _, method := range cfg.SampleMethods {
+		s.methods[method] = struct{}{}
+	}
+
+	for _, idStr := range cfg.SampleSessions {
+		idBytes, err := hex.DecodeString(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample session %s: %v",
+				idStr, err)
+		}
+
+		id, err := session.IDFromBytes(idBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample session %s: %v",
+				idStr, err)
+		}
+
+		s.sessions[id] = struct{}{}
+	}
+
+	return s, nil
+}
+
+// hasFilters returns true if requests are always logged for some methods or
+// sessions.
+func (s *requestSampler) hasFilters() bool {
+	return len(s.methods) > 0 || len(s.sessions) > 0
+}
+
+// sample returns true if the request to the given URI that was made with the
+// macaroon of the given session should be logged. Sessions that are unknown are
+// identified by the empty ID.
+func (s *requestSampler) sample(uri string, sessionID session.ID) bool {
+	if _, ok := s.methods[uri]; ok {
+		return true
+	}
+
+	if _, ok := s.sessions[sessionID]; ok {
+		return true
+	}
+
+	switch {
+	case s.sampleRate == 0:
+		return !s.hasFilters()
+
+	case s.sampleRate == 1:
+		return true
+	}
+
+	// We log the first request and then every sampleRate-th one after
+	// it.
+	count := s.count.Add(1) - 1
+
+	return count%uint64(s.sampleRate) == 0
+}
@@ -0,0 +1,137 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestSampler tests that requests are sampled at the configured rate
+// and that requests matching a method or session filter are always sampled.
+func TestRequestSampler(t *testing.T) {
+	t.Parallel()
+
+	const (
+		otherURI  = "/lnrpc.Lightning/GetInfo"
+		sampleURI = "/lnrpc.Lightning/SendPaymentSync"
+	)
+
+	var (
+		otherSession  = session.ID{1, 2, 3, 4}
+		sampleSession = session.ID{0xaa, 0xbb, 0xcc, 0xdd}
+	)
+
+	tests := []struct {
+		name string
+		cfg  *RequestLoggerConfig
+
+		// uri and sessionID describe the requests that are sampled.
+		uri       string
+		sessionID session.ID
+
+		// expected is the number of the 10 requests that are sampled.
+		expected int
+	}{
+		{
+			name:     "no sampling",
+			cfg:      &RequestLoggerConfig{},
+			uri:      otherURI,
+			expected: 10,
+		},
+		{
+			name: "rate of one",
+			cfg: &RequestLoggerConfig{
+				SampleRate: 1,
+			},
+			uri:      otherURI,
+			expected: 10,
+		},
+		{
+			name: "one in three",
+			cfg: &RequestLoggerConfig{
+				SampleRate: 3,
+			},
+			uri:      otherURI,
+			expected: 4,
+		},
+		{
+			name: "method filter only",
+			cfg: &RequestLoggerConfig{
+				SampleMethods: []string{sampleURI},
+			},
+			uri:      otherURI,
+			expected: 0,
+		},
+		{
+			name: "matching method",
+			cfg: &RequestLoggerConfig{
+				SampleRate:    5,
+				SampleMethods: []string{sampleURI},
+			},
+			uri:      sampleURI,
+			expected: 10,
+		},
+		{
+			name: "other method with rate",
+			cfg: &RequestLoggerConfig{
+				SampleRate:    5,
+				SampleMethods: []string{sampleURI},
+			},
+			uri:      otherURI,
+			expected: 2,
+		},
+		{
+			name: "matching session",
+			cfg: &RequestLoggerConfig{
+				SampleSessions: []string{"aabbccdd"},
+			},
+			uri:       otherURI,
+			sessionID: sampleSession,
+			expected:  10,
+		},
+		{
+			name: "other session",
+			cfg: &RequestLoggerConfig{
+				SampleSessions: []string{"aabbccdd"},
+			},
+			uri:       otherURI,
+			sessionID: otherSession,
+			expected:  0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			sampler, err := newRequestSampler(test.cfg)
+			require.NoError(t, err)
+
+			var sampled int
+			for i := 0; i < 10; i++ {
+				if sampler.sample(test.uri, test.sessionID) {
+					sampled++
+				}
+			}
+
+			require.Equal(t, test.expected, sampled)
+		})
+	}
+}
+
+// TestRequestSamplerInvalidSession tests that invalid session IDs are rejected.
+func TestRequestSamplerInvalidSession(t *testing.T) {
+	t.Parallel()
+
+	_, err := newRequestSampler(&RequestLoggerConfig{
+		SampleSessions: []string{"not hex"},
+	})
+	require.ErrorContains(t, err, "invalid sample session")
+
+	_, err = newRequestSampler(&RequestLoggerConfig{
+		SampleSessions: []string{"aabbcc"},
+	})
+	require.ErrorContains(t, err, "must be 4 bytes long")
+}
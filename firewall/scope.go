@@ -0,0 +1,54 @@
+package firewall
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/macaroons"
+)
+
+const (
+	// MetaScopeValuePrefix is the static prefix a macaroon caveat value
+	// has to mark the beginning of the sub-servers a session is scoped to.
+	MetaScopeValuePrefix = "scope"
+)
+
+var (
+	// MetaScopeFullCaveatPrefix is the full prefix a caveat needs to have
+	// to be recognized as a session scope caveat. The caveat is a custom
+	// caveat of the rule enforcer so lnd accepts it, but it is enforced
+	// by LiT itself since LiT routes the requests to the sub-servers.
+	MetaScopeFullCaveatPrefix = fmt.Sprintf("%s %s %s",
+		macaroons.CondLndCustom, RuleEnforcerCaveat,
+		MetaScopeValuePrefix)
+
+	// ErrNoScopeCaveat is the error that is returned if a caveat doesn't
+	// have the prefix to be recognized as a session scope caveat.
+	ErrNoScopeCaveat = errors.New("not a session scope caveat")
+)
+
+// ScopeToCaveat encodes the names of the sub-servers a session may reach as a
+// full custom caveat string representation in this format:
+//
+//	lnd-custom lit-mac-fw scope:<comma_separated_sub_server_names>
+func ScopeToCaveat(subServers []string) string {
+	return fmt.Sprintf("%s:%s", MetaScopeFullCaveatPrefix,
+		strings.Join(subServers, ","))
+}
+
+// ParseScopeCaveat tries to parse the given caveat string as the names of the
+// sub-servers a session may reach.
+func ParseScopeCaveat(caveat string) ([]string, error) {
+	if !strings.HasPrefix(caveat, MetaScopeFullCaveatPrefix+":") {
+		return nil, ErrNoScopeCaveat
+	}
+
+	// Only the prefix isn't enough.
+	names := caveat[len(MetaScopeFullCaveatPrefix)+1:]
+	if names == "" {
+		return nil, ErrNoScopeCaveat
+	}
+
+	return strings.Split(names, ","), nil
+}
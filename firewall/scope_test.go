@@ -0,0 +1,25 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestScopeCaveat makes sure that a session scope can be formatted as a
+// caveat and then parsed again successfully.
+func TestScopeCaveat(t *testing.T) {
+	caveat := ScopeToCaveat([]string{"loop", "pool"})
+	require.Equal(t, "lnd-custom lit-mac-fw scope:loop,pool", caveat)
+
+	scope, err := ParseScopeCaveat(caveat)
+	require.NoError(t, err)
+	require.Equal(t, []string{"loop", "pool"}, scope)
+
+	// Other caveats of the firewall are not mistaken for a scope caveat.
+	_, err = ParseScopeCaveat(testRulesCaveat)
+	require.ErrorIs(t, err, ErrNoScopeCaveat)
+
+	_, err = ParseScopeCaveat(MetaScopeFullCaveatPrefix + ":")
+	require.ErrorIs(t, err, ErrNoScopeCaveat)
+}
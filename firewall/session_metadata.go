@@ -0,0 +1,210 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+)
+
+const (
+	// sessionMetadataEnforcerName is the name of the
+	// SessionMetadataEnforcer interceptor.
+	sessionMetadataEnforcerName = "lit-session-metadata"
+
+	// CondSessionMetadata is the name of the custom caveat that requires
+	// the session of a macaroon to carry certain metadata.
+	CondSessionMetadata = "lit-session-meta"
+)
+
+var (
+	// SessionMetadataCaveatPrefix is the prefix a caveat needs to have to
+	// be recognized as a session metadata caveat.
+	SessionMetadataCaveatPrefix = fmt.Sprintf("%s %s",
+		macaroons.CondLndCustom, CondSessionMetadata)
+
+	// ErrNoSessionMetadataCaveat is the error that is returned if a caveat
+	// doesn't have the prefix to be recognized as a session metadata
+	// caveat.
+	ErrNoSessionMetadataCaveat = errors.New("not a session metadata " +
+		"caveat")
+)
+
+// A compile-time assertion that SessionMetadataEnforcer is a
+// rpcmiddleware.RequestInterceptor.
+var _ mid.RequestInterceptor = (*SessionMetadataEnforcer)(nil)
+
+// SessionMetadataEnforcer is a RequestInterceptor that only accepts requests
+// made with a macaroon whose session carries the metadata required by the
+// macaroon's session metadata caveat.
+type SessionMetadataEnforcer struct {
+	sessionDB firewalldb.SessionDB
+}
+
+// NewSessionMetadataEnforcer returns a new instance of
+// SessionMetadataEnforcer.
+func NewSessionMetadataEnforcer(
+	sessionDB firewalldb.SessionDB) *SessionMetadataEnforcer {
+
+	return &SessionMetadataEnforcer{
+		sessionDB: sessionDB,
+	}
+}
+
+// Name returns the name of the interceptor.
+func (s *SessionMetadataEnforcer) Name() string {
+	return sessionMetadataEnforcerName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (s *SessionMetadataEnforcer) ReadOnly() bool {
+	return false
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (s *SessionMetadataEnforcer) CustomCaveatName() string {
+	return CondSessionMetadata
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (s *SessionMetadataEnforcer) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	ri, err := NewInfoFromRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing incoming RPC middleware "+
+			"interception request: %v", err)
+	}
+
+	log.Tracef("SessionMetadataEnforcer: Intercepting %v", ri)
+
+	switch r := req.InterceptType.(type) {
+	// The metadata of a session doesn't depend on the request, so it can
+	// be checked once a stream is set up or a request comes in.
+	case *lnrpc.RPCMiddlewareRequest_StreamAuth,
+		*lnrpc.RPCMiddlewareRequest_Request:
+
+		required, err := ParseSessionMetadataCondition(
+			req.CustomCaveatCondition,
+		)
+		if err != nil {
+			return mid.RPCErr(req, err)
+		}
+
+		sessionID, err := session.IDFromMacaroon(ri.Macaroon)
+		if err != nil {
+			return mid.RPCErrString(req, "could not extract ID "+
+				"from macaroon: %v", err)
+		}
+
+		sess, err := s.sessionDB.GetSessionByID(sessionID)
+		if err != nil {
+			return mid.RPCErrString(req, "macaroon with session "+
+				"metadata caveat is not bound to a session: %v",
+				err)
+		}
+
+		err = CheckSessionMetadata(required, sess.Metadata)
+		if err != nil {
+			return mid.RPCErr(req, err)
+		}
+
+		return mid.RPCOk(req)
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		return mid.RPCOk(req)
+
+	default:
+		return mid.RPCErrString(req, "invalid intercept type: %v", r)
+	}
+}
+
+// SessionMetadataCaveat formats a session metadata caveat that requires all
+// the given key/value pairs to be present in the metadata of the session.
+func SessionMetadataCaveat(required map[string]string) string {
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for idx, key := range keys {
+		pairs[idx] = fmt.Sprintf("%s=%s", key, required[key])
+	}
+
+	return fmt.Sprintf("%s %s", SessionMetadataCaveatPrefix,
+		strings.Join(pairs, ","))
+}
+
+// ParseSessionMetadataCaveat parses the required metadata of a full session
+// metadata caveat.
+func ParseSessionMetadataCaveat(caveat string) (map[string]string, error) {
+	if !strings.HasPrefix(caveat, SessionMetadataCaveatPrefix+" ") {
+		return nil, ErrNoSessionMetadataCaveat
+	}
+
+	return ParseSessionMetadataCondition(
+		strings.TrimPrefix(caveat, SessionMetadataCaveatPrefix+" "),
+	)
+}
+
+// ParseSessionMetadataCondition parses the condition of a session metadata
+// caveat, which is a comma separated list of key=value pairs.
+func ParseSessionMetadataCondition(condition string) (map[string]string,
+	error) {
+
+	required := make(map[string]string)
+	for _, pair := range strings.Split(condition, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid session metadata "+
+				"condition %q, must be a comma separated list "+
+				"of key=value pairs", condition)
+		}
+
+		if _, ok := required[key]; ok {
+			return nil, fmt.Errorf("duplicate session metadata "+
+				"key %s", key)
+		}
+
+		required[key] = value
+	}
+
+	return required, nil
+}
+
+// CheckSessionMetadata makes sure the metadata of a session contains all the
+// required key/value pairs.
+func CheckSessionMetadata(required, metadata map[string]string) error {
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := metadata[key]
+		switch {
+		case !ok:
+			return fmt.Errorf("session metadata has no key %s", key)
+
+		case value != required[key]:
+			return fmt.Errorf("session metadata %s=%s doesn't "+
+				"match required value %s", key, value,
+				required[key])
+		}
+	}
+
+	return nil
+}
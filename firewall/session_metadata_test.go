@@ -0,0 +1,107 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionMetadataCaveat makes sure that required session metadata can be
+// formatted as a caveat and then parsed again successfully.
+func TestSessionMetadataCaveat(t *testing.T) {
+	required := map[string]string{
+		"team":   "payments",
+		"region": "eu",
+	}
+
+	caveat := SessionMetadataCaveat(required)
+	require.Equal(
+		t, "lnd-custom lit-session-meta region=eu,team=payments",
+		caveat,
+	)
+
+	parsed, err := ParseSessionMetadataCaveat(caveat)
+	require.NoError(t, err)
+	require.Equal(t, required, parsed)
+
+	_, err = ParseSessionMetadataCaveat("lnd-custom lit-mac-fw meta:{}")
+	require.ErrorIs(t, err, ErrNoSessionMetadataCaveat)
+}
+
+// TestParseSessionMetadataCondition makes sure invalid session metadata
+// conditions are rejected.
+func TestParseSessionMetadataCondition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		condition string
+		result    map[string]string
+		err       string
+	}{{
+		name:      "single pair",
+		condition: "team=payments",
+		result:    map[string]string{"team": "payments"},
+	}, {
+		name:      "empty value",
+		condition: "team=",
+		result:    map[string]string{"team": ""},
+	}, {
+		name:      "spaces around pairs",
+		condition: "team=payments, region=eu",
+		result: map[string]string{
+			"team":   "payments",
+			"region": "eu",
+		},
+	}, {
+		name:      "empty",
+		condition: "",
+		err:       "must be a comma separated list",
+	}, {
+		name:      "missing value",
+		condition: "team",
+		err:       "must be a comma separated list",
+	}, {
+		name:      "missing key",
+		condition: "=payments",
+		err:       "must be a comma separated list",
+	}, {
+		name:      "duplicate key",
+		condition: "team=payments,team=ops",
+		err:       "duplicate session metadata key team",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseSessionMetadataCondition(
+				tc.condition,
+			)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
+
+// TestCheckSessionMetadata makes sure the metadata of a session is compared
+// correctly with the required metadata.
+func TestCheckSessionMetadata(t *testing.T) {
+	required := map[string]string{"team": "payments"}
+
+	err := CheckSessionMetadata(required, map[string]string{
+		"team":   "payments",
+		"region": "eu",
+	})
+	require.NoError(t, err)
+
+	err = CheckSessionMetadata(required, nil)
+	require.ErrorContains(t, err, "session metadata has no key team")
+
+	err = CheckSessionMetadata(required, map[string]string{"team": "ops"})
+	require.ErrorContains(t, err, "team=ops doesn't match")
+}
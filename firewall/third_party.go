@@ -0,0 +1,314 @@
+package firewall
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// thirdPartyEnforcerName is the name of the ThirdPartyEnforcer
+	// interceptor.
+	thirdPartyEnforcerName = "lit-third-party"
+
+	// CondThirdParty is the name of the custom caveat that marks a third
+	// party caveat. lnd can't verify real third party caveats, since it
+	// only accepts the macaroon itself without any discharges. So the
+	// caveat is a custom caveat with its own middleware, and the
+	// discharge is verified by LiT itself.
+	CondThirdParty = "lit-3p"
+
+	// thirdPartyProofPrefix is the prefix of the condition of the caveat
+	// LiT adds to a macaroon once it verified the discharges of all its
+	// third party caveats.
+	thirdPartyProofPrefix = "verified:"
+)
+
+var (
+	// ThirdPartyCaveatPrefix is the prefix a caveat needs to have to be
+	// recognized as a third party caveat.
+	ThirdPartyCaveatPrefix = fmt.Sprintf("%s %s", macaroons.CondLndCustom,
+		CondThirdParty)
+
+	// ErrNoThirdPartyCaveat is the error that is returned if a caveat
+	// doesn't have the prefix to be recognized as a third party caveat.
+	ErrNoThirdPartyCaveat = errors.New("not a third party caveat")
+)
+
+// A compile-time assertion that ThirdPartyEnforcer is a
+// rpcmiddleware.RequestInterceptor.
+var _ mid.RequestInterceptor = (*ThirdPartyEnforcer)(nil)
+
+// ThirdPartyEnforcer is a RequestInterceptor that rejects requests made with
+// a macaroon that has third party caveats, unless LiT verified their
+// discharges and added a proof caveat to the macaroon. lnd doesn't pass the
+// request metadata on to the middleware, so the discharges can't be checked
+// here directly. Requests that are sent to lnd without going through LiT are
+// rejected that way.
+type ThirdPartyEnforcer struct {
+	proofKey []byte
+}
+
+// NewThirdPartyEnforcer returns a new instance of ThirdPartyEnforcer. The
+// proof key must be the one LiT uses to add proof caveats.
+func NewThirdPartyEnforcer(proofKey []byte) *ThirdPartyEnforcer {
+	return &ThirdPartyEnforcer{
+		proofKey: proofKey,
+	}
+}
+
+// Name returns the name of the interceptor.
+func (e *ThirdPartyEnforcer) Name() string {
+	return thirdPartyEnforcerName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (e *ThirdPartyEnforcer) ReadOnly() bool {
+	return false
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (e *ThirdPartyEnforcer) CustomCaveatName() string {
+	return CondThirdParty
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (e *ThirdPartyEnforcer) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	ri, err := NewInfoFromRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing incoming RPC middleware "+
+			"interception request: %v", err)
+	}
+
+	log.Tracef("ThirdPartyEnforcer: Intercepting %v", ri)
+
+	switch r := req.InterceptType.(type) {
+	// The proof only has to be checked once a stream is set up. The
+	// messages of the stream are covered by that check, since the proof
+	// might expire while the stream is still open.
+	case *lnrpc.RPCMiddlewareRequest_StreamAuth:
+		return mid.RPCErr(
+			req, CheckThirdPartyProof(
+				ri.Macaroon, e.proofKey, time.Now(),
+			),
+		)
+
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		if r.Request.StreamRpc {
+			return mid.RPCOk(req)
+		}
+
+		return mid.RPCErr(
+			req, CheckThirdPartyProof(
+				ri.Macaroon, e.proofKey, time.Now(),
+			),
+		)
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		return mid.RPCOk(req)
+
+	default:
+		return mid.RPCErrString(req, "invalid intercept type: %v", r)
+	}
+}
+
+// ThirdPartyCaveat is a caveat that must be discharged by an external
+// authorization server before the macaroon that carries it can be used.
+type ThirdPartyCaveat struct {
+	// Location is the location of the authorization server, usually its
+	// URL. It must not contain any white space.
+	Location string
+
+	// Predicate is the condition the authorization server needs to check
+	// before it issues a discharge macaroon. The discharge macaroon must
+	// use the predicate as its ID.
+	Predicate string
+}
+
+// Validate makes sure the caveat can be encoded.
+func (c *ThirdPartyCaveat) Validate() error {
+	if c.Location == "" {
+		return errors.New("third party location must be set")
+	}
+	if strings.HasPrefix(c.Location, thirdPartyProofPrefix) {
+		return fmt.Errorf("third party location must not start with "+
+			"%s", thirdPartyProofPrefix)
+	}
+	if strings.IndexFunc(c.Location, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("third party location %q must not contain "+
+			"white space", c.Location)
+	}
+
+	// lnd rejects caveats with leading or trailing white space.
+	if c.Predicate == "" || c.Predicate != strings.TrimSpace(c.Predicate) {
+		return errors.New("third party predicate must be set and " +
+			"must not start or end with white space")
+	}
+
+	return nil
+}
+
+// ThirdPartyToCaveat encodes a third party caveat as a full custom caveat
+// string representation in this format:
+//
+//	lnd-custom lit-3p <location> <predicate>
+func ThirdPartyToCaveat(c *ThirdPartyCaveat) string {
+	return fmt.Sprintf("%s %s %s", ThirdPartyCaveatPrefix, c.Location,
+		c.Predicate)
+}
+
+// ParseThirdPartyCaveat tries to parse the given caveat string as a third
+// party caveat.
+func ParseThirdPartyCaveat(caveat string) (*ThirdPartyCaveat, error) {
+	if !strings.HasPrefix(caveat, ThirdPartyCaveatPrefix+" ") {
+		return nil, ErrNoThirdPartyCaveat
+	}
+
+	// The proof caveat uses the same custom caveat name, but isn't a
+	// third party caveat itself.
+	value := strings.TrimPrefix(caveat, ThirdPartyCaveatPrefix+" ")
+	if strings.HasPrefix(value, thirdPartyProofPrefix) {
+		return nil, ErrNoThirdPartyCaveat
+	}
+
+	location, predicate, _ := strings.Cut(value, " ")
+	c := &ThirdPartyCaveat{
+		Location:  location,
+		Predicate: predicate,
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid third party caveat: %w", err)
+	}
+
+	return c, nil
+}
+
+// AddThirdPartyProof adds a caveat to the given macaroon that proves that LiT
+// verified the discharges of all its third party caveats. The proof is only
+// valid until the given expiry and is signed with the given key, which only
+// LiT knows:
+//
+//	lnd-custom lit-3p verified:<expiry>:<hex signature>
+func AddThirdPartyProof(mac *macaroon.Macaroon, key []byte,
+	expiry time.Time) error {
+
+	sig := thirdPartyProofSignature(key, mac, mac.Caveats(), expiry.Unix())
+	caveat := fmt.Sprintf("%s %s%d:%s", ThirdPartyCaveatPrefix,
+		thirdPartyProofPrefix, expiry.Unix(), hex.EncodeToString(sig))
+
+	return mac.AddFirstPartyCaveat([]byte(caveat))
+}
+
+// CheckThirdPartyProof makes sure all third party caveats of the given
+// macaroon are followed by a valid proof caveat that isn't expired at the
+// given time. Macaroons without third party caveats don't need a proof.
+func CheckThirdPartyProof(mac *macaroon.Macaroon, key []byte,
+	now time.Time) error {
+
+	var (
+		caveats    = mac.Caveats()
+		lastCaveat = -1
+		lastProof  = -1
+	)
+	for idx, caveat := range caveats {
+		value, ok := strings.CutPrefix(
+			string(caveat.Id), ThirdPartyCaveatPrefix+" ",
+		)
+		switch {
+		case !ok:
+			continue
+
+		case strings.HasPrefix(value, thirdPartyProofPrefix):
+			lastProof = idx
+
+		default:
+			lastCaveat = idx
+		}
+	}
+
+	switch {
+	case lastCaveat == -1:
+		return nil
+
+	case lastProof < lastCaveat:
+		return errors.New("third party caveats of macaroon weren't " +
+			"verified by LiT")
+	}
+
+	value := strings.TrimPrefix(
+		string(caveats[lastProof].Id),
+		ThirdPartyCaveatPrefix+" "+thirdPartyProofPrefix,
+	)
+	expiryStr, sigHex, ok := strings.Cut(value, ":")
+	if !ok {
+		return errors.New("invalid third party proof")
+	}
+	expiry, err := strconv.ParseInt(expiryStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid third party proof expiry: %v", err)
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return fmt.Errorf("invalid third party proof signature: %v",
+			err)
+	}
+
+	expected := thirdPartyProofSignature(
+		key, mac, caveats[:lastProof], expiry,
+	)
+	if !hmac.Equal(expected, sig) {
+		return errors.New("invalid third party proof signature")
+	}
+	if !now.Before(time.Unix(expiry, 0)) {
+		return errors.New("third party proof expired")
+	}
+
+	return nil
+}
+
+// thirdPartyProofSignature signs the ID of the given macaroon, the given
+// caveats and the expiry of a third party proof with the given key.
+func thirdPartyProofSignature(key []byte, mac *macaroon.Macaroon,
+	caveats []macaroon.Caveat, expiry int64) []byte {
+
+	// Every field is length prefixed, so the boundaries between them
+	// can't be moved.
+	h := hmac.New(sha256.New, key)
+	write := func(data []byte) {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+		_, _ = h.Write(length[:])
+		_, _ = h.Write(data)
+	}
+
+	write(mac.Id())
+	for _, caveat := range caveats {
+		write(caveat.Id)
+		write(caveat.VerificationId)
+		write([]byte(caveat.Location))
+	}
+
+	var expiryBytes [8]byte
+	binary.BigEndian.PutUint64(expiryBytes[:], uint64(expiry))
+	write(expiryBytes[:])
+
+	return h.Sum(nil)
+}
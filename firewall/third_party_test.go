@@ -0,0 +1,148 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestThirdPartyCaveat makes sure that a third party caveat can be formatted
+// as a caveat and then parsed again successfully.
+func TestThirdPartyCaveat(t *testing.T) {
+	c := &ThirdPartyCaveat{
+		Location:  "https://auth.example.com",
+		Predicate: "user = alice",
+	}
+	require.NoError(t, c.Validate())
+
+	caveat := ThirdPartyToCaveat(c)
+	require.Equal(
+		t, "lnd-custom lit-3p https://auth.example.com user = alice",
+		caveat,
+	)
+
+	parsed, err := ParseThirdPartyCaveat(caveat)
+	require.NoError(t, err)
+	require.Equal(t, c, parsed)
+
+	// Other caveats of the firewall are not mistaken for a third party
+	// caveat.
+	_, err = ParseThirdPartyCaveat(testRulesCaveat)
+	require.ErrorIs(t, err, ErrNoThirdPartyCaveat)
+
+	_, err = ParseThirdPartyCaveat(
+		ThirdPartyCaveatPrefix + " https://auth.example.com",
+	)
+	require.ErrorContains(t, err, "predicate must be set")
+
+	// The proof caveat isn't a third party caveat.
+	_, err = ParseThirdPartyCaveat(
+		ThirdPartyCaveatPrefix + " verified:123:abcd",
+	)
+	require.ErrorIs(t, err, ErrNoThirdPartyCaveat)
+
+	c.Location = "https://auth example"
+	require.ErrorContains(t, c.Validate(), "must not contain white space")
+}
+
+// TestThirdPartyEnforcer makes sure that lnd only accepts a macaroon with a
+// third party caveat if LiT added a valid proof after checking its
+// discharges.
+func TestThirdPartyEnforcer(t *testing.T) {
+	proofKey := []byte("0123456789abcdef0123456789abcdef")
+	enforcer := NewThirdPartyEnforcer(proofKey)
+
+	newMac := func(caveats ...string) *macaroon.Macaroon {
+		mac, err := macaroon.New(
+			[]byte("root key"), []byte("id"), "lnd",
+			macaroon.LatestVersion,
+		)
+		require.NoError(t, err)
+
+		for _, caveat := range caveats {
+			require.NoError(
+				t, mac.AddFirstPartyCaveat([]byte(caveat)),
+			)
+		}
+
+		return mac
+	}
+
+	intercept := func(mac *macaroon.Macaroon, stream bool) string {
+		macBytes, err := mac.MarshalBinary()
+		require.NoError(t, err)
+
+		req := &lnrpc.RPCMiddlewareRequest{
+			RawMacaroon: macBytes,
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+				Request: &lnrpc.RPCMessage{
+					MethodFullUri: "/lnrpc.Lightning/" +
+						"GetInfo",
+					StreamRpc: stream,
+				},
+			},
+		}
+		resp, err := enforcer.Intercept(context.Background(), req)
+		require.NoError(t, err)
+
+		return resp.GetFeedback().Error
+	}
+
+	thirdParty := ThirdPartyToCaveat(&ThirdPartyCaveat{
+		Location:  "https://auth.example.com",
+		Predicate: "user = alice",
+	})
+	expiry := time.Now().Add(time.Minute)
+
+	// A macaroon without any third party caveats doesn't need a proof.
+	require.Empty(t, intercept(newMac(), false))
+
+	// A request that is sent to lnd directly, without LiT checking the
+	// discharge, is rejected.
+	mac := newMac(thirdParty)
+	require.Contains(t, intercept(mac, false), "weren't verified by LiT")
+
+	// Once LiT added its proof, the request is accepted.
+	require.NoError(t, AddThirdPartyProof(mac, proofKey, expiry))
+	require.Empty(t, intercept(mac, false))
+
+	// Messages of a stream were already checked when it was set up.
+	require.Empty(t, intercept(newMac(thirdParty), true))
+
+	// A proof that was signed with another key is rejected.
+	mac = newMac(thirdParty)
+	require.NoError(t, AddThirdPartyProof(
+		mac, []byte("another key of at least 32 bytes"), expiry,
+	))
+	require.Contains(t, intercept(mac, false), "invalid third party proof")
+
+	// An expired proof is rejected.
+	mac = newMac(thirdParty)
+	require.NoError(t, AddThirdPartyProof(
+		mac, proofKey, time.Now().Add(-time.Minute),
+	))
+	require.Contains(t, intercept(mac, false), "proof expired")
+
+	// A proof doesn't cover third party caveats that are added after it.
+	mac = newMac(thirdParty)
+	require.NoError(t, AddThirdPartyProof(mac, proofKey, expiry))
+	require.NoError(t, mac.AddFirstPartyCaveat([]byte(ThirdPartyToCaveat(
+		&ThirdPartyCaveat{
+			Location:  "https://other.example.com",
+			Predicate: "user = bob",
+		},
+	))))
+	require.Contains(t, intercept(mac, false), "weren't verified by LiT")
+
+	// A proof copied from another macaroon is rejected.
+	other := newMac(thirdParty)
+	require.NoError(t, AddThirdPartyProof(other, proofKey, expiry))
+	mac = newMac("lnd-custom lit-3p https://auth.example.com user = bob")
+	proof := other.Caveats()[len(other.Caveats())-1]
+	require.NoError(t, mac.AddFirstPartyCaveat(proof.Id))
+	require.Contains(t, intercept(mac, false), "invalid third party proof")
+}
@@ -0,0 +1,77 @@
+package firewalldb
+
+import (
+	"sync"
+
+	"github.com/lightninglabs/lightning-terminal/queue"
+)
+
+// actionSubscribers keeps track of all clients that subscribed to updates of
+// the actions in the DB.
+type actionSubscribers struct {
+	subscribers map[uint64]*ActionSubscription
+	nextID      uint64
+	mu          sync.Mutex
+}
+
+// ActionSubscription is a subscription to the actions that are added to the
+// DB or whose state changes.
+type ActionSubscription struct {
+	id      uint64
+	updates *queue.ConcurrentQueue[*Action]
+	cancel  func()
+}
+
+// Updates returns the channel over which the added or updated actions are
+// delivered.
+func (s *ActionSubscription) Updates() <-chan *Action {
+	return s.updates.ChanOut()
+}
+
+// Cancel cancels the subscription. No more updates will be delivered after
+// this returns.
+func (s *ActionSubscription) Cancel() {
+	s.cancel()
+}
+
+// SubscribeActions returns a new subscription that delivers each action that
+// is added to the DB and each action whose state changes. The subscription
+// must be cancelled once it is no longer needed.
+func (db *DB) SubscribeActions() *ActionSubscription {
+	db.actionSubs.mu.Lock()
+	defer db.actionSubs.mu.Unlock()
+
+	sub := &ActionSubscription{
+		id:      db.actionSubs.nextID,
+		updates: queue.NewConcurrentQueue[*Action](queue.DefaultQueueSize),
+	}
+	sub.cancel = func() {
+		db.actionSubs.mu.Lock()
+		defer db.actionSubs.mu.Unlock()
+
+		if _, ok := db.actionSubs.subscribers[sub.id]; !ok {
+			return
+		}
+
+		delete(db.actionSubs.subscribers, sub.id)
+		sub.updates.Stop()
+	}
+
+	sub.updates.Start()
+	db.actionSubs.subscribers[sub.id] = sub
+	db.actionSubs.nextID++
+
+	return sub
+}
+
+// notifyActionSubscribers delivers the given action to all active
+// subscriptions. Since each subscription is backed by an unbounded queue, this
+// never blocks on a slow subscriber.
+func (db *DB) notifyActionSubscribers(action *Action) {
+	db.actionSubs.mu.Lock()
+	defer db.actionSubs.mu.Unlock()
+
+	for _, sub := range db.actionSubs.subscribers {
+		sub.updates.ChanIn() <- action
+	}
+}
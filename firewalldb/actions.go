@@ -25,6 +25,8 @@ const (
 	typeAttemptedAt        tlv.Type = 8
 	typeState              tlv.Type = 9
 	typeErrorReason        tlv.Type = 10
+	typeMacRootKeyID       tlv.Type = 11
+	typeMacCaveats         tlv.Type = 12
 
 	typeLocatorSessionID tlv.Type = 1
 	typeLocatorActionID  tlv.Type = 2
@@ -114,6 +116,22 @@ type Action struct {
 	// ErrorReason is the human-readable reason for why the action failed.
 	// It will only be set if State is ActionStateError.
 	ErrorReason string
+
+	// MacaroonInfo describes the macaroon the request was made with. It
+	// is nil if the request logger wasn't configured to record it or if
+	// the request had no macaroon.
+	MacaroonInfo *ActionMacaroonInfo
+}
+
+// ActionMacaroonInfo describes the constraints of the macaroon an action was
+// performed with.
+type ActionMacaroonInfo struct {
+	// RootKeyID is the root key ID of the macaroon.
+	RootKeyID uint64
+
+	// Caveats are the conditions of the macaroon's caveats, with any
+	// values that could be sensitive redacted.
+	Caveats []string
 }
 
 // AddAction serialises and adds an Action to the DB under the given sessionID.
@@ -184,6 +202,10 @@ func (db *DB) AddAction(sessionID session.ID, action *Action) (uint64, error) {
 		return 0, err
 	}
 
+	added := *action
+	added.SessionID = sessionID
+	db.notifyActionSubscribers(&added)
+
 	return id, nil
 }
 
@@ -239,7 +261,8 @@ func (db *DB) SetActionState(al *ActionLocator, state ActionState,
 			"ActionStateError")
 	}
 
-	return db.DB.Update(func(tx *bbolt.Tx) error {
+	var action *Action
+	err := db.DB.Update(func(tx *bbolt.Tx) error {
 		mainActionsBucket, err := getBucket(tx, actionsBucketKey)
 		if err != nil {
 			return err
@@ -250,7 +273,7 @@ func (db *DB) SetActionState(al *ActionLocator, state ActionState,
 			return ErrNoSuchKeyFound
 		}
 
-		action, err := getAction(actionsBucket, al)
+		action, err = getAction(actionsBucket, al)
 		if err != nil {
 			return err
 		}
@@ -260,6 +283,13 @@ func (db *DB) SetActionState(al *ActionLocator, state ActionState,
 
 		return putAction(tx, al, action)
 	})
+	if err != nil {
+		return err
+	}
+
+	db.notifyActionSubscribers(action)
+
+	return nil
 }
 
 // ListActionsQuery can be used to tweak the query to ListActions and
@@ -493,6 +523,21 @@ func SerializeAction(w io.Writer, action *Action) error {
 		tlv.MakePrimitiveRecord(typeErrorReason, &errorReason),
 	}
 
+	if action.MacaroonInfo != nil {
+		rootKeyID := action.MacaroonInfo.RootKeyID
+		caveats := action.MacaroonInfo.Caveats
+
+		tlvRecords = append(
+			tlvRecords,
+			tlv.MakePrimitiveRecord(typeMacRootKeyID, &rootKeyID),
+			tlv.MakeDynamicRecord(
+				typeMacCaveats, &caveats, func() uint64 {
+					return stringsSize(caveats)
+				}, stringsEncoder, stringsDecoder,
+			),
+		)
+	}
+
 	tlvStream, err := tlv.NewStream(tlvRecords...)
 	if err != nil {
 		return err
@@ -512,6 +557,8 @@ func DeserializeAction(r io.Reader, sessionID session.ID) (*Action, error) {
 		attemptedAt           uint64
 		state                 uint8
 		errorReason           []byte
+		macRootKeyID          uint64
+		macCaveats            []string
 	)
 	tlvStream, err := tlv.NewStream(
 		tlv.MakePrimitiveRecord(typeActorName, &actor),
@@ -524,12 +571,17 @@ func DeserializeAction(r io.Reader, sessionID session.ID) (*Action, error) {
 		tlv.MakePrimitiveRecord(typeAttemptedAt, &attemptedAt),
 		tlv.MakePrimitiveRecord(typeState, &state),
 		tlv.MakePrimitiveRecord(typeErrorReason, &errorReason),
+		tlv.MakePrimitiveRecord(typeMacRootKeyID, &macRootKeyID),
+		tlv.MakeDynamicRecord(
+			typeMacCaveats, &macCaveats, nil, stringsEncoder,
+			stringsDecoder,
+		),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = tlvStream.DecodeWithParsedTypes(r)
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(r)
 	if err != nil {
 		return nil, err
 	}
@@ -546,9 +598,89 @@ func DeserializeAction(r io.Reader, sessionID session.ID) (*Action, error) {
 	action.State = ActionState(state)
 	action.ErrorReason = string(errorReason)
 
+	if _, ok := parsedTypes[typeMacRootKeyID]; ok {
+		action.MacaroonInfo = &ActionMacaroonInfo{
+			RootKeyID: macRootKeyID,
+			Caveats:   macCaveats,
+		}
+	}
+
 	return &action, nil
 }
 
+// stringsSize returns the encoded size of the given list of strings.
+func stringsSize(strs []string) uint64 {
+	var size uint64
+	for _, str := range strs {
+		size += tlv.VarIntSize(uint64(len(str))) + uint64(len(str))
+	}
+
+	return size
+}
+
+// stringsEncoder is a custom TLV encoder for a list of strings. Each string is
+// encoded as its varint length followed by its bytes.
+func stringsEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*[]string); ok {
+		for _, str := range *v {
+			err := tlv.WriteVarInt(w, uint64(len(str)), buf)
+			if err != nil {
+				return err
+			}
+
+			if _, err := w.Write([]byte(str)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "[]string")
+}
+
+// stringsDecoder is a custom TLV decoder for a list of strings.
+func stringsDecoder(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if v, ok := val.(*[]string); ok {
+		// The strings are read from a limited reader, so we know
+		// we're done once it returns an EOF.
+		lr := io.LimitedReader{
+			R: r,
+			N: int64(l),
+		}
+
+		strs := []string{}
+		for {
+			strLen, err := tlv.ReadVarInt(&lr, buf)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			if strLen > uint64(lr.N) {
+				return fmt.Errorf("string length %d exceeds "+
+					"record length", strLen)
+			}
+
+			str := make([]byte, strLen)
+			if _, err := io.ReadFull(&lr, str); err != nil {
+				return err
+			}
+
+			strs = append(strs, string(str))
+		}
+
+		*v = strs
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "[]string", l, l)
+}
+
 // ActionsWriteDB is an abstraction over the Actions DB that will allow a
 // caller to add new actions as well as change the values of an existing action.
 type ActionsWriteDB interface {
@@ -1,6 +1,7 @@
 package firewalldb
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
@@ -381,3 +382,99 @@ func TestListGroupActions(t *testing.T) {
 	require.Equal(t, sessionID1, al[0].SessionID)
 	require.Equal(t, sessionID2, al[1].SessionID)
 }
+
+// TestSubscribeActions tests that subscribers are notified about added actions
+// and action state changes.
+func TestSubscribeActions(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sub := db.SubscribeActions()
+
+	receive := func() *Action {
+		select {
+		case a := <-sub.Updates():
+			return a
+		case <-time.After(time.Second):
+			t.Fatalf("no action received")
+		}
+
+		return nil
+	}
+
+	action := &Action{
+		ActorName:   "Autopilot",
+		FeatureName: "auto-fees",
+		RPCMethod:   "UpdateChanPolicy",
+		AttemptedAt: time.Unix(32100, 0),
+		State:       ActionStateInit,
+	}
+	id, err := db.AddAction(sessionID1, action)
+	require.NoError(t, err)
+
+	added := receive()
+	require.Equal(t, sessionID1, added.SessionID)
+	require.Equal(t, ActionStateInit, added.State)
+
+	err = db.SetActionState(&ActionLocator{
+		SessionID: sessionID1,
+		ActionID:  id,
+	}, ActionStateError, "failed")
+	require.NoError(t, err)
+
+	updated := receive()
+	require.Equal(t, sessionID1, updated.SessionID)
+	require.Equal(t, ActionStateError, updated.State)
+	require.Equal(t, "failed", updated.ErrorReason)
+
+	// Once the subscription is cancelled, no more actions are delivered.
+	sub.Cancel()
+
+	_, err = db.AddAction(sessionID2, action)
+	require.NoError(t, err)
+
+	select {
+	case a := <-sub.Updates():
+		t.Fatalf("unexpected action received: %v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestActionMacaroonInfo makes sure the macaroon info of an action survives a
+// serialization round trip and that actions without it stay without it.
+func TestActionMacaroonInfo(t *testing.T) {
+	roundTrip := func(action *Action) *Action {
+		var buf bytes.Buffer
+		require.NoError(t, SerializeAction(&buf, action))
+
+		decoded, err := DeserializeAction(&buf, action.SessionID)
+		require.NoError(t, err)
+
+		return decoded
+	}
+
+	decoded := roundTrip(action1)
+	require.Nil(t, decoded.MacaroonInfo)
+
+	action := *action1
+	action.MacaroonInfo = &ActionMacaroonInfo{
+		RootKeyID: 1234,
+		Caveats: []string{
+			"time-before 2030-01-01T00:00:00Z",
+			"lnd-custom account <redacted>",
+		},
+	}
+	decoded = roundTrip(&action)
+	require.Equal(t, action.MacaroonInfo, decoded.MacaroonInfo)
+
+	action.MacaroonInfo = &ActionMacaroonInfo{
+		Caveats: []string{},
+	}
+	decoded = roundTrip(&action)
+	require.Equal(t, action.MacaroonInfo, decoded.MacaroonInfo)
+}
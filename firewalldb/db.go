@@ -42,6 +42,8 @@ type DB struct {
 	*bbolt.DB
 
 	sessionIDIndex SessionDB
+
+	actionSubs actionSubscribers
 }
 
 // NewDB creates a new bolt database that can be found at the given directory.
@@ -71,6 +73,9 @@ func NewDB(dir, fileName string, sessionIDIndex SessionDB) (*DB, error) {
 	return &DB{
 		DB:             db,
 		sessionIDIndex: sessionIDIndex,
+		actionSubs: actionSubscribers{
+			subscribers: make(map[uint64]*ActionSubscription),
+		},
 	}, nil
 }
 
@@ -0,0 +1,224 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// healthPath is the path of the HTTP endpoint that serves the health
+	// report.
+	healthPath = "/health"
+
+	// healthProbeTimeout is the maximum time a single health probe may
+	// take before the sub-server is considered unhealthy.
+	healthProbeTimeout = 5 * time.Second
+
+	// healthCacheTTL is the time for which a health report is reused.
+	// The health can be checked without credentials, so we don't want
+	// every check to result in calls to all daemons.
+	healthCacheTTL = time.Second
+)
+
+// healthProbe is the cheap call that is made to check whether a sub-server is
+// healthy.
+type healthProbe struct {
+	// method is the full URI of the method that is called with an empty
+	// request.
+	method string
+
+	// rejected is true if the daemon rejects the empty request before
+	// doing any work. The sub-server is then healthy if the call reached
+	// the daemon and was rejected by it.
+	rejected bool
+}
+
+// healthProbes are the calls made to check the health of each sub-server.
+var healthProbes = map[string]healthProbe{
+	subservers.LND:  {method: "/lnrpc.Lightning/GetInfo"},
+	subservers.LIT:  {method: "/litrpc.Proxy/GetInfo"},
+	subservers.LOOP: {method: "/looprpc.SwapClient/GetInfo"},
+	subservers.POOL: {method: "/poolrpc.Trader/GetInfo"},
+	subservers.TAP:  {method: "/taprpc.TaprootAssets/GetInfo"},
+
+	// Faraday has no call that is cheap with an empty request. An
+	// exchange rate request without any timestamps is rejected right
+	// away though.
+	subservers.FARADAY: {
+		method:   "/frdrpc.FaradayServer/ExchangeRate",
+		rejected: true,
+	},
+}
+
+// healthChecker probes the sub-servers and caches the resulting report for a
+// short time.
+type healthChecker struct {
+	mu        sync.Mutex
+	report    *litrpc.CheckHealthResponse
+	checkedAt time.Time
+}
+
+// checkHealth returns the health of lnd, LiT itself and each enabled
+// sub-server. A recent report is reused.
+func (p *rpcProxy) checkHealth(
+	ctx context.Context) (*litrpc.CheckHealthResponse, error) {
+
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+
+	if p.health.report != nil &&
+		time.Since(p.health.checkedAt) < healthCacheTTL {
+
+		return p.health.report, nil
+	}
+
+	statusResp, err := p.statusMgr.SubServerStatus(
+		ctx, &litrpc.SubServerStatusReq{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range healthProbes {
+		ss, ok := statusResp.SubServers[name]
+		if !ok || ss.Disabled {
+			continue
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The report is shared with other callers, so the probes must not be
+	// cancelled if this caller goes away.
+	probeCtx := context.Background()
+	results := make([]*litrpc.SubServerHealth, len(names))
+
+	var wg sync.WaitGroup
+	for idx, name := range names {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+
+			results[idx] = p.probeHealth(
+				probeCtx, name, healthProbes[name],
+			)
+		}(idx, name)
+	}
+	wg.Wait()
+
+	report := &litrpc.CheckHealthResponse{
+		Healthy:    true,
+		SubServers: results,
+	}
+	for _, result := range results {
+		if !result.Healthy {
+			report.Healthy = false
+		}
+	}
+
+	p.health.report = report
+	p.health.checkedAt = time.Now()
+
+	return report, nil
+}
+
+// probeHealth makes the given probe call to the sub-server with the given name
+// through the loopback connection, so the call takes the same path as any
+// other call to the sub-server.
+func (p *rpcProxy) probeHealth(ctx context.Context, name string,
+	probe healthProbe) *litrpc.SubServerHealth {
+
+	result := &litrpc.SubServerHealth{
+		Name: name,
+	}
+
+	if !p.hasStarted() || p.loopbackConn == nil {
+		result.Error = codes.Unavailable.String()
+		return result
+	}
+
+	// We only return the status code of a failed probe. The message
+	// could contain details of the setup, like paths or addresses.
+	mac, err := p.fullAccessMacaroon(probe.method)
+	if err != nil {
+		result.Error = status.Code(err).String()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		HeaderMacaroon, hex.EncodeToString(mac),
+	))
+
+	start := time.Now()
+	_, err = p.invokeLoopback(ctx, probe.method, nil)
+	result.LatencyMs = uint64(time.Since(start).Milliseconds())
+
+	code := status.Code(err)
+	switch {
+	case err == nil:
+		result.Healthy = true
+
+	// The daemon rejects the empty request with an error of its own,
+	// which means it is up and responded.
+	case probe.rejected && (code == codes.Unknown ||
+		code == codes.InvalidArgument):
+
+		result.Healthy = true
+
+	default:
+		result.Error = code.String()
+	}
+
+	return result
+}
+
+// serveHealth serves the health report as JSON. If any of the sub-servers is
+// unhealthy, the status 503 is returned.
+func (p *rpcProxy) serveHealth(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	report, err := p.checkHealth(req.Context())
+	if err != nil {
+		log.Errorf("Unable to check health: %v", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(report)
+	if err != nil {
+		log.Errorf("Unable to encode health report: %v", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Cache-Control", "no-store")
+
+	if !report.Healthy {
+		resp.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_, _ = resp.Write(body)
+}
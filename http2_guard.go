@@ -0,0 +1,297 @@
+package terminal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultHTTP2StreamRate is the default maximum number of new streams
+	// per second a single HTTP/2 connection may open.
+	defaultHTTP2StreamRate = 200
+
+	// defaultHTTP2ResetRate is the default maximum number of streams per
+	// second a single HTTP/2 client may reset.
+	defaultHTTP2ResetRate = 50
+
+	// defaultHTTP2PingRate is the default maximum number of pings per
+	// second a single HTTP/2 client may send.
+	defaultHTTP2PingRate = 10
+
+	// http2FrameHeaderLen is the length of an HTTP/2 frame header.
+	http2FrameHeaderLen = 9
+
+	// abuseReasonStreams, abuseReasonResets and abuseReasonPings are the
+	// reasons for which an abusive HTTP/2 connection is closed.
+	abuseReasonStreams = "streams"
+	abuseReasonResets  = "resets"
+	abuseReasonPings   = "pings"
+)
+
+// enabled returns true if any of the HTTP/2 limits is set.
+func (c *HTTP2Config) enabled() bool {
+	return c.StreamRate != 0 || c.ResetRate != 0 || c.PingRate != 0
+}
+
+// configureServer makes the given server handle its HTTP/2 connections with
+// the configured limits. A connection that exceeds a limit is closed with a
+// GOAWAY frame and reported to the given callback.
+func (c *HTTP2Config) configureServer(srv *http.Server,
+	onAbuse func(reason string)) {
+
+	h2Server := &http2.Server{}
+
+	// Setting our own handler for the h2 protocol prevents the HTTP
+	// server from using its bundled HTTP/2 implementation.
+	srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn,
+		http.Handler){
+
+		http2.NextProtoTLS: func(hs *http.Server, conn *tls.Conn,
+			h http.Handler) {
+
+			// The handler of the HTTP server carries the
+			// connection's context, we use it for the HTTP/2
+			// connection the same way the HTTP/2 library does.
+			var ctx context.Context
+			if bc, ok := h.(interface {
+				BaseContext() context.Context
+			}); ok {
+				ctx = bc.BaseContext()
+			}
+
+			h2Server.ServeConn(
+				newHTTP2GuardConn(conn, c, onAbuse),
+				&http2.ServeConnOpts{
+					Context:    ctx,
+					BaseConfig: hs,
+					Handler:    h,
+				},
+			)
+		},
+	}
+}
+
+// newLimiter returns a rate limiter that allows the given number of events per
+// second with bursts of twice as many, or nil if the rate is 0.
+func newLimiter(perSecond uint32) *rate.Limiter {
+	if perSecond == 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(perSecond), 2*int(perSecond))
+}
+
+// http2FrameScanner follows the frame boundaries of one direction of an
+// HTTP/2 connection.
+type http2FrameScanner struct {
+	// preface is the number of bytes of the connection preface that are
+	// still to be skipped.
+	preface int
+
+	header    [http2FrameHeaderLen]byte
+	headerLen int
+
+	// payload is the number of payload bytes of the current frame that
+	// are still to be skipped.
+	payload int
+}
+
+// scan advances the scanner over the given bytes and calls onHeader for each
+// frame header. Scanning stops at the first error returned by onHeader.
+func (s *http2FrameScanner) scan(b []byte, onHeader func(http2.FrameType,
+	http2.Flags, uint32) error) error {
+
+	for len(b) > 0 {
+		switch {
+		case s.preface > 0:
+			n := min(s.preface, len(b))
+			s.preface -= n
+			b = b[n:]
+
+		case s.payload > 0:
+			n := min(s.payload, len(b))
+			s.payload -= n
+			b = b[n:]
+
+		default:
+			n := copy(s.header[s.headerLen:], b)
+			s.headerLen += n
+			b = b[n:]
+
+			if s.headerLen < http2FrameHeaderLen {
+				continue
+			}
+			s.headerLen = 0
+
+			h := s.header
+			s.payload = int(h[0])<<16 | int(h[1])<<8 | int(h[2])
+
+			if onHeader == nil {
+				continue
+			}
+
+			streamID := binary.BigEndian.Uint32(h[5:]) & (1<<31 - 1)
+			err := onHeader(
+				http2.FrameType(h[3]), http2.Flags(h[4]),
+				streamID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// atBoundary returns true if the scanner is between two frames.
+func (s *http2FrameScanner) atBoundary() bool {
+	return s.preface == 0 && s.headerLen == 0 && s.payload == 0
+}
+
+// http2GuardConn is a TLS connection that serves HTTP/2 and is closed if the
+// client creates streams, resets streams or sends pings faster than allowed.
+type http2GuardConn struct {
+	*tls.Conn
+
+	onAbuse func(reason string)
+
+	streams *rate.Limiter
+	resets  *rate.Limiter
+	pings   *rate.Limiter
+
+	// in and lastStreamID are only accessed by the connection's single
+	// reader.
+	in           http2FrameScanner
+	lastStreamID uint32
+
+	// out follows the frames written to the connection, so we only send
+	// a GOAWAY frame in between two frames.
+	out     http2FrameScanner
+	writeMu sync.Mutex
+}
+
+// newHTTP2GuardConn wraps the given connection with the configured limits.
+func newHTTP2GuardConn(conn *tls.Conn, cfg *HTTP2Config,
+	onAbuse func(reason string)) *http2GuardConn {
+
+	return &http2GuardConn{
+		Conn:    conn,
+		onAbuse: onAbuse,
+		streams: newLimiter(cfg.StreamRate),
+		resets:  newLimiter(cfg.ResetRate),
+		pings:   newLimiter(cfg.PingRate),
+		in: http2FrameScanner{
+			preface: len(http2.ClientPreface),
+		},
+	}
+}
+
+// Read reads from the connection and checks the frames the client sent. If
+// the client exceeds a limit, the connection is closed.
+//
+// NOTE: this is part of the net.Conn interface.
+func (c *http2GuardConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	if scanErr := c.in.scan(b[:n], c.checkFrame); scanErr != nil {
+		c.goAway()
+		_ = c.Conn.Close()
+
+		return 0, scanErr
+	}
+
+	return n, err
+}
+
+// Write writes to the connection and keeps track of the frame boundaries.
+//
+// NOTE: this is part of the net.Conn interface.
+func (c *http2GuardConn) Write(b []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	n, err := c.Conn.Write(b)
+	_ = c.out.scan(b[:n], nil)
+
+	return n, err
+}
+
+// checkFrame counts the frames that are subject to a limit and returns an
+// error if the client exceeds one.
+func (c *http2GuardConn) checkFrame(typ http2.FrameType, flags http2.Flags,
+	streamID uint32) error {
+
+	switch typ {
+	// Only the first HEADERS frame of a stream creates it, trailers are
+	// sent on the same stream.
+	case http2.FrameHeaders:
+		if streamID <= c.lastStreamID {
+			return nil
+		}
+		c.lastStreamID = streamID
+
+		return c.allow(c.streams, abuseReasonStreams)
+
+	case http2.FrameRSTStream:
+		return c.allow(c.resets, abuseReasonResets)
+
+	// Acknowledgements are answers to our own pings.
+	case http2.FramePing:
+		if flags.Has(http2.FlagPingAck) {
+			return nil
+		}
+
+		return c.allow(c.pings, abuseReasonPings)
+	}
+
+	return nil
+}
+
+// allow takes an event from the given limiter and returns an error if the
+// limit is exceeded.
+func (c *http2GuardConn) allow(limiter *rate.Limiter, reason string) error {
+	if limiter == nil || limiter.Allow() {
+		return nil
+	}
+
+	log.Warnf("Closing HTTP/2 connection from %v: too many %s per "+
+		"second", c.RemoteAddr(), reason)
+
+	if c.onAbuse != nil {
+		c.onAbuse(reason)
+	}
+
+	return fmt.Errorf("HTTP/2 connection closed: too many %s per second",
+		reason)
+}
+
+// goAway tells the client why the connection is closed. The GOAWAY frame can
+// only be sent if no other frame is being written at the moment, otherwise the
+// connection is closed without it.
+func (c *http2GuardConn) goAway() {
+	if !c.writeMu.TryLock() {
+		return
+	}
+	defer c.writeMu.Unlock()
+
+	if !c.out.atBoundary() {
+		return
+	}
+
+	framer := http2.NewFramer(c.Conn, nil)
+	err := framer.WriteGoAway(
+		c.lastStreamID, http2.ErrCodeEnhanceYourCalm, nil,
+	)
+	if err != nil {
+		log.Debugf("Unable to send GOAWAY to %v: %v", c.RemoteAddr(),
+			err)
+	}
+}
@@ -0,0 +1,281 @@
+package terminal
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	litstatus "github.com/lightninglabs/lightning-terminal/status"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+// scannedFrame is a frame header that was found by the http2FrameScanner.
+type scannedFrame struct {
+	typ      http2.FrameType
+	flags    http2.Flags
+	streamID uint32
+}
+
+// TestHTTP2FrameScanner makes sure the frame scanner finds all frame headers,
+// no matter how the bytes of the connection are split up.
+func TestHTTP2FrameScanner(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString(http2.ClientPreface)
+
+	framer := http2.NewFramer(&buf, nil)
+	require.NoError(t, framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: []byte("header block"),
+		EndHeaders:    true,
+	}))
+	require.NoError(t, framer.WriteData(1, true, []byte("data")))
+	require.NoError(t, framer.WritePing(false, [8]byte{1}))
+	require.NoError(t, framer.WriteRSTStream(3, http2.ErrCodeCancel))
+
+	expected := []scannedFrame{{
+		typ:      http2.FrameHeaders,
+		flags:    http2.FlagHeadersEndHeaders,
+		streamID: 1,
+	}, {
+		typ:      http2.FrameData,
+		flags:    http2.FlagDataEndStream,
+		streamID: 1,
+	}, {
+		typ: http2.FramePing,
+	}, {
+		typ:      http2.FrameRSTStream,
+		streamID: 3,
+	}}
+
+	stream := buf.Bytes()
+	for _, chunkSize := range []int{1, 2, 5, 9, 10, 24, 100, len(stream)} {
+		scanner := &http2FrameScanner{
+			preface: len(http2.ClientPreface),
+		}
+
+		var frames []scannedFrame
+		onHeader := func(typ http2.FrameType, flags http2.Flags,
+			streamID uint32) error {
+
+			frames = append(frames, scannedFrame{
+				typ:      typ,
+				flags:    flags,
+				streamID: streamID,
+			})
+
+			return nil
+		}
+
+		for b := stream; len(b) > 0; {
+			n := min(chunkSize, len(b))
+			require.NoError(t, scanner.scan(b[:n], onHeader))
+			b = b[n:]
+		}
+
+		require.Equal(t, expected, frames, "chunk size %d", chunkSize)
+		require.True(t, scanner.atBoundary())
+	}
+
+	// The payload of a frame is skipped, even if it looks like a frame
+	// header, and the scanner is only at a boundary once it is complete.
+	scanner := &http2FrameScanner{}
+	var frames []scannedFrame
+	onHeader := func(typ http2.FrameType, _ http2.Flags, _ uint32) error {
+		frames = append(frames, scannedFrame{typ: typ})
+		return nil
+	}
+
+	buf.Reset()
+	require.NoError(t, framer.WriteData(1, false, stream[24:33]))
+	frame := buf.Bytes()
+
+	require.NoError(t, scanner.scan(frame[:12], onHeader))
+	require.False(t, scanner.atBoundary())
+	require.NoError(t, scanner.scan(frame[12:], onHeader))
+	require.True(t, scanner.atBoundary())
+	require.Equal(t, []scannedFrame{{typ: http2.FrameData}}, frames)
+
+	// Scanning stops at the first error.
+	errStop := errors.New("stop")
+	frames = nil
+	err := scanner.scan(append(frame, frame...), func(typ http2.FrameType,
+		_ http2.Flags, _ uint32) error {
+
+		frames = append(frames, scannedFrame{typ: typ})
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Len(t, frames, 1)
+}
+
+// newHTTP2GuardServer starts a TLS test server that serves HTTP/2 with the
+// given limits. The returned metrics count the abusive connections.
+func newHTTP2GuardServer(t *testing.T, cfg *HTTP2Config) (*httptest.Server,
+	*rpcMetrics) {
+
+	metrics := newRPCMetrics(&PrometheusConfig{
+		MethodLabels: MethodLabelsNone,
+	}, litstatus.NewStatusManager())
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto))
+		},
+	))
+	srv.EnableHTTP2 = true
+	cfg.configureServer(srv.Config, metrics.observeHTTP2Abuse)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv, metrics
+}
+
+// dialHTTP2 opens a raw HTTP/2 connection to the given server and waits until
+// the initial settings were exchanged.
+func dialHTTP2(t *testing.T, srv *httptest.Server) (*tls.Conn,
+	*http2.Framer) {
+
+	conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), &tls.Config{
+		InsecureSkipVerify: true,
+		NextProtos:         []string{http2.NextProtoTLS},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	_, err = conn.Write([]byte(http2.ClientPreface))
+	require.NoError(t, err)
+
+	framer := http2.NewFramer(conn, conn)
+	require.NoError(t, framer.WriteSettings())
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+
+		settings, ok := frame.(*http2.SettingsFrame)
+		if !ok {
+			continue
+		}
+		if !settings.IsAck() {
+			require.NoError(t, framer.WriteSettingsAck())
+			continue
+		}
+
+		return conn, framer
+	}
+}
+
+// writeFrames sends the frames written by the given function to the
+// connection in a single write.
+func writeFrames(t *testing.T, conn *tls.Conn, write func(*http2.Framer)) {
+	var buf bytes.Buffer
+	write(http2.NewFramer(&buf, nil))
+
+	_, err := conn.Write(buf.Bytes())
+	require.NoError(t, err)
+}
+
+// requireGoAway makes sure the server sends a GOAWAY frame with the
+// ENHANCE_YOUR_CALM code and closes the connection afterwards.
+func requireGoAway(t *testing.T, framer *http2.Framer) {
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+
+		goAway, ok := frame.(*http2.GoAwayFrame)
+		if !ok {
+			continue
+		}
+
+		require.Equal(t, http2.ErrCodeEnhanceYourCalm, goAway.ErrCode)
+		break
+	}
+
+	_, err := framer.ReadFrame()
+	require.Error(t, err)
+}
+
+// TestHTTP2Guard makes sure that normal HTTP/2 requests are served, and that a
+// connection that floods the server with resets or pings is closed.
+func TestHTTP2Guard(t *testing.T) {
+	cfg := &HTTP2Config{
+		StreamRate: defaultHTTP2StreamRate,
+		ResetRate:  1,
+		PingRate:   1,
+	}
+	require.True(t, cfg.enabled())
+	srv, metrics := newHTTP2GuardServer(t, cfg)
+
+	// A normal client can use the server.
+	for i := 0; i < 5; i++ {
+		resp, err := srv.Client().Get(srv.URL)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, "HTTP/2.0", string(body))
+	}
+
+	// The limits allow bursts of twice the rate, so the third ping in a
+	// row is too much. The frames are sent in a single write, so the
+	// server doesn't handle any of them before the limit is hit.
+	conn, framer := dialHTTP2(t, srv)
+	writeFrames(t, conn, func(f *http2.Framer) {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, f.WritePing(false, [8]byte{byte(i)}))
+		}
+	})
+	requireGoAway(t, framer)
+
+	// Resetting streams too quickly closes the connection as well. The
+	// server reads one frame at a time, so the streams must exist before
+	// they are reset.
+	var headerBlock bytes.Buffer
+	encoder := hpack.NewEncoder(&headerBlock)
+	for _, field := range []hpack.HeaderField{
+		{Name: ":method", Value: "GET"},
+		{Name: ":scheme", Value: "https"},
+		{Name: ":authority", Value: "localhost"},
+		{Name: ":path", Value: "/"},
+	} {
+		require.NoError(t, encoder.WriteField(field))
+	}
+
+	conn, framer = dialHTTP2(t, srv)
+	writeFrames(t, conn, func(f *http2.Framer) {
+		for i := uint32(0); i < 3; i++ {
+			err := f.WriteHeaders(http2.HeadersFrameParam{
+				StreamID:      2*i + 1,
+				BlockFragment: headerBlock.Bytes(),
+				EndHeaders:    true,
+			})
+			require.NoError(t, err)
+			require.NoError(t, f.WriteRSTStream(
+				2*i+1, http2.ErrCodeCancel,
+			))
+		}
+	})
+	requireGoAway(t, framer)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		metrics.http2Abuse.WithLabelValues(abuseReasonPings),
+	))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		metrics.http2Abuse.WithLabelValues(abuseReasonResets),
+	))
+	require.Zero(t, testutil.ToFloat64(
+		metrics.http2Abuse.WithLabelValues(abuseReasonStreams),
+	))
+}
@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// validateHTTPPathPrefix normalizes the given URL path prefix so that it
+// starts with a slash and doesn't end with one. The root path results in an
+// empty prefix.
+func validateHTTPPathPrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return "", nil
+	}
+
+	if !strings.HasPrefix(prefix, "/") || path.Clean(prefix) != prefix &&
+		path.Clean(prefix)+"/" != prefix {
+
+		return "", fmt.Errorf("%s must be an absolute, clean URL path "+
+			"like /lightning", prefix)
+	}
+
+	if strings.ContainsAny(prefix, "?#%") {
+		return "", fmt.Errorf("%s must not contain a query, fragment "+
+			"or escaped characters", prefix)
+	}
+
+	return strings.TrimSuffix(prefix, "/"), nil
+}
+
+// stripPathPrefix removes the given prefix from the path. False is returned
+// if the path isn't within the prefix.
+func stripPathPrefix(urlPath, prefix string) (string, bool) {
+	switch {
+	case urlPath == prefix:
+		return "/", true
+
+	case strings.HasPrefix(urlPath, prefix+"/"):
+		return strings.TrimPrefix(urlPath, prefix), true
+
+	default:
+		return urlPath, false
+	}
+}
+
+// isNativeGrpcRequest returns true if the request is a native gRPC request,
+// as opposed to a gRPC web request.
+func isNativeGrpcRequest(req *http.Request) bool {
+	return isGrpcRequest(req) && !strings.HasPrefix(
+		req.Header.Get("content-type"), "application/grpc-web",
+	)
+}
+
+// withPathPrefix returns a handler that serves the given handler under the
+// URL path prefix. The prefix is removed from the path of each request before
+// it is passed on. If strict is true, requests outside the prefix are answered
+// with 404, otherwise they are passed on unchanged. Native gRPC requests are
+// never rejected, since gRPC clients can't add a prefix to the path of their
+// calls.
+func withPathPrefix(next http.Handler, prefix string,
+	strict bool) http.Handler {
+
+	if prefix == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stripped, ok := stripPathPrefix(r.URL.Path, prefix)
+		if !ok {
+			if strict && !isNativeGrpcRequest(r) {
+				http.NotFound(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Like http.StripPrefix, we pass on a copy of the request, so
+		// the original one isn't modified.
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = stripped
+		r2.URL.RawPath = ""
+		r2.RequestURI = r2.URL.RequestURI()
+
+		next.ServeHTTP(w, r2)
+	})
+}
@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPathPrefix tests that requests are only served within the path prefix
+// and that the prefix is removed before the request is passed on.
+func TestPathPrefix(t *testing.T) {
+	for prefix, expected := range map[string]string{
+		"":             "",
+		"/":            "",
+		"/lightning":   "/lightning",
+		"/lightning/":  "/lightning",
+		"/a/b":         "/a/b",
+		"lightning":    "",
+		"/lightning/.": "",
+		"/a//b":        "",
+		"/a%20b":       "",
+	} {
+		normalized, err := validateHTTPPathPrefix(prefix)
+		if expected == "" && prefix != "" && prefix != "/" {
+			require.Error(t, err, prefix)
+			continue
+		}
+
+		require.NoError(t, err, prefix)
+		require.Equal(t, expected, normalized, prefix)
+	}
+
+	var seenPath string
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seenPath = r.URL.Path
+	})
+
+	serve := func(handler http.Handler, path, contentType string) int {
+		seenPath = ""
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.ProtoMajor = 2
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Result().StatusCode
+	}
+
+	strict := withPathPrefix(next, "/lightning", true)
+	testCases := []struct {
+		path        string
+		contentType string
+		status      int
+		seenPath    string
+	}{{
+		path:     "/lightning",
+		status:   http.StatusOK,
+		seenPath: "/",
+	}, {
+		path:     "/lightning/static/js/main.js",
+		status:   http.StatusOK,
+		seenPath: "/static/js/main.js",
+	}, {
+		path:        "/lightning/lnrpc.Lightning/GetInfo",
+		contentType: "application/grpc-web+proto",
+		status:      http.StatusOK,
+		seenPath:    "/lnrpc.Lightning/GetInfo",
+	}, {
+		path:   "/lightningx",
+		status: http.StatusNotFound,
+	}, {
+		path:   "/v1/getinfo",
+		status: http.StatusNotFound,
+	}, {
+		path:        "/lnrpc.Lightning/GetInfo",
+		contentType: "application/grpc-web+proto",
+		status:      http.StatusNotFound,
+	}, {
+		// Native gRPC clients can't use a prefix, so their calls are
+		// passed on unchanged.
+		path:        "/lnrpc.Lightning/GetInfo",
+		contentType: "application/grpc",
+		status:      http.StatusOK,
+		seenPath:    "/lnrpc.Lightning/GetInfo",
+	}}
+	for _, tc := range testCases {
+		status := serve(strict, tc.path, tc.contentType)
+		require.Equal(t, tc.status, status, tc.path)
+		require.Equal(t, tc.seenPath, seenPath, tc.path)
+	}
+
+	// Without strict checking, requests outside the prefix are passed on
+	// unchanged.
+	lenient := withPathPrefix(next, "/lightning", false)
+	require.Equal(t, http.StatusOK, serve(lenient, "/v1/getinfo", ""))
+	require.Equal(t, "/v1/getinfo", seenPath)
+}
@@ -0,0 +1,174 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+)
+
+// clientAddrMetadataKey is the metadata key under which LiT passes on the IP
+// of the client when it makes a request to its own gRPC server on behalf of
+// the client, as the REST proxy and batch calls do. The gRPC peer of such a
+// request is LiT itself, so the IP of the peer can't be used.
+const clientAddrMetadataKey = "lit-client-addr"
+
+// clientAddrForwarder passes the IP of a client on to LiT's own gRPC server.
+// Each forwarded IP is prefixed with a random token that is only known to this
+// process, so a client can't claim a different IP by setting the metadata
+// itself.
+type clientAddrForwarder struct {
+	token string
+}
+
+// newClientAddrForwarder creates a new forwarder with a random token.
+func newClientAddrForwarder() (*clientAddrForwarder, error) {
+	var token [32]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return nil, fmt.Errorf("unable to create client address "+
+			"token: %v", err)
+	}
+
+	return &clientAddrForwarder{
+		token: hex.EncodeToString(token[:]),
+	}, nil
+}
+
+// forward returns the metadata that passes on the given client IP.
+func (f *clientAddrForwarder) forward(ip net.IP) metadata.MD {
+	return metadata.Pairs(
+		clientAddrMetadataKey, fmt.Sprintf("%s %s", f.token, ip),
+	)
+}
+
+// restMetadata returns the metadata that passes on the IP of the HTTP client
+// of a REST request to the gRPC server.
+func (f *clientAddrForwarder) restMetadata(_ context.Context,
+	req *http.Request) metadata.MD {
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil
+	}
+
+	return f.forward(ip)
+}
+
+// clientIP returns the IP of the client of the request in the given context.
+// If the request was made by LiT on behalf of a client, the forwarded IP is
+// returned, otherwise the IP of the gRPC peer. For grpc-web requests, the peer
+// is the HTTP client.
+func (f *clientAddrForwarder) clientIP(ctx context.Context) (net.IP, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(clientAddrMetadataKey) {
+		token, addr, ok := strings.Cut(value, " ")
+		if !ok || subtle.ConstantTimeCompare(
+			[]byte(token), []byte(f.token),
+		) != 1 {
+
+			continue
+		}
+
+		if ip := net.ParseIP(addr); ip != nil {
+			return ip, nil
+		}
+	}
+
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return nil, errors.New("no peer in request context")
+	}
+
+	host, _, err := net.SplitHostPort(pr.Addr.String())
+	if err != nil {
+		return nil, fmt.Errorf("peer address %v is not an IP "+
+			"address: %v", pr.Addr, err)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("peer address %v is not an IP "+
+			"address", pr.Addr)
+	}
+
+	return ip, nil
+}
+
+// macaroonFromContext returns the macaroon in the macaroon header of the
+// request in the given context, or nil if there is none.
+func macaroonFromContext(ctx context.Context) (*macaroon.Macaroon, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	macHeader := md.Get(HeaderMacaroon)
+	if len(macHeader) != 1 {
+		return nil, nil
+	}
+
+	return session.ParseMacaroon(macHeader[0])
+}
+
+// checkIPRestrictions makes sure the client of the request in the given
+// context is allowed by all allowed IP ranges caveats of the request's
+// macaroon. Requests that aren't allowed are rejected with PermissionDenied.
+func (p *rpcProxy) checkIPRestrictions(ctx context.Context) error {
+	mac, err := macaroonFromContext(ctx)
+	if err != nil || mac == nil {
+		// An invalid macaroon is rejected by the authentication, so
+		// there is nothing to check here.
+		return nil
+	}
+
+	var restrictions [][]*net.IPNet
+	for _, caveat := range mac.Caveats() {
+		ranges, err := firewall.ParseIPRangesCaveat(string(caveat.Id))
+		if errors.Is(err, firewall.ErrNoIPRangesCaveat) {
+			continue
+		}
+		if err != nil {
+			return status.Errorf(codes.PermissionDenied, "invalid "+
+				"allowed IP ranges caveat: %v", err)
+		}
+
+		restrictions = append(restrictions, ranges)
+	}
+
+	if len(restrictions) == 0 {
+		return nil
+	}
+
+	ip, err := p.clientAddrs.clientIP(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "unable to "+
+			"determine client IP for allowed IP ranges: %v", err)
+	}
+
+	for _, ranges := range restrictions {
+		if err := firewall.CheckIPRanges(ranges, ip); err != nil {
+			return status.Errorf(codes.PermissionDenied,
+				"permission denied: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package itest
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -11,6 +12,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -32,6 +34,7 @@ import (
 	tap "github.com/lightninglabs/taproot-assets/perms"
 	"github.com/lightninglabs/taproot-assets/taprpc"
 	"github.com/lightninglabs/taproot-assets/taprpc/universerpc"
+	"github.com/lightningnetwork/lnd/cert"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
@@ -43,6 +46,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon.v2"
@@ -221,11 +225,6 @@ var (
 		// disabledPattern represents a substring that is expected to be
 		// part of the error returned when a gRPC request is made to the
 		// disabled endpoint.
-		// TODO: once we have a subsystem manager, we can unify the
-		// returned for disabled endpoints for both subsystems and
-		// subservers by not registering the subsystem URIs to the
-		// permsMgr if it has been disabled. This field will then be
-		// unnecessary and can be removed.
 		disabledPattern string
 
 		allowedThroughLNC bool
@@ -280,7 +279,7 @@ var (
 		macaroonFn:        faradayMacaroonFn,
 		requestFn:         faradayRequestFn,
 		successPattern:    "\"reports\":[]",
-		disabledPattern:   "unknown request",
+		disabledPattern:   "faraday has been disabled",
 		allowedThroughLNC: true,
 		grpcWebURI:        "/frdrpc.FaradayServer/RevenueReport",
 		restWebURI:        "/v1/faraday/revenue",
@@ -290,7 +289,7 @@ var (
 		macaroonFn:        loopMacaroonFn,
 		requestFn:         loopRequestFn,
 		successPattern:    "\"swaps\":[]",
-		disabledPattern:   "unknown request",
+		disabledPattern:   "loop has been disabled",
 		allowedThroughLNC: true,
 		grpcWebURI:        "/looprpc.SwapClient/ListSwaps",
 		restWebURI:        "/v1/loop/swaps",
@@ -300,7 +299,7 @@ var (
 		macaroonFn:        poolMacaroonFn,
 		requestFn:         poolRequestFn,
 		successPattern:    "\"accounts_active\":0",
-		disabledPattern:   "unknown request",
+		disabledPattern:   "pool has been disabled",
 		allowedThroughLNC: true,
 		grpcWebURI:        "/poolrpc.Trader/GetInfo",
 		restWebURI:        "/v1/pool/info",
@@ -310,7 +309,7 @@ var (
 		macaroonFn:        tapMacaroonFn,
 		requestFn:         tapRequestFn,
 		successPattern:    "\"assets\":[]",
-		disabledPattern:   "unknown request",
+		disabledPattern:   "taproot-assets has been disabled",
 		allowedThroughLNC: true,
 		grpcWebURI:        "/taprpc.TaprootAssets/ListAssets",
 		restWebURI:        "/v1/taproot-assets/assets",
@@ -320,7 +319,7 @@ var (
 		macaroonFn:        emptyMacaroonFn,
 		requestFn:         tapUniverseRequestFn,
 		successPattern:    "\"runtime_id\":",
-		disabledPattern:   "unknown request",
+		disabledPattern:   "taproot-assets has been disabled",
 		allowedThroughLNC: true,
 		grpcWebURI:        "/universerpc.Universe/Info",
 		restWebURI:        "/v1/taproot-assets/universe/info",
@@ -485,6 +484,9 @@ func integratedTestSuite(ctx context.Context, net *NetworkHarness, t *testing.T,
 	t.Run("certificate check", func(tt *testing.T) {
 		runCertificateCheck(tt, net.Alice)
 	})
+	t.Run("TLS reload", func(tt *testing.T) {
+		runTLSReloadCheck(tt, net.Alice)
+	})
 	t.Run("gRPC macaroon auth check", func(tt *testing.T) {
 		cfg := net.Alice.Cfg
 
@@ -519,6 +521,13 @@ func integratedTestSuite(ctx context.Context, net *NetworkHarness, t *testing.T,
 		}
 	})
 
+	t.Run("unknown method", func(tt *testing.T) {
+		cfg := net.Alice.Cfg
+		runUnknownMethodCheck(
+			tt, cfg.LitAddr(), cfg.LitTLSCertPath, cfg.AdminMacPath,
+		)
+	})
+
 	t.Run("UI password auth check", func(tt *testing.T) {
 		cfg := net.Alice.Cfg
 
@@ -814,6 +823,84 @@ func runCertificateCheck(t *testing.T, node *HarnessNode) {
 	)
 }
 
+// runTLSReloadCheck swaps the TLS certificate of the lit port at runtime and
+// makes sure it is only served after it was reloaded. The original
+// certificate is restored at the end, so the other tests aren't affected.
+func runTLSReloadCheck(t *testing.T, node *HarnessNode) {
+	cfg := node.Cfg
+	certPath := cfg.LitTLSCertPath
+	keyPath := filepath.Join(filepath.Dir(certPath), "tls.key")
+
+	ctx := context.Background()
+	ctxt, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// We connect while the original certificate is still on disk, the
+	// connection stays valid after the certificate is swapped.
+	rawConn, err := connectRPC(ctxt, cfg.LitAddr(), certPath)
+	require.NoError(t, err)
+	defer rawConn.Close()
+
+	macBytes, err := os.ReadFile(cfg.LitMacPath)
+	require.NoError(t, err)
+	ctxm := macaroonContext(ctxt, macBytes)
+	client := litrpc.NewProxyClient(rawConn)
+
+	oldCertBytes, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+	oldKeyBytes, err := os.ReadFile(keyPath)
+	require.NoError(t, err)
+
+	oldCerts, err := getServerCertificates(cfg.LitAddr())
+	require.NoError(t, err)
+	require.Len(t, oldCerts, 1)
+
+	// Swapping the files alone doesn't change the served certificate.
+	certBytes, keyBytes, err := cert.GenCertPair(
+		"litd autogenerated cert", nil, nil, false, time.Hour,
+	)
+	require.NoError(t, err)
+	require.NoError(t, cert.WriteCertPair(
+		certPath, keyPath, certBytes, keyBytes,
+	))
+
+	// Whatever happens, the original certificate must be served again
+	// after this test.
+	defer func() {
+		require.NoError(t, cert.WriteCertPair(
+			certPath, keyPath, oldCertBytes, oldKeyBytes,
+		))
+		_, err := client.ReloadTLS(ctxm, &litrpc.ReloadTLSRequest{})
+		require.NoError(t, err)
+
+		certs, err := getServerCertificates(cfg.LitAddr())
+		require.NoError(t, err)
+		require.Equal(t, oldCerts[0].Raw, certs[0].Raw)
+	}()
+
+	certs, err := getServerCertificates(cfg.LitAddr())
+	require.NoError(t, err)
+	require.Equal(t, oldCerts[0].Raw, certs[0].Raw)
+
+	// After the reload, new connections are served the new certificate.
+	resp, err := client.ReloadTLS(ctxm, &litrpc.ReloadTLSRequest{})
+	require.NoError(t, err)
+
+	certs, err = getServerCertificates(cfg.LitAddr())
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.NotEqual(t, oldCerts[0].Raw, certs[0].Raw)
+
+	fingerprint := sha256.Sum256(certs[0].Raw)
+	require.Equal(
+		t, hex.EncodeToString(fingerprint[:]), resp.Sha256Fingerprint,
+	)
+
+	// The existing connection still works.
+	_, err = client.GetInfo(ctxm, &litrpc.GetInfoRequest{})
+	require.NoError(t, err)
+}
+
 // runGRPCAuthTest tests authentication of the given gRPC interface.
 func runGRPCAuthTest(t *testing.T, hostPort, tlsCertPath, macPath string,
 	noMac bool, makeRequest requestFn, successContent string, disabled bool,
@@ -876,6 +963,34 @@ func runGRPCAuthTest(t *testing.T, hostPort, tlsCertPath, macPath string,
 	require.Contains(t, string(json), successContent)
 }
 
+// runUnknownMethodCheck makes sure that a call to a method that isn't known
+// to LiT is rejected with a clean Unimplemented error.
+func runUnknownMethodCheck(t *testing.T, hostPort, tlsCertPath,
+	macPath string) {
+
+	ctxb := context.Background()
+	ctxt, cancel := context.WithTimeout(ctxb, defaultTimeout)
+	defer cancel()
+
+	rawConn, err := connectRPC(ctxt, hostPort, tlsCertPath)
+	require.NoError(t, err)
+	defer rawConn.Close()
+
+	macBytes, err := os.ReadFile(macPath)
+	require.NoError(t, err)
+	ctxm := macaroonContext(ctxt, macBytes)
+
+	// The request is rejected before it is decoded, so we can use any
+	// message type here.
+	bogusURI := "/lnrpc.Lightning/BogusMethod"
+	err = rawConn.Invoke(
+		ctxm, bogusURI, &lnrpc.GetInfoRequest{},
+		&lnrpc.GetInfoResponse{},
+	)
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	require.ErrorContains(t, err, "unknown method "+bogusURI)
+}
+
 // runUIPasswordCheck tests UI password authentication.
 func runUIPasswordCheck(t *testing.T, hostPort, tlsCertPath, uiPassword string,
 	makeRequest requestFn, noAuth, shouldFailWithoutMacaroon bool,
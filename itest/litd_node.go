@@ -238,7 +238,11 @@ func (cfg *LitNodeConfig) defaultLitdArgs() *litArgs {
 	}
 
 	// In remote mode, we don't need any lnd specific arguments other than
-	// those we need to connect.
+	// those we need to connect. The lnd nodes of the itests only listen on
+	// TCP, so RPCAddr() is always a host:port. To connect over a Unix
+	// domain socket instead, the lnd node would need an additional
+	// rpclisten=unix:///... argument and remote.lnd.rpcserver would need to
+	// be set to the same unix:// address.
 	if cfg.RemoteMode {
 		args["lnd-mode"] = "remote"
 		args["remote.lnd.rpcserver"] = cfg.RPCAddr()
@@ -0,0 +1,617 @@
+package terminal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// defaultJWTScopeClaim is the default claim of a JWT that is mapped to
+	// a permission scope.
+	defaultJWTScopeClaim = "scope"
+
+	// defaultJWKSRefreshInterval is the default interval after which the
+	// keys of the JWKS URL are fetched again.
+	defaultJWKSRefreshInterval = time.Hour
+
+	// minJWKSRefetchInterval is the minimum time between two fetches of
+	// the JWKS URL that are caused by tokens with an unknown key ID, so
+	// such tokens can't be used to flood the JWKS server.
+	minJWKSRefetchInterval = time.Minute
+
+	// jwksFetchTimeout is the timeout of a single fetch of the JWKS URL.
+	jwksFetchTimeout = 10 * time.Second
+
+	// jwtScopeAdmin grants a token the same full access as the UI
+	// password.
+	jwtScopeAdmin = "admin"
+
+	// jwtScopeReadOnly grants a token the read permissions of all active
+	// daemons.
+	jwtScopeReadOnly = "readonly"
+)
+
+var (
+	// jwtValidMethods are the signing algorithms that tokens are accepted
+	// with. Symmetric algorithms aren't supported, since the key that
+	// verifies a token could then also be used to issue tokens.
+	jwtValidMethods = []string{
+		"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512", "EdDSA",
+	}
+)
+
+// JWTConfig holds the options for authenticating requests with JWT bearer
+// tokens.
+type JWTConfig struct {
+	JWKSURL       string        `long:"jwksurl" description:"The URL of the JSON Web Key Set that the signatures of the JWT bearer tokens are verified with. Setting this or publickeypath enables JWT authentication."`
+	JWKSRefresh   time.Duration `long:"jwksrefresh" description:"The interval after which the keys of the JWKS URL are fetched again. Tokens signed with an unknown key ID cause an earlier fetch, but at most once per minute."`
+	PublicKeyPath string        `long:"publickeypath" description:"Path to a PEM encoded public key that the signatures of the JWT bearer tokens are verified with. Can be used instead of jwksurl."`
+	Issuer        string        `long:"issuer" description:"If set, only tokens with this iss claim are accepted."`
+	Audience      string        `long:"audience" description:"If set, only tokens with this aud claim are accepted."`
+	ScopeClaim    string        `long:"scopeclaim" description:"The claim of a token that is mapped to a permission scope with scopemap. The claim can be a string, a space separated list of strings or an array of strings."`
+	ScopeMap      []string      `long:"scopemap" description:"Map a value of the scope claim to the permissions it grants, in the form value=scope. The scope is either admin for full access, readonly for the read permissions of all active daemons or a comma separated list of entity:action permissions. Specify this option multiple times to map several values. If a token has several mapped values, the first mapping in the configured order is used. Tokens without a mapped value are rejected."`
+
+	scopes    []*jwtScope
+	publicKey crypto.PublicKey
+}
+
+// enabled returns true if JWT authentication is configured.
+func (c *JWTConfig) enabled() bool {
+	return c.JWKSURL != "" || c.PublicKeyPath != ""
+}
+
+// validate checks the JWT options and parses the scope mapping and the static
+// public key.
+func (c *JWTConfig) validate() error {
+	if !c.enabled() {
+		if len(c.ScopeMap) > 0 {
+			return fmt.Errorf("scopemap requires jwksurl or " +
+				"publickeypath to be set")
+		}
+
+		return nil
+	}
+
+	if c.JWKSURL != "" && c.PublicKeyPath != "" {
+		return fmt.Errorf("jwksurl and publickeypath can't be " +
+			"combined")
+	}
+
+	if c.JWKSURL != "" && c.JWKSRefresh <= 0 {
+		return fmt.Errorf("jwksrefresh must be positive")
+	}
+
+	if c.ScopeClaim == "" {
+		return fmt.Errorf("scopeclaim must not be empty")
+	}
+
+	if len(c.ScopeMap) == 0 {
+		return fmt.Errorf("at least one scopemap is required")
+	}
+
+	scopes, err := parseJWTScopes(c.ScopeMap)
+	if err != nil {
+		return err
+	}
+	c.scopes = scopes
+
+	if c.PublicKeyPath != "" {
+		c.PublicKeyPath = lncfg.CleanAndExpandPath(c.PublicKeyPath)
+		c.publicKey, err = readPublicKey(c.PublicKeyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jwtScope maps a value of the scope claim of a token to the permissions it
+// grants.
+type jwtScope struct {
+	value    string
+	admin    bool
+	readOnly bool
+	perms    []bakery.Op
+}
+
+// parseJWTScopes parses the configured scope mappings, each in the form
+// value=scope.
+func parseJWTScopes(mappings []string) ([]*jwtScope, error) {
+	seen := make(map[string]struct{}, len(mappings))
+	scopes := make([]*jwtScope, 0, len(mappings))
+	for _, mapping := range mappings {
+		value, scopeStr, ok := strings.Cut(mapping, "=")
+		if !ok || value == "" || scopeStr == "" {
+			return nil, fmt.Errorf("invalid scopemap %s, must be "+
+				"in the form value=scope", mapping)
+		}
+
+		if _, ok := seen[value]; ok {
+			return nil, fmt.Errorf("duplicate scopemap for %s",
+				value)
+		}
+		seen[value] = struct{}{}
+
+		scope, err := parseScope(value, scopeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scopemap %s: %v",
+				mapping, err)
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, nil
+}
+
+// parseScope parses a scope that is either admin, readonly or a comma
+// separated list of entity:action permissions. The value is the name the scope
+// is known by, for example the value of a token's scope claim.
+func parseScope(value, scopeStr string) (*jwtScope, error) {
+	scope := &jwtScope{value: value}
+	switch scopeStr {
+	case jwtScopeAdmin:
+		scope.admin = true
+
+	case jwtScopeReadOnly:
+		scope.readOnly = true
+
+	default:
+		ops, err := perms.ParsePermissions(
+			strings.Split(scopeStr, ","),
+		)
+		if err != nil {
+			return nil, err
+		}
+		scope.perms = ops
+	}
+
+	return scope, nil
+}
+
+// readPublicKey reads a PEM encoded public key from the given file.
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public key: %v", err)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	// Besides a plain public key, we also accept a certificate, since
+	// some identity providers only publish that.
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: "+
+				"%v", err)
+		}
+
+		return cert.PublicKey, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key: %v", err)
+	}
+
+	return key, nil
+}
+
+// jwk is a single key of a JSON Web Key Set.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey converts the JSON web key into a public key.
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key length")
+		}
+
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// jwtKeySet provides the keys that the signatures of tokens are verified
+// with, either a single static key or the keys of a JWKS URL.
+type jwtKeySet struct {
+	staticKey crypto.PublicKey
+
+	url             string
+	refreshInterval time.Duration
+	client          *http.Client
+
+	// fetches makes sure only one fetch of the JWKS URL is in flight at a
+	// time. Requests that need the keys while they are fetched wait for
+	// that fetch instead of starting their own.
+	fetches singleflight.Group
+
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	mu        sync.Mutex
+}
+
+// fetchKeys returns the keys of the JWKS URL. Keys that can't be parsed are
+// skipped, so a single unsupported key doesn't make all others unusable.
+func (s *jwtKeySet) fetchKeys() (map[string]crypto.PublicKey, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			log.Warnf("Skipping JWKS key %s: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// refresh fetches the keys of the JWKS URL and swaps them in. The mutex isn't
+// held during the fetch, so a slow JWKS URL doesn't block requests that can
+// be verified with the keys that are already known.
+func (s *jwtKeySet) refresh() {
+	_, _, _ = s.fetches.Do(s.url, func() (interface{}, error) {
+		keys, err := s.fetchKeys()
+		if err != nil {
+			log.Errorf("Unable to fetch JWKS from %s: %v", s.url,
+				err)
+		}
+
+		// The fetch time is also updated if the fetch failed, so
+		// that an unreachable JWKS URL isn't retried on every
+		// request.
+		s.mu.Lock()
+		s.fetchedAt = time.Now()
+		if err == nil {
+			s.keys = keys
+		}
+		s.mu.Unlock()
+
+		return nil, nil
+	})
+}
+
+// key returns the key that the signature of the given token must be verified
+// with.
+//
+// NOTE: this is a jwt.Keyfunc.
+func (s *jwtKeySet) key(token *jwt.Token) (interface{}, error) {
+	if s.staticKey != nil {
+		return s.staticKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	now := time.Now()
+
+	s.mu.Lock()
+	_, known := s.keys[kid]
+	refetch := !known && now.Sub(s.fetchedAt) >= minJWKSRefetchInterval
+	stale := s.keys == nil || now.Sub(s.fetchedAt) >= s.refreshInterval
+	s.mu.Unlock()
+
+	if stale || refetch {
+		s.refresh()
+	}
+
+	s.mu.Lock()
+	key, ok := s.keys[kid]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+
+	return key, nil
+}
+
+// jwtVerifier checks JWT bearer tokens and maps them to their permission
+// scope.
+type jwtVerifier struct {
+	cfg    *JWTConfig
+	keys   *jwtKeySet
+	parser *jwt.Parser
+}
+
+// newJWTVerifier creates a new verifier with the given options.
+func newJWTVerifier(cfg *JWTConfig) *jwtVerifier {
+	return &jwtVerifier{
+		cfg: cfg,
+		keys: &jwtKeySet{
+			staticKey:       cfg.publicKey,
+			url:             cfg.JWKSURL,
+			refreshInterval: cfg.JWKSRefresh,
+			client:          &http.Client{Timeout: jwksFetchTimeout},
+		},
+		parser: jwt.NewParser(jwt.WithValidMethods(jwtValidMethods)),
+	}
+}
+
+// verify checks the signature and the claims of the given token and returns
+// the scope it is mapped to. An error with the UNAUTHENTICATED code is
+// returned if the token is invalid, for example because it expired or its
+// signature is wrong.
+func (v *jwtVerifier) verify(token string) (*jwtScope, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, v.keys.key)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid "+
+			"JWT: %v", err)
+	}
+
+	// The parser only checks the expiry of tokens that have one, but a
+	// token without an expiry would be valid forever.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, status.Error(codes.Unauthenticated, "invalid JWT: "+
+			"missing expiry")
+	}
+
+	if v.cfg.Issuer != "" && !claims.VerifyIssuer(v.cfg.Issuer, true) {
+		return nil, status.Error(codes.Unauthenticated, "invalid JWT: "+
+			"unexpected issuer")
+	}
+
+	if v.cfg.Audience != "" &&
+		!claims.VerifyAudience(v.cfg.Audience, true) {
+
+		return nil, status.Error(codes.Unauthenticated, "invalid JWT: "+
+			"unexpected audience")
+	}
+
+	values := jwtClaimValues(claims[v.cfg.ScopeClaim])
+	for _, scope := range v.cfg.scopes {
+		if _, ok := values[scope.value]; ok {
+			return scope, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.PermissionDenied, "JWT has no "+
+		"mapped %s claim", v.cfg.ScopeClaim)
+}
+
+// jwtClaimValues returns the values of a claim that is either a string, a
+// space separated list of strings or an array of strings.
+func jwtClaimValues(claim interface{}) map[string]struct{} {
+	values := make(map[string]struct{})
+	switch c := claim.(type) {
+	case string:
+		for _, value := range strings.Fields(c) {
+			values[value] = struct{}{}
+		}
+
+	case []interface{}:
+		for _, value := range c {
+			if s, ok := value.(string); ok {
+				values[s] = struct{}{}
+			}
+		}
+	}
+
+	return values
+}
+
+// bearerToken returns the bearer token of the given authorization header.
+// False is returned if the header doesn't use the bearer scheme.
+func bearerToken(authHeader string) (string, bool) {
+	scheme, token, ok := strings.Cut(authHeader, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// scopePermsMacaroon returns the macaroon that is attached to requests that
+// were authenticated with a credential of the given scope. The macaroons of
+// scopes with custom permissions are cached under the given prefix and the
+// scope's value.
+func (p *rpcProxy) scopePermsMacaroon(prefix string, scope *jwtScope,
+	requestURI string) ([]byte, error) {
+
+	switch {
+	case scope.admin:
+		return p.fullAccessMacaroon(requestURI)
+
+	case scope.readOnly:
+		return p.scopeMacaroon(UIUserScopeReadOnly, func() []bakery.Op {
+			return p.permsMgr.ActivePermissions(true)
+		})
+
+	default:
+		return p.scopeMacaroon(prefix+scope.value, func() []bakery.Op {
+			return scope.perms
+		})
+	}
+}
+
+// requestJWTMacaroon verifies the JWT bearer token of the request in the
+// given context and returns the macaroon of its scope. errNoCredential is
+// returned if the request doesn't carry a bearer token.
+func (p *rpcProxy) requestJWTMacaroon(ctx context.Context,
+	requestURI string) ([]byte, error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errNoCredential
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return nil, errNoCredential
+	}
+
+	token, ok := bearerToken(authHeaders[0])
+	if !ok {
+		return nil, errNoCredential
+	}
+
+	scope, err := p.jwt.verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.scopePermsMacaroon("jwt:", scope, requestURI)
+}
+
+// jwtAuthBackend authenticates requests with a JWT bearer token.
+type jwtAuthBackend struct {
+	p *rpcProxy
+}
+
+// name returns the name of the backend.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *jwtAuthBackend) name() string {
+	return AuthBackendJWT
+}
+
+// authenticate checks the JWT bearer token of the request and, if it is
+// valid, attaches the macaroon of the token's scope.
+//
+// NOTE: this is part of the authBackend interface.
+func (b *jwtAuthBackend) authenticate(ctx context.Context,
+	requestURI string, requiredPermissions []bakery.Op) (context.Context,
+	error) {
+
+	macBytes, err := b.p.requestJWTMacaroon(ctx, requestURI)
+	if err != nil {
+		return nil, err
+	}
+	if len(macBytes) == 0 {
+		return nil, errors.New("no macaroon available for JWT")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	err = b.p.macValidator.ValidateMacaroon(
+		ctx, requiredPermissions, requestURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
@@ -0,0 +1,149 @@
+package terminal
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signTestJWT signs a token with the given claims and key ID.
+func signTestJWT(t *testing.T, key ed25519.PrivateKey, kid string,
+	claims jwt.MapClaims) string {
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+// TestJWTVerifier tests that tokens are checked against the keys of a JWKS URL
+// and mapped to the configured scopes.
+func TestJWTVerifier(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"kid": "key1",
+			"use": "sig",
+			"x":   base64.RawURLEncoding.EncodeToString(pubKey),
+		}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(jwks)
+		},
+	))
+	defer server.Close()
+
+	cfg := &JWTConfig{
+		JWKSURL:     server.URL,
+		JWKSRefresh: defaultJWKSRefreshInterval,
+		Issuer:      "https://issuer.example.com",
+		ScopeClaim:  defaultJWTScopeClaim,
+		ScopeMap: []string{
+			"lit:admin=admin",
+			"lit:read=readonly",
+			"lit:invoices=invoices:read,invoices:write",
+		},
+	}
+	require.NoError(t, cfg.validate())
+
+	v := newJWTVerifier(cfg)
+	exp := time.Now().Add(time.Hour).Unix()
+
+	// A valid token is mapped to the first scope it has a value of.
+	scope, err := v.verify(signTestJWT(t, privKey, "key1", jwt.MapClaims{
+		"iss":   cfg.Issuer,
+		"exp":   exp,
+		"scope": "openid lit:invoices lit:read",
+	}))
+	require.NoError(t, err)
+	require.True(t, scope.readOnly)
+
+	scope, err = v.verify(signTestJWT(t, privKey, "key1", jwt.MapClaims{
+		"iss":   cfg.Issuer,
+		"exp":   exp,
+		"scope": []string{"lit:invoices"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, scope.perms, 2)
+
+	// Expired tokens, tokens without an expiry, tokens with a wrong
+	// signature, an unknown key ID or a different issuer are
+	// unauthenticated.
+	invalid := []string{
+		signTestJWT(t, privKey, "key1", jwt.MapClaims{
+			"iss":   cfg.Issuer,
+			"exp":   time.Now().Add(-time.Minute).Unix(),
+			"scope": "lit:admin",
+		}),
+		signTestJWT(t, privKey, "key1", jwt.MapClaims{
+			"iss":   cfg.Issuer,
+			"scope": "lit:admin",
+		}),
+		signTestJWT(t, otherKey, "key1", jwt.MapClaims{
+			"iss":   cfg.Issuer,
+			"exp":   exp,
+			"scope": "lit:admin",
+		}),
+		signTestJWT(t, privKey, "key2", jwt.MapClaims{
+			"iss":   cfg.Issuer,
+			"exp":   exp,
+			"scope": "lit:admin",
+		}),
+		signTestJWT(t, privKey, "key1", jwt.MapClaims{
+			"iss":   "https://other.example.com",
+			"exp":   exp,
+			"scope": "lit:admin",
+		}),
+	}
+	for _, token := range invalid {
+		_, err := v.verify(token)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+
+	// A valid token without a mapped scope is denied.
+	_, err = v.verify(signTestJWT(t, privKey, "key1", jwt.MapClaims{
+		"iss":   cfg.Issuer,
+		"exp":   exp,
+		"scope": "openid",
+	}))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// TestParseJWTScopes tests the parsing of the scope mappings.
+func TestParseJWTScopes(t *testing.T) {
+	scopes, err := parseJWTScopes([]string{
+		"a=admin", "b=readonly", "c=info:read",
+	})
+	require.NoError(t, err)
+	require.True(t, scopes[0].admin)
+	require.True(t, scopes[1].readOnly)
+	require.Equal(t, "info", scopes[2].perms[0].Entity)
+
+	for _, mappings := range [][]string{
+		{"a"}, {"=admin"}, {"a="}, {"a=info"},
+		{"a=admin", "a=readonly"},
+	} {
+		_, err := parseJWTScopes(mappings)
+		require.Error(t, err)
+	}
+}
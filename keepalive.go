@@ -0,0 +1,71 @@
+package terminal
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// defaultKeepaliveTime is the default duration a gRPC connection may be
+	// idle before a keepalive ping is sent. It matches lnd's default.
+	defaultKeepaliveTime = time.Minute
+
+	// defaultKeepaliveTimeout is the default duration to wait for the
+	// response to a keepalive ping. It matches lnd's default.
+	defaultKeepaliveTimeout = 20 * time.Second
+
+	// defaultKeepaliveMinPing is the default minimum duration a client must
+	// wait between two keepalive pings. It matches lnd's default.
+	defaultKeepaliveMinPing = 5 * time.Second
+)
+
+// validate checks that the keepalive config is sane.
+func (c *KeepaliveConfig) validate() error {
+	if c.Time <= 0 {
+		return fmt.Errorf("time must be positive")
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+
+	if c.MinPing <= 0 {
+		return fmt.Errorf("minping must be positive")
+	}
+
+	return nil
+}
+
+// serverOpts returns the gRPC server options that make a server ping its idle
+// clients and disconnect clients that ping it too often. Clients that exceed
+// the enforcement policy are sent a GOAWAY frame with the too_many_pings
+// reason by the gRPC library.
+func (c *KeepaliveConfig) serverOpts() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    c.Time,
+			Timeout: c.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime: c.MinPing,
+		}),
+	}
+}
+
+// dialOpt returns the gRPC dial option that makes a client connection ping
+// its server while it is idle.
+//
+// NOTE: Pings are only sent while there are active calls, since the servers
+// reject pings without any by default. This must only be used for lnd, which
+// allows a ping every 5 seconds. The other daemons use gRPC's default policy
+// of one ping every 5 minutes and send a too_many_pings GOAWAY to clients
+// that ping more often.
+func (c *KeepaliveConfig) dialOpt() grpc.DialOption {
+	return grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:    c.Time,
+		Timeout: c.Timeout,
+	})
+}
@@ -0,0 +1,199 @@
+package terminal
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+const (
+	// listenerSelfTestTimeout is the maximum duration of the self-test of
+	// a single listener.
+	listenerSelfTestTimeout = 5 * time.Second
+)
+
+// listenerInfo describes a listener that LiT serves on.
+type listenerInfo struct {
+	// name is a short name of the listener, for example "https".
+	name string
+
+	// network is the network of the listener, "tcp" or "unix".
+	network string
+
+	// addr is the address the listener is bound to.
+	addr string
+
+	// protocols are the protocols served on the listener.
+	protocols []string
+
+	// tls is true if connections to the listener use TLS.
+	tls bool
+
+	// authPolicy describes the credentials that are accepted on the
+	// listener.
+	authPolicy string
+}
+
+// listenerRegistry keeps track of all listeners LiT serves on, so they can be
+// reported in one place.
+type listenerRegistry struct {
+	listeners []*listenerInfo
+	mu        sync.Mutex
+}
+
+// newListenerRegistry creates a new, empty listenerRegistry.
+func newListenerRegistry() *listenerRegistry {
+	return &listenerRegistry{}
+}
+
+// add registers a listener.
+func (r *listenerRegistry) add(info *listenerInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, info)
+}
+
+// all returns all registered listeners in the order they were added.
+func (r *listenerRegistry) all() []*listenerInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	listeners := make([]*listenerInfo, len(r.listeners))
+	copy(listeners, r.listeners)
+
+	return listeners
+}
+
+// newListenerInfo creates the description of a listener that is bound to the
+// given address.
+func newListenerInfo(name string, addr net.Addr, protocols []string,
+	useTLS bool, authPolicy string) *listenerInfo {
+
+	return &listenerInfo{
+		name:       name,
+		network:    addr.Network(),
+		addr:       addr.String(),
+		protocols:  protocols,
+		tls:        useTLS,
+		authPolicy: authPolicy,
+	}
+}
+
+// webAuthPolicy describes the credentials that are accepted on LiT's main web
+// listeners.
+func webAuthPolicy(cfg *Config, useTLS bool) string {
+	policy := "macaroon"
+	if !cfg.DisableUI {
+		policy += ", UI password"
+	}
+	switch {
+	// A required client certificate only grants access to the listener,
+	// the requests must still be authenticated.
+	case useTLS && cfg.LitClientCAPath != "":
+		policy = "client certificate required, then " + policy
+
+	case useTLS && cfg.TLSClientCAPath != "":
+		policy += ", client certificate"
+	}
+
+	return policy
+}
+
+// webProtocols returns the protocols that are served on LiT's main web
+// listeners.
+func webProtocols(cfg *Config) []string {
+	protocols := []string{"grpc", "grpc-web"}
+	if cfg.EnableREST {
+		protocols = append(protocols, "rest")
+	}
+	if !cfg.DisableUI {
+		protocols = append(protocols, "ui")
+	}
+
+	return protocols
+}
+
+// lndAuthPolicy describes the credentials that are accepted on the listeners
+// of the integrated lnd.
+func lndAuthPolicy(cfg *Config) string {
+	if cfg.Lnd.NoMacaroons {
+		return "none"
+	}
+
+	return "lnd macaroon"
+}
+
+// selfTest connects to the listener and, if it uses TLS, completes a TLS
+// handshake. Wildcard addresses are dialed on localhost.
+func (l *listenerInfo) selfTest(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, listenerSelfTestTimeout)
+	defer cancel()
+
+	addr := l.addr
+	if l.network != "unix" {
+		addr = toLocalAddress(addr)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, l.network, addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if !l.tls {
+		return nil
+	}
+
+	// We only want to know whether the listener speaks TLS, the
+	// certificate itself is checked by the client that uses it.
+	tlsConn := tls.Client(conn, &tls.Config{
+		InsecureSkipVerify: true,
+	})
+
+	return tlsConn.HandshakeContext(ctx)
+}
+
+// ListListeners returns all listeners LiT serves on, together with the result
+// of connecting to each of them.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) ListListeners(ctx context.Context,
+	_ *litrpc.ListListenersRequest) (*litrpc.ListListenersResponse, error) {
+
+	listeners := p.listeners.all()
+	results := make([]*litrpc.Listener, len(listeners))
+
+	var wg sync.WaitGroup
+	for idx, info := range listeners {
+		wg.Add(1)
+		go func(idx int, info *listenerInfo) {
+			defer wg.Done()
+
+			result := &litrpc.Listener{
+				Name:       info.name,
+				Address:    info.addr,
+				Protocols:  info.protocols,
+				Tls:        info.tls,
+				AuthPolicy: info.authPolicy,
+				SelfTestOk: true,
+			}
+			if err := info.selfTest(ctx); err != nil {
+				result.SelfTestOk = false
+				result.SelfTestError = err.Error()
+			}
+
+			results[idx] = result
+		}(idx, info)
+	}
+	wg.Wait()
+
+	return &litrpc.ListListenersResponse{
+		Listeners: results,
+	}, nil
+}
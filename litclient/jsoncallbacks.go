@@ -51,6 +51,7 @@ var Registrations = []StubPackageRegistration{
 	litrpc.RegisterAutopilotJSONCallbacks,
 	litrpc.RegisterFirewallJSONCallbacks,
 	litrpc.RegisterStatusJSONCallbacks,
+	litrpc.RegisterApiKeysJSONCallbacks,
 	taprpc.RegisterTaprootAssetsJSONCallbacks,
 	assetwalletrpc.RegisterAssetWalletJSONCallbacks,
 	universerpc.RegisterUniverseJSONCallbacks,
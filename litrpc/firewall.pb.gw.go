@@ -65,6 +65,34 @@ func local_request_Firewall_ListActions_0(ctx context.Context, marshaler runtime
 
 }
 
+var (
+	filter_Firewall_SubscribeActions_0 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
+)
+
+func request_Firewall_SubscribeActions_0(ctx context.Context, marshaler runtime.Marshaler, client FirewallClient, req *http.Request, pathParams map[string]string) (Firewall_SubscribeActionsClient, runtime.ServerMetadata, error) {
+	var protoReq SubscribeActionsRequest
+	var metadata runtime.ServerMetadata
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_Firewall_SubscribeActions_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream, err := client.SubscribeActions(ctx, &protoReq)
+	if err != nil {
+		return nil, metadata, err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	return stream, metadata, nil
+
+}
+
 func request_Firewall_PrivacyMapConversion_0(ctx context.Context, marshaler runtime.Marshaler, client FirewallClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var protoReq PrivacyMapConversionRequest
 	var metadata runtime.ServerMetadata
@@ -130,6 +158,13 @@ func RegisterFirewallHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 	})
 
+	mux.Handle("GET", pattern_Firewall_SubscribeActions_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+		return
+	})
+
 	mux.Handle("POST", pattern_Firewall_PrivacyMapConversion_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
@@ -218,6 +253,28 @@ func RegisterFirewallHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 	})
 
+	mux.Handle("GET", pattern_Firewall_SubscribeActions_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		var annotatedContext context.Context
+		annotatedContext, err = runtime.AnnotateContext(ctx, mux, req, "/litrpc.Firewall/SubscribeActions", runtime.WithHTTPPathPattern("/v1/firewall/actions/subscribe"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_Firewall_SubscribeActions_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_Firewall_SubscribeActions_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
+
+	})
+
 	mux.Handle("POST", pattern_Firewall_PrivacyMapConversion_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
@@ -246,11 +303,15 @@ func RegisterFirewallHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 var (
 	pattern_Firewall_ListActions_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2}, []string{"v1", "firewall", "actions"}, ""))
 
+	pattern_Firewall_SubscribeActions_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"v1", "firewall", "actions", "subscribe"}, ""))
+
 	pattern_Firewall_PrivacyMapConversion_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"v1", "firewall", "privacy_map", "convert"}, ""))
 )
 
 var (
 	forward_Firewall_ListActions_0 = runtime.ForwardResponseMessage
 
+	forward_Firewall_SubscribeActions_0 = runtime.ForwardResponseStream
+
 	forward_Firewall_PrivacyMapConversion_0 = runtime.ForwardResponseMessage
 )
@@ -30,6 +30,11 @@ type FirewallClient interface {
 	// URI and timestamp of the actions will be stored. The "full" mode will
 	// persist all request data for all actions.
 	ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (*ListActionsResponse, error)
+	// litcli: `subscribeactions`
+	// SubscribeActions streams the actions that are added to the action log as
+	// well as the actions whose state changes, optionally filtered by session.
+	// Past actions are not replayed, use ListActions to query them.
+	SubscribeActions(ctx context.Context, in *SubscribeActionsRequest, opts ...grpc.CallOption) (Firewall_SubscribeActionsClient, error)
 	// litcli: `privacy`
 	// PrivacyMapConversion can be used map real values to their pseudo
 	// counterpart and vice versa.
@@ -53,6 +58,38 @@ func (c *firewallClient) ListActions(ctx context.Context, in *ListActionsRequest
 	return out, nil
 }
 
+func (c *firewallClient) SubscribeActions(ctx context.Context, in *SubscribeActionsRequest, opts ...grpc.CallOption) (Firewall_SubscribeActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Firewall_ServiceDesc.Streams[0], "/litrpc.Firewall/SubscribeActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &firewallSubscribeActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Firewall_SubscribeActionsClient interface {
+	Recv() (*Action, error)
+	grpc.ClientStream
+}
+
+type firewallSubscribeActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *firewallSubscribeActionsClient) Recv() (*Action, error) {
+	m := new(Action)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *firewallClient) PrivacyMapConversion(ctx context.Context, in *PrivacyMapConversionRequest, opts ...grpc.CallOption) (*PrivacyMapConversionResponse, error) {
 	out := new(PrivacyMapConversionResponse)
 	err := c.cc.Invoke(ctx, "/litrpc.Firewall/PrivacyMapConversion", in, out, opts...)
@@ -78,6 +115,11 @@ type FirewallServer interface {
 	// URI and timestamp of the actions will be stored. The "full" mode will
 	// persist all request data for all actions.
 	ListActions(context.Context, *ListActionsRequest) (*ListActionsResponse, error)
+	// litcli: `subscribeactions`
+	// SubscribeActions streams the actions that are added to the action log as
+	// well as the actions whose state changes, optionally filtered by session.
+	// Past actions are not replayed, use ListActions to query them.
+	SubscribeActions(*SubscribeActionsRequest, Firewall_SubscribeActionsServer) error
 	// litcli: `privacy`
 	// PrivacyMapConversion can be used map real values to their pseudo
 	// counterpart and vice versa.
@@ -92,6 +134,9 @@ type UnimplementedFirewallServer struct {
 func (UnimplementedFirewallServer) ListActions(context.Context, *ListActionsRequest) (*ListActionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
 }
+func (UnimplementedFirewallServer) SubscribeActions(*SubscribeActionsRequest, Firewall_SubscribeActionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeActions not implemented")
+}
 func (UnimplementedFirewallServer) PrivacyMapConversion(context.Context, *PrivacyMapConversionRequest) (*PrivacyMapConversionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrivacyMapConversion not implemented")
 }
@@ -126,6 +171,27 @@ func _Firewall_ListActions_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Firewall_SubscribeActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FirewallServer).SubscribeActions(m, &firewallSubscribeActionsServer{stream})
+}
+
+type Firewall_SubscribeActionsServer interface {
+	Send(*Action) error
+	grpc.ServerStream
+}
+
+type firewallSubscribeActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *firewallSubscribeActionsServer) Send(m *Action) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Firewall_PrivacyMapConversion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PrivacyMapConversionRequest)
 	if err := dec(in); err != nil {
@@ -160,6 +226,12 @@ var Firewall_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Firewall_PrivacyMapConversion_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeActions",
+			Handler:       _Firewall_SubscribeActions_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "firewall.proto",
 }
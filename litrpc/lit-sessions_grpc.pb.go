@@ -26,8 +26,112 @@ type SessionsClient interface {
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 	// litcli: `sessions revoke`
 	// RevokeSession revokes a single session and also stops it if it is currently
-	// active.
+	// active. The session is identified either by its local public key or by its
+	// label. If more than one session that isn't revoked yet has the label, the
+	// call fails with FailedPrecondition and lists their public keys.
 	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	// litcli: `sessions rotatekey`
+	// RotateSessionKey replaces the macaroon root key of a session with a new one
+	// and returns the session's newly baked macaroon. The session keeps its ID,
+	// label, permissions and metadata, but all macaroons that were baked with the
+	// old root key are rejected from then on because their root key no longer
+	// exists. If the session is active, it is restarted with the new macaroon.
+	// This also makes a session usable again whose root key was rotated out with
+	// RotateSuperMacaroonRootKey.
+	RotateSessionKey(ctx context.Context, in *RotateSessionKeyRequest, opts ...grpc.CallOption) (*RotateSessionKeyResponse, error)
+	// litcli: `sessions renew`
+	// RenewSession sets a new expiry for a session that is neither revoked nor
+	// expired yet. The new expiry must be later than the current one. The
+	// session keeps its pairing, so a remote client can continue to use it
+	// without pairing again. If the session is active, it is restarted with a
+	// macaroon that expires with the new expiry. A macaroon expiry that was set
+	// when the session was added still applies.
+	RenewSession(ctx context.Context, in *RenewSessionRequest, opts ...grpc.CallOption) (*RenewSessionResponse, error)
+	// litcli: `sessions import`
+	// ImportSession adds a session that was exported from another litd node to
+	// the session store. The session keeps its keys, pairing and state, so a
+	// remote client that paired with it can continue to use it on this node
+	// without pairing again. This can be used to restore a session from a backup
+	// or to move it to a replacement node. If a session with the same local
+	// public key already exists, the call fails with AlreadyExists unless
+	// overwrite is set. Autopilot sessions can't be imported. Account sessions
+	// can only be imported if their account exists on this node.
+	ImportSession(ctx context.Context, in *ImportSessionRequest, opts ...grpc.CallOption) (*ImportSessionResponse, error)
+	// litcli: `sessions export`
+	// ExportSession serializes a session, including its local private key,
+	// pairing secret and macaroon root key ID, so that it can be imported on
+	// another node with ImportSession. If a passphrase is given, the export is
+	// encrypted with it, which makes it safe to store. The macaroon root key
+	// itself is kept by lnd, the importing node bakes the session's macaroons
+	// with its own root key for the same ID. Lightning Node Connect clients get
+	// a freshly baked macaroon on each connection, so they are not affected.
+	// Requires the sessions:export permission, which only admin-level
+	// credentials have, since the export allows to take over the session.
+	// Autopilot sessions can't be exported.
+	//
+	// The export starts with a version byte. With version 0, the rest is the
+	// session in the TLV format litd stores it in. With version 1, the rest is
+	// a 16 byte salt, a 12 byte nonce and the version 0 export encrypted with
+	// ChaCha20-Poly1305, using the version byte as additional data and a key
+	// that is derived from the passphrase and the salt with scrypt (N=32768,
+	// r=8, p=1). Newer litd versions can add TLV records without changing the
+	// version, so an export imports into any version that knows its version.
+	ExportSession(ctx context.Context, in *ExportSessionRequest, opts ...grpc.CallOption) (*ExportSessionResponse, error)
+	// litcli: `sessions checkstore`
+	// CheckSessionStore audits the session store for inconsistencies that can be
+	// left behind by an unclean shutdown, such as index entries for macaroon root
+	// key IDs that have no session or sessions that are missing from the index.
+	// It also compares the super macaroon root keys in lnd's macaroon root key
+	// store with the root keys of the active sessions, which reports orphaned
+	// root keys without a session and sessions whose root key is missing. If
+	// requested, the found inconsistencies are repaired and the orphaned root
+	// keys are deleted. It is recommended to only repair the store while no
+	// sessions are being created.
+	CheckSessionStore(ctx context.Context, in *CheckSessionStoreRequest, opts ...grpc.CallOption) (*CheckSessionStoreResponse, error)
+	// litcli: `sessions testwebhook`
+	// TestWebhook sends a synthetic, signed test event to the given webhook URL
+	// and reports the HTTP status code of the response and the round trip time.
+	// This can be used to verify that a webhook endpoint is reachable and that
+	// it validates the signature correctly.
+	TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error)
+	// litcli: `sessions checkperms`
+	// CheckSessionPermissions reports whether the macaroon of a session grants
+	// the permissions a method requires and, if not, which permissions are
+	// missing. Only the permissions are checked, caveats such as the allowed
+	// operations or an account restriction can still reject a request.
+	CheckSessionPermissions(ctx context.Context, in *CheckSessionPermissionsRequest, opts ...grpc.CallOption) (*CheckSessionPermissionsResponse, error)
+	// litcli: `sessions previewpolicy`
+	// PreviewMethodPolicy reports which of the active sessions would be affected
+	// if the given methods were denied or allowed. For each denied method the
+	// sessions that can currently call it are listed, for each allowed method
+	// the sessions that currently can't. Like CheckSessionPermissions, only the
+	// permissions of the sessions are considered. Nothing is changed.
+	PreviewMethodPolicy(ctx context.Context, in *PreviewMethodPolicyRequest, opts ...grpc.CallOption) (*PreviewMethodPolicyResponse, error)
+	// litcli: `sessions usage`
+	// GetSessionUsage returns the number of requests sessions made, bucketed by
+	// day or by week. This can be used to review trends and to find sessions that
+	// are no longer used. The usage is kept for the duration configured with
+	// lit-session-usage-retention, so older buckets are not available.
+	GetSessionUsage(ctx context.Context, in *GetSessionUsageRequest, opts ...grpc.CallOption) (*GetSessionUsageResponse, error)
+	// litcli: `sessions attenuate`
+	// AttenuateMacaroon adds caveats to the given macaroon and returns the
+	// resulting, more restricted macaroon. The new caveats are bound to the
+	// macaroon's signature, so they can't be removed again. This can be used to
+	// hand out a restricted copy of a macaroon without access to its root key.
+	//
+	// Attenuation can only narrow what a macaroon allows, never broaden it: a
+	// request must satisfy every caveat of the macaroon, the ones it already had
+	// as well as the added ones. Since adding caveats doesn't require any
+	// secret, this call doesn't require any authentication.
+	AttenuateMacaroon(ctx context.Context, in *AttenuateMacaroonRequest, opts ...grpc.CallOption) (*AttenuateMacaroonResponse, error)
+	// litcli: `sessions events`
+	// SubscribeSessionEvents streams an event whenever a session is created,
+	// revoked or expires, and, if configured, when it is about to expire. Each
+	// event has an ID that is one higher than the ID of
+	// the event before it, so clients can detect missed events. By default only
+	// new events are streamed. To resume a subscription, from_event_id can be
+	// set to replay the stored events from that ID on before the new ones.
+	SubscribeSessionEvents(ctx context.Context, in *SubscribeSessionEventsRequest, opts ...grpc.CallOption) (Sessions_SubscribeSessionEventsClient, error)
 }
 
 type sessionsClient struct {
@@ -65,6 +169,128 @@ func (c *sessionsClient) RevokeSession(ctx context.Context, in *RevokeSessionReq
 	return out, nil
 }
 
+func (c *sessionsClient) RotateSessionKey(ctx context.Context, in *RotateSessionKeyRequest, opts ...grpc.CallOption) (*RotateSessionKeyResponse, error) {
+	out := new(RotateSessionKeyResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/RotateSessionKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) RenewSession(ctx context.Context, in *RenewSessionRequest, opts ...grpc.CallOption) (*RenewSessionResponse, error) {
+	out := new(RenewSessionResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/RenewSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ImportSession(ctx context.Context, in *ImportSessionRequest, opts ...grpc.CallOption) (*ImportSessionResponse, error) {
+	out := new(ImportSessionResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ImportSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ExportSession(ctx context.Context, in *ExportSessionRequest, opts ...grpc.CallOption) (*ExportSessionResponse, error) {
+	out := new(ExportSessionResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ExportSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) CheckSessionStore(ctx context.Context, in *CheckSessionStoreRequest, opts ...grpc.CallOption) (*CheckSessionStoreResponse, error) {
+	out := new(CheckSessionStoreResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/CheckSessionStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) TestWebhook(ctx context.Context, in *TestWebhookRequest, opts ...grpc.CallOption) (*TestWebhookResponse, error) {
+	out := new(TestWebhookResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/TestWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) CheckSessionPermissions(ctx context.Context, in *CheckSessionPermissionsRequest, opts ...grpc.CallOption) (*CheckSessionPermissionsResponse, error) {
+	out := new(CheckSessionPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/CheckSessionPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) PreviewMethodPolicy(ctx context.Context, in *PreviewMethodPolicyRequest, opts ...grpc.CallOption) (*PreviewMethodPolicyResponse, error) {
+	out := new(PreviewMethodPolicyResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/PreviewMethodPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) GetSessionUsage(ctx context.Context, in *GetSessionUsageRequest, opts ...grpc.CallOption) (*GetSessionUsageResponse, error) {
+	out := new(GetSessionUsageResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/GetSessionUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) AttenuateMacaroon(ctx context.Context, in *AttenuateMacaroonRequest, opts ...grpc.CallOption) (*AttenuateMacaroonResponse, error) {
+	out := new(AttenuateMacaroonResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/AttenuateMacaroon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) SubscribeSessionEvents(ctx context.Context, in *SubscribeSessionEventsRequest, opts ...grpc.CallOption) (Sessions_SubscribeSessionEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Sessions_ServiceDesc.Streams[0], "/litrpc.Sessions/SubscribeSessionEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sessionsSubscribeSessionEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sessions_SubscribeSessionEventsClient interface {
+	Recv() (*SessionEvent, error)
+	grpc.ClientStream
+}
+
+type sessionsSubscribeSessionEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sessionsSubscribeSessionEventsClient) Recv() (*SessionEvent, error) {
+	m := new(SessionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SessionsServer is the server API for Sessions service.
 // All implementations must embed UnimplementedSessionsServer
 // for forward compatibility
@@ -77,8 +303,112 @@ type SessionsServer interface {
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
 	// litcli: `sessions revoke`
 	// RevokeSession revokes a single session and also stops it if it is currently
-	// active.
+	// active. The session is identified either by its local public key or by its
+	// label. If more than one session that isn't revoked yet has the label, the
+	// call fails with FailedPrecondition and lists their public keys.
 	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// litcli: `sessions rotatekey`
+	// RotateSessionKey replaces the macaroon root key of a session with a new one
+	// and returns the session's newly baked macaroon. The session keeps its ID,
+	// label, permissions and metadata, but all macaroons that were baked with the
+	// old root key are rejected from then on because their root key no longer
+	// exists. If the session is active, it is restarted with the new macaroon.
+	// This also makes a session usable again whose root key was rotated out with
+	// RotateSuperMacaroonRootKey.
+	RotateSessionKey(context.Context, *RotateSessionKeyRequest) (*RotateSessionKeyResponse, error)
+	// litcli: `sessions renew`
+	// RenewSession sets a new expiry for a session that is neither revoked nor
+	// expired yet. The new expiry must be later than the current one. The
+	// session keeps its pairing, so a remote client can continue to use it
+	// without pairing again. If the session is active, it is restarted with a
+	// macaroon that expires with the new expiry. A macaroon expiry that was set
+	// when the session was added still applies.
+	RenewSession(context.Context, *RenewSessionRequest) (*RenewSessionResponse, error)
+	// litcli: `sessions import`
+	// ImportSession adds a session that was exported from another litd node to
+	// the session store. The session keeps its keys, pairing and state, so a
+	// remote client that paired with it can continue to use it on this node
+	// without pairing again. This can be used to restore a session from a backup
+	// or to move it to a replacement node. If a session with the same local
+	// public key already exists, the call fails with AlreadyExists unless
+	// overwrite is set. Autopilot sessions can't be imported. Account sessions
+	// can only be imported if their account exists on this node.
+	ImportSession(context.Context, *ImportSessionRequest) (*ImportSessionResponse, error)
+	// litcli: `sessions export`
+	// ExportSession serializes a session, including its local private key,
+	// pairing secret and macaroon root key ID, so that it can be imported on
+	// another node with ImportSession. If a passphrase is given, the export is
+	// encrypted with it, which makes it safe to store. The macaroon root key
+	// itself is kept by lnd, the importing node bakes the session's macaroons
+	// with its own root key for the same ID. Lightning Node Connect clients get
+	// a freshly baked macaroon on each connection, so they are not affected.
+	// Requires the sessions:export permission, which only admin-level
+	// credentials have, since the export allows to take over the session.
+	// Autopilot sessions can't be exported.
+	//
+	// The export starts with a version byte. With version 0, the rest is the
+	// session in the TLV format litd stores it in. With version 1, the rest is
+	// a 16 byte salt, a 12 byte nonce and the version 0 export encrypted with
+	// ChaCha20-Poly1305, using the version byte as additional data and a key
+	// that is derived from the passphrase and the salt with scrypt (N=32768,
+	// r=8, p=1). Newer litd versions can add TLV records without changing the
+	// version, so an export imports into any version that knows its version.
+	ExportSession(context.Context, *ExportSessionRequest) (*ExportSessionResponse, error)
+	// litcli: `sessions checkstore`
+	// CheckSessionStore audits the session store for inconsistencies that can be
+	// left behind by an unclean shutdown, such as index entries for macaroon root
+	// key IDs that have no session or sessions that are missing from the index.
+	// It also compares the super macaroon root keys in lnd's macaroon root key
+	// store with the root keys of the active sessions, which reports orphaned
+	// root keys without a session and sessions whose root key is missing. If
+	// requested, the found inconsistencies are repaired and the orphaned root
+	// keys are deleted. It is recommended to only repair the store while no
+	// sessions are being created.
+	CheckSessionStore(context.Context, *CheckSessionStoreRequest) (*CheckSessionStoreResponse, error)
+	// litcli: `sessions testwebhook`
+	// TestWebhook sends a synthetic, signed test event to the given webhook URL
+	// and reports the HTTP status code of the response and the round trip time.
+	// This can be used to verify that a webhook endpoint is reachable and that
+	// it validates the signature correctly.
+	TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error)
+	// litcli: `sessions checkperms`
+	// CheckSessionPermissions reports whether the macaroon of a session grants
+	// the permissions a method requires and, if not, which permissions are
+	// missing. Only the permissions are checked, caveats such as the allowed
+	// operations or an account restriction can still reject a request.
+	CheckSessionPermissions(context.Context, *CheckSessionPermissionsRequest) (*CheckSessionPermissionsResponse, error)
+	// litcli: `sessions previewpolicy`
+	// PreviewMethodPolicy reports which of the active sessions would be affected
+	// if the given methods were denied or allowed. For each denied method the
+	// sessions that can currently call it are listed, for each allowed method
+	// the sessions that currently can't. Like CheckSessionPermissions, only the
+	// permissions of the sessions are considered. Nothing is changed.
+	PreviewMethodPolicy(context.Context, *PreviewMethodPolicyRequest) (*PreviewMethodPolicyResponse, error)
+	// litcli: `sessions usage`
+	// GetSessionUsage returns the number of requests sessions made, bucketed by
+	// day or by week. This can be used to review trends and to find sessions that
+	// are no longer used. The usage is kept for the duration configured with
+	// lit-session-usage-retention, so older buckets are not available.
+	GetSessionUsage(context.Context, *GetSessionUsageRequest) (*GetSessionUsageResponse, error)
+	// litcli: `sessions attenuate`
+	// AttenuateMacaroon adds caveats to the given macaroon and returns the
+	// resulting, more restricted macaroon. The new caveats are bound to the
+	// macaroon's signature, so they can't be removed again. This can be used to
+	// hand out a restricted copy of a macaroon without access to its root key.
+	//
+	// Attenuation can only narrow what a macaroon allows, never broaden it: a
+	// request must satisfy every caveat of the macaroon, the ones it already had
+	// as well as the added ones. Since adding caveats doesn't require any
+	// secret, this call doesn't require any authentication.
+	AttenuateMacaroon(context.Context, *AttenuateMacaroonRequest) (*AttenuateMacaroonResponse, error)
+	// litcli: `sessions events`
+	// SubscribeSessionEvents streams an event whenever a session is created,
+	// revoked or expires, and, if configured, when it is about to expire. Each
+	// event has an ID that is one higher than the ID of
+	// the event before it, so clients can detect missed events. By default only
+	// new events are streamed. To resume a subscription, from_event_id can be
+	// set to replay the stored events from that ID on before the new ones.
+	SubscribeSessionEvents(*SubscribeSessionEventsRequest, Sessions_SubscribeSessionEventsServer) error
 	mustEmbedUnimplementedSessionsServer()
 }
 
@@ -95,6 +425,39 @@ func (UnimplementedSessionsServer) ListSessions(context.Context, *ListSessionsRe
 func (UnimplementedSessionsServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
 }
+func (UnimplementedSessionsServer) RotateSessionKey(context.Context, *RotateSessionKeyRequest) (*RotateSessionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSessionKey not implemented")
+}
+func (UnimplementedSessionsServer) RenewSession(context.Context, *RenewSessionRequest) (*RenewSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewSession not implemented")
+}
+func (UnimplementedSessionsServer) ImportSession(context.Context, *ImportSessionRequest) (*ImportSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSession not implemented")
+}
+func (UnimplementedSessionsServer) ExportSession(context.Context, *ExportSessionRequest) (*ExportSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSession not implemented")
+}
+func (UnimplementedSessionsServer) CheckSessionStore(context.Context, *CheckSessionStoreRequest) (*CheckSessionStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSessionStore not implemented")
+}
+func (UnimplementedSessionsServer) TestWebhook(context.Context, *TestWebhookRequest) (*TestWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestWebhook not implemented")
+}
+func (UnimplementedSessionsServer) CheckSessionPermissions(context.Context, *CheckSessionPermissionsRequest) (*CheckSessionPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSessionPermissions not implemented")
+}
+func (UnimplementedSessionsServer) PreviewMethodPolicy(context.Context, *PreviewMethodPolicyRequest) (*PreviewMethodPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewMethodPolicy not implemented")
+}
+func (UnimplementedSessionsServer) GetSessionUsage(context.Context, *GetSessionUsageRequest) (*GetSessionUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionUsage not implemented")
+}
+func (UnimplementedSessionsServer) AttenuateMacaroon(context.Context, *AttenuateMacaroonRequest) (*AttenuateMacaroonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttenuateMacaroon not implemented")
+}
+func (UnimplementedSessionsServer) SubscribeSessionEvents(*SubscribeSessionEventsRequest, Sessions_SubscribeSessionEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeSessionEvents not implemented")
+}
 func (UnimplementedSessionsServer) mustEmbedUnimplementedSessionsServer() {}
 
 // UnsafeSessionsServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +525,207 @@ func _Sessions_RevokeSession_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sessions_RotateSessionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSessionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).RotateSessionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/RotateSessionKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).RotateSessionKey(ctx, req.(*RotateSessionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_RenewSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).RenewSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/RenewSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).RenewSession(ctx, req.(*RenewSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ImportSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ImportSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ImportSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ImportSession(ctx, req.(*ImportSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ExportSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ExportSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ExportSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ExportSession(ctx, req.(*ExportSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_CheckSessionStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSessionStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).CheckSessionStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/CheckSessionStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).CheckSessionStore(ctx, req.(*CheckSessionStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_TestWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).TestWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/TestWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).TestWebhook(ctx, req.(*TestWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_CheckSessionPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSessionPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).CheckSessionPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/CheckSessionPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).CheckSessionPermissions(ctx, req.(*CheckSessionPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_PreviewMethodPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewMethodPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).PreviewMethodPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/PreviewMethodPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).PreviewMethodPolicy(ctx, req.(*PreviewMethodPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_GetSessionUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).GetSessionUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/GetSessionUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).GetSessionUsage(ctx, req.(*GetSessionUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_AttenuateMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttenuateMacaroonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).AttenuateMacaroon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/AttenuateMacaroon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).AttenuateMacaroon(ctx, req.(*AttenuateMacaroonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_SubscribeSessionEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeSessionEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SessionsServer).SubscribeSessionEvents(m, &sessionsSubscribeSessionEventsServer{stream})
+}
+
+type Sessions_SubscribeSessionEventsServer interface {
+	Send(*SessionEvent) error
+	grpc.ServerStream
+}
+
+type sessionsSubscribeSessionEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sessionsSubscribeSessionEventsServer) Send(m *SessionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Sessions_ServiceDesc is the grpc.ServiceDesc for Sessions service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,7 +745,53 @@ var Sessions_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeSession",
 			Handler:    _Sessions_RevokeSession_Handler,
 		},
+		{
+			MethodName: "RotateSessionKey",
+			Handler:    _Sessions_RotateSessionKey_Handler,
+		},
+		{
+			MethodName: "RenewSession",
+			Handler:    _Sessions_RenewSession_Handler,
+		},
+		{
+			MethodName: "ImportSession",
+			Handler:    _Sessions_ImportSession_Handler,
+		},
+		{
+			MethodName: "ExportSession",
+			Handler:    _Sessions_ExportSession_Handler,
+		},
+		{
+			MethodName: "CheckSessionStore",
+			Handler:    _Sessions_CheckSessionStore_Handler,
+		},
+		{
+			MethodName: "TestWebhook",
+			Handler:    _Sessions_TestWebhook_Handler,
+		},
+		{
+			MethodName: "CheckSessionPermissions",
+			Handler:    _Sessions_CheckSessionPermissions_Handler,
+		},
+		{
+			MethodName: "PreviewMethodPolicy",
+			Handler:    _Sessions_PreviewMethodPolicy_Handler,
+		},
+		{
+			MethodName: "GetSessionUsage",
+			Handler:    _Sessions_GetSessionUsage_Handler,
+		},
+		{
+			MethodName: "AttenuateMacaroon",
+			Handler:    _Sessions_AttenuateMacaroon_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeSessionEvents",
+			Handler:       _Sessions_SubscribeSessionEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "lit-sessions.proto",
 }
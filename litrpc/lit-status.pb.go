@@ -20,6 +20,161 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AuthStepResult int32
+
+const (
+	AuthStepResult_AUTH_STEP_PASSED  AuthStepResult = 0
+	AuthStepResult_AUTH_STEP_FAILED  AuthStepResult = 1
+	AuthStepResult_AUTH_STEP_SKIPPED AuthStepResult = 2
+)
+
+// Enum value maps for AuthStepResult.
+var (
+	AuthStepResult_name = map[int32]string{
+		0: "AUTH_STEP_PASSED",
+		1: "AUTH_STEP_FAILED",
+		2: "AUTH_STEP_SKIPPED",
+	}
+	AuthStepResult_value = map[string]int32{
+		"AUTH_STEP_PASSED":  0,
+		"AUTH_STEP_FAILED":  1,
+		"AUTH_STEP_SKIPPED": 2,
+	}
+)
+
+func (x AuthStepResult) Enum() *AuthStepResult {
+	p := new(AuthStepResult)
+	*p = x
+	return p
+}
+
+func (x AuthStepResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthStepResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_lit_status_proto_enumTypes[0].Descriptor()
+}
+
+func (AuthStepResult) Type() protoreflect.EnumType {
+	return &file_lit_status_proto_enumTypes[0]
+}
+
+func (x AuthStepResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthStepResult.Descriptor instead.
+func (AuthStepResult) EnumDescriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{0}
+}
+
+type SubServerMode int32
+
+const (
+	SubServerMode_SUB_SERVER_MODE_INTEGRATED SubServerMode = 0
+	SubServerMode_SUB_SERVER_MODE_REMOTE     SubServerMode = 1
+	SubServerMode_SUB_SERVER_MODE_DISABLED   SubServerMode = 2
+)
+
+// Enum value maps for SubServerMode.
+var (
+	SubServerMode_name = map[int32]string{
+		0: "SUB_SERVER_MODE_INTEGRATED",
+		1: "SUB_SERVER_MODE_REMOTE",
+		2: "SUB_SERVER_MODE_DISABLED",
+	}
+	SubServerMode_value = map[string]int32{
+		"SUB_SERVER_MODE_INTEGRATED": 0,
+		"SUB_SERVER_MODE_REMOTE":     1,
+		"SUB_SERVER_MODE_DISABLED":   2,
+	}
+)
+
+func (x SubServerMode) Enum() *SubServerMode {
+	p := new(SubServerMode)
+	*p = x
+	return p
+}
+
+func (x SubServerMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubServerMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_lit_status_proto_enumTypes[1].Descriptor()
+}
+
+func (SubServerMode) Type() protoreflect.EnumType {
+	return &file_lit_status_proto_enumTypes[1]
+}
+
+func (x SubServerMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubServerMode.Descriptor instead.
+func (SubServerMode) EnumDescriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{1}
+}
+
+type LndStartupState int32
+
+const (
+	// LiT can't reach lnd's RPC server yet.
+	LndStartupState_LND_STATE_NOT_REACHABLE LndStartupState = 0
+	// lnd is waiting for its wallet to be created or unlocked.
+	LndStartupState_LND_STATE_LOCKED LndStartupState = 1
+	// lnd's wallet is unlocked, but lnd is still syncing to its chain
+	// backend or LiT is still connecting its clients.
+	LndStartupState_LND_STATE_SYNCING LndStartupState = 2
+	// lnd is ready and calls to it are forwarded.
+	LndStartupState_LND_STATE_READY LndStartupState = 3
+)
+
+// Enum value maps for LndStartupState.
+var (
+	LndStartupState_name = map[int32]string{
+		0: "LND_STATE_NOT_REACHABLE",
+		1: "LND_STATE_LOCKED",
+		2: "LND_STATE_SYNCING",
+		3: "LND_STATE_READY",
+	}
+	LndStartupState_value = map[string]int32{
+		"LND_STATE_NOT_REACHABLE": 0,
+		"LND_STATE_LOCKED":        1,
+		"LND_STATE_SYNCING":       2,
+		"LND_STATE_READY":         3,
+	}
+)
+
+func (x LndStartupState) Enum() *LndStartupState {
+	p := new(LndStartupState)
+	*p = x
+	return p
+}
+
+func (x LndStartupState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LndStartupState) Descriptor() protoreflect.EnumDescriptor {
+	return file_lit_status_proto_enumTypes[2].Descriptor()
+}
+
+func (LndStartupState) Type() protoreflect.EnumType {
+	return &file_lit_status_proto_enumTypes[2]
+}
+
+func (x LndStartupState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LndStartupState.Descriptor instead.
+func (LndStartupState) EnumDescriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{2}
+}
+
 type SubServerStatusReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -65,6 +220,13 @@ type SubServerStatusResp struct {
 
 	// A map of sub-server names to their status.
 	SubServers map[string]*SubServerStatus `protobuf:"bytes,1,rep,name=sub_servers,json=subServers,proto3" json:"sub_servers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The names of the lnd sub-servers that the connected lnd has been
+	// compiled with. Requests to other lnd sub-servers are rejected. This is
+	// empty until LiT is connected to lnd.
+	LndSubServers []string `protobuf:"bytes,2,rep,name=lnd_sub_servers,json=lndSubServers,proto3" json:"lnd_sub_servers,omitempty"`
+	// The state of lnd's wallet recovery. This is only set while LiT is
+	// waiting for lnd to finish a wallet recovery.
+	LndRecovery *LndRecoveryStatus `protobuf:"bytes,3,opt,name=lnd_recovery,json=lndRecovery,proto3" json:"lnd_recovery,omitempty"`
 }
 
 func (x *SubServerStatusResp) Reset() {
@@ -106,6 +268,79 @@ func (x *SubServerStatusResp) GetSubServers() map[string]*SubServerStatus {
 	return nil
 }
 
+func (x *SubServerStatusResp) GetLndSubServers() []string {
+	if x != nil {
+		return x.LndSubServers
+	}
+	return nil
+}
+
+func (x *SubServerStatusResp) GetLndRecovery() *LndRecoveryStatus {
+	if x != nil {
+		return x.LndRecovery
+	}
+	return nil
+}
+
+type LndRecoveryStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether lnd is currently recovering its wallet. Until the recovery is
+	// done, calls to lnd are rejected with UNAVAILABLE, except for the safe
+	// ones if LiT is configured to allow them.
+	InRecovery bool `protobuf:"varint,1,opt,name=in_recovery,json=inRecovery,proto3" json:"in_recovery,omitempty"`
+	// The progress of the recovery, ranging from 0 to 1.
+	Progress float64 `protobuf:"fixed64,2,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (x *LndRecoveryStatus) Reset() {
+	*x = LndRecoveryStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LndRecoveryStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LndRecoveryStatus) ProtoMessage() {}
+
+func (x *LndRecoveryStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LndRecoveryStatus.ProtoReflect.Descriptor instead.
+func (*LndRecoveryStatus) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LndRecoveryStatus) GetInRecovery() bool {
+	if x != nil {
+		return x.InRecovery
+	}
+	return false
+}
+
+func (x *LndRecoveryStatus) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
 type SubServerStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -128,7 +363,7 @@ type SubServerStatus struct {
 func (x *SubServerStatus) Reset() {
 	*x = SubServerStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lit_status_proto_msgTypes[2]
+		mi := &file_lit_status_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -141,7 +376,7 @@ func (x *SubServerStatus) String() string {
 func (*SubServerStatus) ProtoMessage() {}
 
 func (x *SubServerStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_lit_status_proto_msgTypes[2]
+	mi := &file_lit_status_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +389,7 @@ func (x *SubServerStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubServerStatus.ProtoReflect.Descriptor instead.
 func (*SubServerStatus) Descriptor() ([]byte, []int) {
-	return file_lit_status_proto_rawDescGZIP(), []int{2}
+	return file_lit_status_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SubServerStatus) GetDisabled() bool {
@@ -185,107 +420,1334 @@ func (x *SubServerStatus) GetCustomStatus() string {
 	return ""
 }
 
-var File_lit_status_proto protoreflect.FileDescriptor
+type SimulateAuthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_lit_status_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x6c, 0x69, 0x74, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x06, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x75,
-	0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
-	0x22, 0xbb, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4c, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
-	0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x1a, 0x56, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x74,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x82,
-	0x01, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23,
-	0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x32, 0x54, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a,
-	0x0f, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x1a, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x6c,
-	0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e,
-	0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x2d,
-	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x2f, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// The full gRPC URI of the method to simulate the authentication for, for
+	// example /lnrpc.Lightning/GetInfo.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The hex encoded macaroon to authenticate with. Either this or password
+	// must be set.
+	Macaroon string `protobuf:"bytes,2,opt,name=macaroon,proto3" json:"macaroon,omitempty"`
+	// The UI password to authenticate with. Either this or macaroon must be
+	// set.
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-var (
-	file_lit_status_proto_rawDescOnce sync.Once
-	file_lit_status_proto_rawDescData = file_lit_status_proto_rawDesc
-)
+func (x *SimulateAuthRequest) Reset() {
+	*x = SimulateAuthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_lit_status_proto_rawDescGZIP() []byte {
-	file_lit_status_proto_rawDescOnce.Do(func() {
-		file_lit_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_lit_status_proto_rawDescData)
-	})
-	return file_lit_status_proto_rawDescData
+func (x *SimulateAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_lit_status_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_lit_status_proto_goTypes = []interface{}{
-	(*SubServerStatusReq)(nil),  // 0: litrpc.SubServerStatusReq
-	(*SubServerStatusResp)(nil), // 1: litrpc.SubServerStatusResp
-	(*SubServerStatus)(nil),     // 2: litrpc.SubServerStatus
-	nil,                         // 3: litrpc.SubServerStatusResp.SubServersEntry
+func (*SimulateAuthRequest) ProtoMessage() {}
+
+func (x *SimulateAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_lit_status_proto_depIdxs = []int32{
-	3, // 0: litrpc.SubServerStatusResp.sub_servers:type_name -> litrpc.SubServerStatusResp.SubServersEntry
-	2, // 1: litrpc.SubServerStatusResp.SubServersEntry.value:type_name -> litrpc.SubServerStatus
-	0, // 2: litrpc.Status.SubServerStatus:input_type -> litrpc.SubServerStatusReq
-	1, // 3: litrpc.Status.SubServerStatus:output_type -> litrpc.SubServerStatusResp
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+// Deprecated: Use SimulateAuthRequest.ProtoReflect.Descriptor instead.
+func (*SimulateAuthRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{4}
 }
 
-func init() { file_lit_status_proto_init() }
-func file_lit_status_proto_init() {
-	if File_lit_status_proto != nil {
-		return
+func (x *SimulateAuthRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_lit_status_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubServerStatusReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_lit_status_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubServerStatusResp); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *SimulateAuthRequest) GetMacaroon() string {
+	if x != nil {
+		return x.Macaroon
+	}
+	return ""
+}
+
+func (x *SimulateAuthRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AuthStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the step in the authentication pipeline.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the step passed, failed or was skipped.
+	Result AuthStepResult `protobuf:"varint,2,opt,name=result,proto3,enum=litrpc.AuthStepResult" json:"result,omitempty"`
+	// A human readable description of what was checked and, if the step
+	// failed, why.
+	Details string `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *AuthStep) Reset() {
+	*x = AuthStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthStep) ProtoMessage() {}
+
+func (x *AuthStep) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lit_status_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubServerStatus); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthStep.ProtoReflect.Descriptor instead.
+func (*AuthStep) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuthStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuthStep) GetResult() AuthStepResult {
+	if x != nil {
+		return x.Result
+	}
+	return AuthStepResult_AUTH_STEP_PASSED
+}
+
+func (x *AuthStep) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type SimulateAuthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the credential would be accepted for the method.
+	Authorized bool `protobuf:"varint,1,opt,name=authorized,proto3" json:"authorized,omitempty"`
+	// The steps of the authentication pipeline in the order they were run.
+	Steps []*AuthStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *SimulateAuthResponse) Reset() {
+	*x = SimulateAuthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimulateAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateAuthResponse) ProtoMessage() {}
+
+func (x *SimulateAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateAuthResponse.ProtoReflect.Descriptor instead.
+func (*SimulateAuthResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SimulateAuthResponse) GetAuthorized() bool {
+	if x != nil {
+		return x.Authorized
+	}
+	return false
+}
+
+func (x *SimulateAuthResponse) GetSteps() []*AuthStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type GetTLSCertChainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTLSCertChainRequest) Reset() {
+	*x = GetTLSCertChainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTLSCertChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTLSCertChainRequest) ProtoMessage() {}
+
+func (x *GetTLSCertChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTLSCertChainRequest.ProtoReflect.Descriptor instead.
+func (*GetTLSCertChainRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{7}
+}
+
+type GetTLSCertChainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The DER encoded certificates of the chain, starting with the leaf
+	// certificate.
+	Certificates [][]byte `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	// The PEM encoded certificates of the chain, starting with the leaf
+	// certificate.
+	PemChain string `protobuf:"bytes,2,opt,name=pem_chain,json=pemChain,proto3" json:"pem_chain,omitempty"`
+}
+
+func (x *GetTLSCertChainResponse) Reset() {
+	*x = GetTLSCertChainResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTLSCertChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTLSCertChainResponse) ProtoMessage() {}
+
+func (x *GetTLSCertChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTLSCertChainResponse.ProtoReflect.Descriptor instead.
+func (*GetTLSCertChainResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTLSCertChainResponse) GetCertificates() [][]byte {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+func (x *GetTLSCertChainResponse) GetPemChain() string {
+	if x != nil {
+		return x.PemChain
+	}
+	return ""
+}
+
+type StepUpAuthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The UI password.
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// The full gRPC URI of the sensitive method the token should allow a call
+	// to, for example "/lnrpc.Lightning/CloseChannel".
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *StepUpAuthRequest) Reset() {
+	*x = StepUpAuthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepUpAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepUpAuthRequest) ProtoMessage() {}
+
+func (x *StepUpAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepUpAuthRequest.ProtoReflect.Descriptor instead.
+func (*StepUpAuthRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StepUpAuthRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *StepUpAuthRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type StepUpAuthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The one-time token to send in the lit-stepup-token header.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The unix timestamp in seconds after which the token is no longer valid.
+	ExpiresAt int64 `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *StepUpAuthResponse) Reset() {
+	*x = StepUpAuthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepUpAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepUpAuthResponse) ProtoMessage() {}
+
+func (x *StepUpAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepUpAuthResponse.ProtoReflect.Descriptor instead.
+func (*StepUpAuthResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StepUpAuthResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *StepUpAuthResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CheckHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CheckHealthRequest) Reset() {
+	*x = CheckHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHealthRequest) ProtoMessage() {}
+
+func (x *CheckHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHealthRequest.ProtoReflect.Descriptor instead.
+func (*CheckHealthRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{11}
+}
+
+type CheckHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether all probed sub-servers are healthy.
+	Healthy bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// The health of each probed sub-server, sorted by name.
+	SubServers []*SubServerHealth `protobuf:"bytes,2,rep,name=sub_servers,json=subServers,proto3" json:"sub_servers,omitempty"`
+}
+
+func (x *CheckHealthResponse) Reset() {
+	*x = CheckHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHealthResponse) ProtoMessage() {}
+
+func (x *CheckHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHealthResponse.ProtoReflect.Descriptor instead.
+func (*CheckHealthResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckHealthResponse) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *CheckHealthResponse) GetSubServers() []*SubServerHealth {
+	if x != nil {
+		return x.SubServers
+	}
+	return nil
+}
+
+type SubServerHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the sub-server, for example "lnd" or "loop".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the sub-server responded to the probe.
+	Healthy bool `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// The time the probe took in milliseconds.
+	LatencyMs uint64 `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// The gRPC status code of the failed probe, for example "Unavailable", if
+	// the sub-server is unhealthy. The error message itself is not returned.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *SubServerHealth) Reset() {
+	*x = SubServerHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubServerHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubServerHealth) ProtoMessage() {}
+
+func (x *SubServerHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubServerHealth.ProtoReflect.Descriptor instead.
+func (*SubServerHealth) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubServerHealth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubServerHealth) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *SubServerHealth) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *SubServerHealth) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetSubServerStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSubServerStateRequest) Reset() {
+	*x = GetSubServerStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubServerStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubServerStateRequest) ProtoMessage() {}
+
+func (x *GetSubServerStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubServerStateRequest.ProtoReflect.Descriptor instead.
+func (*GetSubServerStateRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{14}
+}
+
+type GetSubServerStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The state of lnd and each daemon, sorted by name.
+	SubServers []*SubServerState `protobuf:"bytes,1,rep,name=sub_servers,json=subServers,proto3" json:"sub_servers,omitempty"`
+}
+
+func (x *GetSubServerStateResponse) Reset() {
+	*x = GetSubServerStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubServerStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubServerStateResponse) ProtoMessage() {}
+
+func (x *GetSubServerStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubServerStateResponse.ProtoReflect.Descriptor instead.
+func (*GetSubServerStateResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSubServerStateResponse) GetSubServers() []*SubServerState {
+	if x != nil {
+		return x.SubServers
+	}
+	return nil
+}
+
+type SubServerState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the sub-server.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The mode the sub-server is run in.
+	Mode SubServerMode `protobuf:"varint,2,opt,name=mode,proto3,enum=litrpc.SubServerMode" json:"mode,omitempty"`
+	// Whether an integrated sub-server is started or the connection to a
+	// remote sub-server is up.
+	Running bool `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	// The error of the last failed attempt to start or connect to the
+	// sub-server. It is kept after a later attempt succeeded, so it can
+	// still be inspected while the sub-server is running.
+	StartError string `protobuf:"bytes,4,opt,name=start_error,json=startError,proto3" json:"start_error,omitempty"`
+}
+
+func (x *SubServerState) Reset() {
+	*x = SubServerState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubServerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubServerState) ProtoMessage() {}
+
+func (x *SubServerState) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubServerState.ProtoReflect.Descriptor instead.
+func (*SubServerState) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubServerState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubServerState) GetMode() SubServerMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SubServerMode_SUB_SERVER_MODE_INTEGRATED
+}
+
+func (x *SubServerState) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *SubServerState) GetStartError() string {
+	if x != nil {
+		return x.StartError
+	}
+	return ""
+}
+
+type GetLitStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLitStatusRequest) Reset() {
+	*x = GetLitStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLitStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLitStatusRequest) ProtoMessage() {}
+
+func (x *GetLitStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLitStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetLitStatusRequest) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{17}
+}
+
+type GetLitStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The startup state of lnd.
+	LndState LndStartupState `protobuf:"varint,1,opt,name=lnd_state,json=lndState,proto3,enum=litrpc.LndStartupState" json:"lnd_state,omitempty"`
+	// The unix timestamp in seconds at which lnd entered its current state.
+	LndStateSince int64 `protobuf:"varint,2,opt,name=lnd_state_since,json=lndStateSince,proto3" json:"lnd_state_since,omitempty"`
+	// The error of the last failed attempt to reach lnd. This is only set while
+	// lnd isn't reachable.
+	LndError string `protobuf:"bytes,3,opt,name=lnd_error,json=lndError,proto3" json:"lnd_error,omitempty"`
+	// Set if the last attempt to unlock lnd's wallet with the password from
+	// the file configured with lit-wallet-unlock-password-file failed. The
+	// details of the error are only written to LiT's log.
+	LndUnlockError string `protobuf:"bytes,4,opt,name=lnd_unlock_error,json=lndUnlockError,proto3" json:"lnd_unlock_error,omitempty"`
+}
+
+func (x *GetLitStatusResponse) Reset() {
+	*x = GetLitStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lit_status_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLitStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLitStatusResponse) ProtoMessage() {}
+
+func (x *GetLitStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lit_status_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLitStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetLitStatusResponse) Descriptor() ([]byte, []int) {
+	return file_lit_status_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetLitStatusResponse) GetLndState() LndStartupState {
+	if x != nil {
+		return x.LndState
+	}
+	return LndStartupState_LND_STATE_NOT_REACHABLE
+}
+
+func (x *GetLitStatusResponse) GetLndStateSince() int64 {
+	if x != nil {
+		return x.LndStateSince
+	}
+	return 0
+}
+
+func (x *GetLitStatusResponse) GetLndError() string {
+	if x != nil {
+		return x.LndError
+	}
+	return ""
+}
+
+func (x *GetLitStatusResponse) GetLndUnlockError() string {
+	if x != nil {
+		return x.LndUnlockError
+	}
+	return ""
+}
+
+var File_lit_status_proto protoreflect.FileDescriptor
+
+var file_lit_status_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x6c, 0x69, 0x74, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x75,
+	0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x22, 0xa1, 0x02, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4c, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x6e, 0x64, 0x5f, 0x73, 0x75,
+	0x62, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x6c, 0x6e, 0x64, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x3c,
+	0x0a, 0x0c, 0x6c, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6e,
+	0x64, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0b, 0x6c, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x1a, 0x56, 0x0a, 0x0f,
+	0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x50, 0x0a, 0x11, 0x4c, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x5f,
+	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x69, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x65, 0x0a, 0x13, 0x53,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61,
+	0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61,
+	0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0x68, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x53, 0x74, 0x65, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x5e, 0x0a, 0x14,
+	0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x22, 0x18, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x54, 0x4c, 0x53, 0x43, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54, 0x4c, 0x53,
+	0x43, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x6d, 0x5f, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x65, 0x6d, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x22, 0x47, 0x0a, 0x11, 0x53, 0x74, 0x65, 0x70, 0x55, 0x70, 0x41, 0x75, 0x74, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x49, 0x0a, 0x12, 0x53,
+	0x74, 0x65, 0x70, 0x55, 0x70, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x69, 0x0a, 0x13,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x38, 0x0a,
+	0x0b, 0x73, 0x75, 0x62, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x0a, 0x73, 0x75, 0x62,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x78, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x21, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x02, 0x30, 0x01,
+	0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a,
+	0x19, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x73, 0x75,
+	0x62, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c,
+	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x09, 0x6c, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x6c, 0x6e,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x6e, 0x64, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x6c, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x6e, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x6c,
+	0x6e, 0x64, 0x5f, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6e, 0x64, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x53, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x68, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x55, 0x54, 0x48, 0x5f,
+	0x53, 0x54, 0x45, 0x50, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x53, 0x54, 0x45, 0x50,
+	0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x69, 0x0a, 0x0d, 0x53, 0x75,
+	0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x53,
+	0x55, 0x42, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49,
+	0x4e, 0x54, 0x45, 0x47, 0x52, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x55, 0x42, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52,
+	0x45, 0x4d, 0x4f, 0x54, 0x45, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x55, 0x42, 0x5f, 0x53,
+	0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42,
+	0x4c, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x70, 0x0a, 0x0f, 0x4c, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x4e, 0x44, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x43, 0x48, 0x41,
+	0x42, 0x4c, 0x45, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x4c,
+	0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x03, 0x32, 0xa5, 0x04, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x4a, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x1b, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x49,
+	0x0a, 0x0c, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1b,
+	0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x69,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x54, 0x4c, 0x53, 0x43, 0x65, 0x72, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1e, 0x2e, 0x6c,
+	0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x4c, 0x53, 0x43, 0x65, 0x72, 0x74,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c,
+	0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x4c, 0x53, 0x43, 0x65, 0x72, 0x74,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a,
+	0x0a, 0x53, 0x74, 0x65, 0x70, 0x55, 0x70, 0x41, 0x75, 0x74, 0x68, 0x12, 0x19, 0x2e, 0x6c, 0x69,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x55, 0x70, 0x41, 0x75, 0x74, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x65, 0x70, 0x55, 0x70, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x12, 0x1a, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x53, 0x75, 0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x20, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75,
+	0x62, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x69, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x6e, 0x69, 0x6e, 0x67, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x6e, 0x69, 0x6e, 0x67, 0x2d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x2f, 0x6c,
+	0x69, 0x74, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_lit_status_proto_rawDescOnce sync.Once
+	file_lit_status_proto_rawDescData = file_lit_status_proto_rawDesc
+)
+
+func file_lit_status_proto_rawDescGZIP() []byte {
+	file_lit_status_proto_rawDescOnce.Do(func() {
+		file_lit_status_proto_rawDescData = protoimpl.X.CompressGZIP(file_lit_status_proto_rawDescData)
+	})
+	return file_lit_status_proto_rawDescData
+}
+
+var file_lit_status_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_lit_status_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_lit_status_proto_goTypes = []interface{}{
+	(AuthStepResult)(0),               // 0: litrpc.AuthStepResult
+	(SubServerMode)(0),                // 1: litrpc.SubServerMode
+	(LndStartupState)(0),              // 2: litrpc.LndStartupState
+	(*SubServerStatusReq)(nil),        // 3: litrpc.SubServerStatusReq
+	(*SubServerStatusResp)(nil),       // 4: litrpc.SubServerStatusResp
+	(*LndRecoveryStatus)(nil),         // 5: litrpc.LndRecoveryStatus
+	(*SubServerStatus)(nil),           // 6: litrpc.SubServerStatus
+	(*SimulateAuthRequest)(nil),       // 7: litrpc.SimulateAuthRequest
+	(*AuthStep)(nil),                  // 8: litrpc.AuthStep
+	(*SimulateAuthResponse)(nil),      // 9: litrpc.SimulateAuthResponse
+	(*GetTLSCertChainRequest)(nil),    // 10: litrpc.GetTLSCertChainRequest
+	(*GetTLSCertChainResponse)(nil),   // 11: litrpc.GetTLSCertChainResponse
+	(*StepUpAuthRequest)(nil),         // 12: litrpc.StepUpAuthRequest
+	(*StepUpAuthResponse)(nil),        // 13: litrpc.StepUpAuthResponse
+	(*CheckHealthRequest)(nil),        // 14: litrpc.CheckHealthRequest
+	(*CheckHealthResponse)(nil),       // 15: litrpc.CheckHealthResponse
+	(*SubServerHealth)(nil),           // 16: litrpc.SubServerHealth
+	(*GetSubServerStateRequest)(nil),  // 17: litrpc.GetSubServerStateRequest
+	(*GetSubServerStateResponse)(nil), // 18: litrpc.GetSubServerStateResponse
+	(*SubServerState)(nil),            // 19: litrpc.SubServerState
+	(*GetLitStatusRequest)(nil),       // 20: litrpc.GetLitStatusRequest
+	(*GetLitStatusResponse)(nil),      // 21: litrpc.GetLitStatusResponse
+	nil,                               // 22: litrpc.SubServerStatusResp.SubServersEntry
+}
+var file_lit_status_proto_depIdxs = []int32{
+	22, // 0: litrpc.SubServerStatusResp.sub_servers:type_name -> litrpc.SubServerStatusResp.SubServersEntry
+	5,  // 1: litrpc.SubServerStatusResp.lnd_recovery:type_name -> litrpc.LndRecoveryStatus
+	0,  // 2: litrpc.AuthStep.result:type_name -> litrpc.AuthStepResult
+	8,  // 3: litrpc.SimulateAuthResponse.steps:type_name -> litrpc.AuthStep
+	16, // 4: litrpc.CheckHealthResponse.sub_servers:type_name -> litrpc.SubServerHealth
+	19, // 5: litrpc.GetSubServerStateResponse.sub_servers:type_name -> litrpc.SubServerState
+	1,  // 6: litrpc.SubServerState.mode:type_name -> litrpc.SubServerMode
+	2,  // 7: litrpc.GetLitStatusResponse.lnd_state:type_name -> litrpc.LndStartupState
+	6,  // 8: litrpc.SubServerStatusResp.SubServersEntry.value:type_name -> litrpc.SubServerStatus
+	3,  // 9: litrpc.Status.SubServerStatus:input_type -> litrpc.SubServerStatusReq
+	7,  // 10: litrpc.Status.SimulateAuth:input_type -> litrpc.SimulateAuthRequest
+	10, // 11: litrpc.Status.GetTLSCertChain:input_type -> litrpc.GetTLSCertChainRequest
+	12, // 12: litrpc.Status.StepUpAuth:input_type -> litrpc.StepUpAuthRequest
+	14, // 13: litrpc.Status.CheckHealth:input_type -> litrpc.CheckHealthRequest
+	17, // 14: litrpc.Status.GetSubServerState:input_type -> litrpc.GetSubServerStateRequest
+	20, // 15: litrpc.Status.GetLitStatus:input_type -> litrpc.GetLitStatusRequest
+	4,  // 16: litrpc.Status.SubServerStatus:output_type -> litrpc.SubServerStatusResp
+	9,  // 17: litrpc.Status.SimulateAuth:output_type -> litrpc.SimulateAuthResponse
+	11, // 18: litrpc.Status.GetTLSCertChain:output_type -> litrpc.GetTLSCertChainResponse
+	13, // 19: litrpc.Status.StepUpAuth:output_type -> litrpc.StepUpAuthResponse
+	15, // 20: litrpc.Status.CheckHealth:output_type -> litrpc.CheckHealthResponse
+	18, // 21: litrpc.Status.GetSubServerState:output_type -> litrpc.GetSubServerStateResponse
+	21, // 22: litrpc.Status.GetLitStatus:output_type -> litrpc.GetLitStatusResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_lit_status_proto_init() }
+func file_lit_status_proto_init() {
+	if File_lit_status_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_lit_status_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubServerStatusReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubServerStatusResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LndRecoveryStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubServerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimulateAuthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimulateAuthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTLSCertChainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTLSCertChainResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepUpAuthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepUpAuthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubServerHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubServerStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubServerStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubServerState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLitStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lit_status_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLitStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -302,13 +1764,14 @@ func file_lit_status_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lit_status_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      3,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_lit_status_proto_goTypes,
 		DependencyIndexes: file_lit_status_proto_depIdxs,
+		EnumInfos:         file_lit_status_proto_enumTypes,
 		MessageInfos:      file_lit_status_proto_msgTypes,
 	}.Build()
 	File_lit_status_proto = out.File
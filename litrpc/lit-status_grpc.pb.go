@@ -19,6 +19,52 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StatusClient interface {
 	SubServerStatus(ctx context.Context, in *SubServerStatusReq, opts ...grpc.CallOption) (*SubServerStatusResp, error)
+	// litcli: `status simulateauth`
+	// SimulateAuth runs the authentication pipeline of LiT's RPC proxy for the
+	// given credential and method and returns a step by step trace of which
+	// checks passed and which failed. The method itself is never called. This
+	// can be used to find out why a credential is rejected.
+	SimulateAuth(ctx context.Context, in *SimulateAuthRequest, opts ...grpc.CallOption) (*SimulateAuthResponse, error)
+	// litcli: `status tlscertchain`
+	// GetTLSCertChain returns the full TLS certificate chain, including any
+	// intermediate certificates, that LiT presents on its main HTTPS listener.
+	// Clients can use it to configure trust for custom or Let's Encrypt
+	// certificates. The chain is always the one currently presented, so it is
+	// updated if the certificate is renewed. This call does not require
+	// authentication.
+	GetTLSCertChain(ctx context.Context, in *GetTLSCertChainRequest, opts ...grpc.CallOption) (*GetTLSCertChainResponse, error)
+	// litcli: `status stepup`
+	// StepUpAuth exchanges the UI password for a one-time token that allows a
+	// single call to one of the configured sensitive methods. The token must be
+	// sent in the lit-stepup-token header of the call, in addition to the
+	// regular credential. A call to a sensitive method without a valid token
+	// fails with UNAUTHENTICATED and the lit-stepup-required trailer. This call
+	// does not require any other authentication.
+	StepUpAuth(ctx context.Context, in *StepUpAuthRequest, opts ...grpc.CallOption) (*StepUpAuthResponse, error)
+	// litcli: `status health`
+	// CheckHealth probes lnd, LiT itself and each enabled daemon with a cheap
+	// call through LiT's RPC proxy and returns whether each of them responded,
+	// along with the latency of the call. The same report is served on the
+	// /health HTTP endpoint, which answers with status 503 if any of them is
+	// unhealthy. This call does not require authentication and doesn't return
+	// any data of the daemons.
+	CheckHealth(ctx context.Context, in *CheckHealthRequest, opts ...grpc.CallOption) (*CheckHealthResponse, error)
+	// litcli: `status subservers`
+	// GetSubServerState returns the state of lnd and each daemon that LiT
+	// manages, as known by the component that starts and stops them: whether
+	// it is run in integrated or remote mode or is disabled, whether it is
+	// currently running and the error of the last failed attempt to start or
+	// connect to it.
+	GetSubServerState(ctx context.Context, in *GetSubServerStateRequest, opts ...grpc.CallOption) (*GetSubServerStateResponse, error)
+	// litcli: `status lit`
+	// GetLitStatus returns the startup state of lnd as seen by LiT: whether lnd
+	// can't be reached yet, is waiting for its wallet to be unlocked, is syncing
+	// to its chain backend or is ready. Until lnd is ready, calls to lnd are
+	// rejected with FAILED_PRECONDITION and an error message that names the
+	// state. If LiT fails to unlock lnd with a stored password, the error is
+	// returned too. This call does not require authentication, so the state can
+	// be shown before the user is logged in.
+	GetLitStatus(ctx context.Context, in *GetLitStatusRequest, opts ...grpc.CallOption) (*GetLitStatusResponse, error)
 }
 
 type statusClient struct {
@@ -38,11 +84,111 @@ func (c *statusClient) SubServerStatus(ctx context.Context, in *SubServerStatusR
 	return out, nil
 }
 
+func (c *statusClient) SimulateAuth(ctx context.Context, in *SimulateAuthRequest, opts ...grpc.CallOption) (*SimulateAuthResponse, error) {
+	out := new(SimulateAuthResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/SimulateAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetTLSCertChain(ctx context.Context, in *GetTLSCertChainRequest, opts ...grpc.CallOption) (*GetTLSCertChainResponse, error) {
+	out := new(GetTLSCertChainResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/GetTLSCertChain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) StepUpAuth(ctx context.Context, in *StepUpAuthRequest, opts ...grpc.CallOption) (*StepUpAuthResponse, error) {
+	out := new(StepUpAuthResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/StepUpAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) CheckHealth(ctx context.Context, in *CheckHealthRequest, opts ...grpc.CallOption) (*CheckHealthResponse, error) {
+	out := new(CheckHealthResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/CheckHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetSubServerState(ctx context.Context, in *GetSubServerStateRequest, opts ...grpc.CallOption) (*GetSubServerStateResponse, error) {
+	out := new(GetSubServerStateResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/GetSubServerState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetLitStatus(ctx context.Context, in *GetLitStatusRequest, opts ...grpc.CallOption) (*GetLitStatusResponse, error) {
+	out := new(GetLitStatusResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Status/GetLitStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatusServer is the server API for Status service.
 // All implementations must embed UnimplementedStatusServer
 // for forward compatibility
 type StatusServer interface {
 	SubServerStatus(context.Context, *SubServerStatusReq) (*SubServerStatusResp, error)
+	// litcli: `status simulateauth`
+	// SimulateAuth runs the authentication pipeline of LiT's RPC proxy for the
+	// given credential and method and returns a step by step trace of which
+	// checks passed and which failed. The method itself is never called. This
+	// can be used to find out why a credential is rejected.
+	SimulateAuth(context.Context, *SimulateAuthRequest) (*SimulateAuthResponse, error)
+	// litcli: `status tlscertchain`
+	// GetTLSCertChain returns the full TLS certificate chain, including any
+	// intermediate certificates, that LiT presents on its main HTTPS listener.
+	// Clients can use it to configure trust for custom or Let's Encrypt
+	// certificates. The chain is always the one currently presented, so it is
+	// updated if the certificate is renewed. This call does not require
+	// authentication.
+	GetTLSCertChain(context.Context, *GetTLSCertChainRequest) (*GetTLSCertChainResponse, error)
+	// litcli: `status stepup`
+	// StepUpAuth exchanges the UI password for a one-time token that allows a
+	// single call to one of the configured sensitive methods. The token must be
+	// sent in the lit-stepup-token header of the call, in addition to the
+	// regular credential. A call to a sensitive method without a valid token
+	// fails with UNAUTHENTICATED and the lit-stepup-required trailer. This call
+	// does not require any other authentication.
+	StepUpAuth(context.Context, *StepUpAuthRequest) (*StepUpAuthResponse, error)
+	// litcli: `status health`
+	// CheckHealth probes lnd, LiT itself and each enabled daemon with a cheap
+	// call through LiT's RPC proxy and returns whether each of them responded,
+	// along with the latency of the call. The same report is served on the
+	// /health HTTP endpoint, which answers with status 503 if any of them is
+	// unhealthy. This call does not require authentication and doesn't return
+	// any data of the daemons.
+	CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error)
+	// litcli: `status subservers`
+	// GetSubServerState returns the state of lnd and each daemon that LiT
+	// manages, as known by the component that starts and stops them: whether
+	// it is run in integrated or remote mode or is disabled, whether it is
+	// currently running and the error of the last failed attempt to start or
+	// connect to it.
+	GetSubServerState(context.Context, *GetSubServerStateRequest) (*GetSubServerStateResponse, error)
+	// litcli: `status lit`
+	// GetLitStatus returns the startup state of lnd as seen by LiT: whether lnd
+	// can't be reached yet, is waiting for its wallet to be unlocked, is syncing
+	// to its chain backend or is ready. Until lnd is ready, calls to lnd are
+	// rejected with FAILED_PRECONDITION and an error message that names the
+	// state. If LiT fails to unlock lnd with a stored password, the error is
+	// returned too. This call does not require authentication, so the state can
+	// be shown before the user is logged in.
+	GetLitStatus(context.Context, *GetLitStatusRequest) (*GetLitStatusResponse, error)
 	mustEmbedUnimplementedStatusServer()
 }
 
@@ -53,6 +199,24 @@ type UnimplementedStatusServer struct {
 func (UnimplementedStatusServer) SubServerStatus(context.Context, *SubServerStatusReq) (*SubServerStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubServerStatus not implemented")
 }
+func (UnimplementedStatusServer) SimulateAuth(context.Context, *SimulateAuthRequest) (*SimulateAuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateAuth not implemented")
+}
+func (UnimplementedStatusServer) GetTLSCertChain(context.Context, *GetTLSCertChainRequest) (*GetTLSCertChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTLSCertChain not implemented")
+}
+func (UnimplementedStatusServer) StepUpAuth(context.Context, *StepUpAuthRequest) (*StepUpAuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StepUpAuth not implemented")
+}
+func (UnimplementedStatusServer) CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckHealth not implemented")
+}
+func (UnimplementedStatusServer) GetSubServerState(context.Context, *GetSubServerStateRequest) (*GetSubServerStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubServerState not implemented")
+}
+func (UnimplementedStatusServer) GetLitStatus(context.Context, *GetLitStatusRequest) (*GetLitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLitStatus not implemented")
+}
 func (UnimplementedStatusServer) mustEmbedUnimplementedStatusServer() {}
 
 // UnsafeStatusServer may be embedded to opt out of forward compatibility for this service.
@@ -84,6 +248,114 @@ func _Status_SubServerStatus_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Status_SimulateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).SimulateAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/SimulateAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).SimulateAuth(ctx, req.(*SimulateAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetTLSCertChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTLSCertChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetTLSCertChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/GetTLSCertChain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetTLSCertChain(ctx, req.(*GetTLSCertChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_StepUpAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepUpAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).StepUpAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/StepUpAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).StepUpAuth(ctx, req.(*StepUpAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_CheckHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).CheckHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/CheckHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).CheckHealth(ctx, req.(*CheckHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetSubServerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubServerStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetSubServerState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/GetSubServerState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetSubServerState(ctx, req.(*GetSubServerStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetLitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetLitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Status/GetLitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetLitStatus(ctx, req.(*GetLitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Status_ServiceDesc is the grpc.ServiceDesc for Status service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -95,6 +367,30 @@ var Status_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubServerStatus",
 			Handler:    _Status_SubServerStatus_Handler,
 		},
+		{
+			MethodName: "SimulateAuth",
+			Handler:    _Status_SimulateAuth_Handler,
+		},
+		{
+			MethodName: "GetTLSCertChain",
+			Handler:    _Status_GetTLSCertChain_Handler,
+		},
+		{
+			MethodName: "StepUpAuth",
+			Handler:    _Status_StepUpAuth_Handler,
+		},
+		{
+			MethodName: "CheckHealth",
+			Handler:    _Status_CheckHealth_Handler,
+		},
+		{
+			MethodName: "GetSubServerState",
+			Handler:    _Status_GetSubServerState_Handler,
+		},
+		{
+			MethodName: "GetLitStatus",
+			Handler:    _Status_GetLitStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lit-status.proto",
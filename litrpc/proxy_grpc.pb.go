@@ -27,8 +27,78 @@ type ProxyClient interface {
 	StopDaemon(ctx context.Context, in *StopDaemonRequest, opts ...grpc.CallOption) (*StopDaemonResponse, error)
 	// litcli: `bakesupermacaroon`
 	// BakeSuperMacaroon bakes a new macaroon that includes permissions for
-	// all the active daemons that LiT is connected to.
+	// all the active daemons that LiT is connected to. The permissions can be
+	// narrowed down to the read-only ones or to an explicit list. Requires the
+	// supermacaroon:write permission, which only admin-level credentials have.
 	BakeSuperMacaroon(ctx context.Context, in *BakeSuperMacaroonRequest, opts ...grpc.CallOption) (*BakeSuperMacaroonResponse, error)
+	// litcli: `rotatesupermacaroonkey`
+	// RotateSuperMacaroonRootKey invalidates all super macaroons that were baked
+	// so far by deleting their root keys from lnd and marking them as stale, so
+	// LiT rejects macaroons baked with them even if lnd recreates them. A new
+	// root key with a fresh ID becomes the current one that LiT's own super
+	// macaroon and super macaroons baked without a root key ID suffix use. This
+	// includes the macaroons of sessions, which stop working until their root
+	// key is rotated with RotateSessionKey, but not those of accounts. The
+	// rotation is persisted and survives restarts. Requires the
+	// supermacaroon:write permission.
+	RotateSuperMacaroonRootKey(ctx context.Context, in *RotateSuperMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateSuperMacaroonRootKeyResponse, error)
+	// BatchCall executes multiple unary calls in one request and returns their
+	// results in the same order. Each call is authorized independently with the
+	// macaroon and its discharge macaroons or the UI password of the batch
+	// request, a call that fails doesn't fail the batch. Streaming calls can't
+	// be part of a batch. Client certificates are not passed on to the calls.
+	// The step-up token of the batch request is passed on, so a batch can
+	// contain at most one call to a method that requires step-up
+	// authentication.
+	BatchCall(ctx context.Context, in *BatchCallRequest, opts ...grpc.CallOption) (*BatchCallResponse, error)
+	// StartReportJob starts a faraday report in the background and returns
+	// immediately. Faraday reports can take a long time, the job can be polled
+	// with ReportJobStatus and its result fetched with FetchReportJobResult
+	// once it completed. The caller needs the same permissions as for calling
+	// the faraday method directly, the same applies to querying the job.
+	// Completed jobs are removed after the configured TTL.
+	StartReportJob(ctx context.Context, in *StartReportJobRequest, opts ...grpc.CallOption) (*ReportJob, error)
+	// ReportJobStatus returns the current status of a report job.
+	ReportJobStatus(ctx context.Context, in *ReportJobStatusRequest, opts ...grpc.CallOption) (*ReportJob, error)
+	// FetchReportJobResult returns the response of a completed report job. If
+	// the job failed, its error is returned instead.
+	FetchReportJobResult(ctx context.Context, in *FetchReportJobResultRequest, opts ...grpc.CallOption) (*FetchReportJobResultResponse, error)
+	// litcli: `listeners`
+	// ListListeners returns every listener LiT serves on with its address,
+	// protocols, TLS status and accepted credentials. LiT connects to each
+	// listener and reports whether it is reachable and, for TLS listeners,
+	// completes a TLS handshake.
+	ListListeners(ctx context.Context, in *ListListenersRequest, opts ...grpc.CallOption) (*ListListenersResponse, error)
+	// litcli: `routes`
+	// ListRoutes returns the routing table of the proxy: every method URI LiT
+	// knows, the daemon it is routed to and whether the daemon can currently
+	// handle calls to it. The table reflects the live state, including
+	// disabled daemons, lnd sub-servers that lnd isn't compiled with and
+	// remote daemons that aren't connected.
+	ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error)
+	// litcli: `listpermissions`
+	// ListMacaroonPermissions returns every permission that the methods of the
+	// active daemons require, grouped by daemon. These are the permissions a
+	// session or super macaroon can be scoped to. The permissions of lnd's
+	// sub-servers are listed as lnd's, those of the sub-servers lnd isn't
+	// compiled with are not listed.
+	ListMacaroonPermissions(ctx context.Context, in *ListMacaroonPermissionsRequest, opts ...grpc.CallOption) (*ListMacaroonPermissionsResponse, error)
+	// litcli: `reloadtls`
+	// ReloadTLS re-reads the TLS certificate and key of LiT's main HTTPS
+	// listener from the configured files, so a renewed certificate can be used
+	// without restarting LiT. Existing connections are not affected, new
+	// connections use the new certificate. If the files can't be loaded, the
+	// current certificate stays in use. LiT reloads the certificate on SIGHUP as
+	// well. Certificates from Let's Encrypt are renewed automatically and can't
+	// be reloaded. Requires the proxy:write permission.
+	ReloadTLS(ctx context.Context, in *ReloadTLSRequest, opts ...grpc.CallOption) (*ReloadTLSResponse, error)
+	// litcli: `whoami`
+	// WhoAmI returns what the super macaroon of the call is allowed to do. This
+	// includes the session it belongs to, its caveats and expiry, and the
+	// methods it can call. Session macaroons are super macaroons too. The method
+	// can be called with any valid super macaroon, since the macaroon is
+	// verified against the permissions it carries itself.
+	WhoAmI(ctx context.Context, in *WhoAmIRequest, opts ...grpc.CallOption) (*WhoAmIResponse, error)
 }
 
 type proxyClient struct {
@@ -66,6 +136,96 @@ func (c *proxyClient) BakeSuperMacaroon(ctx context.Context, in *BakeSuperMacaro
 	return out, nil
 }
 
+func (c *proxyClient) RotateSuperMacaroonRootKey(ctx context.Context, in *RotateSuperMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateSuperMacaroonRootKeyResponse, error) {
+	out := new(RotateSuperMacaroonRootKeyResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/RotateSuperMacaroonRootKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) BatchCall(ctx context.Context, in *BatchCallRequest, opts ...grpc.CallOption) (*BatchCallResponse, error) {
+	out := new(BatchCallResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/BatchCall", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) StartReportJob(ctx context.Context, in *StartReportJobRequest, opts ...grpc.CallOption) (*ReportJob, error) {
+	out := new(ReportJob)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/StartReportJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ReportJobStatus(ctx context.Context, in *ReportJobStatusRequest, opts ...grpc.CallOption) (*ReportJob, error) {
+	out := new(ReportJob)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ReportJobStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) FetchReportJobResult(ctx context.Context, in *FetchReportJobResultRequest, opts ...grpc.CallOption) (*FetchReportJobResultResponse, error) {
+	out := new(FetchReportJobResultResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/FetchReportJobResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ListListeners(ctx context.Context, in *ListListenersRequest, opts ...grpc.CallOption) (*ListListenersResponse, error) {
+	out := new(ListListenersResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ListListeners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error) {
+	out := new(ListRoutesResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ListRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ListMacaroonPermissions(ctx context.Context, in *ListMacaroonPermissionsRequest, opts ...grpc.CallOption) (*ListMacaroonPermissionsResponse, error) {
+	out := new(ListMacaroonPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ListMacaroonPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ReloadTLS(ctx context.Context, in *ReloadTLSRequest, opts ...grpc.CallOption) (*ReloadTLSResponse, error) {
+	out := new(ReloadTLSResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ReloadTLS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) WhoAmI(ctx context.Context, in *WhoAmIRequest, opts ...grpc.CallOption) (*WhoAmIResponse, error) {
+	out := new(WhoAmIResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/WhoAmI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProxyServer is the server API for Proxy service.
 // All implementations must embed UnimplementedProxyServer
 // for forward compatibility
@@ -79,8 +239,78 @@ type ProxyServer interface {
 	StopDaemon(context.Context, *StopDaemonRequest) (*StopDaemonResponse, error)
 	// litcli: `bakesupermacaroon`
 	// BakeSuperMacaroon bakes a new macaroon that includes permissions for
-	// all the active daemons that LiT is connected to.
+	// all the active daemons that LiT is connected to. The permissions can be
+	// narrowed down to the read-only ones or to an explicit list. Requires the
+	// supermacaroon:write permission, which only admin-level credentials have.
 	BakeSuperMacaroon(context.Context, *BakeSuperMacaroonRequest) (*BakeSuperMacaroonResponse, error)
+	// litcli: `rotatesupermacaroonkey`
+	// RotateSuperMacaroonRootKey invalidates all super macaroons that were baked
+	// so far by deleting their root keys from lnd and marking them as stale, so
+	// LiT rejects macaroons baked with them even if lnd recreates them. A new
+	// root key with a fresh ID becomes the current one that LiT's own super
+	// macaroon and super macaroons baked without a root key ID suffix use. This
+	// includes the macaroons of sessions, which stop working until their root
+	// key is rotated with RotateSessionKey, but not those of accounts. The
+	// rotation is persisted and survives restarts. Requires the
+	// supermacaroon:write permission.
+	RotateSuperMacaroonRootKey(context.Context, *RotateSuperMacaroonRootKeyRequest) (*RotateSuperMacaroonRootKeyResponse, error)
+	// BatchCall executes multiple unary calls in one request and returns their
+	// results in the same order. Each call is authorized independently with the
+	// macaroon and its discharge macaroons or the UI password of the batch
+	// request, a call that fails doesn't fail the batch. Streaming calls can't
+	// be part of a batch. Client certificates are not passed on to the calls.
+	// The step-up token of the batch request is passed on, so a batch can
+	// contain at most one call to a method that requires step-up
+	// authentication.
+	BatchCall(context.Context, *BatchCallRequest) (*BatchCallResponse, error)
+	// StartReportJob starts a faraday report in the background and returns
+	// immediately. Faraday reports can take a long time, the job can be polled
+	// with ReportJobStatus and its result fetched with FetchReportJobResult
+	// once it completed. The caller needs the same permissions as for calling
+	// the faraday method directly, the same applies to querying the job.
+	// Completed jobs are removed after the configured TTL.
+	StartReportJob(context.Context, *StartReportJobRequest) (*ReportJob, error)
+	// ReportJobStatus returns the current status of a report job.
+	ReportJobStatus(context.Context, *ReportJobStatusRequest) (*ReportJob, error)
+	// FetchReportJobResult returns the response of a completed report job. If
+	// the job failed, its error is returned instead.
+	FetchReportJobResult(context.Context, *FetchReportJobResultRequest) (*FetchReportJobResultResponse, error)
+	// litcli: `listeners`
+	// ListListeners returns every listener LiT serves on with its address,
+	// protocols, TLS status and accepted credentials. LiT connects to each
+	// listener and reports whether it is reachable and, for TLS listeners,
+	// completes a TLS handshake.
+	ListListeners(context.Context, *ListListenersRequest) (*ListListenersResponse, error)
+	// litcli: `routes`
+	// ListRoutes returns the routing table of the proxy: every method URI LiT
+	// knows, the daemon it is routed to and whether the daemon can currently
+	// handle calls to it. The table reflects the live state, including
+	// disabled daemons, lnd sub-servers that lnd isn't compiled with and
+	// remote daemons that aren't connected.
+	ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error)
+	// litcli: `listpermissions`
+	// ListMacaroonPermissions returns every permission that the methods of the
+	// active daemons require, grouped by daemon. These are the permissions a
+	// session or super macaroon can be scoped to. The permissions of lnd's
+	// sub-servers are listed as lnd's, those of the sub-servers lnd isn't
+	// compiled with are not listed.
+	ListMacaroonPermissions(context.Context, *ListMacaroonPermissionsRequest) (*ListMacaroonPermissionsResponse, error)
+	// litcli: `reloadtls`
+	// ReloadTLS re-reads the TLS certificate and key of LiT's main HTTPS
+	// listener from the configured files, so a renewed certificate can be used
+	// without restarting LiT. Existing connections are not affected, new
+	// connections use the new certificate. If the files can't be loaded, the
+	// current certificate stays in use. LiT reloads the certificate on SIGHUP as
+	// well. Certificates from Let's Encrypt are renewed automatically and can't
+	// be reloaded. Requires the proxy:write permission.
+	ReloadTLS(context.Context, *ReloadTLSRequest) (*ReloadTLSResponse, error)
+	// litcli: `whoami`
+	// WhoAmI returns what the super macaroon of the call is allowed to do. This
+	// includes the session it belongs to, its caveats and expiry, and the
+	// methods it can call. Session macaroons are super macaroons too. The method
+	// can be called with any valid super macaroon, since the macaroon is
+	// verified against the permissions it carries itself.
+	WhoAmI(context.Context, *WhoAmIRequest) (*WhoAmIResponse, error)
 	mustEmbedUnimplementedProxyServer()
 }
 
@@ -97,6 +327,36 @@ func (UnimplementedProxyServer) StopDaemon(context.Context, *StopDaemonRequest)
 func (UnimplementedProxyServer) BakeSuperMacaroon(context.Context, *BakeSuperMacaroonRequest) (*BakeSuperMacaroonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BakeSuperMacaroon not implemented")
 }
+func (UnimplementedProxyServer) RotateSuperMacaroonRootKey(context.Context, *RotateSuperMacaroonRootKeyRequest) (*RotateSuperMacaroonRootKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSuperMacaroonRootKey not implemented")
+}
+func (UnimplementedProxyServer) BatchCall(context.Context, *BatchCallRequest) (*BatchCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCall not implemented")
+}
+func (UnimplementedProxyServer) StartReportJob(context.Context, *StartReportJobRequest) (*ReportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReportJob not implemented")
+}
+func (UnimplementedProxyServer) ReportJobStatus(context.Context, *ReportJobStatusRequest) (*ReportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportJobStatus not implemented")
+}
+func (UnimplementedProxyServer) FetchReportJobResult(context.Context, *FetchReportJobResultRequest) (*FetchReportJobResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchReportJobResult not implemented")
+}
+func (UnimplementedProxyServer) ListListeners(context.Context, *ListListenersRequest) (*ListListenersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListListeners not implemented")
+}
+func (UnimplementedProxyServer) ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoutes not implemented")
+}
+func (UnimplementedProxyServer) ListMacaroonPermissions(context.Context, *ListMacaroonPermissionsRequest) (*ListMacaroonPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMacaroonPermissions not implemented")
+}
+func (UnimplementedProxyServer) ReloadTLS(context.Context, *ReloadTLSRequest) (*ReloadTLSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadTLS not implemented")
+}
+func (UnimplementedProxyServer) WhoAmI(context.Context, *WhoAmIRequest) (*WhoAmIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhoAmI not implemented")
+}
 func (UnimplementedProxyServer) mustEmbedUnimplementedProxyServer() {}
 
 // UnsafeProxyServer may be embedded to opt out of forward compatibility for this service.
@@ -164,6 +424,186 @@ func _Proxy_BakeSuperMacaroon_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Proxy_RotateSuperMacaroonRootKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSuperMacaroonRootKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).RotateSuperMacaroonRootKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/RotateSuperMacaroonRootKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).RotateSuperMacaroonRootKey(ctx, req.(*RotateSuperMacaroonRootKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_BatchCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).BatchCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/BatchCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).BatchCall(ctx, req.(*BatchCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_StartReportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartReportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).StartReportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/StartReportJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).StartReportJob(ctx, req.(*StartReportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ReportJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportJobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ReportJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ReportJobStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ReportJobStatus(ctx, req.(*ReportJobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_FetchReportJobResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchReportJobResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).FetchReportJobResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/FetchReportJobResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).FetchReportJobResult(ctx, req.(*FetchReportJobResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ListListeners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListListenersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ListListeners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ListListeners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ListListeners(ctx, req.(*ListListenersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ListRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ListRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ListRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ListRoutes(ctx, req.(*ListRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ListMacaroonPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMacaroonPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ListMacaroonPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ListMacaroonPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ListMacaroonPermissions(ctx, req.(*ListMacaroonPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ReloadTLS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadTLSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ReloadTLS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ReloadTLS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ReloadTLS(ctx, req.(*ReloadTLSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_WhoAmI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhoAmIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).WhoAmI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/WhoAmI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).WhoAmI(ctx, req.(*WhoAmIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Proxy_ServiceDesc is the grpc.ServiceDesc for Proxy service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -183,6 +623,46 @@ var Proxy_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BakeSuperMacaroon",
 			Handler:    _Proxy_BakeSuperMacaroon_Handler,
 		},
+		{
+			MethodName: "RotateSuperMacaroonRootKey",
+			Handler:    _Proxy_RotateSuperMacaroonRootKey_Handler,
+		},
+		{
+			MethodName: "BatchCall",
+			Handler:    _Proxy_BatchCall_Handler,
+		},
+		{
+			MethodName: "StartReportJob",
+			Handler:    _Proxy_StartReportJob_Handler,
+		},
+		{
+			MethodName: "ReportJobStatus",
+			Handler:    _Proxy_ReportJobStatus_Handler,
+		},
+		{
+			MethodName: "FetchReportJobResult",
+			Handler:    _Proxy_FetchReportJobResult_Handler,
+		},
+		{
+			MethodName: "ListListeners",
+			Handler:    _Proxy_ListListeners_Handler,
+		},
+		{
+			MethodName: "ListRoutes",
+			Handler:    _Proxy_ListRoutes_Handler,
+		},
+		{
+			MethodName: "ListMacaroonPermissions",
+			Handler:    _Proxy_ListMacaroonPermissions_Handler,
+		},
+		{
+			MethodName: "ReloadTLS",
+			Handler:    _Proxy_ReloadTLS_Handler,
+		},
+		{
+			MethodName: "WhoAmI",
+			Handler:    _Proxy_WhoAmI_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proxy.proto",
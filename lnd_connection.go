@@ -18,8 +18,16 @@ func connectLND(cfg *Config, bufListener *bufconn.Listener) (*grpc.ClientConn,
 	error) {
 
 	if cfg.lndRemote {
-		host, _, tlsPath, _, _ := cfg.lndConnectParams()
-		return dialBackend("lnd", host, tlsPath)
+		creds, err := cfg.Remote.Lnd.TransportCredentials()
+		if err != nil {
+			return nil, fmt.Errorf("could not set up lnd TLS "+
+				"verification: %v", err)
+		}
+
+		return dialBackend(
+			"lnd", cfg.Remote.Lnd.RPCServer, creds,
+			cfg.Keepalive.dialOpt(),
+		)
 	}
 
 	// If LND is running in integrated mode, then we use a bufconn to
@@ -28,25 +36,23 @@ func connectLND(cfg *Config, bufListener *bufconn.Listener) (*grpc.ClientConn,
 }
 
 // dialBackend connects to a gRPC backend through the given address and uses the
-// given TLS certificate to authenticate the connection.
-func dialBackend(name, dialAddr, tlsCertPath string) (*grpc.ClientConn, error) {
-	tlsConfig, err := credentials.NewClientTLSFromFile(tlsCertPath, "")
-	if err != nil {
-		return nil, fmt.Errorf("could not read %s TLS cert %s: %v",
-			name, tlsCertPath, err)
-	}
+// given transport credentials to authenticate the connection. The extra dial
+// options are added to the default ones.
+func dialBackend(name, dialAddr string, creds credentials.TransportCredentials,
+	extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 
 	opts := []grpc.DialOption{
 		// From the grpcProxy doc: This codec is *crucial* to the
 		// functioning of the proxy.
 		grpc.WithCodec(grpcProxy.Codec()), // nolint
-		grpc.WithTransportCredentials(tlsConfig),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(maxMsgRecvSize),
 		grpc.WithConnectParams(grpc.ConnectParams{
 			Backoff:           backoff.DefaultConfig,
 			MinConnectTimeout: defaultConnectTimeout,
 		}),
 	}
+	opts = append(opts, extraOpts...)
 
 	log.Infof("Dialing %s gRPC server at %s", name, dialAddr)
 	cc, err := grpc.Dial(dialAddr, opts...)
@@ -0,0 +1,200 @@
+package terminal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	litstatus "github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// lndRecoveringStatus is a custom status that will be used with the
+	// LND subserver while lnd is recovering its wallet. LiT waits for the
+	// recovery to finish before it marks lnd as running.
+	lndRecoveringStatus = "Recovering"
+
+	// defaultLndRecoveryPollInterval is the default interval at which lnd
+	// is asked for the progress of its wallet recovery.
+	defaultLndRecoveryPollInterval = 30 * time.Second
+)
+
+// lndRecoverySafeMethods are the lnd methods that don't depend on the wallet
+// being synced. If configured, they are allowed while lnd is recovering its
+// wallet.
+var lndRecoverySafeMethods = map[string]struct{}{
+	"/lnrpc.Lightning/GetInfo":         {},
+	"/lnrpc.Lightning/GetRecoveryInfo": {},
+	"/lnrpc.Lightning/ListPeers":       {},
+	"/lnrpc.Lightning/DebugLevel":      {},
+	"/lnrpc.Lightning/StopDaemon":      {},
+	"/lnrpc.State/GetState":            {},
+	"/lnrpc.State/SubscribeState":      {},
+}
+
+// lndRecoveryMonitor tracks lnd's wallet recovery while LiT waits for lnd to
+// be ready. During a recovery, lnd rescans the chain, which can take hours.
+type lndRecoveryMonitor struct {
+	cfg       *LndRecoveryConfig
+	statusMgr *litstatus.Manager
+
+	mu         sync.RWMutex
+	inRecovery bool
+	progress   float64
+
+	quit     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newLndRecoveryMonitor creates a new monitor that reports lnd's wallet
+// recovery to the given status manager.
+func newLndRecoveryMonitor(cfg *LndRecoveryConfig,
+	statusMgr *litstatus.Manager) *lndRecoveryMonitor {
+
+	return &lndRecoveryMonitor{
+		cfg:       cfg,
+		statusMgr: statusMgr,
+		quit:      make(chan struct{}),
+	}
+}
+
+// start starts polling lnd for the state of its wallet recovery. Polling stops
+// once lnd isn't or no longer is in recovery, or once the monitor is stopped.
+func (m *lndRecoveryMonitor) start(client lnrpc.LightningClient) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if done := m.poll(client); done {
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+// stop stops polling lnd. It must be called before lnd is marked as running,
+// since the monitor would otherwise overwrite lnd's status.
+func (m *lndRecoveryMonitor) stop() {
+	m.stopOnce.Do(func() {
+		close(m.quit)
+		m.wg.Wait()
+
+		m.mu.Lock()
+		m.inRecovery = false
+		m.mu.Unlock()
+	})
+}
+
+// poll queries the state of lnd's wallet recovery once. True is returned if
+// lnd isn't in recovery, so there is nothing left to track.
+func (m *lndRecoveryMonitor) poll(client lnrpc.LightningClient) bool {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), defaultServerTimeout,
+	)
+	defer cancel()
+
+	info, err := client.GetRecoveryInfo(
+		ctx, &lnrpc.GetRecoveryInfoRequest{},
+	)
+	if err != nil {
+		log.Debugf("Unable to get lnd recovery info: %v", err)
+
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	// If lnd wasn't started in recovery mode or already recovered its
+	// wallet before we checked, there is nothing to do.
+	case !m.inRecovery && (!info.RecoveryMode || info.RecoveryFinished):
+		return true
+
+	case info.RecoveryFinished:
+		log.Infof("lnd finished the recovery of its wallet")
+
+		m.inRecovery = false
+		m.progress = info.Progress
+		m.statusMgr.SetCustomStatus(
+			subservers.LND, lndWalletReadyStatus,
+		)
+
+		return true
+	}
+
+	if !m.inRecovery {
+		log.Infof("lnd is recovering its wallet, calls to lnd are " +
+			"unavailable until the recovery is done")
+
+		m.inRecovery = true
+		m.statusMgr.SetCustomStatus(
+			subservers.LND, lndRecoveringStatus,
+		)
+	}
+
+	if info.Progress != m.progress {
+		log.Infof("lnd wallet recovery progress: %.2f%%",
+			info.Progress*100)
+	}
+	m.progress = info.Progress
+
+	return false
+}
+
+// status returns the state of lnd's wallet recovery. Nil is returned if lnd
+// isn't in recovery.
+func (m *lndRecoveryMonitor) status() *litrpc.LndRecoveryStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.inRecovery {
+		return nil
+	}
+
+	return &litrpc.LndRecoveryStatus{
+		InRecovery: true,
+		Progress:   m.progress,
+	}
+}
+
+// restrictsCalls returns true if lnd is in recovery and calls are rejected
+// with the error for a recovering lnd, instead of the one for an lnd that
+// isn't ready yet.
+func (m *lndRecoveryMonitor) restrictsCalls() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.inRecovery && m.cfg.AllowSafeCalls
+}
+
+// allowsCall returns true if the call to the given URI may be forwarded to lnd
+// while lnd is recovering its wallet.
+func (m *lndRecoveryMonitor) allowsCall(uri string) bool {
+	// The status command must work at all times.
+	if uri == "/lnrpc.State/GetState" {
+		return true
+	}
+
+	if !m.cfg.AllowSafeCalls {
+		return false
+	}
+
+	_, ok := lndRecoverySafeMethods[uri]
+
+	return ok
+}
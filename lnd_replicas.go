@@ -0,0 +1,246 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// lndReplicaFailureBackoff is the duration for which no requests are sent to
+// an lnd read replica after a request to it failed because it was
+// unavailable.
+const lndReplicaFailureBackoff = 30 * time.Second
+
+// lndReplica is a connection to an lnd read replica.
+type lndReplica struct {
+	addr   string
+	weight uint32
+	conn   *grpc.ClientConn
+
+	// failedUntil is the time in unix nanoseconds until which the replica
+	// is considered unhealthy because a request to it failed.
+	failedUntil int64
+}
+
+// lndReplicaSet distributes read-only lnd requests across a set of lnd read
+// replicas and the primary lnd.
+type lndReplicaSet struct {
+	replicas      []*lndReplica
+	primaryWeight uint32
+}
+
+// connectLndReplicas dials all configured lnd read replicas. Nil is returned
+// if no replicas are configured.
+func connectLndReplicas(cfg *Config) (*lndReplicaSet, error) {
+	replicaCfg := cfg.LndReplicas
+	if len(replicaCfg.replicas) == 0 {
+		return nil, nil
+	}
+
+	// The replicas are verified like the primary remote lnd, unless they
+	// have a TLS cert of their own.
+	creds, err := cfg.Remote.Lnd.TransportCredentials()
+	if replicaCfg.TLSCertPath != "" {
+		creds, err = credentials.NewClientTLSFromFile(
+			replicaCfg.TLSCertPath, "",
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not set up lnd replica TLS "+
+			"verification: %v", err)
+	}
+
+	set := &lndReplicaSet{
+		primaryWeight: replicaCfg.PrimaryWeight,
+	}
+	for _, cfgReplica := range replicaCfg.replicas {
+		replica := &lndReplica{
+			addr:   cfgReplica.addr,
+			weight: cfgReplica.weight,
+		}
+		replica.conn, err = dialBackend(
+			"lnd replica", replica.addr, creds,
+			cfg.Keepalive.dialOpt(),
+			grpc.WithChainStreamInterceptor(
+				replica.failureStreamInterceptor,
+			),
+		)
+		if err != nil {
+			set.close()
+
+			return nil, err
+		}
+
+		set.replicas = append(set.replicas, replica)
+	}
+
+	return set, nil
+}
+
+// isHealthy returns true if the connection to the replica is usable. A
+// connection that is idle is asked to connect but is considered healthy since
+// gRPC connects it on the first request. A replica that recently failed a
+// request because it was unavailable isn't healthy until the backoff passed.
+func (r *lndReplica) isHealthy() bool {
+	if time.Now().UnixNano() < atomic.LoadInt64(&r.failedUntil) {
+		return false
+	}
+
+	switch r.conn.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+
+	case connectivity.Idle:
+		r.conn.Connect()
+	}
+
+	return true
+}
+
+// checkErr marks the replica as unhealthy for the failure backoff if the given
+// error of a request to it means that the replica is unavailable. The gRPC
+// connection state alone doesn't catch replicas that accept connections but
+// fail requests, for example while their lnd is still starting up.
+func (r *lndReplica) checkErr(err error) {
+	if status.Code(err) != codes.Unavailable {
+		return
+	}
+
+	log.Warnf("Request to lnd replica %s failed, not using it for %v: %v",
+		r.addr, lndReplicaFailureBackoff, err)
+
+	atomic.StoreInt64(
+		&r.failedUntil,
+		time.Now().Add(lndReplicaFailureBackoff).UnixNano(),
+	)
+}
+
+// failureStreamInterceptor is a gRPC client interceptor that passes the errors
+// of the requests to the replica to checkErr. The proxy forwards all requests
+// as streams, so there is no unary counterpart.
+func (r *lndReplica) failureStreamInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream,
+	error) {
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		r.checkErr(err)
+
+		return nil, err
+	}
+
+	return &replicaClientStream{ClientStream: stream, replica: r}, nil
+}
+
+// replicaClientStream is a client stream to an lnd replica that passes the
+// errors it receives to the replica's checkErr.
+type replicaClientStream struct {
+	grpc.ClientStream
+
+	replica *lndReplica
+}
+
+// RecvMsg receives a message from the replica.
+//
+// NOTE: This is part of the grpc.ClientStream interface.
+func (s *replicaClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil && err != io.EOF {
+		s.replica.checkErr(err)
+	}
+
+	return err
+}
+
+// pick chooses the connection a read-only request is sent to. The choice is
+// random, weighted by the configured weights of the healthy replicas and the
+// primary. If no replica is healthy, the primary is used.
+//
+// NOTE: A request that fails because its replica is unavailable isn't retried
+// on the primary, since the proxy can't replay it. Only the requests after it
+// are sent elsewhere until the replica's failure backoff passed.
+func (s *lndReplicaSet) pick(primary *grpc.ClientConn) *grpc.ClientConn {
+	var (
+		healthy     []*lndReplica
+		totalWeight = uint64(s.primaryWeight)
+	)
+	for _, replica := range s.replicas {
+		if !replica.isHealthy() {
+			continue
+		}
+
+		healthy = append(healthy, replica)
+		totalWeight += uint64(replica.weight)
+	}
+
+	if len(healthy) == 0 {
+		return primary
+	}
+
+	// nolint:gosec
+	choice := uint64(rand.Int63n(int64(totalWeight)))
+	for _, replica := range healthy {
+		if choice < uint64(replica.weight) {
+			return replica.conn
+		}
+		choice -= uint64(replica.weight)
+	}
+
+	return primary
+}
+
+// close closes the connections to all replicas.
+func (s *lndReplicaSet) close() {
+	for _, replica := range s.replicas {
+		if err := replica.conn.Close(); err != nil {
+			log.Errorf("Error closing connection to lnd replica "+
+				"%s: %v", replica.addr, err)
+		}
+	}
+}
+
+// lndConnForURI returns the lnd connection a request with the given URI is
+// forwarded to. Read-only requests are distributed across the lnd read
+// replicas, if there are any. Writes, streaming calls and calls that require
+// no permissions always go to the primary lnd.
+func (p *rpcProxy) lndConnForURI(requestURI string) *grpc.ClientConn {
+	if p.lndReplicas == nil || !p.isReadOnlyLndURI(requestURI) {
+		return p.lndConn
+	}
+
+	return p.lndReplicas.pick(p.lndConn)
+}
+
+// isReadOnlyLndURI returns true if the given URI belongs to a non-streaming
+// lnd RPC that only requires read permissions.
+func (p *rpcProxy) isReadOnlyLndURI(requestURI string) bool {
+	if !p.permsMgr.IsSubServerURI(subservers.LND, requestURI) ||
+		isStreamingMethod(requestURI) {
+
+		return false
+	}
+
+	ops, ok := p.permsMgr.URIPermissions(requestURI)
+	if !ok || len(ops) == 0 {
+		return false
+	}
+
+	for _, op := range ops {
+		if op.Action != "read" {
+			return false
+		}
+	}
+
+	return true
+}
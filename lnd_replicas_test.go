@@ -0,0 +1,51 @@
+package terminal
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// TestLndReplicaFailure tests that a replica that failed a request because it
+// was unavailable isn't picked anymore, while other errors are ignored.
+func TestLndReplicaFailure(t *testing.T) {
+	// The replica must be reachable, so its connection state alone
+	// doesn't make it unhealthy.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	dial := func() *grpc.ClientConn {
+		conn, err := grpc.Dial(
+			lis.Addr().String(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		return conn
+	}
+
+	primary := dial()
+	replica := &lndReplica{addr: "replica", weight: 1, conn: dial()}
+	set := &lndReplicaSet{replicas: []*lndReplica{replica}}
+	require.Equal(t, replica.conn, set.pick(primary))
+
+	replica.checkErr(status.Error(codes.Canceled, "canceled"))
+	require.Equal(t, replica.conn, set.pick(primary))
+
+	replica.checkErr(status.Error(codes.Unavailable, "unavailable"))
+	require.False(t, replica.isHealthy())
+	require.Equal(t, primary, set.pick(primary))
+}
@@ -0,0 +1,231 @@
+package terminal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// lndStartupRetryInterval is the interval at which LiT tries to
+	// subscribe to lnd's state again if lnd can't be reached.
+	lndStartupRetryInterval = 2 * time.Second
+)
+
+// lndStartupDescriptions are the human readable descriptions of the states
+// lnd can be in before it is ready. They are part of the error that is
+// returned for calls to lnd, so clients can tell the user what LiT is waiting
+// for.
+var lndStartupDescriptions = map[litrpc.LndStartupState]string{
+	litrpc.LndStartupState_LND_STATE_NOT_REACHABLE: "lnd is not reachable",
+	litrpc.LndStartupState_LND_STATE_LOCKED: "waiting for the lnd " +
+		"wallet to be unlocked",
+	litrpc.LndStartupState_LND_STATE_SYNCING: "waiting for lnd to sync " +
+		"to the chain",
+}
+
+// errUnlockFailed is the reason that is reported if lnd's wallet couldn't be
+// unlocked. The details are only logged.
+const errUnlockFailed = "unlock failed, see the logs for details"
+
+// lndStartupTracker tracks the startup state of lnd while LiT waits for lnd to
+// be ready. The state is derived from lnd's wallet state, which lnd reports
+// even before its wallet is unlocked.
+type lndStartupTracker struct {
+	mu        sync.RWMutex
+	state     litrpc.LndStartupState
+	since     time.Time
+	err       string
+	unlockErr string
+
+	quit     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newLndStartupTracker creates a new tracker that starts out in the state of
+// an lnd that isn't reachable.
+func newLndStartupTracker() *lndStartupTracker {
+	return &lndStartupTracker{
+		state: litrpc.LndStartupState_LND_STATE_NOT_REACHABLE,
+		since: time.Now(),
+		quit:  make(chan struct{}),
+	}
+}
+
+// start subscribes to lnd's wallet state and updates the startup state on
+// each change. If lnd can't be reached, the subscription is retried until the
+// tracker is stopped. If an unlock function is given, it is called each time
+// lnd reports that its wallet is locked.
+func (t *lndStartupTracker) start(client lnrpc.StateClient,
+	unlock func(context.Context) error) {
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		for {
+			err := t.subscribe(client, unlock)
+
+			select {
+			case <-t.quit:
+				return
+			default:
+			}
+
+			log.Debugf("Unable to subscribe to lnd state: %v", err)
+			t.set(
+				litrpc.LndStartupState_LND_STATE_NOT_REACHABLE,
+				err,
+			)
+
+			select {
+			case <-time.After(lndStartupRetryInterval):
+			case <-t.quit:
+				return
+			}
+		}
+	}()
+}
+
+// subscribe subscribes to lnd's wallet state and blocks until the
+// subscription fails or the tracker is stopped.
+func (t *lndStartupTracker) subscribe(client lnrpc.StateClient,
+	unlock func(context.Context) error) error {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-t.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := client.SubscribeState(
+		ctx, &lnrpc.SubscribeStateRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		t.set(lndStartupState(resp.State), nil)
+
+		// A wallet that doesn't exist yet can't be unlocked, it must
+		// be created by the user.
+		if resp.State == lnrpc.WalletState_LOCKED && unlock != nil {
+			t.setUnlockError(unlock(ctx))
+		}
+	}
+}
+
+// stop stops the subscription to lnd's wallet state.
+func (t *lndStartupTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.quit)
+		t.wg.Wait()
+	})
+}
+
+// setReady stops tracking lnd's wallet state and marks lnd as ready. It must
+// be called once LiT has connected all its lnd clients.
+func (t *lndStartupTracker) setReady() {
+	t.stop()
+	t.set(litrpc.LndStartupState_LND_STATE_READY, nil)
+}
+
+// set updates the startup state. The error is only kept for an lnd that isn't
+// reachable.
+func (t *lndStartupTracker) set(state litrpc.LndStartupState, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if state != t.state {
+		log.Infof("lnd startup state changed from %v to %v", t.state,
+			state)
+
+		t.state = state
+		t.since = time.Now()
+	}
+
+	t.err = ""
+	unreachable := state == litrpc.LndStartupState_LND_STATE_NOT_REACHABLE
+	if err != nil && unreachable {
+		t.err = err.Error()
+	}
+}
+
+// setUnlockError records the result of the last attempt to unlock lnd's
+// wallet. The status is served without authentication, so the error itself
+// is only logged, since it can contain the path of the password file.
+func (t *lndStartupTracker) setUnlockError(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.unlockErr = ""
+	if err != nil {
+		log.Errorf("Unable to unlock lnd: %v", err)
+
+		t.unlockErr = errUnlockFailed
+	}
+}
+
+// status returns the current startup state of lnd.
+func (t *lndStartupTracker) status() *litrpc.GetLitStatusResponse {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return &litrpc.GetLitStatusResponse{
+		LndState:       t.state,
+		LndStateSince:  t.since.Unix(),
+		LndError:       t.err,
+		LndUnlockError: t.unlockErr,
+	}
+}
+
+// notReadyError returns the error for a call to lnd that names the state lnd
+// is in. Nil is returned if lnd is ready.
+func (t *lndStartupTracker) notReadyError() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.state == litrpc.LndStartupState_LND_STATE_READY {
+		return nil
+	}
+
+	return status.Errorf(codes.FailedPrecondition, "lnd not ready (%v): %s",
+		t.state, lndStartupDescriptions[t.state])
+}
+
+// lndStartupState maps the given lnd wallet state to the startup state. Once
+// the wallet is unlocked, LiT still waits for lnd to sync to its chain backend
+// before it marks lnd as ready, so all later states count as syncing.
+func lndStartupState(state lnrpc.WalletState) litrpc.LndStartupState {
+	switch state {
+	case lnrpc.WalletState_NON_EXISTING, lnrpc.WalletState_LOCKED:
+		return litrpc.LndStartupState_LND_STATE_LOCKED
+
+	case lnrpc.WalletState_UNLOCKED, lnrpc.WalletState_RPC_ACTIVE,
+		lnrpc.WalletState_SERVER_ACTIVE:
+
+		return litrpc.LndStartupState_LND_STATE_SYNCING
+
+	// An lnd that waits to become the leader of its cluster doesn't serve
+	// any calls yet.
+	default:
+		return litrpc.LndStartupState_LND_STATE_NOT_REACHABLE
+	}
+}
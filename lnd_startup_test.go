@@ -0,0 +1,66 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestLndStartupTracker tests that the startup state follows lnd's wallet
+// state and that calls to lnd are rejected with an error naming the state.
+func TestLndStartupTracker(t *testing.T) {
+	tracker := newLndStartupTracker()
+
+	requireState := func(state litrpc.LndStartupState, errStr string) {
+		resp := tracker.status()
+		require.Equal(t, state, resp.LndState)
+		require.Equal(t, errStr, resp.LndError)
+		require.NotZero(t, resp.LndStateSince)
+
+		err := tracker.notReadyError()
+		if state == litrpc.LndStartupState_LND_STATE_READY {
+			require.NoError(t, err)
+			return
+		}
+
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Contains(t, err.Error(), state.String())
+	}
+
+	requireState(litrpc.LndStartupState_LND_STATE_NOT_REACHABLE, "")
+
+	tracker.set(
+		litrpc.LndStartupState_LND_STATE_NOT_REACHABLE,
+		errors.New("connection refused"),
+	)
+	requireState(
+		litrpc.LndStartupState_LND_STATE_NOT_REACHABLE,
+		"connection refused",
+	)
+
+	tracker.set(lndStartupState(lnrpc.WalletState_LOCKED), nil)
+	requireState(litrpc.LndStartupState_LND_STATE_LOCKED, "")
+	require.Contains(
+		t, tracker.notReadyError().Error(), "waiting for the lnd "+
+			"wallet to be unlocked",
+	)
+
+	// The details of a failed unlock attempt aren't exposed.
+	tracker.setUnlockError(errors.New("open /secret/password: no such " +
+		"file or directory"))
+	require.Equal(t, errUnlockFailed, tracker.status().LndUnlockError)
+
+	tracker.setUnlockError(nil)
+	require.Empty(t, tracker.status().LndUnlockError)
+
+	tracker.set(lndStartupState(lnrpc.WalletState_RPC_ACTIVE), nil)
+	requireState(litrpc.LndStartupState_LND_STATE_SYNCING, "")
+
+	tracker.setReady()
+	requireState(litrpc.LndStartupState_LND_STATE_READY, "")
+}
@@ -0,0 +1,75 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// checkUnlockPasswordFile makes sure the file that contains the password to
+// unlock lnd's wallet can only be accessed by the user litd runs as.
+func checkUnlockPasswordFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("unable to access wallet unlock password "+
+			"file: %w", err)
+	}
+
+	// Windows doesn't use the unix permission bits, so there is nothing
+	// we can check there.
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("wallet unlock password file %s must only "+
+			"be accessible by its owner, but has permissions %v",
+			path, info.Mode().Perm())
+	}
+
+	return nil
+}
+
+// unlockLndWallet reads the password from the given file and unlocks lnd's
+// wallet with it. Our copy of the password is zeroed once the call is done.
+func unlockLndWallet(ctx context.Context, client lnrpc.WalletUnlockerClient,
+	path string) error {
+
+	if err := checkUnlockPasswordFile(path); err != nil {
+		return err
+	}
+
+	pwBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read wallet unlock password "+
+			"file: %w", err)
+	}
+	defer func() {
+		for i := range pwBytes {
+			pwBytes[i] = 0
+		}
+	}()
+
+	// Just like lnd, we ignore any trailing line breaks, so the file can
+	// be created with an editor or echo.
+	password := bytes.TrimRight(pwBytes, "\r\n")
+	if len(password) == 0 {
+		return errors.New("wallet unlock password file is empty")
+	}
+
+	log.Infof("Unlocking lnd wallet with the password from %s", path)
+
+	_, err = client.UnlockWallet(ctx, &lnrpc.UnlockWalletRequest{
+		WalletPassword: password,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to unlock lnd wallet: %w", err)
+	}
+
+	return nil
+}
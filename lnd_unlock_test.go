@@ -0,0 +1,59 @@
+package terminal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// mockWalletUnlocker is a lnrpc.WalletUnlockerClient that records the
+// password it was called with.
+type mockWalletUnlocker struct {
+	lnrpc.WalletUnlockerClient
+
+	// received is a copy of the password of the last call.
+	received string
+
+	// password is the password slice of the last call itself.
+	password []byte
+}
+
+func (m *mockWalletUnlocker) UnlockWallet(_ context.Context,
+	req *lnrpc.UnlockWalletRequest,
+	_ ...grpc.CallOption) (*lnrpc.UnlockWalletResponse, error) {
+
+	m.received = string(req.WalletPassword)
+	m.password = req.WalletPassword
+
+	return &lnrpc.UnlockWalletResponse{}, nil
+}
+
+// TestUnlockLndWallet tests that lnd is unlocked with the password from the
+// file, that the password is zeroed afterwards and that files other users can
+// access are rejected.
+func TestUnlockLndWallet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.pw")
+	require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0644))
+
+	ctx := context.Background()
+	unlocker := &mockWalletUnlocker{}
+
+	err := unlockLndWallet(ctx, unlocker, path)
+	require.ErrorContains(t, err, "must only be accessible by its owner")
+	require.Nil(t, unlocker.password)
+
+	require.NoError(t, os.Chmod(path, 0600))
+	require.NoError(t, checkUnlockPasswordFile(path))
+	require.NoError(t, unlockLndWallet(ctx, unlocker, path))
+	require.Equal(t, "s3cret", unlocker.received)
+	require.Equal(t, make([]byte, len("s3cret")), unlocker.password)
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0600))
+	err = unlockLndWallet(ctx, unlocker, path)
+	require.ErrorContains(t, err, "is empty")
+}
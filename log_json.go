@@ -0,0 +1,477 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/jrick/logrotate/rotator"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd"
+	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/signal"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// LogFormatConsole is the default log format that writes the
+	// human-readable log lines of lnd's logging backend.
+	LogFormatConsole = "console"
+
+	// LogFormatJSON is the log format that writes one JSON object per
+	// log entry for the proxy, auth and session components.
+	LogFormatJSON = "json"
+
+	// defaultJSONLogFilename is the name of the file that the JSON log
+	// entries are written to, next to the regular log file.
+	defaultJSONLogFilename = "litd.json.log"
+
+	// redactedValue replaces sensitive values in log entries.
+	redactedValue = "[redacted]"
+)
+
+var (
+	// sensitiveLogPatterns match values that must never end up in a log
+	// entry: hex or base64 encoded macaroons and the credentials of
+	// authorization headers.
+	sensitiveLogPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`[0-9a-fA-F]{128,}`),
+		regexp.MustCompile(`AgE[A-Za-z0-9+/_-]{60,}={0,2}`),
+	}
+
+	// authHeaderPattern matches the credential of a basic or bearer
+	// authorization header.
+	authHeaderPattern = regexp.MustCompile(
+		`(?i)\b(basic|bearer)\s+[A-Za-z0-9+/._~=-]+`,
+	)
+)
+
+// LoggingConfig holds the options for the format of LiT's own logs.
+type LoggingConfig struct {
+	Format string `long:"format" description:"The format of the log entries of the proxy, auth and session components. With json, each entry is written as a JSON object on its own line to stdout and to litd.json.log in the log directory, with fields like subsystem, level, remote_addr, rpc_method and session_pubkey." choice:"console" choice:"json"`
+}
+
+// validate checks the logging options.
+func (c *LoggingConfig) validate() error {
+	switch c.Format {
+	case LogFormatConsole, LogFormatJSON:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown log format %q, must be %s or %s",
+			c.Format, LogFormatConsole, LogFormatJSON)
+	}
+}
+
+// logFields are the additional fields of a JSON log entry.
+type logFields map[string]string
+
+// jsonLogOutput serializes the writes of all JSON loggers, so the entries of
+// different subsystems don't interleave.
+type jsonLogOutput struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// write writes a single log entry.
+func (o *jsonLogOutput) write(entry []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	_, _ = o.w.Write(entry)
+}
+
+// jsonLogBackend is the state that a JSON logger shares with all loggers that
+// are derived from it with additional fields.
+type jsonLogBackend struct {
+	subsystem string
+	level     atomic.Uint32
+	out       *jsonLogOutput
+	shutdown  func()
+}
+
+// jsonLogger is a btclog.Logger that writes each log entry as a JSON object on
+// its own line. Sensitive values are redacted from the message and the fields
+// of every entry, regardless of the log level.
+type jsonLogger struct {
+	backend *jsonLogBackend
+	fields  logFields
+}
+
+// A compile-time check to ensure that jsonLogger implements btclog.Logger.
+var _ btclog.Logger = (*jsonLogger)(nil)
+
+// newJSONLogger creates a JSON logger for the given subsystem. The shutdown
+// function is called after a critical log entry, if it is set.
+func newJSONLogger(subsystem string, out *jsonLogOutput,
+	shutdown func()) *jsonLogger {
+
+	backend := &jsonLogBackend{
+		subsystem: subsystem,
+		out:       out,
+		shutdown:  shutdown,
+	}
+	backend.level.Store(uint32(btclog.LevelInfo))
+
+	return &jsonLogger{
+		backend: backend,
+	}
+}
+
+// withFields returns a logger that adds the given fields to each entry on top
+// of the fields of this logger. The returned logger shares its level with this
+// logger.
+func (l *jsonLogger) withFields(fields logFields) *jsonLogger {
+	merged := make(logFields, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		if value != "" {
+			merged[key] = value
+		}
+	}
+
+	return &jsonLogger{
+		backend: l.backend,
+		fields:  merged,
+	}
+}
+
+// logWithFields returns a logger that adds the given fields to each entry if
+// the given logger writes JSON entries. Other loggers can't represent fields,
+// so they are returned as they are.
+func logWithFields(logger btclog.Logger, fields logFields) btclog.Logger {
+	jsonLog, ok := logger.(*jsonLogger)
+	if !ok {
+		return logger
+	}
+
+	return jsonLog.withFields(fields)
+}
+
+// redactSensitive replaces macaroons and authorization credentials in the
+// given string.
+func redactSensitive(s string) string {
+	for _, pattern := range sensitiveLogPatterns {
+		s = pattern.ReplaceAllString(s, redactedValue)
+	}
+
+	return authHeaderPattern.ReplaceAllString(s, "$1 "+redactedValue)
+}
+
+// jsonLevelNames are the names of the log levels in JSON log entries.
+var jsonLevelNames = map[btclog.Level]string{
+	btclog.LevelTrace:    "trace",
+	btclog.LevelDebug:    "debug",
+	btclog.LevelInfo:     "info",
+	btclog.LevelWarn:     "warn",
+	btclog.LevelError:    "error",
+	btclog.LevelCritical: "critical",
+}
+
+// log writes a log entry with the given level and message if the level is
+// enabled.
+func (l *jsonLogger) log(level btclog.Level, msg string) {
+	if level < l.Level() {
+		return
+	}
+
+	var buf bytes.Buffer
+	writeField := func(key, value string) {
+		if buf.Len() == 0 {
+			buf.WriteByte('{')
+		} else {
+			buf.WriteByte(',')
+		}
+
+		// Marshaling a string can't fail.
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(value)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	writeField("time", time.Now().UTC().Format(time.RFC3339Nano))
+	writeField("level", jsonLevelNames[level])
+	writeField("subsystem", l.backend.subsystem)
+	writeField("message", redactSensitive(msg))
+
+	keys := make([]string, 0, len(l.fields))
+	for key := range l.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		writeField(key, redactSensitive(l.fields[key]))
+	}
+	buf.WriteString("}\n")
+
+	l.backend.out.write(buf.Bytes())
+}
+
+// criticalShutdown requests a shutdown after a critical log entry.
+func (l *jsonLogger) criticalShutdown() {
+	if l.backend.shutdown == nil {
+		return
+	}
+
+	l.log(btclog.LevelInfo, "Sending request for shutdown")
+	l.backend.shutdown()
+}
+
+// Tracef formats the message according to the format specifier and writes it
+// with the trace level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Tracef(format string, params ...interface{}) {
+	l.log(btclog.LevelTrace, fmt.Sprintf(format, params...))
+}
+
+// Debugf formats the message according to the format specifier and writes it
+// with the debug level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Debugf(format string, params ...interface{}) {
+	l.log(btclog.LevelDebug, fmt.Sprintf(format, params...))
+}
+
+// Infof formats the message according to the format specifier and writes it
+// with the info level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Infof(format string, params ...interface{}) {
+	l.log(btclog.LevelInfo, fmt.Sprintf(format, params...))
+}
+
+// Warnf formats the message according to the format specifier and writes it
+// with the warn level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Warnf(format string, params ...interface{}) {
+	l.log(btclog.LevelWarn, fmt.Sprintf(format, params...))
+}
+
+// Errorf formats the message according to the format specifier and writes it
+// with the error level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Errorf(format string, params ...interface{}) {
+	l.log(btclog.LevelError, fmt.Sprintf(format, params...))
+}
+
+// Criticalf formats the message according to the format specifier and writes
+// it with the critical level. A shutdown is requested afterwards.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Criticalf(format string, params ...interface{}) {
+	l.log(btclog.LevelCritical, fmt.Sprintf(format, params...))
+	l.criticalShutdown()
+}
+
+// Trace formats the message using the default formats for its operands and
+// writes it with the trace level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Trace(v ...interface{}) {
+	l.log(btclog.LevelTrace, fmt.Sprint(v...))
+}
+
+// Debug formats the message using the default formats for its operands and
+// writes it with the debug level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Debug(v ...interface{}) {
+	l.log(btclog.LevelDebug, fmt.Sprint(v...))
+}
+
+// Info formats the message using the default formats for its operands and
+// writes it with the info level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Info(v ...interface{}) {
+	l.log(btclog.LevelInfo, fmt.Sprint(v...))
+}
+
+// Warn formats the message using the default formats for its operands and
+// writes it with the warn level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Warn(v ...interface{}) {
+	l.log(btclog.LevelWarn, fmt.Sprint(v...))
+}
+
+// Error formats the message using the default formats for its operands and
+// writes it with the error level.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Error(v ...interface{}) {
+	l.log(btclog.LevelError, fmt.Sprint(v...))
+}
+
+// Critical formats the message using the default formats for its operands and
+// writes it with the critical level. A shutdown is requested afterwards.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Critical(v ...interface{}) {
+	l.log(btclog.LevelCritical, fmt.Sprint(v...))
+	l.criticalShutdown()
+}
+
+// Level returns the current log level of the logger.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) Level() btclog.Level {
+	return btclog.Level(l.backend.level.Load())
+}
+
+// SetLevel changes the log level of the logger and of all loggers that share
+// its backend.
+//
+// NOTE: this is part of the btclog.Logger interface.
+func (l *jsonLogger) SetLevel(level btclog.Level) {
+	l.backend.level.Store(uint32(level))
+}
+
+// setupJSONLogging replaces the loggers of the proxy, auth and session
+// components with loggers that write JSON entries to stdout and to a rotated
+// file in LiT's log directory. This must be called before the debug levels
+// are parsed, so the new loggers pick up the configured levels.
+func setupJSONLogging(cfg *Config, root *build.RotatingLogWriter,
+	intercept signal.Interceptor) error {
+
+	// In integrated mode, the log directory isn't adjusted to a custom
+	// lit directory yet, so we do the same as in remote mode here.
+	logDir := cfg.Remote.LitLogDir
+	litDir := lnd.CleanAndExpandPath(cfg.LitDir)
+	if litDir != DefaultLitDir && logDir == defaultLogDir {
+		logDir = filepath.Join(litDir, defaultLogDirname)
+	}
+	logFile := filepath.Join(
+		lncfg.CleanAndExpandPath(logDir), cfg.Network,
+		defaultJSONLogFilename,
+	)
+
+	if err := os.MkdirAll(filepath.Dir(logFile), 0700); err != nil {
+		return fmt.Errorf("unable to create log directory: %w", err)
+	}
+
+	logRotator, err := rotator.New(
+		logFile, int64(cfg.Remote.LitMaxLogFileSize*1024), false,
+		cfg.Remote.LitMaxLogFiles,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON log rotator: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		if err := logRotator.Run(pr); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to run JSON log "+
+				"rotator: %v\n", err)
+		}
+	}()
+
+	out := &jsonLogOutput{
+		w: io.MultiWriter(os.Stdout, pw),
+	}
+	shutdown := func() {
+		if !intercept.Listening() {
+			return
+		}
+
+		intercept.RequestShutdown()
+	}
+
+	lnd.SetSubLogger(
+		root, Subsystem, newJSONLogger(Subsystem, out, shutdown),
+		UseLogger,
+	)
+	lnd.SetSubLogger(
+		root, session.Subsystem,
+		newJSONLogger(session.Subsystem, out, shutdown),
+		session.UseLogger,
+	)
+
+	return nil
+}
+
+// requestLog returns the logger for entries about the request with the given
+// URI. In the JSON log format, the entries carry the method, the address of
+// the client, the ID of the request and the public key of the session that the
+// request was made with. In the console format, the entries are prefixed with
+// the ID of the request.
+func (p *rpcProxy) requestLog(ctx context.Context,
+	requestURI string) btclog.Logger {
+
+	requestID := requestIDFromContext(ctx)
+	if _, ok := log.(*jsonLogger); !ok {
+		return requestIDLog(requestID)
+	}
+
+	fields := logFields{
+		"rpc_method": requestURI,
+		"request_id": requestID,
+	}
+
+	if p.clientAddrs != nil {
+		if ip, err := p.clientAddrs.clientIP(ctx); err == nil {
+			fields["remote_addr"] = ip.String()
+		}
+	}
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil &&
+		fields["remote_addr"] == "" {
+
+		fields["remote_addr"] = pr.Addr.String()
+	}
+
+	if sess, ok := p.sessionFromContext(ctx); ok {
+		fields["session_pubkey"] = hex.EncodeToString(
+			sess.LocalPublicKey.SerializeCompressed(),
+		)
+	}
+
+	return logWithFields(log, fields)
+}
+
+// httpRequestLog returns the logger for entries about the given HTTP request.
+// In the JSON log format, the entries carry the path of the request, the
+// address of the client and the ID of the request. In the console format, the
+// entries are prefixed with the ID of the request.
+func httpRequestLog(req *http.Request) btclog.Logger {
+	requestID := req.Header.Get(HeaderRequestID)
+	if _, ok := log.(*jsonLogger); !ok {
+		return requestIDLog(requestID)
+	}
+
+	return logWithFields(log, logFields{
+		"rpc_method":  req.URL.Path,
+		"remote_addr": req.RemoteAddr,
+		"request_id":  requestID,
+	})
+}
+
+// requestIDLog returns a console logger that prefixes each entry with the
+// given request ID, or the package logger if there is no ID.
+func requestIDLog(requestID string) btclog.Logger {
+	if requestID == "" {
+		return log
+	}
+
+	return build.NewPrefixLog(fmt.Sprintf("[request: %s]", requestID), log)
+}
@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btclog"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONLogger tests that the JSON logger writes one JSON object per entry
+// with the logger's fields and that sensitive values are redacted.
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newJSONLogger("LITD", &jsonLogOutput{w: &buf}, nil)
+
+	entries := func() []map[string]string {
+		var result []map[string]string
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		for _, line := range lines {
+			if line == "" {
+				continue
+			}
+
+			var entry map[string]string
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			result = append(result, entry)
+		}
+		buf.Reset()
+
+		return result
+	}
+
+	// Entries below the level of the logger are dropped.
+	logger.Debugf("not logged")
+	require.Empty(t, entries())
+
+	reqLog := logWithFields(logger, logFields{
+		"rpc_method":     "/lnrpc.Lightning/GetInfo",
+		"remote_addr":    "127.0.0.1:1234",
+		"session_pubkey": "02abcd",
+		"request_id":     "",
+	})
+	reqLog.Warnf("Slow request: duration=%v", 5)
+
+	entry := entries()
+	require.Len(t, entry, 1)
+	require.NotEmpty(t, entry[0]["time"])
+	delete(entry[0], "time")
+	require.Equal(t, map[string]string{
+		"level":          "warn",
+		"subsystem":      "LITD",
+		"message":        "Slow request: duration=5",
+		"rpc_method":     "/lnrpc.Lightning/GetInfo",
+		"remote_addr":    "127.0.0.1:1234",
+		"session_pubkey": "02abcd",
+	}, entry[0])
+
+	// The derived logger shares the level of its parent.
+	logger.SetLevel(btclog.LevelDebug)
+	require.Equal(t, btclog.LevelDebug, reqLog.Level())
+
+	// Macaroons and authorization credentials are never logged, not even
+	// at the debug level.
+	macHex := hex.EncodeToString(bytes.Repeat([]byte{0x02, 0x01}, 100))
+	macBase64 := "AgEDbG5kAvgBAwoQ" + strings.Repeat("a", 100)
+	reqLog = logWithFields(logger, logFields{"macaroon": macHex})
+	reqLog.Debugf("Header mac=%s, ui=%s, auth=Basic dXNlcjpwYXNzd29yZA==",
+		macHex, macBase64)
+
+	entry = entries()
+	require.Len(t, entry, 1)
+	require.Equal(
+		t, "Header mac=[redacted], ui=[redacted], "+
+			"auth=Basic [redacted]", entry[0]["message"],
+	)
+	require.Equal(t, redactedValue, entry[0]["macaroon"])
+
+	// Loggers of the console format are used as they are.
+	consoleLog := btclog.Disabled
+	require.Equal(
+		t, consoleLog, logWithFields(consoleLog, logFields{"a": "b"}),
+	)
+}
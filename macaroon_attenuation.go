@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+)
+
+// firstPartyConstraint returns a constraint that adds the given condition as a
+// first party caveat.
+func firstPartyConstraint(condition string) macaroons.Constraint {
+	return func(mac *macaroon.Macaroon) error {
+		return mac.AddFirstPartyCaveat([]byte(condition))
+	}
+}
+
+// AttenuateMacaroon adds the requested caveats to the given macaroon and
+// returns the result. Caveats can only restrict a macaroon further, so no
+// secret and no authentication is needed.
+func (s *sessionRpcServer) AttenuateMacaroon(_ context.Context,
+	req *litrpc.AttenuateMacaroonRequest) (
+	*litrpc.AttenuateMacaroonResponse, error) {
+
+	mac, err := session.ParseMacaroon(req.Macaroon)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid "+
+			"macaroon: %v", err)
+	}
+
+	var constraints []macaroons.Constraint
+	for _, caveat := range req.Caveats {
+		if strings.TrimSpace(caveat) == "" {
+			return nil, status.Error(codes.InvalidArgument, "empty "+
+				"caveat")
+		}
+
+		constraints = append(constraints, firstPartyConstraint(caveat))
+	}
+
+	if req.TimeoutSeconds > 0 {
+		constraints = append(constraints, macaroons.TimeoutConstraint(
+			int64(req.TimeoutSeconds),
+		))
+	}
+
+	if req.IpAddress != "" {
+		if net.ParseIP(req.IpAddress) == nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"invalid IP address %s", req.IpAddress)
+		}
+
+		constraints = append(
+			constraints, macaroons.IPLockConstraint(req.IpAddress),
+		)
+	}
+
+	// Each operations caveat must allow an operation, so an additional
+	// caveat can only remove operations from the ones already allowed.
+	if len(req.AllowedOperations) > 0 {
+		ops, err := firewall.ParseOperations(req.AllowedOperations)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+
+		caveat, err := firewall.OperationsToCaveat(ops)
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, firstPartyConstraint(caveat))
+	}
+
+	if len(constraints) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one "+
+			"caveat must be added")
+	}
+
+	attenuated, err := macaroons.AddConstraints(mac, constraints...)
+	if err != nil {
+		return nil, fmt.Errorf("error adding caveats: %v", err)
+	}
+
+	macBytes, err := attenuated.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing macaroon: %v", err)
+	}
+
+	return &litrpc.AttenuateMacaroonResponse{
+		Macaroon: hex.EncodeToString(macBytes),
+	}, nil
+}
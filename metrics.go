@@ -0,0 +1,453 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	litstatus "github.com/lightninglabs/lightning-terminal/status"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// metricsNamespace is the namespace all of LiT's Prometheus metrics are
+	// registered under.
+	metricsNamespace = "litd"
+
+	// MethodLabelsNone aggregates the RPC metrics by daemon only. This
+	// keeps the number of time series small and independent of the
+	// number of RPC methods.
+	MethodLabelsNone = "none"
+
+	// MethodLabelsAllowlist only adds a method label to the RPC metrics of
+	// the configured methods. The metrics of all other methods are
+	// aggregated under the method label value "other".
+	MethodLabelsAllowlist = "allowlist"
+
+	// MethodLabelsAll adds a method label to the RPC metrics of every
+	// method. Combined with the daemon and status code labels, this can
+	// result in thousands of time series on a busy node.
+	MethodLabelsAll = "all"
+
+	// otherMethodLabel is the method label value of all methods that are
+	// not on the allowlist.
+	otherMethodLabel = "other"
+
+	// The reasons that authentication failures are counted under.
+	authFailureNoMacaroon      = "no_macaroon"
+	authFailureBadMacaroon     = "bad_macaroon"
+	authFailureWrongUIPassword = "wrong_ui_password"
+	authFailureBadCredential   = "bad_credential"
+	authFailureLockedOut       = "locked_out"
+)
+
+// isMetricsEnabled returns true if the RPC metrics should be exported to
+// Prometheus.
+func (c *Config) isMetricsEnabled() bool {
+	return c.Prometheus.Listen != ""
+}
+
+// rpcMetrics holds the Prometheus metrics of the requests handled by the RPC
+// proxy.
+type rpcMetrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+
+	// denials counts the requests that were denied because of missing or
+	// insufficient credentials. This is nil if the denials aren't
+	// exported.
+	denials *prometheus.CounterVec
+
+	// authFailures counts the requests that none of the authentication
+	// backends accepted, by the reason they were rejected for.
+	authFailures *prometheus.CounterVec
+
+	// http2Abuse counts the HTTP/2 connections that were closed because
+	// they exceeded a limit.
+	http2Abuse *prometheus.CounterVec
+
+	// orphanedStreams counts the streams that were cancelled because
+	// their client disconnected while they were still running.
+	orphanedStreams *prometheus.CounterVec
+
+	// methodLabels is the configured method label mode.
+	methodLabels string
+
+	// allowlist is the set of methods that get their own method label in
+	// the allowlist mode.
+	allowlist map[string]struct{}
+}
+
+// newRPCMetrics creates the RPC metrics and registers them in a new registry,
+// together with the up/down gauges of the sub-servers that are tracked by the
+// given status manager. Whether the metrics have a method label depends on the
+// configured mode.
+func newRPCMetrics(cfg *PrometheusConfig,
+	statusMgr *litstatus.Manager) *rpcMetrics {
+
+	m := &rpcMetrics{
+		registry:     prometheus.NewRegistry(),
+		methodLabels: cfg.MethodLabels,
+		allowlist:    make(map[string]struct{}, len(cfg.Methods)),
+	}
+	for _, method := range cfg.Methods {
+		m.allowlist[method] = struct{}{}
+	}
+
+	labels := []string{"daemon"}
+	if m.methodLabels != MethodLabelsNone {
+		labels = append(labels, "method")
+	}
+
+	m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "rpc_requests_total",
+		Help:      "Total number of requests handled by the RPC proxy.",
+	}, append(labels, "code"))
+	m.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "rpc_request_duration_seconds",
+		Help: "Duration of the non-streaming requests handled by " +
+			"the RPC proxy.",
+		Buckets: prometheus.DefBuckets,
+	}, labels)
+
+	m.authFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "auth_failures_total",
+		Help: "Total number of requests that no authentication " +
+			"backend accepted, by reason.",
+	}, []string{"reason"})
+
+	m.http2Abuse = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http2_abusive_connections_closed_total",
+		Help: "Total number of HTTP/2 connections that were closed " +
+			"for exceeding a stream, reset or ping limit.",
+	}, []string{"reason"})
+
+	m.orphanedStreams = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "orphaned_streams_cleaned_total",
+		Help: "Total number of gRPC web streams over WebSockets " +
+			"that were cancelled because their client " +
+			"disconnected.",
+	}, []string{"daemon"})
+
+	m.registry.MustRegister(
+		m.requests, m.duration, m.authFailures, m.http2Abuse,
+		m.orphanedStreams, newSubServerCollector(statusMgr),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(
+			collectors.ProcessCollectorOpts{},
+		),
+	)
+
+	// The session label only has a handful of values, so it doesn't
+	// change the cardinality much.
+	if cfg.PermissionDenials {
+		m.denials = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "permission_denials_total",
+			Help: "Total number of requests that were denied " +
+				"because of missing or insufficient " +
+				"credentials.",
+		}, append(labels, "session"))
+		m.registry.MustRegister(m.denials)
+	}
+
+	return m
+}
+
+// labelValues returns the values of the daemon and (depending on the mode)
+// method labels of a request.
+func (m *rpcMetrics) labelValues(daemon, requestURI string) []string {
+	switch m.methodLabels {
+	case MethodLabelsAll:
+		return []string{daemon, requestURI}
+
+	case MethodLabelsAllowlist:
+		if _, ok := m.allowlist[requestURI]; ok {
+			return []string{daemon, requestURI}
+		}
+
+		return []string{daemon, otherMethodLabel}
+
+	default:
+		return []string{daemon}
+	}
+}
+
+// observe records a finished request.
+func (m *rpcMetrics) observe(daemon, requestURI string, err error,
+	duration time.Duration, streaming bool) {
+
+	values := m.labelValues(daemon, requestURI)
+
+	code := status.Code(err).String()
+	m.requests.WithLabelValues(append(values, code)...).Inc()
+
+	// The duration of streaming calls isn't a meaningful latency.
+	if !streaming {
+		m.duration.WithLabelValues(values...).Observe(
+			duration.Seconds(),
+		)
+	}
+}
+
+// observeDenial records a request that was denied for a credential of the
+// given session category.
+func (m *rpcMetrics) observeDenial(daemon, requestURI, category string) {
+	if m.denials == nil {
+		return
+	}
+
+	values := m.labelValues(daemon, requestURI)
+	m.denials.WithLabelValues(append(values, category)...).Inc()
+}
+
+// sessionCategory returns the session label value of a request made with a
+// credential of the given session, or "none" if the credential doesn't belong
+// to a session.
+func sessionCategory(sess *session.Session, ok bool) string {
+	if !ok {
+		return "none"
+	}
+
+	switch sess.Type {
+	case session.TypeMacaroonReadonly:
+		return "readonly"
+
+	case session.TypeMacaroonAdmin:
+		return "admin"
+
+	case session.TypeMacaroonCustom:
+		return "custom"
+
+	case session.TypeUIPassword:
+		return "ui_password"
+
+	case session.TypeAutopilot:
+		return "autopilot"
+
+	case session.TypeMacaroonAccount:
+		return "account"
+
+	default:
+		return "unknown"
+	}
+}
+
+// observeDenial records a request with the given URI that was denied in the
+// permission denial metrics, if they are enabled.
+func (p *rpcProxy) observeDenial(ctx context.Context, requestURI string) {
+	if p.metrics == nil || p.metrics.denials == nil {
+		return
+	}
+
+	daemon, err := p.subSystemForURI(requestURI)
+	if err != nil {
+		daemon = "unknown"
+	}
+
+	category := sessionCategory(p.sessionFromContext(ctx))
+	p.metrics.observeDenial(daemon, requestURI, category)
+}
+
+// authFailureReason returns the reason that a request which no authentication
+// backend accepted is counted under. A lockout or a wrong UI password take
+// precedence, since the request usually doesn't carry a macaroon then.
+func authFailureReason(ctx context.Context, lockedOut, wrongPassword,
+	otherFailed bool) string {
+
+	switch {
+	case lockedOut:
+		return authFailureLockedOut
+
+	case wrongPassword:
+		return authFailureWrongUIPassword
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	switch {
+	case len(md.Get(HeaderMacaroon)) > 0:
+		return authFailureBadMacaroon
+
+	case otherFailed:
+		return authFailureBadCredential
+
+	default:
+		return authFailureNoMacaroon
+	}
+}
+
+// observeAuthFailure records a request that was rejected by all
+// authentication backends for the given reason, if metrics are enabled.
+func (p *rpcProxy) observeAuthFailure(reason string) {
+	if p.metrics == nil {
+		return
+	}
+
+	p.metrics.authFailures.WithLabelValues(reason).Inc()
+}
+
+// subServerCollector exports a gauge for each enabled sub-server that is 1 if
+// the sub-server is running and 0 otherwise. The status is read from the
+// status manager on each scrape, so it is always up to date.
+type subServerCollector struct {
+	statusMgr *litstatus.Manager
+	up        *prometheus.Desc
+}
+
+// newSubServerCollector creates a collector for the status of the sub-servers
+// tracked by the given status manager.
+func newSubServerCollector(statusMgr *litstatus.Manager) *subServerCollector {
+	return &subServerCollector{
+		statusMgr: statusMgr,
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(
+				metricsNamespace, "", "subserver_up",
+			),
+			"Whether an enabled sub-server is running (1) or not "+
+				"(0).", []string{"subserver"}, nil,
+		),
+	}
+}
+
+// Describe sends the description of the sub-server gauge.
+//
+// NOTE: this is part of the prometheus.Collector interface.
+func (c *subServerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+}
+
+// Collect sends the current status of each enabled sub-server.
+//
+// NOTE: this is part of the prometheus.Collector interface.
+func (c *subServerCollector) Collect(ch chan<- prometheus.Metric) {
+	resp, err := c.statusMgr.SubServerStatus(
+		context.Background(), &litrpc.SubServerStatusReq{},
+	)
+	if err != nil {
+		return
+	}
+
+	for name, subServer := range resp.SubServers {
+		if subServer.Disabled {
+			continue
+		}
+
+		var up float64
+		if subServer.Running {
+			up = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.up, prometheus.GaugeValue, up, name,
+		)
+	}
+}
+
+// observeHTTP2Abuse records an HTTP/2 connection that was closed for the
+// given reason.
+func (m *rpcMetrics) observeHTTP2Abuse(reason string) {
+	m.http2Abuse.WithLabelValues(reason).Inc()
+}
+
+// observeOrphanedStream records a stream of the given daemon that was
+// cancelled because its client disconnected.
+func (m *rpcMetrics) observeOrphanedStream(daemon string) {
+	m.orphanedStreams.WithLabelValues(daemon).Inc()
+}
+
+// observeRequest records a finished request with the given URI in the RPC
+// metrics.
+func (p *rpcProxy) observeRequest(requestURI string, err error,
+	duration time.Duration, streaming bool) {
+
+	daemon, daemonErr := p.subSystemForURI(requestURI)
+	if daemonErr != nil {
+		daemon = "unknown"
+	}
+
+	p.metrics.observe(daemon, requestURI, err, duration, streaming)
+}
+
+// metricsUnaryInterceptor is a gRPC interceptor that records the metrics of
+// each unary request handled by the proxy.
+func (p *rpcProxy) metricsUnaryInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	p.observeRequest(info.FullMethod, err, time.Since(start), false)
+
+	return resp, err
+}
+
+// metricsStreamInterceptor is a gRPC interceptor that records the metrics of
+// each streaming request handled by the proxy. This includes all requests
+// that are forwarded to a backend daemon by the director.
+func (p *rpcProxy) metricsStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	start := time.Now()
+	err := handler(srv, ss)
+	p.observeRequest(
+		info.FullMethod, err, time.Since(start),
+		isStreamingMethod(info.FullMethod),
+	)
+
+	return err
+}
+
+// startMetricsServer starts the HTTP server that serves the metrics of the
+// given registry to Prometheus.
+func (g *LightningTerminal) startMetricsServer(
+	registry *prometheus.Registry) error {
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
+		registry, promhttp.HandlerOpts{},
+	))
+
+	g.metricsServer = &http.Server{
+		ReadHeaderTimeout: defaultServerTimeout,
+		Handler:           mux,
+	}
+
+	listener, err := net.Listen("tcp", g.cfg.Prometheus.Listen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v: %v",
+			g.cfg.Prometheus.Listen, err)
+	}
+	g.rpcProxy.listeners.add(newListenerInfo(
+		"prometheus", listener.Addr(), []string{"http"}, false, "none",
+	))
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		log.Infof("Serving Prometheus metrics on: %v", listener.Addr())
+		err := g.metricsServer.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("Prometheus metrics server error: %v", err)
+		}
+	}()
+
+	return nil
+}
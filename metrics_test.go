@@ -0,0 +1,70 @@
+package terminal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	litstatus "github.com/lightninglabs/lightning-terminal/status"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestAuthFailureMetrics tests that authentication failures are counted by
+// reason and that the sub-server gauges follow the status manager.
+func TestAuthFailureMetrics(t *testing.T) {
+	withMacaroon := metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs(HeaderMacaroon, "abcd"),
+	)
+	ctx := context.Background()
+
+	require.Equal(
+		t, authFailureLockedOut,
+		authFailureReason(withMacaroon, true, true, true),
+	)
+	require.Equal(
+		t, authFailureWrongUIPassword,
+		authFailureReason(ctx, false, true, false),
+	)
+	require.Equal(
+		t, authFailureBadMacaroon,
+		authFailureReason(withMacaroon, false, false, true),
+	)
+	require.Equal(
+		t, authFailureBadCredential,
+		authFailureReason(ctx, false, false, true),
+	)
+	require.Equal(
+		t, authFailureNoMacaroon,
+		authFailureReason(ctx, false, false, false),
+	)
+
+	statusMgr := litstatus.NewStatusManager()
+	statusMgr.RegisterAndEnableSubServer("loop")
+	statusMgr.RegisterAndEnableSubServer("pool")
+	statusMgr.RegisterSubServer("faraday")
+	statusMgr.SetRunning("loop")
+
+	p := &rpcProxy{
+		metrics: newRPCMetrics(&PrometheusConfig{
+			MethodLabels: MethodLabelsNone,
+		}, statusMgr),
+	}
+	p.observeAuthFailure(authFailureNoMacaroon)
+	p.observeAuthFailure(authFailureNoMacaroon)
+	p.observeAuthFailure(authFailureWrongUIPassword)
+
+	require.NoError(t, testutil.GatherAndCompare(
+		p.metrics.registry, strings.NewReader(`
+# HELP litd_auth_failures_total Total number of requests that no authentication backend accepted, by reason.
+# TYPE litd_auth_failures_total counter
+litd_auth_failures_total{reason="no_macaroon"} 2
+litd_auth_failures_total{reason="wrong_ui_password"} 1
+# HELP litd_subserver_up Whether an enabled sub-server is running (1) or not (0).
+# TYPE litd_subserver_up gauge
+litd_subserver_up{subserver="loop"} 1
+litd_subserver_up{subserver="pool"} 0
+`), "litd_auth_failures_total", "litd_subserver_up",
+	))
+}
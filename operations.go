@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	grpcProxy "github.com/mwitkow/grpc-proxy/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// allowedOperationsFromContext returns the lists of allowed operations of all
+// allowed operations caveats of the macaroon in the given context. Each list
+// must allow an operation for it to be executed.
+func allowedOperationsFromContext(
+	ctx context.Context) ([][]firewall.Operation, error) {
+
+	mac, err := macaroonFromContext(ctx)
+	if err != nil || mac == nil {
+		return nil, err
+	}
+
+	var allowed [][]firewall.Operation
+	for _, caveat := range mac.Caveats() {
+		ops, err := firewall.ParseOperationsCaveat(string(caveat.Id))
+		if errors.Is(err, firewall.ErrNoOperationsCaveat) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		allowed = append(allowed, ops)
+	}
+
+	return allowed, nil
+}
+
+// checkOperation makes sure the given request message is allowed by all
+// allowed operations caveats. The message can either be a proto message or a
+// raw frame of the proxy.
+func checkOperation(allowed [][]firewall.Operation, requestURI string,
+	msg interface{}) error {
+
+	rawReq, err := grpcProxy.Codec().Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, ops := range allowed {
+		err := firewall.CheckOperation(ops, requestURI, rawReq)
+		if err != nil {
+			return status.Errorf(
+				codes.PermissionDenied, "permission denied: "+
+					"%v", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// operationRestrictions returns the allowed operations caveats that apply to
+// a request with the given URI. Nil is returned if the request isn't
+// restricted.
+func operationRestrictions(ctx context.Context,
+	requestURI string) ([][]firewall.Operation, error) {
+
+	if !firewall.ExecutesOperation(requestURI) {
+		return nil, nil
+	}
+
+	allowed, err := allowedOperationsFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.PermissionDenied, "invalid allowed operations "+
+				"caveat: %v", err,
+		)
+	}
+
+	return allowed, nil
+}
+
+// operationCheckStream wraps a grpc.ServerStream and makes sure each received
+// request executes an allowed operation.
+type operationCheckStream struct {
+	grpc.ServerStream
+
+	requestURI string
+	allowed    [][]firewall.Operation
+}
+
+// RecvMsg receives the next request message and checks its operation.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *operationCheckStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return checkOperation(s.allowed, s.requestURI, m)
+}
@@ -0,0 +1,43 @@
+package terminal
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// ListMacaroonPermissions returns every permission that the methods of the
+// active daemons require, grouped by daemon.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) ListMacaroonPermissions(_ context.Context,
+	_ *litrpc.ListMacaroonPermissionsRequest) (
+	*litrpc.ListMacaroonPermissionsResponse, error) {
+
+	bySubServer := p.permsMgr.ActivePermissionsBySubServer()
+
+	daemons := make([]*litrpc.DaemonPermissions, 0, len(bySubServer))
+	for name, ops := range bySubServer {
+		perms := make([]*litrpc.PermissionInfo, len(ops))
+		for idx, op := range ops {
+			perms[idx] = &litrpc.PermissionInfo{
+				Entity:   op.Entity,
+				Action:   op.Action,
+				ReadOnly: op.Action == "read",
+			}
+		}
+
+		daemons = append(daemons, &litrpc.DaemonPermissions{
+			Daemon:      name,
+			Permissions: perms,
+		})
+	}
+	sort.Slice(daemons, func(i, j int) bool {
+		return daemons[i].Daemon < daemons[j].Daemon
+	})
+
+	return &litrpc.ListMacaroonPermissionsResponse{
+		Daemons: daemons,
+	}, nil
+}
@@ -2,6 +2,7 @@ package perms
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -37,6 +38,12 @@ type Manager struct {
 	// once the permsMu mutex is held.
 	perms   map[string][]bakery.Op
 	permsMu sync.RWMutex
+
+	// lndSubServersEnabled maps the name of each LND sub-server to whether
+	// the connected LND has been compiled with it. This map is nil until
+	// OnLNDBuildTags is called and must only be accessed once the permsMu
+	// mutex is held.
+	lndSubServersEnabled map[string]bool
 }
 
 // NewManager constructs a new Manager instance and collects any of the
@@ -139,8 +146,9 @@ func (pm *Manager) RegisterSubServer(name string,
 
 // OnLNDBuildTags should be called once a list of LND build tags has been
 // obtained. It then uses those build tags to decide which of the LND sub-server
-// permissions to add to the main permissions list. This method should only
-// be called once.
+// permissions to add to the main permissions list. This method can be called
+// again every time the connection to LND is re-established, the permissions of
+// sub-servers that are no longer compiled into LND are then removed.
 func (pm *Manager) OnLNDBuildTags(lndBuildTags []string) {
 	pm.permsMu.Lock()
 	defer pm.permsMu.Unlock()
@@ -150,20 +158,71 @@ func (pm *Manager) OnLNDBuildTags(lndBuildTags []string) {
 		tagLookup[strings.ToLower(t)] = true
 	}
 
+	pm.lndSubServersEnabled = make(map[string]bool)
 	for subServerName, perms := range pm.lndSubServerPerms {
 		name := subServerName
 		if tagName, ok := lndSubServerNameToTag[name]; ok {
 			name = tagName
 		}
 
-		if !tagLookup[strings.ToLower(name)] {
+		enabled := tagLookup[strings.ToLower(name)] ||
+			lndAutoCompiledSubServers[subServerName]
+		pm.lndSubServersEnabled[subServerName] = enabled
+
+		for key, value := range perms {
+			if enabled {
+				pm.perms[key] = value
+			} else {
+				delete(pm.perms, key)
+			}
+		}
+	}
+}
+
+// LndSubServers returns the names of the LND sub-servers that the connected
+// LND has been compiled with. Nil is returned if LND's build tags are not yet
+// known.
+func (pm *Manager) LndSubServers() []string {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	if pm.lndSubServersEnabled == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(pm.lndSubServersEnabled))
+	for name, enabled := range pm.lndSubServersEnabled {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// DisabledLndSubServer returns the name of the LND sub-server the given URI
+// belongs to if the connected LND has not been compiled with that sub-server.
+// False is returned if the URI doesn't belong to a disabled LND sub-server or
+// if LND's build tags are not yet known.
+func (pm *Manager) DisabledLndSubServer(uri string) (string, bool) {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	for name, perms := range pm.lndSubServerPerms {
+		if _, ok := perms[uri]; !ok {
 			continue
 		}
 
-		for key, value := range perms {
-			pm.perms[key] = value
+		enabled, known := pm.lndSubServersEnabled[name]
+		if !known || enabled {
+			return "", false
 		}
+
+		return name, true
 	}
+
+	return "", false
 }
 
 // URIPermissions returns a list of permission operations for the given URI if
@@ -177,6 +236,31 @@ func (pm *Manager) URIPermissions(uri string) ([]bakery.Op, bool) {
 	return ops, ok
 }
 
+// URIs returns the sorted list of all URIs known to the manager, including the
+// URIs of LND sub-servers that the connected LND hasn't been compiled with.
+func (pm *Manager) URIs() []string {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	uriSet := make(map[string]struct{}, len(pm.perms))
+	for uri := range pm.perms {
+		uriSet[uri] = struct{}{}
+	}
+	for _, perms := range pm.lndSubServerPerms {
+		for uri := range perms {
+			uriSet[uri] = struct{}{}
+		}
+	}
+
+	uris := make([]string, 0, len(uriSet))
+	for uri := range uriSet {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	return uris
+}
+
 // MatchRegexURI first checks that the given URI is in fact a regex. If it is,
 // then it is used to match on the perms that the manager has. The return values
 // are a list of URIs that match the regex and the boolean represents whether
@@ -252,6 +336,80 @@ func (pm *Manager) ActivePermissions(readOnly bool) []bakery.Op {
 	return result
 }
 
+// ActivePermissionsBySubServer returns the active permissions grouped by the
+// name of the sub-server whose methods require them. The permissions of LND's
+// sub-servers are part of LND's. The permissions of each sub-server are
+// de-duplicated and sorted by entity and action.
+func (pm *Manager) ActivePermissionsBySubServer() map[string][]bakery.Op {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	dedupMap := make(map[string]map[bakery.Op]bool)
+	for uri, methodPerms := range pm.perms {
+		subServer := pm.subServerForURI(uri)
+		if subServer == "" {
+			continue
+		}
+
+		for _, methodPerm := range methodPerms {
+			if methodPerm.Action == "" || methodPerm.Entity == "" {
+				continue
+			}
+
+			if dedupMap[subServer] == nil {
+				dedupMap[subServer] = make(map[bakery.Op]bool)
+			}
+			dedupMap[subServer][methodPerm] = true
+		}
+	}
+
+	result := make(map[string][]bakery.Op, len(dedupMap))
+	for subServer, ops := range dedupMap {
+		sorted := make([]bakery.Op, 0, len(ops))
+		for op := range ops {
+			sorted = append(sorted, op)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			if sorted[i].Entity != sorted[j].Entity {
+				return sorted[i].Entity < sorted[j].Entity
+			}
+
+			return sorted[i].Action < sorted[j].Action
+		})
+
+		result[subServer] = sorted
+	}
+
+	return result
+}
+
+// SubServerForURI returns the name of the sub-server the given URI belongs to.
+// The URIs of LND's sub-servers belong to LND, the URIs of LiT's own RPCs
+// belong to "lit". An empty string is returned if the URI is unknown.
+func (pm *Manager) SubServerForURI(uri string) string {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	return pm.subServerForURI(uri)
+}
+
+// subServerForURI returns the name of the sub-server the given URI belongs to.
+// The URIs of LND's sub-servers belong to LND. An empty string is returned if
+// the URI is unknown. The permsMu mutex must be held when calling this.
+func (pm *Manager) subServerForURI(uri string) string {
+	if pm.isLndURI(uri) {
+		return lndPerms
+	}
+
+	for name, perms := range pm.fixedPerms {
+		if _, ok := perms[uri]; ok {
+			return name
+		}
+	}
+
+	return ""
+}
+
 // GetLitPerms returns a map of all permissions that the manager is aware of
 // _except_ for any LND permissions. In other words, this returns permissions
 // for which the external validator of Lit is responsible.
@@ -85,3 +85,118 @@ func TestMatchRegexURI(t *testing.T) {
 	require.False(t, isRegex)
 	require.Empty(t, uris)
 }
+
+// TestOnLNDBuildTags tests that the permissions of LND sub-servers are only
+// active if LND was compiled with them and that they are updated if the build
+// tags change.
+func TestOnLNDBuildTags(t *testing.T) {
+	const (
+		signURI     = "/signrpc.Signer/SignMessage"
+		versionURI  = "/verrpc.Versioner/GetVersion"
+		signerName  = "SignRPC"
+		versionName = "VersionRPC"
+	)
+
+	signOps := []bakery.Op{{Entity: "signer", Action: "generate"}}
+	versionOps := []bakery.Op{{Entity: "info", Action: "read"}}
+	m := &Manager{
+		lndSubServerPerms: map[string]map[string][]bakery.Op{
+			signerName:  {signURI: signOps},
+			versionName: {versionURI: versionOps},
+		},
+		perms: map[string][]bakery.Op{
+			versionURI: versionOps,
+		},
+	}
+
+	// Before the build tags are known, no sub-server is reported as
+	// disabled.
+	require.Nil(t, m.LndSubServers())
+	_, disabled := m.DisabledLndSubServer(signURI)
+	require.False(t, disabled)
+
+	// Without the signrpc tag, only the automatically compiled version
+	// sub-server is enabled.
+	m.OnLNDBuildTags([]string{"walletrpc"})
+	require.Equal(t, []string{versionName}, m.LndSubServers())
+
+	_, ok := m.URIPermissions(signURI)
+	require.False(t, ok)
+
+	// The URIs of the disabled sub-server are still listed.
+	require.Equal(t, []string{signURI, versionURI}, m.URIs())
+
+	name, disabled := m.DisabledLndSubServer(signURI)
+	require.True(t, disabled)
+	require.Equal(t, signerName, name)
+
+	_, disabled = m.DisabledLndSubServer(versionURI)
+	require.False(t, disabled)
+
+	// Once LND is compiled with the signrpc tag, its permissions are
+	// added.
+	m.OnLNDBuildTags([]string{"signrpc"})
+	require.Equal(t, []string{signerName, versionName}, m.LndSubServers())
+
+	ops, ok := m.URIPermissions(signURI)
+	require.True(t, ok)
+	require.Equal(t, signOps, ops)
+
+	_, disabled = m.DisabledLndSubServer(signURI)
+	require.False(t, disabled)
+
+	// And removed again if a re-connected LND no longer has it.
+	m.OnLNDBuildTags(nil)
+	_, ok = m.URIPermissions(signURI)
+	require.False(t, ok)
+}
+
+// TestActivePermissionsBySubServer tests that the active permissions are
+// grouped by their sub-server, with LND's sub-servers being part of LND.
+func TestActivePermissionsBySubServer(t *testing.T) {
+	const (
+		infoURI = "/lnrpc.Lightning/GetInfo"
+		signURI = "/signrpc.Signer/SignMessage"
+		loopURI = "/looprpc.SwapClient/LoopOut"
+	)
+
+	infoOps := []bakery.Op{{Entity: "info", Action: "read"}}
+	signOps := []bakery.Op{{Entity: "signer", Action: "generate"}}
+	m := &Manager{
+		lndSubServerPerms: map[string]map[string][]bakery.Op{
+			"SignRPC": {signURI: signOps},
+		},
+		fixedPerms: map[string]map[string][]bakery.Op{
+			lndPerms: {infoURI: infoOps},
+		},
+		perms: map[string][]bakery.Op{
+			infoURI: infoOps,
+		},
+	}
+
+	m.RegisterSubServer("loop", map[string][]bakery.Op{
+		loopURI: {
+			{Entity: "swap", Action: "execute"},
+			{Entity: "loop", Action: "out"},
+			{Entity: "info", Action: "read"},
+		},
+	}, nil)
+
+	// The permissions of the signer aren't active yet.
+	require.Equal(t, map[string][]bakery.Op{
+		lndPerms: infoOps,
+		"loop": {
+			{Entity: "info", Action: "read"},
+			{Entity: "loop", Action: "out"},
+			{Entity: "swap", Action: "execute"},
+		},
+	}, m.ActivePermissionsBySubServer())
+
+	m.OnLNDBuildTags([]string{"signrpc"})
+
+	perms := m.ActivePermissionsBySubServer()
+	require.Equal(t, []bakery.Op{
+		{Entity: "info", Action: "read"},
+		{Entity: "signer", Action: "generate"},
+	}, perms[lndPerms])
+}
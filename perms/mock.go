@@ -8,7 +8,6 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/autopilotrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
-	"github.com/lightningnetwork/lnd/lnrpc/devrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/neutrinorpc"
 	"github.com/lightningnetwork/lnd/lnrpc/peersrpc"
@@ -57,7 +56,7 @@ func (t *mockConfig) FetchConfig(subServerName string) (interface{}, bool) {
 			Chain:         &mock.ChainIO{},
 		}, true
 	case "DevRPC":
-		return &devrpc.Config{}, true
+		return mockDevRPCConfig(), true
 	case "NeutrinoKitRPC":
 		return &neutrinorpc.Config{}, true
 	case "PeersRPC":
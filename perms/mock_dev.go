@@ -0,0 +1,21 @@
+//go:build dev
+// +build dev
+
+package perms
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc/devrpc"
+)
+
+// mockDevRPCConfig returns a config that the DevRPC sub-server can be created
+// with. With the dev build tag, the sub-server refuses to be created without
+// network parameters and a graph DB, even though they are only used by its
+// RPCs.
+func mockDevRPCConfig() *devrpc.Config {
+	return &devrpc.Config{
+		ActiveNetParams: &chaincfg.RegressionNetParams,
+		GraphDB:         &channeldb.ChannelGraph{},
+	}
+}
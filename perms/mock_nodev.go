@@ -0,0 +1,12 @@
+//go:build !dev
+// +build !dev
+
+package perms
+
+import "github.com/lightningnetwork/lnd/lnrpc/devrpc"
+
+// mockDevRPCConfig returns a config that the DevRPC sub-server can be created
+// with. Without the dev build tag, the sub-server doesn't need anything.
+func mockDevRPCConfig() *devrpc.Config {
+	return &devrpc.Config{}
+}
@@ -0,0 +1,37 @@
+package perms
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// ParsePermission parses a permission in the form entity:action.
+func ParsePermission(perm string) (bakery.Op, error) {
+	entity, action, ok := strings.Cut(perm, ":")
+	if !ok || entity == "" || action == "" {
+		return bakery.Op{}, fmt.Errorf("invalid permission %s, must "+
+			"be in the form entity:action", perm)
+	}
+
+	return bakery.Op{
+		Entity: entity,
+		Action: action,
+	}, nil
+}
+
+// ParsePermissions parses a list of permissions in the form entity:action.
+func ParsePermissions(perms []string) ([]bakery.Op, error) {
+	ops := make([]bakery.Op, 0, len(perms))
+	for _, perm := range perms {
+		op, err := ParsePermission(perm)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
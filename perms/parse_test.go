@@ -0,0 +1,26 @@
+package perms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestParsePermissions tests that permissions in the form entity:action are
+// parsed and that malformed ones are rejected.
+func TestParsePermissions(t *testing.T) {
+	ops, err := ParsePermissions([]string{"info:read", "uri:/a:b"})
+	require.NoError(t, err)
+	require.Equal(t, []bakery.Op{
+		{Entity: "info", Action: "read"},
+		{Entity: "uri", Action: "/a:b"},
+	}, ops)
+
+	for _, perm := range []string{"info", ":read", "info:", ""} {
+		_, err := ParsePermission(perm)
+		require.ErrorContains(
+			t, err, "must be in the form entity:action",
+		)
+	}
+}
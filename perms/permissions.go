@@ -20,6 +20,46 @@ var (
 			Entity: "sessions",
 			Action: "write",
 		}},
+		"/litrpc.Sessions/RotateSessionKey": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/RenewSession": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/ImportSession": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/ExportSession": {{
+			Entity: "sessions",
+			Action: "export",
+		}},
+		"/litrpc.Sessions/CheckSessionPermissions": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/SubscribeSessionEvents": {{
+			Entity: "sessions",
+			Action: "read",
+		}},
+		"/litrpc.Sessions/GetSessionUsage": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/PreviewMethodPolicy": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/CheckSessionStore": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/TestWebhook": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
 		"/litrpc.Accounts/CreateAccount": {{
 			Entity: "account",
 			Action: "write",
@@ -44,6 +84,10 @@ var (
 			Entity: "actions",
 			Action: "read",
 		}},
+		"/litrpc.Firewall/SubscribeActions": {{
+			Entity: "actions",
+			Action: "read",
+		}},
 		"/litrpc.Autopilot/ListAutopilotFeatures": {{
 			Entity: "autopilot",
 			Action: "read",
@@ -72,10 +116,50 @@ var (
 			Entity: "proxy",
 			Action: "read",
 		}},
+		"/litrpc.Proxy/ListListeners": {{
+			Entity: "proxy",
+			Action: "write",
+		}},
+		"/litrpc.Proxy/ListRoutes": {{
+			Entity: "proxy",
+			Action: "write",
+		}},
+		"/litrpc.Proxy/ListMacaroonPermissions": {{
+			Entity: "proxy",
+			Action: "read",
+		}},
+		"/litrpc.Proxy/ReloadTLS": {{
+			Entity: "proxy",
+			Action: "write",
+		}},
 		"/litrpc.Proxy/BakeSuperMacaroon": {{
 			Entity: "supermacaroon",
 			Action: "write",
 		}},
+		"/litrpc.Proxy/RotateSuperMacaroonRootKey": {{
+			Entity: "supermacaroon",
+			Action: "write",
+		}},
+		"/litrpc.ApiKeys/CreateApiKey": {{
+			Entity: "apikey",
+			Action: "write",
+		}},
+		"/litrpc.ApiKeys/ListApiKeys": {{
+			Entity: "apikey",
+			Action: "read",
+		}},
+		"/litrpc.ApiKeys/RevokeApiKey": {{
+			Entity: "apikey",
+			Action: "write",
+		}},
+		"/litrpc.Status/SimulateAuth": {{
+			Entity: "proxy",
+			Action: "write",
+		}},
+		"/litrpc.Status/GetSubServerState": {{
+			Entity: "proxy",
+			Action: "read",
+		}},
 	}
 
 	// whiteListedLNDMethods is a map of all lnd RPC methods that don't
@@ -98,6 +182,42 @@ var (
 		// The Status service must be available at all times, even
 		// before we can check macaroons, so we whitelist it.
 		"/litrpc.Status/SubServerStatus": {},
+
+		// TLS certificates are public, so clients can fetch the chain
+		// to bootstrap their trust without any credentials.
+		"/litrpc.Status/GetTLSCertChain": {},
+
+		// The UI password in the request is the credential of the
+		// step-up, so no other authentication is needed.
+		"/litrpc.Status/StepUpAuth": {},
+
+		// Load balancers and monitoring must be able to check the
+		// health without credentials. The response contains no data
+		// of the daemons.
+		"/litrpc.Status/CheckHealth": {},
+
+		// The UI must be able to show why lnd isn't ready yet before
+		// the user is logged in.
+		"/litrpc.Status/GetLitStatus": {},
+
+		// Attenuating only adds caveats to the macaroon in the
+		// request, which doesn't require any secret.
+		"/litrpc.Sessions/AttenuateMacaroon": {},
+
+		// Each call of a batch is authenticated on its own, so the
+		// batch itself doesn't need any permissions.
+		"/litrpc.Proxy/BatchCall": {},
+
+		// Report jobs are authorized with the permissions of the
+		// faraday method they call.
+		"/litrpc.Proxy/StartReportJob":       {},
+		"/litrpc.Proxy/ReportJobStatus":      {},
+		"/litrpc.Proxy/FetchReportJobResult": {},
+
+		// The macaroon of the call is verified by the method itself
+		// against the permissions it carries, so any valid macaroon
+		// can be used.
+		"/litrpc.Proxy/WhoAmI": {},
 	}
 
 	// lndSubServerNameToTag is a map from the name of an LND subserver to
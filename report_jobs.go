@@ -0,0 +1,280 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultReportJobTTL is the default duration for which the result of
+	// a completed report job is kept.
+	defaultReportJobTTL = time.Hour
+
+	// maxReportJobs is the maximum number of report jobs that are kept at
+	// the same time, including the completed ones.
+	maxReportJobs = 100
+)
+
+// reportJob is a faraday report that is run in the background.
+type reportJob struct {
+	id      string
+	method  string
+	started time.Time
+
+	// The following fields are set once the job completed and must only
+	// be accessed while holding the tracker's mutex.
+	completed time.Time
+	response  []byte
+	err       error
+}
+
+// toProto converts the job to its RPC representation.
+func (j *reportJob) toProto(now time.Time) *litrpc.ReportJob {
+	job := &litrpc.ReportJob{
+		JobId:     j.id,
+		Method:    j.method,
+		State:     litrpc.ReportJobState_JOB_STATE_RUNNING,
+		StartedAt: j.started.Unix(),
+		ElapsedMs: uint64(now.Sub(j.started).Milliseconds()),
+	}
+
+	if j.completed.IsZero() {
+		return job
+	}
+
+	job.State = litrpc.ReportJobState_JOB_STATE_COMPLETED
+	job.CompletedAt = j.completed.Unix()
+	job.ElapsedMs = uint64(j.completed.Sub(j.started).Milliseconds())
+
+	if j.err != nil {
+		s := status.Convert(j.err)
+
+		job.State = litrpc.ReportJobState_JOB_STATE_FAILED
+		job.ErrorCode = uint32(s.Code())
+		job.Error = s.Message()
+	}
+
+	return job
+}
+
+// reportJobTracker keeps track of the report jobs and removes completed jobs
+// once their TTL expired.
+type reportJobTracker struct {
+	ttl time.Duration
+
+	jobs map[string]*reportJob
+	mu   sync.Mutex
+
+	// ctx is cancelled once the tracker is stopped, which aborts all jobs
+	// that are still running.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newReportJobTracker creates a new report job tracker that keeps completed
+// jobs for the given TTL.
+func newReportJobTracker(ttl time.Duration) *reportJobTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &reportJobTracker{
+		ttl:    ttl,
+		jobs:   make(map[string]*reportJob),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// add registers a new job, unless the maximum number of jobs is reached.
+func (t *reportJobTracker) add(job *reportJob) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(time.Now())
+
+	if len(t.jobs) >= maxReportJobs {
+		return status.Errorf(codes.ResourceExhausted, "too many report "+
+			"jobs, at most %d jobs are kept at the same time",
+			maxReportJobs)
+	}
+
+	t.jobs[job.id] = job
+
+	return nil
+}
+
+// complete records the outcome of the given job.
+func (t *reportJobTracker) complete(job *reportJob, response []byte,
+	err error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job.completed = time.Now()
+	job.response = response
+	job.err = err
+}
+
+// get returns the job with the given ID.
+func (t *reportJobTracker) get(id string) (*reportJob, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(time.Now())
+
+	job, ok := t.jobs[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "report job %s not "+
+			"found", id)
+	}
+
+	return job, nil
+}
+
+// prune removes all completed jobs whose TTL expired. The caller must hold the
+// mutex.
+func (t *reportJobTracker) prune(now time.Time) {
+	for id, job := range t.jobs {
+		if !job.completed.IsZero() && now.Sub(job.completed) > t.ttl {
+			delete(t.jobs, id)
+		}
+	}
+}
+
+// stop aborts all running jobs and waits for them to return.
+func (t *reportJobTracker) stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+// authorizeReportJob makes sure the request in the given context is allowed to
+// call the given faraday method, as if it called the method directly.
+func (p *rpcProxy) authorizeReportJob(ctx context.Context,
+	method string) error {
+
+	requiredPermissions, _ := p.permsMgr.URIPermissions(method)
+	_, _, err := p.authenticate(ctx, method, requiredPermissions)
+
+	return err
+}
+
+// StartReportJob starts a faraday report in the background. The report is
+// called over the loopback connection with the credentials of this request,
+// so it is authorized exactly like a direct call.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) StartReportJob(ctx context.Context,
+	req *litrpc.StartReportJobRequest) (*litrpc.ReportJob, error) {
+
+	if p.loopbackConn == nil {
+		return nil, ErrWaitingToStart
+	}
+
+	if !p.permsMgr.IsSubServerURI(subservers.FARADAY, req.Method) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a "+
+			"faraday method", req.Method)
+	}
+
+	if err := p.authorizeReportJob(ctx, req.Method); err != nil {
+		return nil, err
+	}
+
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("unable to create job ID: %v", err)
+	}
+
+	job := &reportJob{
+		id:      hex.EncodeToString(id[:]),
+		method:  req.Method,
+		started: time.Now(),
+	}
+	if err := p.reportJobs.add(job); err != nil {
+		return nil, err
+	}
+
+	// The job must outlive this request, so it runs with the tracker's
+	// context and only takes over the request's credentials.
+	jobCtx := metadata.NewOutgoingContext(
+		p.reportJobs.ctx, p.forwardedCredentials(ctx),
+	)
+
+	p.reportJobs.wg.Add(1)
+	go func() {
+		defer p.reportJobs.wg.Done()
+
+		resp, err := p.invokeLoopback(jobCtx, job.method, req.Request)
+		if err != nil {
+			log.Debugf("Report job %s (%s) failed: %v", job.id,
+				job.method, err)
+		}
+
+		p.reportJobs.complete(job, resp, err)
+	}()
+
+	return job.toProto(time.Now()), nil
+}
+
+// ReportJobStatus returns the current status of a report job.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) ReportJobStatus(ctx context.Context,
+	req *litrpc.ReportJobStatusRequest) (*litrpc.ReportJob, error) {
+
+	job, err := p.reportJobs.get(req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.authorizeReportJob(ctx, job.method); err != nil {
+		return nil, err
+	}
+
+	p.reportJobs.mu.Lock()
+	defer p.reportJobs.mu.Unlock()
+
+	return job.toProto(time.Now()), nil
+}
+
+// FetchReportJobResult returns the response of a completed report job.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) FetchReportJobResult(ctx context.Context,
+	req *litrpc.FetchReportJobResultRequest) (
+	*litrpc.FetchReportJobResultResponse, error) {
+
+	job, err := p.reportJobs.get(req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.authorizeReportJob(ctx, job.method); err != nil {
+		return nil, err
+	}
+
+	p.reportJobs.mu.Lock()
+	defer p.reportJobs.mu.Unlock()
+
+	switch {
+	case job.completed.IsZero():
+		return nil, status.Errorf(codes.FailedPrecondition, "report "+
+			"job %s is still running", job.id)
+
+	case job.err != nil:
+		return nil, job.err
+	}
+
+	return &litrpc.FetchReportJobResultResponse{
+		Response: job.response,
+	}, nil
+}
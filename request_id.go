@@ -0,0 +1,167 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HeaderRequestID is the header field name that can be used by clients
+	// to attach an ID to a request so it can be identified in the logs. If
+	// a request has no ID, LiT generates one. The ID is echoed in the
+	// response headers.
+	HeaderRequestID = "x-request-id"
+
+	// restHeaderRequestID is the canonical HTTP form of the request ID
+	// header that REST clients send and receive.
+	restHeaderRequestID = "X-Request-Id"
+
+	// maxRequestIDLen is the maximum length of a request ID that a client
+	// attached. Longer IDs are replaced, so clients can't flood the logs.
+	maxRequestIDLen = 128
+)
+
+// requestIDFromContext returns the request ID that the client attached to the
+// request, or an empty string if there is none.
+func requestIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	ids := md.Get(HeaderRequestID)
+	if len(ids) == 0 {
+		return ""
+	}
+
+	return ids[0]
+}
+
+// validRequestID returns true if the given request ID that a client attached
+// to a request can be used as it is. Only printable ASCII characters without
+// spaces are allowed, so the ID can't break up a log line.
+func validRequestID(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLen {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID generates a random request ID in the form of a UUID.
+func newRequestID() string {
+	return uuid.NewString()
+}
+
+// ensureHTTPRequestID makes sure the given HTTP request carries a valid
+// request ID header and returns the ID. If the client didn't attach a valid
+// ID, a new one is generated. Since the header is turned into metadata by the
+// gRPC and gRPC web servers, the ID is available to the interceptors too.
+func ensureHTTPRequestID(req *http.Request) string {
+	id := req.Header.Get(HeaderRequestID)
+	if validRequestID(id) {
+		return id
+	}
+
+	id = newRequestID()
+	req.Header.Set(HeaderRequestID, id)
+
+	return id
+}
+
+// withRequestID makes sure the incoming metadata of the given context carries
+// a valid request ID and returns the context together with the ID. If the
+// client didn't attach a valid ID, a new one is generated. The director copies
+// the incoming metadata, so the ID is also passed on to the backend daemon.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	ids := md.Get(HeaderRequestID)
+	if len(ids) == 1 && validRequestID(ids[0]) {
+		return ctx, ids[0]
+	}
+
+	id := newRequestID()
+	md = md.Copy()
+	md.Set(HeaderRequestID, id)
+
+	return metadata.NewIncomingContext(ctx, md), id
+}
+
+// requestIDServerStream wraps a grpc.ServerStream so that its context carries
+// the request ID.
+type requestIDServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context of the stream, including the request ID.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// requestIDUnaryInterceptor is a gRPC interceptor that makes sure each unary
+// request has a request ID and echoes the ID in the response headers.
+func (p *rpcProxy) requestIDUnaryInterceptor(ctx context.Context,
+	req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	ctx, id := withRequestID(ctx)
+
+	// The headers are also sent along with an error, so the client learns
+	// the ID of failed requests too.
+	err := grpc.SetHeader(ctx, metadata.Pairs(HeaderRequestID, id))
+	if err != nil {
+		return nil, fmt.Errorf("unable to set request ID header: %w",
+			err)
+	}
+
+	return handler(ctx, req)
+}
+
+// requestIDStreamInterceptor is a gRPC interceptor that makes sure each
+// streaming request has a request ID and echoes the ID in the response
+// headers. This includes all requests that are forwarded to a backend daemon
+// by the director.
+func (p *rpcProxy) requestIDStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	ctx, id := withRequestID(ss.Context())
+
+	err := ss.SetHeader(metadata.Pairs(HeaderRequestID, id))
+	if err != nil {
+		return fmt.Errorf("unable to set request ID header: %w", err)
+	}
+
+	return handler(srv, &requestIDServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+}
+
+// restOutgoingHeaderMatcher returns the HTTP header that a response header of
+// the gRPC server is sent to REST clients as. The request ID is sent in the
+// same header the client may have sent it in, all other headers get the
+// default metadata prefix.
+func restOutgoingHeaderMatcher(key string) (string, bool) {
+	if key == HeaderRequestID {
+		return restHeaderRequestID, true
+	}
+
+	return restMetadataPrefix + key, true
+}
@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockTransportStream is a grpc.ServerTransportStream that records the
+// headers that are set.
+type mockTransportStream struct {
+	header metadata.MD
+}
+
+func (m *mockTransportStream) Method() string {
+	return "/lnrpc.Lightning/GetInfo"
+}
+
+func (m *mockTransportStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func (m *mockTransportStream) SendHeader(md metadata.MD) error {
+	return m.SetHeader(md)
+}
+
+func (m *mockTransportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+// TestRequestID tests that every request gets a request ID that is passed on
+// to the handler and echoed in the response headers.
+func TestRequestID(t *testing.T) {
+	p := &rpcProxy{}
+	info := &grpc.UnaryServerInfo{FullMethod: "/lnrpc.Lightning/GetInfo"}
+
+	call := func(md metadata.MD) (string, metadata.MD) {
+		stream := &mockTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(
+			context.Background(), stream,
+		)
+		if md != nil {
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+
+		var handlerID string
+		_, err := p.requestIDUnaryInterceptor(
+			ctx, nil, info, func(ctx context.Context,
+				_ interface{}) (interface{}, error) {
+
+				handlerID = requestIDFromContext(ctx)
+				return nil, nil
+			},
+		)
+		require.NoError(t, err)
+
+		return handlerID, stream.header
+	}
+
+	// A valid ID that the client attached is used as it is.
+	id, header := call(metadata.Pairs(HeaderRequestID, "client-id-1"))
+	require.Equal(t, "client-id-1", id)
+	require.Equal(t, []string{"client-id-1"}, header.Get(HeaderRequestID))
+
+	// Requests without an ID or with an invalid one get a new UUID.
+	for _, md := range []metadata.MD{
+		nil,
+		metadata.Pairs(HeaderRequestID, "with space"),
+		metadata.Pairs(HeaderRequestID, strings.Repeat("a", 129)),
+	} {
+		id, header := call(md)
+		_, err := uuid.Parse(id)
+		require.NoError(t, err)
+		require.Equal(t, []string{id}, header.Get(HeaderRequestID))
+	}
+
+	// HTTP requests get an ID header before they are handled, so the gRPC
+	// web and gRPC servers pass it on as metadata.
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+	id = ensureHTTPRequestID(req)
+	require.Equal(t, id, req.Header.Get(HeaderRequestID))
+	require.Equal(t, id, ensureHTTPRequestID(req))
+
+	// The ID survives the translation of REST requests and responses.
+	key, ok := restHeaderMatcher("x-request-id")
+	require.True(t, ok)
+	require.Equal(t, HeaderRequestID, key)
+
+	key, ok = restOutgoingHeaderMatcher(HeaderRequestID)
+	require.True(t, ok)
+	require.Equal(t, "X-Request-Id", key)
+
+	key, ok = restOutgoingHeaderMatcher("other")
+	require.True(t, ok)
+	require.Equal(t, "Grpc-Metadata-other", key)
+}
@@ -0,0 +1,67 @@
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// defaultRestMaxRequestSize is the default maximum size in bytes of the
+	// body of a REST or gRPC web request.
+	defaultRestMaxRequestSize = 1024 * 1024
+)
+
+// withMaxRequestSize returns a handler that answers requests with a body of
+// more than the given number of bytes with 413, before the body is passed on.
+// If the client doesn't announce the size of the body, at most the allowed
+// number of bytes are read to find out. Native gRPC requests are exempt, since
+// gRPC limits the size of their messages itself.
+func withMaxRequestSize(next http.Handler, limit int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody ||
+			isNativeGrpcRequest(r) {
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.ContentLength > limit {
+			rejectRequestSize(w, r, limit)
+			return
+		}
+
+		// The server makes sure a body with a known size isn't longer
+		// than announced. Otherwise we need to read one more byte than
+		// allowed to know whether the body is too large.
+		if r.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+			if err != nil {
+				http.Error(w, "unable to read request body",
+					http.StatusBadRequest)
+				return
+			}
+
+			if int64(len(body)) > limit {
+				rejectRequestSize(w, r, limit)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rejectRequestSize answers a request with a body that is too large.
+func rejectRequestSize(w http.ResponseWriter, r *http.Request, limit int64) {
+	log.Debugf("Rejecting request for %s with a body of more than %d "+
+		"bytes", r.URL.Path, limit)
+
+	http.Error(
+		w, fmt.Sprintf("request body larger than %d bytes", limit),
+		http.StatusRequestEntityTooLarge,
+	)
+}
@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMaxRequestSize tests that request bodies over the limit are rejected
+// before they are passed on, whether their size is announced or not.
+func TestMaxRequestSize(t *testing.T) {
+	const limit = 8
+
+	handler := withMaxRequestSize(http.HandlerFunc(func(
+		w http.ResponseWriter, r *http.Request) {
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(body)
+	}), limit)
+
+	testCases := []struct {
+		name        string
+		body        string
+		chunked     bool
+		contentType string
+		status      int
+	}{{
+		name:   "within limit",
+		body:   "12345678",
+		status: http.StatusOK,
+	}, {
+		name:   "too large",
+		body:   "123456789",
+		status: http.StatusRequestEntityTooLarge,
+	}, {
+		name:    "chunked within limit",
+		body:    "12345678",
+		chunked: true,
+		status:  http.StatusOK,
+	}, {
+		name:    "chunked too large",
+		body:    "123456789",
+		chunked: true,
+		status:  http.StatusRequestEntityTooLarge,
+	}, {
+		name:        "native gRPC",
+		body:        "123456789",
+		contentType: "application/grpc",
+		status:      http.StatusOK,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost, "/v1/getinfo",
+				strings.NewReader(tc.body),
+			)
+			req.ProtoMajor = 2
+			if tc.chunked {
+				req.ContentLength = -1
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				require.Equal(t, tc.body, rec.Body.String())
+			}
+		})
+	}
+}
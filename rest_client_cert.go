@@ -0,0 +1,70 @@
+package terminal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// restClientCertValidity is the validity of the client certificate that the
+// REST proxy presents to the main HTTPS listener. The certificate only lives
+// in memory and is created anew on every start.
+const restClientCertValidity = 10 * 365 * 24 * time.Hour
+
+// newRESTClientCert creates a self-signed TLS client certificate for the REST
+// proxy. If every client of the main HTTPS listener must present a client
+// certificate, the REST proxy, which forwards its requests to that listener,
+// needs one as well. The certificate must be trusted by adding it to the
+// client CAs of the listener. Its key is never written to disk, so no one but
+// the REST proxy can present it.
+func newRESTClientCert() (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate key: %v", err)
+	}
+
+	serialLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, serialLimit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate serial: %v", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{
+				defaultSelfSignedCertOrganization,
+			},
+			CommonName: "litd REST proxy",
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(restClientCertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(
+		rand.Reader, template, template, &key.PublicKey, key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate: %v", err)
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
@@ -0,0 +1,466 @@
+package terminal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// webSocketMacaroonParam is the query parameter that clients can use to
+	// send the hex encoded macaroon when they open a WebSocket to a REST
+	// endpoint. Browsers can't set any custom headers on a WebSocket.
+	webSocketMacaroonParam = "macaroon"
+
+	// webSocketAuthTimeout is the time a client that didn't send any
+	// credentials when it opened a WebSocket has to send them in its first
+	// message.
+	webSocketAuthTimeout = 10 * time.Second
+)
+
+var (
+	// webSocketForwardedHeaders are the header fields of the request that
+	// opens a WebSocket that are passed on to the REST proxy.
+	webSocketForwardedHeaders = []string{
+		"Origin",
+		"Referer",
+		"Authorization",
+		HeaderAPIKey,
+		restMetadataPrefix + HeaderMacaroon,
+	}
+
+	// errWebSocketAuth is returned if the first message of a WebSocket
+	// client that didn't send any credentials isn't an auth message.
+	errWebSocketAuth = errors.New("first message must contain the " +
+		"macaroon")
+)
+
+// webSocketAuthMessage is the first message a client must send if it didn't
+// send any credentials when it opened the WebSocket.
+type webSocketAuthMessage struct {
+	// Macaroon is the hex encoded macaroon to authenticate the call with.
+	Macaroon string `json:"macaroon"`
+}
+
+// restWebSocketProxy serves the REST endpoints over WebSockets, so browsers
+// can consume streaming RPCs, like the invoice subscription, without gRPC web.
+// The first message of the client is the request and every message the RPC
+// streams is sent back as a text frame of JSON. For client streaming RPCs,
+// every message of the client is a request. Other requests are passed on to
+// the REST proxy unchanged.
+//
+// This works like the WebSocket proxy of lnd, but browsers can additionally
+// send the macaroon in a query parameter or as their first message, since
+// they can't set the macaroon header on a WebSocket.
+type restWebSocketProxy struct {
+	backend  http.Handler
+	upgrader *websocket.Upgrader
+
+	// clientStreamingURIs are the patterns of the URIs of client streaming
+	// RPCs, which read more than one message from the client.
+	clientStreamingURIs []*regexp.Regexp
+
+	pingInterval time.Duration
+	pongWait     time.Duration
+}
+
+// newRESTWebSocketProxy creates a new WebSocket proxy in front of the given
+// REST proxy. Only WebSockets from the same origin or from one of the given
+// CORS origins are accepted, since a browser may send the macaroon cookie
+// along. If the ping interval and pong wait are set, the client is pinged
+// regularly and the connection is closed if it doesn't answer in time.
+func newRESTWebSocketProxy(backend http.Handler, origins []string,
+	pingInterval, pongWait time.Duration,
+	clientStreamingURIs []*regexp.Regexp) *restWebSocketProxy {
+
+	p := &restWebSocketProxy{
+		backend: backend,
+		upgrader: &websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				return isWebSocketOriginAllowed(r, origins)
+			},
+		},
+		clientStreamingURIs: clientStreamingURIs,
+	}
+
+	if pingInterval > 0 && pongWait > 0 {
+		p.pingInterval = pingInterval
+		p.pongWait = pongWait
+	}
+
+	return p
+}
+
+// ServeHTTP serves WebSocket upgrade requests and passes all other requests on
+// to the REST proxy.
+//
+// NOTE: This is part of the http.Handler interface.
+func (p *restWebSocketProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		p.backend.ServeHTTP(w, r)
+		return
+	}
+
+	p.serveWebSocket(w, r)
+}
+
+// serveWebSocket upgrades the request to a WebSocket and forwards the messages
+// of the client to the REST proxy and its responses back to the client until
+// either side closes the connection.
+func (p *restWebSocketProxy) serveWebSocket(w http.ResponseWriter,
+	r *http.Request) {
+
+	header, protocol := webSocketHeader(r)
+
+	// The macaroon must not end up in the query of the REST request, the
+	// REST proxy would otherwise try to map it to a request field.
+	target := *r.URL
+	query := target.Query()
+	if macaroon := query.Get(webSocketMacaroonParam); macaroon != "" {
+		header.Set(restMetadataPrefix+HeaderMacaroon, macaroon)
+	}
+	query.Del(webSocketMacaroonParam)
+	target.RawQuery = query.Encode()
+
+	// The WebSocket handshake is always a GET request, so the method of
+	// the REST request can be overwritten.
+	method := r.Method
+	if m := query.Get(lnrpc.MethodOverrideParam); m != "" {
+		method = m
+	}
+
+	// Browsers fail the handshake if the protocol they offered isn't
+	// accepted, so we echo back the one that carries the macaroon.
+	var responseHeader http.Header
+	if protocol != "" {
+		responseHeader = http.Header{
+			lnrpc.HeaderWebSocketProtocol: []string{protocol},
+		}
+	}
+
+	conn, err := p.upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		log.Debugf("Unable to upgrade REST request for %s to "+
+			"WebSocket: %v", r.URL.Path, err)
+		return
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !lnrpc.IsClosedConnError(err) {
+			log.Errorf("Unable to close WebSocket: %v", err)
+		}
+	}()
+	conn.SetReadLimit(lnrpc.MaxWsMsgSize)
+
+	if !hasWebSocketCredentials(header) {
+		macaroon, err := readWebSocketAuth(conn)
+		if err != nil {
+			log.Debugf("Unable to authenticate WebSocket for "+
+				"%s: %v", r.URL.Path, err)
+			closeWebSocket(conn, websocket.ClosePolicyViolation,
+				errWebSocketAuth.Error())
+			return
+		}
+		header.Set(restMetadataPrefix+HeaderMacaroon, macaroon)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	bodyReader, bodyWriter := io.Pipe()
+	req, err := http.NewRequestWithContext(
+		ctx, method, target.String(), bodyReader,
+	)
+	if err != nil {
+		log.Errorf("Unable to create REST request for WebSocket: %v",
+			err)
+		return
+	}
+	req.Header = header
+
+	// The REST proxy passes the IP of the client on for the allowed IP
+	// ranges caveat.
+	req.RemoteAddr = r.RemoteAddr
+
+	respReader, respWriter := io.Pipe()
+	go func() {
+		<-ctx.Done()
+		_ = bodyWriter.CloseWithError(io.EOF)
+		_ = respReader.CloseWithError(ctx.Err())
+	}()
+
+	backendDone := make(chan struct{})
+	go func() {
+		defer cancel()
+		defer close(backendDone)
+		defer func() { _ = respWriter.Close() }()
+
+		p.backend.ServeHTTP(&webSocketResponseWriter{
+			PipeWriter: respWriter,
+			header:     make(http.Header),
+		}, req)
+	}()
+
+	// The pong handler must be in place before we start reading.
+	if p.pingInterval > 0 {
+		p.keepAlive(ctx, conn)
+	}
+
+	// The client's messages are read in their own goroutine, so a read is
+	// always pending for the pong handler, even while the REST proxy is
+	// still busy with the request.
+	payloads := make(chan []byte, 1)
+	go func() {
+		defer cancel()
+		defer close(payloads)
+
+		for {
+			_, payload, err := conn.ReadMessage()
+			if err != nil {
+				log.Tracef("WebSocket for %s closed: %v",
+					r.URL.Path, err)
+				return
+			}
+
+			select {
+			case payloads <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	clientStreaming := p.isClientStreaming(r.URL.Path)
+	go func() {
+		defer cancel()
+
+		bodyDone := false
+		for payload := range payloads {
+			// Only client streaming RPCs read more than one
+			// message, any further ones are ignored for all others.
+			if bodyDone {
+				continue
+			}
+
+			payload = append(payload, '\n')
+			if _, err := bodyWriter.Write(payload); err != nil {
+				return
+			}
+
+			if !clientStreaming {
+				_ = bodyWriter.Close()
+				bodyDone = true
+			}
+		}
+	}()
+
+	// The REST proxy writes newline delimited JSON messages, each one is
+	// sent as its own frame.
+	scanner := bufio.NewScanner(respReader)
+	scanner.Buffer(
+		make([]byte, 0, bufio.MaxScanTokenSize), lnrpc.MaxWsMsgSize,
+	)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		err := conn.WriteMessage(websocket.TextMessage, scanner.Bytes())
+		if err != nil {
+			log.Debugf("Unable to write to WebSocket for %s: %v",
+				r.URL.Path, err)
+			return
+		}
+	}
+
+	// If the REST proxy ended the call, we tell the client that we're
+	// done. Otherwise the client went away and there's no one to tell.
+	ended := scanner.Err() == nil
+	select {
+	case <-backendDone:
+		ended = true
+	default:
+	}
+	if ended {
+		closeWebSocket(conn, websocket.CloseNormalClosure, "")
+	}
+}
+
+// isClientStreaming returns true if the path is the one of a client streaming
+// RPC.
+func (p *restWebSocketProxy) isClientStreaming(path string) bool {
+	for _, pattern := range p.clientStreamingURIs {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keepAlive regularly pings the client until the context is cancelled. The
+// read deadline of the connection is extended every time the client answers,
+// so a client that doesn't answer in time breaks the connection.
+func (p *restWebSocketProxy) keepAlive(ctx context.Context,
+	conn *websocket.Conn) {
+
+	_ = conn.SetReadDeadline(time.Now().Add(p.pingInterval + p.pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(
+			time.Now().Add(p.pingInterval + p.pongWait),
+		)
+	})
+
+	go func() {
+		ticker := time.NewTicker(p.pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				err := conn.WriteControl(
+					websocket.PingMessage,
+					[]byte(lnrpc.PingContent),
+					time.Now().Add(p.pongWait),
+				)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// webSocketHeader returns the header fields of the request that opens a
+// WebSocket that are passed on to the REST proxy. Like lnd, we also accept the
+// macaroon as a WebSocket protocol of the form "Grpc-Metadata-Macaroon+<hex>",
+// the only header field browsers can set. That protocol is returned as well.
+func webSocketHeader(r *http.Request) (http.Header, string) {
+	header := make(http.Header)
+	for _, key := range webSocketForwardedHeaders {
+		if value := r.Header.Get(key); value != "" {
+			header.Set(key, value)
+		}
+	}
+
+	offered := r.Header.Get(lnrpc.HeaderWebSocketProtocol)
+	for _, protocol := range strings.Split(offered, ",") {
+		protocol = strings.TrimSpace(protocol)
+		name, value, ok := strings.Cut(
+			protocol, lnrpc.WebSocketProtocolDelimiter,
+		)
+		key := restMetadataPrefix + HeaderMacaroon
+		if ok && textproto.CanonicalMIMEHeaderKey(name) == key {
+			header.Set(key, value)
+
+			return header, protocol
+		}
+	}
+
+	return header, ""
+}
+
+// hasWebSocketCredentials returns true if the header fields of the request
+// that opened a WebSocket contain any credentials.
+func hasWebSocketCredentials(header http.Header) bool {
+	return header.Get(restMetadataPrefix+HeaderMacaroon) != "" ||
+		header.Get(HeaderAPIKey) != "" ||
+		header.Get("Authorization") != ""
+}
+
+// readWebSocketAuth reads the first message of a client that didn't send any
+// credentials when it opened the WebSocket and returns the macaroon in it.
+func readWebSocketAuth(conn *websocket.Conn) (string, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(webSocketAuthTimeout))
+	defer func() {
+		_ = conn.SetReadDeadline(time.Time{})
+	}()
+
+	_, payload, err := conn.ReadMessage()
+	if err != nil {
+		return "", err
+	}
+
+	var msg webSocketAuthMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return "", errWebSocketAuth
+	}
+	if msg.Macaroon == "" {
+		return "", errWebSocketAuth
+	}
+
+	return msg.Macaroon, nil
+}
+
+// isWebSocketOriginAllowed returns true if the request that opens a WebSocket
+// comes from the same origin, from one of the given CORS origins or from a
+// client that isn't a browser and doesn't send an origin.
+func isWebSocketOriginAllowed(r *http.Request, origins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+
+	return isAllowedOrigin(origins, origin)
+}
+
+// closeWebSocket sends a close message with the given code and reason to the
+// client.
+func closeWebSocket(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(
+		websocket.CloseMessage, websocket.FormatCloseMessage(
+			code, reason,
+		), time.Now().Add(time.Second),
+	)
+}
+
+// webSocketResponseWriter is the http.ResponseWriter the REST proxy writes the
+// responses of a WebSocket call to. They are read from the other end of the
+// pipe.
+type webSocketResponseWriter struct {
+	*io.PipeWriter
+
+	header http.Header
+}
+
+// Header returns the header fields of the response, which aren't sent to the
+// client.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (w *webSocketResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader ignores the status code, errors are sent as JSON messages.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (w *webSocketResponseWriter) WriteHeader(int) {
+}
+
+// Flush does nothing, every write goes to the pipe right away. The REST proxy
+// only streams responses to writers that can be flushed.
+//
+// NOTE: This is part of the http.Flusher interface.
+func (w *webSocketResponseWriter) Flush() {
+}
@@ -0,0 +1,109 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRESTWebSocketProxy tests that streamed REST responses are sent as
+// WebSocket messages and that the macaroon is accepted in the query, in the
+// protocol header and as the first message.
+func TestRESTWebSocketProxy(t *testing.T) {
+	macaroonHeader := restMetadataPrefix + HeaderMacaroon
+
+	// The fake REST proxy streams the macaroon, the query and the request
+	// back as separate messages.
+	backend := http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+
+		body, _ := io.ReadAll(r.Body)
+		_, _ = fmt.Fprintf(w, "%q\n%q\n%q\n", r.Header.Get(
+			macaroonHeader,
+		), r.URL.RawQuery, strings.TrimSpace(string(body)))
+	})
+
+	proxy := newRESTWebSocketProxy(backend, nil, 0, 0, nil)
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") +
+		"/v2/invoices/subscribe"
+
+	receive := func(conn *websocket.Conn) []string {
+		var messages []string
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				require.True(t, websocket.IsCloseError(
+					err, websocket.CloseNormalClosure,
+				), err)
+
+				return messages
+			}
+			messages = append(messages, string(msg))
+		}
+	}
+
+	// The macaroon in the query isn't passed on in the query.
+	conn, _, err := websocket.DefaultDialer.Dial(
+		wsURL+"?macaroon=0201&method=POST", nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(
+		`{"add_index":1}`,
+	)))
+	require.Equal(t, []string{
+		`"0201"`, `"method=POST"`, `"{\"add_index\":1}"`,
+	}, receive(conn))
+
+	// The macaroon in the protocol header is used and the protocol is
+	// accepted.
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, http.Header{
+		"Sec-Websocket-Protocol": {"Grpc-Metadata-Macaroon+0202"},
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t, "Grpc-Metadata-Macaroon+0202",
+		resp.Header.Get("Sec-Websocket-Protocol"),
+	)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(
+		`{}`,
+	)))
+	require.Equal(t, []string{`"0202"`, `""`, `"{}"`}, receive(conn))
+
+	// Without any credentials, the first message must carry the macaroon.
+	conn, _, err = websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(
+		`{"macaroon":"0203"}`,
+	)))
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(
+		`{}`,
+	)))
+	require.Equal(t, []string{`"0203"`, `""`, `"{}"`}, receive(conn))
+
+	// Anything else as the first message closes the connection.
+	conn, _, err = websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(
+		`{}`,
+	)))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(
+		err, websocket.ClosePolicyViolation,
+	), err)
+
+	// WebSockets from foreign origins are rejected.
+	_, resp, err = websocket.DefaultDialer.Dial(wsURL, http.Header{
+		"Origin": {"https://evil.example.com"},
+	})
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
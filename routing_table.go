@@ -0,0 +1,124 @@
+package terminal
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// routeTargetLit is the target of the methods LiT handles itself.
+	routeTargetLit = "lit"
+
+	// routeTargetIntegrated is the target of the methods of daemons that
+	// run inside of LiT.
+	routeTargetIntegrated = "integrated"
+
+	// routeTargetRemote is the target of the methods that are forwarded to
+	// a remote daemon.
+	routeTargetRemote = "remote"
+
+	// routeTargetLnd is the target of the methods that are forwarded to
+	// lnd.
+	routeTargetLnd = "lnd"
+)
+
+// ListRoutes returns the live routing table of the proxy: every known method
+// URI with the daemon it is routed to and whether calls to it are currently
+// accepted.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) ListRoutes(_ context.Context,
+	_ *litrpc.ListRoutesRequest) (*litrpc.ListRoutesResponse, error) {
+
+	// The methods of explicitly disabled daemons aren't known to the
+	// permission manager, so we add them separately.
+	uris := p.permsMgr.URIs()
+	uris = append(uris, p.subServerMgr.DisabledURIs()...)
+	sort.Strings(uris)
+
+	routes := make([]*litrpc.MethodRoute, 0, len(uris))
+	for _, uri := range uris {
+		routes = append(routes, p.routeForURI(uri))
+	}
+
+	return &litrpc.ListRoutesResponse{
+		Routes: routes,
+	}, nil
+}
+
+// routeForURI returns the route of the given method URI, following the same
+// decisions as the director.
+func (p *rpcProxy) routeForURI(uri string) *litrpc.MethodRoute {
+	route := &litrpc.MethodRoute{
+		Method:      uri,
+		Whitelisted: p.permsMgr.IsWhiteListedURL(uri),
+	}
+
+	// Methods of daemons that are disabled in LiT are never routed
+	// anywhere.
+	if disabled, system := p.subServerMgr.HandlesDisabled(uri); disabled {
+		err := daemonDisabledError(system)
+		route.Daemon = system
+		route.UnavailableReason = errMessage(err)
+
+		return route
+	}
+
+	// Neither are methods of lnd sub-servers that lnd isn't compiled with.
+	if _, disabled := p.permsMgr.DisabledLndSubServer(uri); disabled {
+		err := p.unavailableURIError(uri)
+		route.Daemon = subservers.LND
+		route.Target = routeTargetLnd
+		route.UnavailableReason = errMessage(err)
+
+		return route
+	}
+
+	system, err := p.subSystemForURI(uri)
+	if err != nil {
+		route.UnavailableReason = errMessage(err)
+		return route
+	}
+	route.Daemon = system
+
+	handled, conn, connErr := p.subServerMgr.GetRemoteConn(uri)
+	switch {
+	case handled:
+		route.Target = routeTargetRemote
+
+	case system == subservers.LND:
+		route.Target = routeTargetLnd
+		route.ReadReplicas = p.lndReplicas != nil &&
+			p.isReadOnlyLndURI(uri)
+
+	case system == subservers.LIT || system == subservers.ACCOUNTS:
+		route.Target = routeTargetLit
+
+	default:
+		route.Target = routeTargetIntegrated
+	}
+
+	if err := p.checkSubSystemStarted(uri); err != nil {
+		route.UnavailableReason = errMessage(err)
+		return route
+	}
+
+	if handled && conn == nil {
+		route.UnavailableReason = connErr.Error()
+		return route
+	}
+
+	route.Available = true
+
+	return route
+}
+
+// errMessage returns the message of the given error without the gRPC status
+// code prefix.
+func errMessage(err error) string {
+	return status.Convert(err).Message()
+}
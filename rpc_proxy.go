@@ -8,10 +8,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/lightninglabs/lightning-terminal/firewall"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/lightninglabs/lightning-terminal/perms"
 	"github.com/lightninglabs/lightning-terminal/session"
@@ -23,7 +25,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
 )
 
@@ -33,6 +38,10 @@ const (
 	// HeaderMacaroon is the HTTP header field name that is used to send
 	// the macaroon.
 	HeaderMacaroon = "Macaroon"
+
+	// restMetadataPrefix is the prefix of the HTTP header field names
+	// that the REST proxy forwards to the gRPC server as metadata.
+	restMetadataPrefix = "Grpc-Metadata-"
 )
 
 var (
@@ -69,14 +78,40 @@ func (e *proxyErr) Unwrap() error {
 func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 	superMacValidator session.SuperMacaroonValidator,
 	permsMgr *perms.Manager, subServerMgr *subservers.Manager,
-	statusMgr *litstatus.Manager) *rpcProxy {
+	statusMgr *litstatus.Manager,
+	lndRecovery *lndRecoveryMonitor,
+	lndStartup *lndStartupTracker) (*rpcProxy, error) {
 
 	// The gRPC web calls are protected by HTTP basic auth which is defined
 	// by base64(username:password). Because we only have a password, we
-	// just use base64(password:password).
-	basicAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(
-		"%s:%s", cfg.UIPassword, cfg.UIPassword,
-	)))
+	// just use base64(password:password). If only the hash of the password
+	// is configured, the password is checked against the hash instead.
+	var basicAuth string
+	if cfg.UIPassword != "" {
+		basicAuth = base64.StdEncoding.EncodeToString([]byte(
+			fmt.Sprintf("%s:%s", cfg.UIPassword, cfg.UIPassword),
+		))
+	}
+
+	clientAddrs, err := newClientAddrForwarder()
+	if err != nil {
+		return nil, err
+	}
+
+	uiPasswordHashes, err := newPasswordHashVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	uiUsers, err := newUIUserVerifier(cfg.uiUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	thirdPartyProofKey, err := newThirdPartyProofKey()
+	if err != nil {
+		return nil, err
+	}
 
 	// Set up the final gRPC server that will serve gRPC web to the browser
 	// and translate all incoming gRPC web calls into native gRPC that are
@@ -84,34 +119,146 @@ func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 	// need to be addressed with a custom director that just takes care of a
 	// few HTTP header fields.
 	p := &rpcProxy{
-		cfg:               cfg,
-		basicAuth:         basicAuth,
-		permsMgr:          permsMgr,
-		macValidator:      validator,
-		superMacValidator: superMacValidator,
-		subServerMgr:      subServerMgr,
-		statusMgr:         statusMgr,
-	}
-	p.grpcServer = grpc.NewServer(
+		cfg:                cfg,
+		basicAuth:          basicAuth,
+		permsMgr:           permsMgr,
+		macValidator:       validator,
+		superMacValidator:  superMacValidator,
+		subServerMgr:       subServerMgr,
+		statusMgr:          statusMgr,
+		streams:            newStreamTracker(),
+		authFailures:       newAuthFailureTracker(),
+		passwordLimiter:    newPasswordLimiter(cfg.UIPasswordLimit),
+		uiPasswordHashes:   uiPasswordHashes,
+		uiUsers:            uiUsers,
+		scopeMacaroons:     make(map[string][]byte),
+		apiKeys:            newAPIKeyStore(),
+		reportJobs:         newReportJobTracker(cfg.ReportJobTTL),
+		stepUpTokens:       newStepUpTracker(),
+		listeners:          newListenerRegistry(),
+		lndRecovery:        lndRecovery,
+		lndStartup:         lndStartup,
+		clientAddrs:        clientAddrs,
+		thirdPartyProofKey: thirdPartyProofKey,
+	}
+	if cfg.JWT.enabled() {
+		p.jwt = newJWTVerifier(cfg.JWT)
+	}
+	p.authBackends = newAuthBackends(cfg, p)
+
+	// Every request gets a request ID before anything else happens, so
+	// all log lines of the request and the forwarded call carry it. The
+	// active requests are counted next, so they can be drained on
+	// shutdown. If tracing, metrics or the slow request log are enabled,
+	// their interceptors must come next so that they also cover requests
+	// that are rejected by the auth interceptors.
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		p.requestIDStreamInterceptor,
+		p.activeRequestsStreamInterceptor,
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		p.requestIDUnaryInterceptor,
+		p.activeRequestsUnaryInterceptor,
+	}
+	if cfg.isTracingEnabled() {
+		streamInterceptors = append(
+			streamInterceptors, p.tracingStreamInterceptor,
+		)
+		unaryInterceptors = append(
+			unaryInterceptors, p.tracingUnaryInterceptor,
+		)
+	}
+	if cfg.isMetricsEnabled() {
+		p.metrics = newRPCMetrics(cfg.Prometheus, statusMgr)
+		streamInterceptors = append(
+			streamInterceptors, p.metricsStreamInterceptor,
+		)
+		unaryInterceptors = append(
+			unaryInterceptors, p.metricsUnaryInterceptor,
+		)
+	}
+	if cfg.SlowRequestThreshold > 0 {
+		streamInterceptors = append(
+			streamInterceptors, p.slowRequestStreamInterceptor,
+		)
+		unaryInterceptors = append(
+			unaryInterceptors, p.slowRequestUnaryInterceptor,
+		)
+	}
+	if cfg.StreamLimits.enabled() {
+		streamInterceptors = append(
+			streamInterceptors, p.streamLimitStreamInterceptor,
+		)
+	}
+	streamInterceptors = append(
+		streamInterceptors, p.StreamServerInterceptor,
+	)
+
+	// Responses are only cached for authenticated calls, so the cache key
+	// always includes a valid credential.
+	if cfg.StaleOnError.Enable {
+		p.staleResponses = newStaleResponseCache(
+			cfg.StaleOnError.MaxStaleness,
+		)
+		streamInterceptors = append(
+			streamInterceptors, p.staleResponseStreamInterceptor,
+		)
+	}
+
+	// The reconnect interceptor must come last, so it only sees the errors
+	// of calls that were actually forwarded to a backend.
+	if cfg.BackendReconnect.Notify {
+		streamInterceptors = append(
+			streamInterceptors, p.backendReconnectStreamInterceptor,
+		)
+	}
+	unaryInterceptors = append(unaryInterceptors, p.UnaryServerInterceptor)
+
+	serverOpts := []grpc.ServerOption{
 		// From the grpxProxy doc: This codec is *crucial* to the
 		// functioning of the proxy.
 		grpc.CustomCodec(grpcProxy.Codec()), // nolint:staticcheck
-		grpc.ChainStreamInterceptor(p.StreamServerInterceptor),
-		grpc.ChainUnaryInterceptor(p.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.UnknownServiceHandler(
 			grpcProxy.TransparentHandler(p.makeDirector(true)),
 		),
-	)
+	}
+	serverOpts = append(serverOpts, cfg.Keepalive.serverOpts()...)
+	p.grpcServer = grpc.NewServer(serverOpts...)
 
 	// Create the gRPC web proxy that wraps the just created grpcServer and
 	// converts the browser's gRPC web calls into native gRPC.
+	// The calls are served by serveGrpcWeb, which cancels WebSocket
+	// streams once their client disconnects.
+	// Browser apps served from one of the origins configured with
+	// restcors may call the proxy as well. Without any, only the origin
+	// of the UI itself is allowed.
 	options := []grpcweb.Option{
 		grpcweb.WithWebsockets(true),
-		grpcweb.WithWebsocketPingInterval(2 * time.Minute),
+		grpcweb.WithWebsocketPingInterval(cfg.WebsocketPingInterval),
 		grpcweb.WithCorsForRegisteredEndpointsOnly(false),
+		grpcweb.WithEndpointsFunc(func() []string {
+			return grpcweb.ListGRPCResources(p.grpcServer)
+		}),
+		grpcweb.WithOriginFunc(func(origin string) bool {
+			return isAllowedOrigin(cfg.RestCORS, origin)
+		}),
+		grpcweb.WithWebsocketOriginFunc(func(req *http.Request) bool {
+			host, err := grpcweb.WebsocketRequestOrigin(req)
+			if err == nil && host == req.Host {
+				return true
+			}
+
+			return isAllowedOrigin(
+				cfg.RestCORS, req.Header.Get("Origin"),
+			)
+		}),
 	}
-	p.grpcWebProxy = grpcweb.WrapServer(p.grpcServer, options...)
-	return p
+	p.grpcWebProxy = grpcweb.WrapHandler(
+		http.HandlerFunc(p.serveGrpcWeb), options...,
+	)
+	return p, nil
 }
 
 // rpcProxy is an RPC proxy server that can take any native gRPC, grpc-web or
@@ -156,11 +303,16 @@ func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 //	                                +---------------------+
 type rpcProxy struct {
 	litrpc.UnimplementedProxyServer
+	litrpc.UnimplementedApiKeysServer
 
 	// started is set to 1 once the rpcProxy has successfully started. It
 	// must only ever be used atomically.
 	started int32
 
+	// activeRequests is the number of requests and streams that are
+	// currently being handled. It must only ever be used atomically.
+	activeRequests int64
+
 	cfg          *Config
 	basicAuth    string
 	permsMgr     *perms.Manager
@@ -172,23 +324,150 @@ type rpcProxy struct {
 	macValidator      macaroons.MacaroonValidator
 	superMacValidator session.SuperMacaroonValidator
 
-	superMacaroon string
+	// superMacaroon is LiT's own super macaroon. It must only be accessed
+	// through getSuperMacaroon and setSuperMacaroon, since it is replaced
+	// when the super macaroon root key is rotated.
+	superMacaroon    string
+	superMacaroonMtx sync.RWMutex
+
+	// rotateRootKey rotates the super macaroon root key.
+	rotateRootKey rotateRootKeyFn
+
+	// tlsReloader reloads the TLS certificate of the main HTTPS listener.
+	// It is nil if the certificate isn't loaded from files.
+	tlsReloader *tlsCertReloader
+
+	// sessionDB is used to look up the stream limit of sessions.
+	sessionDB session.Store
+
+	// streams keeps track of the active streams of each session.
+	streams *streamTracker
+
+	// thirdPartyProofKey signs the proof caveats that are added to
+	// macaroons once the discharges of their third party caveats were
+	// verified. It is only kept in memory and shared with lnd's third
+	// party middleware.
+	thirdPartyProofKey []byte
+
+	// authFailures keeps track of the recent authentication failures of
+	// each session.
+	authFailures *authFailureTracker
+
+	// passwordLimiter locks out clients that repeatedly send an incorrect
+	// UI password.
+	passwordLimiter *passwordLimiter
+
+	// uiPasswordHashes checks the UI password against its configured
+	// hash.
+	uiPasswordHashes *passwordHashVerifier
+
+	// uiUsers checks the passwords of the named UI users.
+	uiUsers *uiUserVerifier
+
+	// scopeMacaroons are the macaroons that were baked for the scopes of
+	// the UI users, keyed by scope.
+	scopeMacaroons    map[string][]byte
+	scopeMacaroonsMtx sync.Mutex
+
+	// apiKeys holds the API keys that can be used instead of a macaroon.
+	apiKeys *apiKeyStore
+
+	// jwt checks JWT bearer tokens. It is nil if JWT authentication is
+	// not configured.
+	jwt *jwtVerifier
+
+	// authBackends are the authentication backends in the order they are
+	// tried in.
+	authBackends []authBackend
+
+	// revokeSession is used to automatically revoke sessions that fail
+	// authentication too often.
+	revokeSession revokeSessionFn
+
+	// metrics holds the Prometheus metrics of the handled requests. This
+	// is nil if metrics are disabled.
+	metrics *rpcMetrics
 
 	lndConn *grpc.ClientConn
 
+	// lndReplicas are the lnd read replicas that read-only requests are
+	// distributed across. This is nil if no replicas are configured.
+	lndReplicas *lndReplicaSet
+
 	grpcServer   *grpc.Server
 	grpcWebProxy *grpcweb.WrappedGrpcServer
+
+	// loopbackListener and loopbackConn connect the proxy to its own gRPC
+	// server in memory. They are used to execute the calls of a batch.
+	loopbackListener *bufconn.Listener
+	loopbackConn     *grpc.ClientConn
+
+	// sessionUsage counts the requests of each session. It may be nil, in
+	// which case requests aren't counted.
+	sessionUsage *sessionUsageTracker
+
+	// reportJobs keeps track of the faraday reports that run in the
+	// background.
+	reportJobs *reportJobTracker
+
+	// stepUpTokens keeps track of the issued step-up tokens.
+	stepUpTokens *stepUpTracker
+
+	// listeners keeps track of all listeners LiT serves on.
+	listeners *listenerRegistry
+
+	// staleResponses holds the responses that are served if a backend
+	// daemon is unavailable. It is nil if this is disabled.
+	staleResponses *staleResponseCache
+
+	// lndRecovery tracks lnd's wallet recovery while LiT waits for lnd to
+	// be ready.
+	lndRecovery *lndRecoveryMonitor
+
+	// lndStartup tracks the startup state of lnd, so calls to lnd can be
+	// rejected with an error that names the state lnd is in.
+	lndStartup *lndStartupTracker
+
+	// health probes the sub-servers for the health report.
+	health healthChecker
+
+	// clientAddrs passes on the IP of the client of the requests that LiT
+	// makes to its own gRPC server on behalf of a client.
+	clientAddrs *clientAddrForwarder
 }
 
-// bakeSuperMac can be used to bake a new super macaroon.
-type bakeSuperMac func(ctx context.Context, rootKeyID uint32) (string, error)
+// bakeSuperMac can be used to bake a new super macaroon with the given
+// permissions and additional caveats.
+type bakeSuperMac func(ctx context.Context, rootKeyID uint32,
+	perms []bakery.Op, caveats []macaroon.Caveat) (string, error)
+
+// rotateRootKeyFn rotates the super macaroon root key and returns the ID of
+// the new root key and the IDs of the root keys that were rotated out.
+type rotateRootKeyFn func(ctx context.Context) (uint64, []uint64, error)
 
 // Start creates initial connection to lnd.
 func (p *rpcProxy) Start(lndConn *grpc.ClientConn,
-	bakeSuperMac bakeSuperMac) error {
+	bakeSuperMac bakeSuperMac, rotateRootKey rotateRootKeyFn,
+	sessionDB session.Store, revokeSession revokeSessionFn,
+	lndReplicas *lndReplicaSet, sessionUsage *sessionUsageTracker) error {
 
 	p.lndConn = lndConn
+	p.rotateRootKey = rotateRootKey
+	p.lndReplicas = lndReplicas
+	p.sessionUsage = sessionUsage
 	p.bakeSuperMac = bakeSuperMac
+	p.sessionDB = sessionDB
+	p.revokeSession = revokeSession
+
+	if err := p.apiKeys.load(sessionDB); err != nil {
+		return err
+	}
+
+	// All services are registered with the gRPC server by now, so we can
+	// start serving it on the loopback listener.
+	if err := p.startLoopback(); err != nil {
+		return err
+	}
 
 	atomic.CompareAndSwapInt32(&p.started, 0, 1)
 
@@ -203,6 +482,16 @@ func (p *rpcProxy) hasStarted() bool {
 
 // Stop shuts down the lnd connection.
 func (p *rpcProxy) Stop() error {
+	// The report jobs use the loopback connection, so they need to be
+	// stopped first.
+	p.reportJobs.stop()
+
+	if p.loopbackConn != nil {
+		if err := p.loopbackConn.Close(); err != nil {
+			log.Errorf("Error closing loopback connection: %v", err)
+		}
+	}
+
 	p.grpcServer.Stop()
 
 	return nil
@@ -245,7 +534,37 @@ func (p *rpcProxy) BakeSuperMacaroon(ctx context.Context,
 		return nil, ErrWaitingToStart
 	}
 
-	superMac, err := p.bakeSuperMac(ctx, req.RootKeyIdSuffix)
+	macPerms, err := p.superMacaroonPermissions(
+		req.ReadOnly, req.Permissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var caveats []macaroon.Caveat
+	if len(req.AllowedIpRanges) > 0 {
+		ranges, err := firewall.ParseIPRanges(req.AllowedIpRanges)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(firewall.IPRangesToCaveat(ranges)),
+		})
+	}
+
+	thirdPartyCaveats, err := p.cfg.ThirdPartyAuth.thirdPartyCaveats(
+		req.ThirdPartyCaveats,
+	)
+	if err != nil {
+		return nil, err
+	}
+	caveats = append(caveats, thirdPartyCaveats...)
+
+	superMac, err := p.bakeSuperMac(
+		ctx, req.RootKeyIdSuffix, macPerms, caveats,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +574,88 @@ func (p *rpcProxy) BakeSuperMacaroon(ctx context.Context,
 	}, nil
 }
 
+// superMacaroonPermissions returns the permissions of a super macaroon that
+// either contains the read permissions of all active daemons, the given
+// permissions or, if neither is requested, all permissions of all active
+// daemons.
+func (p *rpcProxy) superMacaroonPermissions(readOnly bool,
+	perms []*litrpc.MacaroonPermission) ([]bakery.Op, error) {
+
+	var macPerms []bakery.Op
+	switch {
+	case readOnly && len(perms) > 0:
+		return nil, status.Error(codes.InvalidArgument, "read_only "+
+			"and permissions can't be combined")
+
+	case readOnly:
+		macPerms = p.permsMgr.ActivePermissions(true)
+
+	case len(perms) > 0:
+		for _, perm := range perms {
+			err := validatePermission(
+				p.permsMgr, perm.Entity, perm.Action,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			macPerms = append(macPerms, bakery.Op{
+				Entity: perm.Entity,
+				Action: perm.Action,
+			})
+		}
+
+	default:
+		macPerms = p.permsMgr.ActivePermissions(false)
+	}
+
+	return macPerms, nil
+}
+
+// RotateSuperMacaroonRootKey invalidates all super macaroons that were baked
+// so far and makes a new root key the current super macaroon root key.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) RotateSuperMacaroonRootKey(ctx context.Context,
+	_ *litrpc.RotateSuperMacaroonRootKeyRequest) (
+	*litrpc.RotateSuperMacaroonRootKeyResponse, error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	rootKeyID, stale, err := p.rotateRootKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The macaroons of the UI users and the API keys were baked with the
+	// old root key.
+	p.resetScopeMacaroons()
+	p.apiKeys.resetMacaroons()
+
+	return &litrpc.RotateSuperMacaroonRootKeyResponse{
+		RootKeyId:       rootKeyID,
+		StaleRootKeyIds: stale,
+	}, nil
+}
+
+// getSuperMacaroon returns LiT's own super macaroon, if it was baked already.
+func (p *rpcProxy) getSuperMacaroon() string {
+	p.superMacaroonMtx.RLock()
+	defer p.superMacaroonMtx.RUnlock()
+
+	return p.superMacaroon
+}
+
+// setSuperMacaroon replaces LiT's own super macaroon.
+func (p *rpcProxy) setSuperMacaroon(superMacaroon string) {
+	p.superMacaroonMtx.Lock()
+	defer p.superMacaroonMtx.Unlock()
+
+	p.superMacaroon = superMacaroon
+}
+
 // isHandling checks if the specified request is something to be handled by lnd
 // or any of the attached sub daemons. If true is returned, the call was handled
 // by the RPC proxy and the caller MUST NOT handle it again. If false is
@@ -263,11 +664,22 @@ func (p *rpcProxy) isHandling(resp http.ResponseWriter,
 	req *http.Request) bool {
 
 	// gRPC web requests are easy to identify. Send them to the gRPC
-	// web proxy.
+	// web proxy. This includes the CORS pre-flight requests of gRPC web
+	// calls, which the proxy answers itself.
+	// The request ID header is turned into metadata by the gRPC web and
+	// gRPC servers, so the interceptors use the same ID.
+	ensureHTTPRequestID(req)
+
 	if p.grpcWebProxy.IsGrpcWebRequest(req) ||
-		p.grpcWebProxy.IsGrpcWebSocketRequest(req) {
+		p.grpcWebProxy.IsGrpcWebSocketRequest(req) ||
+		p.grpcWebProxy.IsAcceptableGrpcCorsRequest(req) {
 
-		log.Infof("Handling gRPC web request: %s", req.URL.Path)
+		if p.grpcWebProxy.IsGrpcWebSocketRequest(req) {
+			req = markWebsocketRequest(req)
+		}
+
+		httpRequestLog(req).Infof("Handling gRPC web request: %s",
+			req.URL.Path)
 		p.grpcWebProxy.ServeHTTP(resp, req)
 
 		return true
@@ -276,7 +688,8 @@ func (p *rpcProxy) isHandling(resp http.ResponseWriter,
 	// Normal gRPC requests are also easy to identify. These we can
 	// send directly to the lnd proxy's gRPC server.
 	if isGrpcRequest(req) {
-		log.Infof("Handling gRPC request: %s", req.URL.Path)
+		httpRequestLog(req).Infof("Handling gRPC request: %s",
+			req.URL.Path)
 		p.grpcServer.ServeHTTP(resp, req)
 
 		return true
@@ -301,13 +714,83 @@ func (p *rpcProxy) makeDirector(allowLitRPC bool) func(ctx context.Context,
 		mdCopy := md.Copy()
 		delete(mdCopy, "connection")
 
+		// The forwarded client IP carries a token that must not leave
+		// LiT.
+		delete(mdCopy, clientAddrMetadataKey)
+
+		// Propagate the trace context of the request to the backend
+		// daemon. This is a no-op if tracing isn't enabled.
+		injectTraceContext(ctx, mdCopy)
+
 		outCtx := metadata.NewOutgoingContext(ctx, mdCopy)
 
-		// Is there a basic auth or super macaroon set?
+		// Is there a basic auth or super macaroon set? If the request
+		// went through our interceptors, we know which authentication
+		// backend authenticated it and only use its credential.
+		authBackend := authBackendFromContext(ctx)
 		authHeaders := md.Get("authorization")
 		macHeader := md.Get(HeaderMacaroon)
 		switch {
-		case len(authHeaders) == 1 && !p.cfg.DisableUI:
+		// A macaroon that the client sent is never replaced, so a
+		// client certificate can't widen its permissions.
+		case authBackend == AuthBackendMTLS && len(macHeader) == 0:
+			macBytes, err := p.mtlsMacaroon(requestURI)
+			if err != nil {
+				return outCtx, nil, err
+			}
+			if len(macBytes) > 0 {
+				mdCopy.Set(HeaderMacaroon, hex.EncodeToString(
+					macBytes,
+				))
+			}
+
+		case authBackend == AuthBackendAPIKey:
+			// The API key must not leave LiT, the backend gets the
+			// super macaroon of the key instead.
+			delete(mdCopy, apiKeyMetadataKey)
+
+			key, err := p.requestAPIKey(ctx)
+			if err != nil {
+				return outCtx, nil, err
+			}
+			superMac, err := p.apiKeyMacaroon(ctx, key)
+			if err != nil {
+				return outCtx, nil, err
+			}
+			mdCopy.Set(HeaderMacaroon, superMac)
+
+			// If the request goes to a remote daemon, the super
+			// macaroon must be converted to its macaroon.
+			macBytes, err := p.convertSuperMacaroon(
+				ctx, superMac, requestURI,
+			)
+			if err != nil {
+				return outCtx, nil, err
+			}
+			if len(macBytes) > 0 {
+				mdCopy.Set(HeaderMacaroon, hex.EncodeToString(
+					macBytes,
+				))
+			}
+
+		case authBackend == AuthBackendJWT:
+			// The token must not leave LiT, the backend gets the
+			// macaroon of the token's scope instead.
+			delete(mdCopy, "authorization")
+
+			macBytes, err := p.requestJWTMacaroon(ctx, requestURI)
+			if err != nil {
+				return outCtx, nil, err
+			}
+			if len(macBytes) > 0 {
+				mdCopy.Set(HeaderMacaroon, hex.EncodeToString(
+					macBytes,
+				))
+			}
+
+		case (authBackend == "" || authBackend == AuthBackendPassword) &&
+			len(authHeaders) == 1 && !p.cfg.DisableUI:
+
 			macBytes, err := p.basicAuthToMacaroon(
 				authHeaders[0], requestURI, nil,
 			)
@@ -320,7 +803,10 @@ func (p *rpcProxy) makeDirector(allowLitRPC bool) func(ctx context.Context,
 				))
 			}
 
-		case len(macHeader) == 1 && session.IsSuperMacaroon(macHeader[0]):
+		case (authBackend == "" || authBackend == AuthBackendMacaroon) &&
+			len(macHeader) == 1 &&
+			session.IsSuperMacaroon(macHeader[0]):
+
 			// If we have a macaroon, and it's a super macaroon,
 			// then we need to convert it into the actual daemon
 			// macaroon if they're running in remote mode.
@@ -337,6 +823,13 @@ func (p *rpcProxy) makeDirector(allowLitRPC bool) func(ctx context.Context,
 			}
 		}
 
+		// The third party caveats of the client's macaroon were
+		// checked by our interceptors, which lnd must know about.
+		err := addThirdPartyProof(md, mdCopy, p.thirdPartyProofKey)
+		if err != nil {
+			return outCtx, nil, err
+		}
+
 		if err := p.checkSubSystemStarted(requestURI); err != nil {
 			return outCtx, nil, err
 		}
@@ -367,132 +860,281 @@ func (p *rpcProxy) makeDirector(allowLitRPC bool) func(ctx context.Context,
 			)
 		}
 
-		return outCtx, p.lndConn, nil
+		return outCtx, p.lndConnForURI(requestURI), nil
 	}
 }
 
 // UnaryServerInterceptor is a gRPC interceptor that checks whether the
-// request is authorized by the included macaroons.
+// request is authorized by one of the configured authentication backends.
 func (p *rpcProxy) UnaryServerInterceptor(ctx context.Context, req interface{},
 	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
 	error) {
 
 	uriPermissions, ok := p.permsMgr.URIPermissions(info.FullMethod)
 	if !ok {
-		return nil, ErrUnknownRequest
+		return nil, p.unknownURIError(ctx, info.FullMethod)
 	}
 
 	if err := p.checkSubSystemStarted(info.FullMethod); err != nil {
 		return nil, err
 	}
 
-	// For now, basic authentication is just a quick fix until we
-	// have proper macaroon support implemented in the UI. We allow
-	// gRPC web requests to have it and "convert" the auth into a
-	// proper macaroon now.
-	newCtx, err := p.convertBasicAuth(ctx, info.FullMethod, nil)
+	// Try the configured authentication backends in order until one of
+	// them authenticates the request.
+	newCtx, ctx, err := p.authenticate(ctx, info.FullMethod, uriPermissions)
 	if err != nil {
-		// Make sure we handle the case where the super macaroon
-		// is still empty on startup.
-		if pErr, ok := err.(*proxyErr); ok &&
-			pErr.proxyContext == "supermacaroon" {
+		return nil, err
+	}
 
-			return nil, fmt.Errorf("super macaroon error: "+
-				"%v", pErr)
-		}
+	if err := p.checkStepUp(ctx, info.FullMethod); err != nil {
 		return nil, err
 	}
 
-	// With the basic auth converted to a macaroon if necessary,
-	// let's now validate the macaroon.
-	err = p.macValidator.ValidateMacaroon(
-		newCtx, uriPermissions, info.FullMethod,
-	)
+	if err := p.checkIPRestrictions(newCtx); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkThirdPartyCaveats(newCtx); err != nil {
+		return nil, err
+	}
+
+	err = p.checkSessionScope(newCtx, info.FullMethod)
 	if err != nil {
 		return nil, err
 	}
 
+	p.recordSessionUsage(newCtx)
+
+	// If the macaroon restricts the operations that may be executed, we
+	// make sure the request is allowed.
+	allowedOps, err := operationRestrictions(newCtx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if len(allowedOps) > 0 {
+		err := checkOperation(allowedOps, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return handler(ctx, req)
 }
 
 // StreamServerInterceptor is a GRPC interceptor that checks whether the
-// request is authorized by the included macaroons.
+// request is authorized by one of the configured authentication backends.
 func (p *rpcProxy) StreamServerInterceptor(srv interface{},
 	ss grpc.ServerStream, info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler) error {
 
 	uriPermissions, ok := p.permsMgr.URIPermissions(info.FullMethod)
 	if !ok {
-		return ErrUnknownRequest
+		return p.unknownURIError(ss.Context(), info.FullMethod)
 	}
 
 	if err := p.checkSubSystemStarted(info.FullMethod); err != nil {
 		return err
 	}
 
-	// For now, basic authentication is just a quick fix until we
-	// have proper macaroon support implemented in the UI. We allow
-	// gRPC web requests to have it and "convert" the auth into a
-	// proper macaroon now.
-	ctx, err := p.convertBasicAuth(
-		ss.Context(), info.FullMethod, nil,
+	// Try the configured authentication backends in order until one of
+	// them authenticates the request. The director needs to know which
+	// backend that was, so we pass it on in the stream's context.
+	ctx, origCtx, err := p.authenticate(
+		ss.Context(), info.FullMethod, uriPermissions,
 	)
 	if err != nil {
-		// Make sure we handle the case where the super macaroon
-		// is still empty on startup.
-		if pErr, ok := err.(*proxyErr); ok &&
-			pErr.proxyContext == "supermacaroon" {
+		return err
+	}
 
-			return fmt.Errorf("super macaroon error: "+
-				"%v", pErr)
-		}
+	if err := p.checkStepUp(origCtx, info.FullMethod); err != nil {
 		return err
 	}
 
-	// With the basic auth converted to a macaroon if necessary,
-	// let's now validate the macaroon.
-	err = p.macValidator.ValidateMacaroon(
-		ctx, uriPermissions, info.FullMethod,
-	)
+	if err := p.checkIPRestrictions(ctx); err != nil {
+		return err
+	}
+
+	if err := p.checkThirdPartyCaveats(ctx); err != nil {
+		return err
+	}
+
+	err = p.checkSessionScope(ctx, info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	p.recordSessionUsage(ctx)
+
+	ss = &authenticatedServerStream{
+		ServerStream: ss,
+		ctx:          origCtx,
+	}
+
+	// If the stream belongs to a session, we make sure the session
+	// doesn't exceed its limit of concurrently active streams. Unary calls
+	// also end up here, since the proxy has no handlers registered, so we
+	// only count the methods that actually stream.
+	if isStreamingMethod(info.FullMethod) {
+		release, err := p.acquireSessionStream(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	// If the macaroon restricts the operations that may be executed, we
+	// check each request message that is received on the stream.
+	allowedOps, err := operationRestrictions(ctx, info.FullMethod)
 	if err != nil {
 		return err
 	}
+	if len(allowedOps) > 0 {
+		ss = &operationCheckStream{
+			ServerStream: ss,
+			requestURI:   info.FullMethod,
+			allowed:      allowedOps,
+		}
+	}
 
 	return handler(srv, ss)
 }
 
-// convertBasicAuth tries to convert the HTTP authorization header into a
-// macaroon based authentication header.
-func (p *rpcProxy) convertBasicAuth(ctx context.Context,
-	requestURI string, ctxErr error) (context.Context, error) {
+// acquireSessionStream registers a new active stream for the session the
+// macaroon in the given context belongs to. An error with the
+// RESOURCE_EXHAUSTED code is returned if the session has already reached its
+// limit of concurrently active streams. The returned function must be called
+// once the stream is done.
+func (p *rpcProxy) acquireSessionStream(ctx context.Context) (func(),
+	error) {
 
-	// If the UI is disabled, then there is no UI password and so the
-	// request is required to have a macaroon in it.
-	if p.cfg.DisableUI {
-		return ctx, ctxErr
+	noop := func() {}
+
+	rootKeyID, ok := superMacRootKeyIDFromContext(ctx)
+	if !ok {
+		return noop, nil
 	}
 
-	md, ok := metadata.FromIncomingContext(ctx)
+	limit, ok := p.streams.sessionLimit(rootKeyID, p.lookupStreamLimit)
 	if !ok {
-		return ctx, ctxErr
+		return noop, nil
+	}
+	if limit == 0 {
+		limit = p.cfg.MaxSessionStreams
+	}
+
+	if !p.streams.acquire(rootKeyID, limit) {
+		id := session.IDFromMacRootKeyID(rootKeyID)
+		return noop, status.Errorf(codes.ResourceExhausted, "session "+
+			"%x has reached its limit of %d active streams",
+			id[:], limit)
 	}
 
-	authHeaders := md.Get("authorization")
-	if len(authHeaders) == 0 {
-		// No basic auth provided, we don't add a macaroon and let the
-		// gRPC security interceptor reject the request.
-		return ctx, ctxErr
+	return func() {
+		p.streams.release(rootKeyID)
+	}, nil
+}
+
+// lookupStreamLimit looks up the stream limit of the session with the given
+// macaroon root key ID. False is returned if the root key doesn't belong to a
+// session.
+func (p *rpcProxy) lookupStreamLimit(rootKeyID uint64) (uint32, bool) {
+	// The session DB is only available once we've fully started.
+	if !p.hasStarted() || p.sessionDB == nil {
+		return 0, false
 	}
 
-	macBytes, err := p.basicAuthToMacaroon(
-		authHeaders[0], requestURI, ctxErr,
+	sess, err := p.sessionDB.GetSessionByID(
+		session.IDFromMacRootKeyID(rootKeyID),
 	)
-	if err != nil || len(macBytes) == 0 {
-		return ctx, err
+	if err != nil {
+		return 0, false
 	}
 
-	md.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
-	return metadata.NewIncomingContext(ctx, md), nil
+	return sess.MaxStreams, true
+}
+
+// sessionFromContext returns the session that the super macaroon in the
+// given context belongs to. False is returned if the context doesn't carry a
+// super macaroon or if the macaroon doesn't belong to any session.
+func (p *rpcProxy) sessionFromContext(ctx context.Context) (*session.Session,
+	bool) {
+
+	// The session DB is only available once we've fully started.
+	if !p.hasStarted() || p.sessionDB == nil {
+		return nil, false
+	}
+
+	id, ok := sessionIDFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	// Not every super macaroon belongs to a session, so we don't treat a
+	// failed lookup as an error.
+	sess, err := p.sessionDB.GetSessionByID(id)
+	if err != nil {
+		return nil, false
+	}
+
+	return sess, true
+}
+
+// sessionIDFromContext returns the session ID that is derived from the root key
+// ID of the super macaroon in the given context. False is returned if the
+// context doesn't carry a super macaroon. The ID is not looked up, so a session
+// with the ID doesn't necessarily exist.
+func sessionIDFromContext(ctx context.Context) (session.ID, bool) {
+	rootKeyID, ok := superMacRootKeyIDFromContext(ctx)
+	if !ok {
+		return session.ID{}, false
+	}
+
+	return session.IDFromMacRootKeyID(rootKeyID), true
+}
+
+// superMacRootKeyIDFromContext returns the root key ID of the super macaroon
+// in the given context. False is returned if the context doesn't carry a super
+// macaroon.
+func superMacRootKeyIDFromContext(ctx context.Context) (uint64, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	macHeader := md.Get(HeaderMacaroon)
+	if len(macHeader) != 1 || !session.IsSuperMacaroon(macHeader[0]) {
+		return 0, false
+	}
+
+	mac, err := session.ParseMacaroon(macHeader[0])
+	if err != nil {
+		return 0, false
+	}
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return 0, false
+	}
+
+	return rootKeyID, true
+}
+
+// recordSessionUsage counts the request in the given context towards the usage
+// of its session, if it was made with the macaroon of a session.
+func (p *rpcProxy) recordSessionUsage(ctx context.Context) {
+	if !p.hasStarted() || p.sessionUsage == nil {
+		return
+	}
+
+	if id, ok := sessionIDFromContext(ctx); ok {
+		p.sessionUsage.record(id, time.Now())
+	}
+}
+
+// activeSessionStreams returns the number of streams that are currently
+// active for the given session.
+func (p *rpcProxy) activeSessionStreams(sess *session.Session) uint32 {
+	return p.streams.activeStreams(sess.MacaroonRootKey)
 }
 
 // basicAuthToMacaroon checks that the incoming request context has the expected
@@ -510,10 +1152,27 @@ func (p *rpcProxy) basicAuthToMacaroon(basicAuth, requestURI string,
 	if len(authHeaderParts) != 2 {
 		return nil, ctxErr
 	}
-	if authHeaderParts[1] != p.basicAuth {
+	if p.isUIPasswordBasicAuth(authHeaderParts[1]) {
+		return p.fullAccessMacaroon(requestURI)
+	}
+
+	// The credential might also belong to one of the named UI users, who
+	// only get the permissions of their scope.
+	user, ok := p.uiUsers.verify(authHeaderParts[1])
+	if !ok {
 		return nil, ctxErr
 	}
 
+	logWithFields(log, logFields{"rpc_method": requestURI}).Debugf("UI "+
+		"user %s authenticated for %s", user.name, requestURI)
+
+	return p.uiUserMacaroon(user, requestURI)
+}
+
+// fullAccessMacaroon returns the macaroon that is attached to requests that
+// were authenticated with a credential that grants full access, like the UI
+// password.
+func (p *rpcProxy) fullAccessMacaroon(requestURI string) ([]byte, error) {
 	var macData []byte
 	handled, macPath := p.subServerMgr.MacaroonPath(requestURI)
 
@@ -533,8 +1192,8 @@ func (p *rpcProxy) basicAuthToMacaroon(basicAuth, requestURI string,
 	switch {
 	// If we have a super macaroon, we can use that one directly since it
 	// will contain all permissions we need.
-	case len(p.superMacaroon) > 0:
-		superMacData, err := hex.DecodeString(p.superMacaroon)
+	case len(p.getSuperMacaroon()) > 0:
+		superMacData, err := hex.DecodeString(p.getSuperMacaroon())
 
 		// Make sure we can avoid running into an empty macaroon here if
 		// something went wrong with the decoding process (if we're
@@ -619,21 +1278,9 @@ func (p *rpcProxy) checkSubSystemStarted(requestURI string) error {
 		return ErrWaitingToStart
 	}
 
-	handled, system := p.subServerMgr.Handles(requestURI)
-	switch {
-	case handled:
-
-	case isAccountsReq(requestURI):
-		system = subservers.ACCOUNTS
-
-	case p.permsMgr.IsSubServerURI(subservers.LIT, requestURI):
-		system = subservers.LIT
-
-	case p.permsMgr.IsSubServerURI(subservers.LND, requestURI):
-		system = subservers.LND
-
-	default:
-		return ErrUnknownRequest
+	system, err := p.subSystemForURI(requestURI)
+	if err != nil {
+		return err
 	}
 
 	// Check with the status manger to see if the sub-server is ready to
@@ -644,16 +1291,121 @@ func (p *rpcProxy) checkSubSystemStarted(requestURI string) error {
 	}
 
 	if disabled {
-		return fmt.Errorf("%s has been disabled", system)
+		return daemonDisabledError(system)
+	}
+
+	if !ready && system == subservers.LND && p.lndRecovery != nil &&
+		p.lndRecovery.restrictsCalls() {
+
+		return status.Error(codes.Unavailable, "lnd in recovery")
+	}
+
+	if !ready && system == subservers.LND && p.lndStartup != nil {
+		if err := p.lndStartup.notReadyError(); err != nil {
+			return err
+		}
 	}
 
 	if !ready {
-		return fmt.Errorf("%s is not ready for: %s", system, requestURI)
+		return status.Errorf(codes.Unavailable, "%s is not ready for: "+
+			"%s", system, requestURI)
 	}
 
 	return nil
 }
 
+// unknownURIError returns the error for a request to a URI that isn't known
+// to the permission manager. Calls to a sub-server that has been disabled are
+// reported as such, everything else is reported as an unknown method. If
+// configured, calls to unknown methods are logged since they might indicate
+// that someone is probing the RPC interface.
+func (p *rpcProxy) unknownURIError(ctx context.Context,
+	requestURI string) error {
+
+	if err := p.unavailableURIError(requestURI); err != nil {
+		return err
+	}
+
+	if p.cfg.LogUnknownMethods {
+		peerAddr := "unknown"
+		if pr, ok := peer.FromContext(ctx); ok {
+			peerAddr = pr.Addr.String()
+		}
+
+		p.requestLog(ctx, requestURI).Warnf("Call to unknown method: "+
+			"method=%s, peer=%s", requestURI, peerAddr)
+	}
+
+	return status.Errorf(
+		codes.Unimplemented, "unknown method %s", requestURI,
+	)
+}
+
+// unavailableURIError returns the error for a request to a URI that belongs to
+// a sub-server that is known but not available, either because it has been
+// disabled in LiT's configuration or because the connected lnd hasn't been
+// compiled with it. Nil is returned if the URI is unknown.
+func (p *rpcProxy) unavailableURIError(requestURI string) error {
+	disabled, system := p.subServerMgr.HandlesDisabled(requestURI)
+	if disabled {
+		return daemonDisabledError(system)
+	}
+
+	lndSubServer, disabled := p.permsMgr.DisabledLndSubServer(requestURI)
+	if disabled {
+		return status.Errorf(
+			codes.Unimplemented, "subserver disabled in lnd: %s "+
+				"is not enabled in the connected lnd",
+			lndSubServer,
+		)
+	}
+
+	return nil
+}
+
+// daemonDisabledError returns the error for a request to a sub system that
+// has been disabled in the configuration.
+func daemonDisabledError(system string) error {
+	return status.Errorf(
+		codes.Unimplemented, "daemon disabled: %s has been disabled",
+		system,
+	)
+}
+
+// permissionDeniedError converts an error returned by the macaroon validation
+// into one with the PERMISSION_DENIED code, unless it already carries a more
+// specific code.
+func permissionDeniedError(err error) error {
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		return err
+	}
+
+	return status.Errorf(codes.PermissionDenied, "permission denied: %v",
+		err)
+}
+
+// subSystemForURI returns the name of the sub system that is responsible for
+// handling the given URI.
+func (p *rpcProxy) subSystemForURI(requestURI string) (string, error) {
+	handled, system := p.subServerMgr.Handles(requestURI)
+	switch {
+	case handled:
+		return system, nil
+
+	case isAccountsReq(requestURI):
+		return subservers.ACCOUNTS, nil
+
+	case p.permsMgr.IsSubServerURI(subservers.LIT, requestURI):
+		return subservers.LIT, nil
+
+	case p.permsMgr.IsSubServerURI(subservers.LND, requestURI):
+		return subservers.LND, nil
+
+	default:
+		return "", ErrUnknownRequest
+	}
+}
+
 // readMacaroon tries to read the macaroon file at the specified path and create
 // gRPC dial options from it.
 func readMacaroon(macPath string) ([]byte, error) {
@@ -0,0 +1,207 @@
+package session
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// apiKeysBucketKey is the top level bucket that holds the API keys.
+	//
+	// The API keys bucket has the following structure:
+	// api-keys -> <key-id> -> label -> <label>
+	// api-keys -> <key-id> -> secret-hash -> <sha256 of the secret>
+	// api-keys -> <key-id> -> created-at -> <ts>
+	// api-keys -> <key-id> -> read-only -> <1 or 0>
+	// api-keys -> <key-id> -> permissions -> <entity:action> -> {}
+	//
+	// The creation timestamp is a unix timestamp encoded as big endian
+	// uint64.
+	apiKeysBucketKey = []byte("api-keys")
+
+	apiKeyLabelKey       = []byte("label")
+	apiKeySecretHashKey  = []byte("secret-hash")
+	apiKeyCreatedAtKey   = []byte("created-at")
+	apiKeyReadOnlyKey    = []byte("read-only")
+	apiKeyPermissionsKey = []byte("permissions")
+
+	// ErrAPIKeyNotFound is returned if an API key with the given ID
+	// doesn't exist.
+	ErrAPIKeyNotFound = errors.New("API key not found")
+)
+
+// APIKeyID is the public identifier of an API key.
+type APIKeyID [8]byte
+
+// APIKey is a long-lived credential that grants the permissions of its scope.
+// Only the hash of the key's secret is stored, the secret itself is only known
+// to the holder of the key.
+type APIKey struct {
+	// ID is the public identifier of the key.
+	ID APIKeyID
+
+	// Label is a user assigned label for the key.
+	Label string
+
+	// SecretHash is the SHA256 hash of the key's secret.
+	SecretHash [sha256.Size]byte
+
+	// CreatedAt is the time the key was created at.
+	CreatedAt time.Time
+
+	// ReadOnly is true if the key grants the read permissions of all
+	// active daemons.
+	ReadOnly bool
+
+	// Permissions are the permissions the key grants. If neither these
+	// nor ReadOnly are set, the key grants all permissions of all active
+	// daemons.
+	Permissions []bakery.Op
+}
+
+// AddAPIKey stores the given API key.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) AddAPIKey(key *APIKey) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		apiKeysBkt, err := tx.CreateBucketIfNotExists(apiKeysBucketKey)
+		if err != nil {
+			return err
+		}
+
+		if apiKeysBkt.Bucket(key.ID[:]) != nil {
+			return errors.New("API key already exists")
+		}
+
+		keyBkt, err := apiKeysBkt.CreateBucket(key.ID[:])
+		if err != nil {
+			return err
+		}
+
+		err = keyBkt.Put(apiKeyLabelKey, []byte(key.Label))
+		if err != nil {
+			return err
+		}
+
+		err = keyBkt.Put(apiKeySecretHashKey, key.SecretHash[:])
+		if err != nil {
+			return err
+		}
+
+		var createdAt [8]byte
+		byteOrder.PutUint64(createdAt[:], uint64(key.CreatedAt.Unix()))
+		err = keyBkt.Put(apiKeyCreatedAtKey, createdAt[:])
+		if err != nil {
+			return err
+		}
+
+		readOnly := []byte{0}
+		if key.ReadOnly {
+			readOnly[0] = 1
+		}
+		if err := keyBkt.Put(apiKeyReadOnlyKey, readOnly); err != nil {
+			return err
+		}
+
+		permsBkt, err := keyBkt.CreateBucket(apiKeyPermissionsKey)
+		if err != nil {
+			return err
+		}
+
+		for _, op := range key.Permissions {
+			perm := []byte(op.Entity + ":" + op.Action)
+			if err := permsBkt.Put(perm, []byte{}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListAPIKeys returns all stored API keys.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) ListAPIKeys() ([]*APIKey, error) {
+	var keys []*APIKey
+	err := db.View(func(tx *bbolt.Tx) error {
+		apiKeysBkt := tx.Bucket(apiKeysBucketKey)
+		if apiKeysBkt == nil {
+			return nil
+		}
+
+		return apiKeysBkt.ForEach(func(k, _ []byte) error {
+			keyBkt := apiKeysBkt.Bucket(k)
+			if keyBkt == nil || len(k) != len(APIKeyID{}) {
+				return nil
+			}
+
+			key := &APIKey{
+				Label: string(keyBkt.Get(apiKeyLabelKey)),
+			}
+			copy(key.ID[:], k)
+
+			secretHash := keyBkt.Get(apiKeySecretHashKey)
+			copy(key.SecretHash[:], secretHash)
+
+			if v := keyBkt.Get(apiKeyCreatedAtKey); len(v) == 8 {
+				key.CreatedAt = time.Unix(
+					int64(byteOrder.Uint64(v)), 0,
+				)
+			}
+
+			readOnly := keyBkt.Get(apiKeyReadOnlyKey)
+			key.ReadOnly = len(readOnly) == 1 && readOnly[0] == 1
+
+			keys = append(keys, key)
+
+			permsBkt := keyBkt.Bucket(apiKeyPermissionsKey)
+			if permsBkt == nil {
+				return nil
+			}
+
+			return permsBkt.ForEach(func(perm, _ []byte) error {
+				entity, action, ok := strings.Cut(
+					string(perm), ":",
+				)
+				if !ok {
+					return nil
+				}
+
+				key.Permissions = append(
+					key.Permissions, bakery.Op{
+						Entity: entity,
+						Action: action,
+					},
+				)
+
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey removes the API key with the given ID. ErrAPIKeyNotFound is
+// returned if no such key exists.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) DeleteAPIKey(id APIKeyID) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		apiKeysBkt := tx.Bucket(apiKeysBucketKey)
+		if apiKeysBkt == nil || apiKeysBkt.Bucket(id[:]) == nil {
+			return ErrAPIKeyNotFound
+		}
+
+		return apiKeysBkt.DeleteBucket(id[:])
+	})
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestAPIKeys tests that API keys can be stored, listed and deleted.
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	db, err := NewDB(dir, "test.db")
+	require.NoError(t, err)
+
+	// Without any keys, the list is empty.
+	keys, err := db.ListAPIKeys()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	readOnlyKey := &APIKey{
+		ID:         APIKeyID{1},
+		Label:      "read only",
+		SecretHash: [32]byte{1, 2, 3},
+		CreatedAt:  time.Unix(1700000000, 0),
+		ReadOnly:   true,
+	}
+	customKey := &APIKey{
+		ID:         APIKeyID{2},
+		Label:      "custom",
+		SecretHash: [32]byte{4, 5, 6},
+		CreatedAt:  time.Unix(1700000100, 0),
+		Permissions: []bakery.Op{{
+			Entity: "info",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+	}
+	require.NoError(t, db.AddAPIKey(readOnlyKey))
+	require.NoError(t, db.AddAPIKey(customKey))
+
+	// A key can't be added twice.
+	require.Error(t, db.AddAPIKey(customKey))
+
+	// The keys must survive a restart.
+	require.NoError(t, db.Close())
+	db, err = NewDB(dir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	keys, err = db.ListAPIKeys()
+	require.NoError(t, err)
+	require.Equal(t, []*APIKey{readOnlyKey, customKey}, keys)
+
+	// Deleting a key removes it from the list.
+	require.NoError(t, db.DeleteAPIKey(readOnlyKey.ID))
+	require.ErrorIs(
+		t, db.DeleteAPIKey(readOnlyKey.ID), ErrAPIKeyNotFound,
+	)
+
+	keys, err = db.ListAPIKeys()
+	require.NoError(t, err)
+	require.Equal(t, []*APIKey{customKey}, keys)
+}
@@ -0,0 +1,257 @@
+package session
+
+import (
+	"bytes"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// endedAt returns the time at which the session was revoked or expired. False
+// is returned if the session is still active.
+func (s *Session) endedAt() (time.Time, bool) {
+	switch s.State {
+	case StateRevoked:
+		// Sessions that were revoked before the revocation time was
+		// recorded are treated as if they were revoked when they
+		// expired.
+		if s.RevokedAt.IsZero() {
+			return s.Expiry, true
+		}
+
+		return s.RevokedAt, true
+
+	case StateExpired:
+		return s.Expiry, true
+
+	default:
+		return time.Time{}, false
+	}
+}
+
+// ExpiredSessionReason is the revocation reason of the sessions that are
+// revoked by ExpireSessions.
+const ExpiredSessionReason = "session expired"
+
+// IdleSessionReason is the revocation reason of the sessions that are revoked
+// because they weren't used within their idle timeout.
+const IdleSessionReason = "session idle"
+
+// ExpireSessions revokes all sessions that are still active but expired
+// before the given time and returns them. This is done in a single
+// transaction, so a session that is renewed at the same time is either renewed
+// or revoked, but never revoked after it was renewed.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) ExpireSessions(now time.Time) ([]*Session, error) {
+	var (
+		expired []*Session
+		events  []*Event
+	)
+	err := db.Update(func(tx *bbolt.Tx) error {
+		expired, events = nil, nil
+
+		sessionBkt, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		err = sessionBkt.ForEach(func(k, v []byte) error {
+			// Skip the index buckets, identified by a nil value.
+			if v == nil {
+				return nil
+			}
+
+			session, err := DeserializeSession(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			if session.State != StateCreated &&
+				session.State != StateInUse {
+
+				return nil
+			}
+			if session.Expiry.After(now) {
+				return nil
+			}
+
+			expired = append(expired, session)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// The bucket can't be modified while iterating over it, so we
+		// update the sessions afterwards.
+		for _, session := range expired {
+			session.State = StateRevoked
+			session.RevokedAt = now
+			session.RevocationReason = ExpiredSessionReason
+
+			var buf bytes.Buffer
+			err := SerializeSession(&buf, session)
+			if err != nil {
+				return err
+			}
+
+			err = sessionBkt.Put(
+				getSessionKey(session), buf.Bytes(),
+			)
+			if err != nil {
+				return err
+			}
+
+			event, err := addEvent(tx, EventExpired, session)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		db.notifyEventSubscribers(event)
+	}
+
+	return expired, nil
+}
+
+// PurgeSessions removes the sessions that were revoked or expired before the
+// given time from the store, together with their index entries, expiry
+// warnings and usage, and returns them. The sessions of a group are only
+// removed together, once all of them ended before the given time, so that
+// the group of a remaining session is never left without its first session.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) PurgeSessions(before time.Time) ([]*Session, error) {
+	var purged []*Session
+	err := db.Update(func(tx *bbolt.Tx) error {
+		purged = nil
+
+		sessionBkt, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		idIndexBkt := sessionBkt.Bucket(idIndexKey)
+		groupIndexBkt := sessionBkt.Bucket(groupIDIndexKey)
+		if idIndexBkt == nil || groupIndexBkt == nil {
+			return ErrDBInitErr
+		}
+
+		// Collect the sessions by group and find the groups that have
+		// a session that must be kept.
+		var (
+			groups   = make(map[ID][]*Session)
+			keepers  = make(map[ID]bool)
+			groupIDs []ID
+		)
+		err = sessionBkt.ForEach(func(k, v []byte) error {
+			// Skip the index buckets, identified by a nil value.
+			if v == nil {
+				return nil
+			}
+
+			session, err := DeserializeSession(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			if _, ok := groups[session.GroupID]; !ok {
+				groupIDs = append(groupIDs, session.GroupID)
+			}
+			groups[session.GroupID] = append(
+				groups[session.GroupID], session,
+			)
+
+			endedAt, ended := session.endedAt()
+			if !ended || !endedAt.Before(before) {
+				keepers[session.GroupID] = true
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		warningsBkt := tx.Bucket(expiryWarningsBucketKey)
+		usageBkt := tx.Bucket(usageBucketKey)
+		activityBkt := tx.Bucket(activityBucketKey)
+		for _, groupID := range groupIDs {
+			if keepers[groupID] {
+				continue
+			}
+
+			for _, session := range groups[groupID] {
+				err := deleteSession(
+					sessionBkt, idIndexBkt, warningsBkt,
+					usageBkt, activityBkt, session,
+				)
+				if err != nil {
+					return err
+				}
+
+				purged = append(purged, session)
+			}
+
+			if groupIndexBkt.Bucket(groupID[:]) != nil {
+				err := groupIndexBkt.DeleteBucket(groupID[:])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+// deleteSession removes the given session and the entries that belong to it
+// from the given buckets. The warnings, usage and activity buckets may be nil
+// if they weren't created yet. The group ID index is left to the caller.
+func deleteSession(sessionBkt, idIndexBkt, warningsBkt, usageBkt,
+	activityBkt *bbolt.Bucket, session *Session) error {
+
+	key := getSessionKey(session)
+	if err := sessionBkt.Delete(key); err != nil {
+		return err
+	}
+
+	if idIndexBkt.Bucket(session.ID[:]) != nil {
+		if err := idIndexBkt.DeleteBucket(session.ID[:]); err != nil {
+			return err
+		}
+	}
+
+	if warningsBkt != nil {
+		if err := warningsBkt.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	if usageBkt != nil && usageBkt.Bucket(session.ID[:]) != nil {
+		if err := usageBkt.DeleteBucket(session.ID[:]); err != nil {
+			return err
+		}
+	}
+
+	if activityBkt != nil {
+		return activityBkt.Delete(session.ID[:])
+	}
+
+	return nil
+}
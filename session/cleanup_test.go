@@ -0,0 +1,77 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestExpireAndPurgeSessions tests that expired sessions are revoked and that
+// old sessions are only purged together with their whole group.
+func TestExpireAndPurgeSessions(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	now := time.Now()
+
+	// Session 1 is expired but still active. Session 2 is active.
+	s1 := newSession(t, db, "session 1", nil)
+	s1.Expiry = now.Add(-time.Hour)
+	require.NoError(t, db.CreateSession(s1))
+
+	s2 := newSession(t, db, "session 2", nil)
+	require.NoError(t, db.CreateSession(s2))
+
+	expired, err := db.ExpireSessions(now)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.Equal(t, s1.ID, expired[0].ID)
+
+	stored, err := db.GetSession(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, StateRevoked, stored.State)
+	require.Equal(t, ExpiredSessionReason, stored.RevocationReason)
+
+	// Session 3 is linked to session 2, which must be revoked first.
+	require.NoError(t, db.RevokeSession(s2.LocalPublicKey))
+	s3 := newSession(t, db, "session 3", &s2.GroupID)
+	require.NoError(t, db.CreateSession(s3))
+
+	// Sessions that ended after the cutoff are kept.
+	purged, err := db.PurgeSessions(now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, purged)
+
+	// Session 1 is purged, but the group of sessions 2 and 3 is kept as
+	// long as session 3 is active.
+	purged, err = db.PurgeSessions(now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	require.Equal(t, s1.ID, purged[0].ID)
+
+	_, err = db.GetSession(s1.LocalPublicKey)
+	require.ErrorIs(t, err, ErrSessionNotFound)
+	_, err = db.GetSessionByID(s1.ID)
+	require.Error(t, err)
+
+	// Once session 3 is revoked, the whole group is purged.
+	require.NoError(t, db.RevokeSession(s3.LocalPublicKey))
+	purged, err = db.PurgeSessions(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, purged, 2)
+
+	sessions, err := db.ListSessions(nil)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	// The store is consistent after purging.
+	report, err := db.CheckConsistency(false)
+	require.NoError(t, err)
+	require.Empty(t, report.OrphanedIndexEntries)
+	require.Empty(t, report.DanglingGroupEntries)
+	require.Empty(t, report.UnindexedSessions)
+}
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"bytes"
+	"sort"
+
+	"go.etcd.io/bbolt"
+)
+
+// ConsistencyReport describes the inconsistencies that were found in the
+// session store.
+type ConsistencyReport struct {
+	// OrphanedIndexEntries are the IDs of entries in the ID index that
+	// don't point to an existing session. Since a session's ID is derived
+	// from its macaroon root key ID, these are root key IDs that have no
+	// session.
+	OrphanedIndexEntries []ID
+
+	// UnindexedSessions are the IDs of sessions that don't have a matching
+	// entry in the ID index and can therefore not be looked up by their
+	// macaroon root key ID.
+	UnindexedSessions []ID
+
+	// DanglingGroupEntries are the IDs of sessions that are referenced in
+	// the group ID index but don't exist.
+	DanglingGroupEntries []ID
+
+	// CorruptSessions are the keys of the sessions that could not be
+	// deserialized or that are missing their local key. These are never
+	// repaired automatically.
+	CorruptSessions [][]byte
+
+	// Repaired is true if the orphaned, unindexed and dangling entries
+	// were repaired.
+	Repaired bool
+}
+
+// groupEntry is a reference to a single session ID entry in the group ID
+// index.
+type groupEntry struct {
+	groupID ID
+	seqNo   []byte
+}
+
+// CheckConsistency audits the session store for index entries that have no
+// session and for sessions that are missing from the index. If repair is set,
+// orphaned and dangling index entries are removed and missing index entries
+// are re-created.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) CheckConsistency(repair bool) (*ConsistencyReport, error) {
+	var report *ConsistencyReport
+
+	txFn := db.View
+	if repair {
+		txFn = db.Update
+	}
+
+	err := txFn(func(tx *bbolt.Tx) error {
+		report = &ConsistencyReport{}
+
+		sessionBkt, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		idIndexBkt := sessionBkt.Bucket(idIndexKey)
+		if idIndexBkt == nil {
+			return ErrDBInitErr
+		}
+
+		groupIndexBkt := sessionBkt.Bucket(groupIDIndexKey)
+		if groupIndexBkt == nil {
+			return ErrDBInitErr
+		}
+
+		// First, we collect all sessions that can be read from the
+		// store.
+		sessions := make(map[ID]*Session)
+		err = sessionBkt.ForEach(func(k, v []byte) error {
+			// We'll also get buckets here, skip those (identified
+			// by nil value).
+			if v == nil {
+				return nil
+			}
+
+			session, err := DeserializeSession(bytes.NewReader(v))
+			if err != nil || session.LocalPublicKey == nil {
+				report.CorruptSessions = append(
+					report.CorruptSessions,
+					append([]byte(nil), k...),
+				)
+
+				return nil
+			}
+
+			sessions[session.ID] = session
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Then we make sure that each entry in the ID index points to
+		// the session with the same ID.
+		indexed := make(map[ID]bool)
+		err = idIndexBkt.ForEach(func(k, v []byte) error {
+			idBkt := idIndexBkt.Bucket(k)
+			if idBkt == nil {
+				return nil
+			}
+
+			var id ID
+			copy(id[:], k)
+
+			session, ok := sessions[id]
+			if !ok || !bytes.Equal(
+				idBkt.Get(sessionKeyKey), getSessionKey(session),
+			) {
+
+				report.OrphanedIndexEntries = append(
+					report.OrphanedIndexEntries, id,
+				)
+
+				return nil
+			}
+
+			indexed[id] = true
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for id := range sessions {
+			if !indexed[id] {
+				report.UnindexedSessions = append(
+					report.UnindexedSessions, id,
+				)
+			}
+		}
+		sortIDs(report.UnindexedSessions)
+
+		// Finally, we check that all sessions referenced in the group
+		// ID index exist.
+		var dangling []groupEntry
+		grouped := make(map[ID]bool)
+		err = groupIndexBkt.ForEach(func(groupKey, v []byte) error {
+			groupBkt := groupIndexBkt.Bucket(groupKey)
+			if groupBkt == nil {
+				return nil
+			}
+
+			sessionIDsBkt := groupBkt.Bucket(sessionIDKey)
+			if sessionIDsBkt == nil {
+				return nil
+			}
+
+			var groupID ID
+			copy(groupID[:], groupKey)
+
+			return sessionIDsBkt.ForEach(func(seqNo,
+				idBytes []byte) error {
+
+				var id ID
+				copy(id[:], idBytes)
+
+				if _, ok := sessions[id]; ok {
+					grouped[id] = true
+
+					return nil
+				}
+
+				report.DanglingGroupEntries = append(
+					report.DanglingGroupEntries, id,
+				)
+				dangling = append(dangling, groupEntry{
+					groupID: groupID,
+					seqNo:   append([]byte(nil), seqNo...),
+				})
+
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		if !repair {
+			return nil
+		}
+
+		for _, id := range report.OrphanedIndexEntries {
+			if err := idIndexBkt.DeleteBucket(id[:]); err != nil {
+				return err
+			}
+		}
+
+		for _, entry := range dangling {
+			sessionIDsBkt := groupIndexBkt.Bucket(
+				entry.groupID[:],
+			).Bucket(sessionIDKey)
+
+			if err := sessionIDsBkt.Delete(entry.seqNo); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range report.UnindexedSessions {
+			session := sessions[id]
+
+			err := addIDToKeyPair(
+				sessionBkt, id, getSessionKey(session),
+			)
+			if err != nil {
+				return err
+			}
+
+			// If the session is already referenced in the group
+			// ID index, we only need to restore the group ID in
+			// the ID index.
+			if grouped[id] {
+				err = idIndexBkt.Bucket(id[:]).Put(
+					groupIDKey, session.GroupID[:],
+				)
+			} else {
+				err = addIDToGroupIDPair(
+					sessionBkt, id, session.GroupID,
+				)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		report.Repaired = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// sortIDs sorts the given session IDs in ascending order.
+func sortIDs(ids []ID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+}
@@ -35,6 +35,9 @@ var (
 // DB is a bolt-backed persistent store.
 type DB struct {
 	*bbolt.DB
+
+	// eventSubs are the subscribers to the session events.
+	eventSubs eventSubscribers
 }
 
 // A compile-time check to ensure that DB implements the Store interface.
@@ -64,7 +67,12 @@ func NewDB(dir, fileName string) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{DB: db}, nil
+	return &DB{
+		DB: db,
+		eventSubs: eventSubscribers{
+			subscribers: make(map[uint64]*EventSubscription),
+		},
+	}, nil
 }
 
 // fileExists reports whether the named file or directory exists.
@@ -0,0 +1,340 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/queue"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// eventsBucketKey is the top level bucket that holds the most recent
+	// session events.
+	//
+	// The events bucket has the following structure:
+	// session-events -> <event-id> -> <serialized event>
+	//
+	// The event ID is encoded as big endian uint64 and is taken from the
+	// bucket's sequence, so IDs keep increasing across restarts.
+	eventsBucketKey = []byte("session-events")
+
+	// expiryWarningsBucketKey is the top level bucket that holds the local
+	// public keys of the sessions for which an expiry warning was emitted,
+	// so the warning is only emitted once per session.
+	//
+	// The expiry warnings bucket has the following structure:
+	// session-expiry-warnings -> <local-pub-key> -> <warning-time>
+	expiryWarningsBucketKey = []byte("session-expiry-warnings")
+)
+
+const (
+	// maxStoredEvents is the number of most recent session events that are
+	// kept in the store so clients can resume their subscription.
+	maxStoredEvents = 10000
+
+	// eventHeaderLen is the length of a serialized event without its
+	// label: the type, the state, the timestamp and the compressed local
+	// public key.
+	eventHeaderLen = 1 + 1 + 8 + btcec.PubKeyBytesLenCompressed
+)
+
+// EventType is the kind of change that happened to a session.
+type EventType uint8
+
+const (
+	// EventCreated is the event of a session that was added to the store.
+	EventCreated EventType = 0
+
+	// EventRevoked is the event of a session that was revoked before it
+	// expired.
+	EventRevoked EventType = 1
+
+	// EventExpired is the event of a session that was revoked because it
+	// expired.
+	EventExpired EventType = 2
+
+	// EventExpiring is the event of a session that will expire soon. It
+	// is emitted at most once per session and expiry.
+	EventExpiring EventType = 3
+
+	// EventRenewed is the event of a session whose expiry was extended.
+	EventRenewed EventType = 4
+)
+
+// Event describes a change of the state of a session.
+type Event struct {
+	// ID is the ID of the event. Each event has a higher ID than the one
+	// before it.
+	ID uint64
+
+	// Type is the kind of change that happened to the session.
+	Type EventType
+
+	// LocalPublicKey is the local public key of the session.
+	LocalPublicKey *btcec.PublicKey
+
+	// Label is the label of the session.
+	Label string
+
+	// State is the state of the session after the change.
+	State State
+
+	// Timestamp is the time at which the change happened.
+	Timestamp time.Time
+}
+
+// eventSubscribers keeps track of all clients that subscribed to the events of
+// the sessions in the store.
+type eventSubscribers struct {
+	subscribers map[uint64]*EventSubscription
+	nextID      uint64
+	mu          sync.Mutex
+}
+
+// EventSubscription is a subscription to the session events of the store.
+type EventSubscription struct {
+	id      uint64
+	updates *queue.ConcurrentQueue[*Event]
+	cancel  func()
+}
+
+// Updates returns the channel over which the new events are delivered.
+func (s *EventSubscription) Updates() <-chan *Event {
+	return s.updates.ChanOut()
+}
+
+// Cancel cancels the subscription. No more events will be delivered after
+// this returns.
+func (s *EventSubscription) Cancel() {
+	s.cancel()
+}
+
+// SubscribeEvents returns a new subscription that delivers each session event
+// that happens from now on. The subscription must be cancelled once it is no
+// longer needed.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) SubscribeEvents() *EventSubscription {
+	db.eventSubs.mu.Lock()
+	defer db.eventSubs.mu.Unlock()
+
+	sub := &EventSubscription{
+		id: db.eventSubs.nextID,
+		updates: queue.NewConcurrentQueue[*Event](
+			queue.DefaultQueueSize,
+		),
+	}
+	sub.cancel = func() {
+		db.eventSubs.mu.Lock()
+		defer db.eventSubs.mu.Unlock()
+
+		if _, ok := db.eventSubs.subscribers[sub.id]; !ok {
+			return
+		}
+
+		delete(db.eventSubs.subscribers, sub.id)
+		sub.updates.Stop()
+	}
+
+	sub.updates.Start()
+	db.eventSubs.subscribers[sub.id] = sub
+	db.eventSubs.nextID++
+
+	return sub
+}
+
+// notifyEventSubscribers delivers the given event to all active subscriptions.
+// Since each subscription is backed by an unbounded queue, this never blocks
+// on a slow subscriber.
+func (db *DB) notifyEventSubscribers(event *Event) {
+	db.eventSubs.mu.Lock()
+	defer db.eventSubs.mu.Unlock()
+
+	for _, sub := range db.eventSubs.subscribers {
+		sub.updates.ChanIn() <- event
+	}
+}
+
+// ListEvents returns the stored session events with an ID of at least the
+// given one, sorted by their ID. Only the most recent events are stored, so
+// older events might be missing.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) ListEvents(fromID uint64) ([]*Event, error) {
+	var events []*Event
+	err := db.View(func(tx *bbolt.Tx) error {
+		eventsBkt := tx.Bucket(eventsBucketKey)
+		if eventsBkt == nil {
+			return nil
+		}
+
+		var start [8]byte
+		byteOrder.PutUint64(start[:], fromID)
+
+		c := eventsBkt.Cursor()
+		for k, v := c.Seek(start[:]); k != nil; k, v = c.Next() {
+			event, err := deserializeEvent(byteOrder.Uint64(k), v)
+			if err != nil {
+				return err
+			}
+
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// AddExpiryWarning emits an EventExpiring event for the session with the given
+// local public key, unless one was already emitted for it or the session was
+// revoked. True is returned if the event was emitted.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) AddExpiryWarning(key *btcec.PublicKey) (bool, error) {
+	var event *Event
+	err := db.Update(func(tx *bbolt.Tx) error {
+		sessionBucket, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		sessionBytes := sessionBucket.Get(key.SerializeCompressed())
+		if len(sessionBytes) == 0 {
+			return ErrSessionNotFound
+		}
+
+		session, err := DeserializeSession(
+			bytes.NewReader(sessionBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		if session.State == StateRevoked {
+			return nil
+		}
+
+		warningsBkt, err := tx.CreateBucketIfNotExists(
+			expiryWarningsBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		if warningsBkt.Get(key.SerializeCompressed()) != nil {
+			return nil
+		}
+
+		event, err = addEvent(tx, EventExpiring, session)
+		if err != nil {
+			return err
+		}
+
+		var warnedAt [8]byte
+		byteOrder.PutUint64(
+			warnedAt[:], uint64(event.Timestamp.Unix()),
+		)
+
+		return warningsBkt.Put(key.SerializeCompressed(), warnedAt[:])
+	})
+	if err != nil || event == nil {
+		return false, err
+	}
+
+	db.notifyEventSubscribers(event)
+
+	return true, nil
+}
+
+// addEvent stores a new event of the given type for the given session within
+// the given transaction and removes the oldest event if the limit is
+// exceeded. The subscribers must only be notified once the transaction is
+// committed.
+func addEvent(tx *bbolt.Tx, eventType EventType, session *Session) (*Event,
+	error) {
+
+	eventsBkt, err := tx.CreateBucketIfNotExists(eventsBucketKey)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := eventsBkt.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		ID:             id,
+		Type:           eventType,
+		LocalPublicKey: session.LocalPublicKey,
+		Label:          session.Label,
+		State:          session.State,
+		Timestamp:      time.Now(),
+	}
+
+	var key [8]byte
+	byteOrder.PutUint64(key[:], id)
+	if err := eventsBkt.Put(key[:], serializeEvent(event)); err != nil {
+		return nil, err
+	}
+
+	// Every sequence number is used for an event, so the event that no
+	// longer fits is the one that is maxStoredEvents older than this one.
+	if id > maxStoredEvents {
+		byteOrder.PutUint64(key[:], id-maxStoredEvents)
+		if err := eventsBkt.Delete(key[:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// serializeEvent serializes the given event without its ID, which is the key
+// the event is stored under.
+func serializeEvent(event *Event) []byte {
+	var b bytes.Buffer
+	b.WriteByte(byte(event.Type))
+	b.WriteByte(byte(event.State))
+
+	var timestamp [8]byte
+	byteOrder.PutUint64(timestamp[:], uint64(event.Timestamp.Unix()))
+	b.Write(timestamp[:])
+
+	b.Write(event.LocalPublicKey.SerializeCompressed())
+	b.WriteString(event.Label)
+
+	return b.Bytes()
+}
+
+// deserializeEvent deserializes the event with the given ID.
+func deserializeEvent(id uint64, b []byte) (*Event, error) {
+	if len(b) < eventHeaderLen {
+		return nil, fmt.Errorf("invalid session event %d of length %d",
+			id, len(b))
+	}
+
+	pubKey, err := btcec.ParsePubKey(b[10:eventHeaderLen])
+	if err != nil {
+		return nil, fmt.Errorf("invalid local public key of session "+
+			"event %d: %v", id, err)
+	}
+
+	return &Event{
+		ID:             id,
+		Type:           EventType(b[0]),
+		State:          State(b[1]),
+		Timestamp:      time.Unix(int64(byteOrder.Uint64(b[2:10])), 0),
+		LocalPublicKey: pubKey,
+		Label:          string(b[eventHeaderLen:]),
+	}, nil
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionEvents tests that creating, revoking and expiring sessions emits
+// the expected events to subscribers and stores them for later replay.
+func TestSessionEvents(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sub := db.SubscribeEvents()
+	t.Cleanup(sub.Cancel)
+
+	receive := func() *Event {
+		select {
+		case event := <-sub.Updates():
+			return event
+
+		case <-time.After(time.Second):
+			t.Fatalf("no session event received")
+			return nil
+		}
+	}
+
+	// Create a session that is later revoked and one that has already
+	// expired when it is revoked.
+	sess1 := newSession(t, db, "session 1", nil)
+	require.NoError(t, db.CreateSession(sess1))
+
+	sess2 := newSession(t, db, "session 2", nil)
+	sess2.Expiry = time.Now().Add(-time.Hour)
+	require.NoError(t, db.CreateSession(sess2))
+
+	require.NoError(t, db.RevokeSession(sess1.LocalPublicKey))
+	require.NoError(t, db.RevokeSession(sess2.LocalPublicKey))
+
+	// Revoking a session again must not emit another event.
+	require.NoError(t, db.RevokeSession(sess1.LocalPublicKey))
+
+	expected := []struct {
+		eventType EventType
+		label     string
+		state     State
+	}{
+		{EventCreated, "session 1", StateCreated},
+		{EventCreated, "session 2", StateCreated},
+		{EventRevoked, "session 1", StateRevoked},
+		{EventExpired, "session 2", StateRevoked},
+	}
+
+	for idx, exp := range expected {
+		event := receive()
+		require.EqualValues(t, idx+1, event.ID)
+		require.Equal(t, exp.eventType, event.Type)
+		require.Equal(t, exp.label, event.Label)
+		require.Equal(t, exp.state, event.State)
+	}
+
+	select {
+	case event := <-sub.Updates():
+		t.Fatalf("unexpected session event: %v", event)
+
+	default:
+	}
+
+	// The stored events must match the ones that were delivered, starting
+	// with the requested one.
+	events, err := db.ListEvents(3)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.EqualValues(t, 3, events[0].ID)
+	require.Equal(t, EventRevoked, events[0].Type)
+	require.True(t, events[0].LocalPublicKey.IsEqual(sess1.LocalPublicKey))
+	require.EqualValues(t, 4, events[1].ID)
+	require.Equal(t, EventExpired, events[1].Type)
+
+	events, err = db.ListEvents(0)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+}
+
+// TestExpiryWarning tests that an expiry warning is only emitted once per
+// session and never for a revoked session.
+func TestExpiryWarning(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sess1 := newSession(t, db, "session 1", nil)
+	require.NoError(t, db.CreateSession(sess1))
+
+	sess2 := newSession(t, db, "session 2", nil)
+	require.NoError(t, db.CreateSession(sess2))
+	require.NoError(t, db.RevokeSession(sess2.LocalPublicKey))
+
+	emitted, err := db.AddExpiryWarning(sess1.LocalPublicKey)
+	require.NoError(t, err)
+	require.True(t, emitted)
+
+	// The warning must not be emitted a second time.
+	emitted, err = db.AddExpiryWarning(sess1.LocalPublicKey)
+	require.NoError(t, err)
+	require.False(t, emitted)
+
+	emitted, err = db.AddExpiryWarning(sess2.LocalPublicKey)
+	require.NoError(t, err)
+	require.False(t, emitted)
+
+	events, err := db.ListEvents(0)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, EventExpiring, events[3].Type)
+	require.Equal(t, "session 1", events[3].Label)
+	require.Equal(t, StateCreated, events[3].State)
+}
@@ -0,0 +1,280 @@
+package session
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Sessions are exported in the following format, so that an export of one
+// litd version can be imported by any version that knows its format version:
+//
+//	<version: 1 byte> <payload>
+//
+// With version 0, the payload is the session in the same TLV format that is
+// used to store it. This includes the session's local private key, its
+// pairing secret and its macaroon root key ID. Since unknown TLV records are
+// ignored when a session is decoded, newer versions can add records without
+// changing the format version.
+//
+// With version 1, the export is encrypted with a passphrase and the payload
+// is:
+//
+//	<salt: 16 bytes> <nonce: 12 bytes> <ciphertext>
+//
+// The ciphertext is the version 0 export, encrypted with ChaCha20-Poly1305
+// and the version byte as additional data. The key is derived from the
+// passphrase and the salt with scrypt, using N=32768, r=8 and p=1.
+const (
+	// ExportVersionPlain is the format version of unencrypted exports.
+	ExportVersionPlain uint8 = 0
+
+	// ExportVersionEncrypted is the format version of exports that are
+	// encrypted with a passphrase.
+	ExportVersionEncrypted uint8 = 1
+
+	// exportSaltLen is the length of the scrypt salt of an encrypted
+	// export.
+	exportSaltLen = 16
+
+	// The scrypt parameters that the key of an encrypted export is
+	// derived with.
+	exportScryptN = 32768
+	exportScryptR = 8
+	exportScryptP = 1
+)
+
+var (
+	// ErrSessionExists is returned when a session is imported that
+	// already exists in the store.
+	ErrSessionExists = errors.New("session already exists")
+
+	// ErrExportPassphrase is returned when an encrypted export is decoded
+	// without a passphrase or with the wrong one.
+	ErrExportPassphrase = errors.New("wrong or missing passphrase for " +
+		"encrypted session export")
+)
+
+// EncodeExport serializes the given session, including its local private key,
+// pairing secret and macaroon root key ID, into the export format. If a
+// passphrase is given, the export is encrypted with it.
+func EncodeExport(session *Session, passphrase []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := buf.WriteByte(ExportVersionPlain); err != nil {
+		return nil, err
+	}
+
+	if err := SerializeSession(&buf, session); err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	var salt [exportSaltLen]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := exportCipher(passphrase, salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := []byte{ExportVersionEncrypted}
+	export := append(header, salt[:]...)
+	export = append(export, nonce...)
+
+	return aead.Seal(export, nonce, buf.Bytes(), header), nil
+}
+
+// DecodeExport parses a session that was serialized with EncodeExport. The
+// passphrase is only needed if the export is encrypted.
+func DecodeExport(export, passphrase []byte) (*Session, error) {
+	if len(export) == 0 {
+		return nil, errors.New("exported session is empty")
+	}
+
+	switch export[0] {
+	case ExportVersionPlain:
+
+	case ExportVersionEncrypted:
+		if len(passphrase) == 0 {
+			return nil, ErrExportPassphrase
+		}
+
+		if len(export) < 1+exportSaltLen+chacha20poly1305.NonceSize {
+			return nil, errors.New("encrypted session export is " +
+				"too short")
+		}
+
+		salt := export[1 : 1+exportSaltLen]
+		aead, err := exportCipher(passphrase, salt)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce := export[1+exportSaltLen : 1+exportSaltLen+
+			aead.NonceSize()]
+		ciphertext := export[1+exportSaltLen+aead.NonceSize():]
+
+		export, err = aead.Open(nil, nonce, ciphertext, export[:1])
+		if err != nil {
+			return nil, ErrExportPassphrase
+		}
+
+		// Only an unencrypted export may be encrypted.
+		if len(export) == 0 || export[0] != ExportVersionPlain {
+			return nil, errors.New("invalid encrypted session " +
+				"export")
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown session export version %d",
+			export[0])
+	}
+
+	session, err := DeserializeSession(bytes.NewReader(export[1:]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode exported session: %v",
+			err)
+	}
+
+	if session.LocalPrivateKey == nil {
+		return nil, errors.New("exported session has no local key")
+	}
+
+	return session, nil
+}
+
+// exportCipher derives the key of an encrypted export from the given
+// passphrase and salt and returns the cipher that uses it.
+func exportCipher(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(
+		passphrase, salt, exportScryptN, exportScryptR, exportScryptP,
+		chacha20poly1305.KeySize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.New(key)
+}
+
+// ImportSession inserts a session that was exported from another store. The
+// session keeps its keys, pairing secret, state and ID, so a remote client
+// that paired with it can continue to use it. If a session with the same
+// local public key already exists, ErrSessionExists is returned unless
+// overwrite is set, in which case the existing session is replaced. Only a
+// session with the same ID can be replaced. If the group of a linked session
+// isn't known to the store, the session becomes the first of its own group.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) ImportSession(session *Session, overwrite bool) error {
+	sessionKey := getSessionKey(session)
+
+	var event *Event
+	err := db.Update(func(tx *bbolt.Tx) error {
+		sessionBucket, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		var existing *Session
+		if v := sessionBucket.Get(sessionKey); len(v) != 0 {
+			if !overwrite {
+				return fmt.Errorf("%w: local public key %x",
+					ErrSessionExists, sessionKey)
+			}
+
+			existing, err = DeserializeSession(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			if existing.ID != session.ID {
+				return fmt.Errorf("existing session with "+
+					"local public key %x has a different "+
+					"ID %x", sessionKey, existing.ID[:])
+			}
+		}
+
+		switch {
+		// The replaced session keeps its index entries and its group.
+		case existing != nil:
+			session.GroupID = existing.GroupID
+
+		default:
+			_, err := getKeyForID(sessionBucket, session.ID)
+			if err == nil {
+				return fmt.Errorf("%w: ID %x", ErrSessionExists,
+					session.ID[:])
+			}
+
+			if session.GroupID != session.ID {
+				_, err := getKeyForID(
+					sessionBucket, session.GroupID,
+				)
+				if err != nil {
+					session.GroupID = session.ID
+				}
+			}
+
+			err = addIDToKeyPair(
+				sessionBucket, session.ID, sessionKey,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = addIDToGroupIDPair(
+				sessionBucket, session.ID, session.GroupID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := SerializeSession(&buf, session); err != nil {
+			return err
+		}
+
+		err = sessionBucket.Put(sessionKey, buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		// The expiry warning of a replaced session is emitted again
+		// for the imported expiry.
+		warningsBkt := tx.Bucket(expiryWarningsBucketKey)
+		if warningsBkt != nil {
+			if err := warningsBkt.Delete(sessionKey); err != nil {
+				return err
+			}
+		}
+
+		event, err = addEvent(tx, EventCreated, session)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	db.notifyEventSubscribers(event)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestImportSession tests that an exported session can be imported into
+// another store and that existing sessions are only replaced if requested.
+func TestImportSession(t *testing.T) {
+	newTestDB := func() *DB {
+		db, err := NewDB(t.TempDir(), "test.db")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+
+		return db
+	}
+
+	source := newTestDB()
+	s1 := newSession(t, source, "session 1", nil)
+	require.NoError(t, source.CreateSession(s1))
+
+	s2 := newSession(t, source, "session 2", &s1.GroupID)
+	require.NoError(t, source.RevokeSession(s1.LocalPublicKey))
+	require.NoError(t, source.CreateSession(s2))
+
+	export, err := EncodeExport(s1, nil)
+	require.NoError(t, err)
+
+	// Exports of an unknown version are rejected.
+	_, err = DecodeExport(append([]byte{2}, export[1:]...), nil)
+	require.ErrorContains(t, err, "unknown session export version")
+
+	imported, err := DecodeExport(export, nil)
+	require.NoError(t, err)
+
+	target := newTestDB()
+	require.NoError(t, target.ImportSession(imported, false))
+
+	stored, err := target.GetSession(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, s1.ID, stored.ID)
+	require.Equal(t, s1.Label, stored.Label)
+	require.Equal(t, s1.PairingSecret, stored.PairingSecret)
+	require.Equal(t, s1.LocalPrivateKey, stored.LocalPrivateKey)
+	require.Equal(t, s1.MacaroonRootKey, stored.MacaroonRootKey)
+
+	byID, err := target.GetSessionByID(s1.ID)
+	require.NoError(t, err)
+	require.Equal(t, s1.LocalPublicKey, byID.LocalPublicKey)
+
+	// Importing the same session again fails unless it is overwritten.
+	err = target.ImportSession(imported, false)
+	require.ErrorIs(t, err, ErrSessionExists)
+
+	imported.Label = "renamed"
+	require.NoError(t, target.ImportSession(imported, true))
+
+	stored, err = target.GetSession(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", stored.Label)
+
+	// A linked session whose group is known joins the group.
+	export, err = EncodeExport(s2, nil)
+	require.NoError(t, err)
+	linked, err := DecodeExport(export, nil)
+	require.NoError(t, err)
+	require.NoError(t, target.ImportSession(linked, false))
+
+	ids, err := target.GetSessionIDs(s1.GroupID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ID{s1.ID, s2.ID}, ids)
+
+	// Without its group, a linked session becomes the first session of
+	// its own group.
+	linked, err = DecodeExport(export, nil)
+	require.NoError(t, err)
+
+	other := newTestDB()
+	require.NoError(t, other.ImportSession(linked, false))
+
+	stored, err = other.GetSession(s2.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, s2.ID, stored.GroupID)
+}
+
+// TestEncryptedExport tests that an encrypted export can only be decoded with
+// the passphrase it was encrypted with.
+func TestEncryptedExport(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	s1 := newSession(t, db, "session 1", nil)
+
+	passphrase := []byte("correct horse battery staple")
+	export, err := EncodeExport(s1, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, ExportVersionEncrypted, export[0])
+
+	// The export doesn't contain the private key in the clear.
+	plain, err := EncodeExport(s1, nil)
+	require.NoError(t, err)
+	require.NotContains(t, string(export), string(plain[1:]))
+
+	_, err = DecodeExport(export, nil)
+	require.ErrorIs(t, err, ErrExportPassphrase)
+
+	_, err = DecodeExport(export, []byte("wrong"))
+	require.ErrorIs(t, err, ErrExportPassphrase)
+
+	// A modified export is rejected.
+	modified := append([]byte(nil), export...)
+	modified[len(modified)-1] ^= 1
+	_, err = DecodeExport(modified, passphrase)
+	require.ErrorIs(t, err, ErrExportPassphrase)
+
+	decoded, err := DecodeExport(export, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, s1.LocalPrivateKey, decoded.LocalPrivateKey)
+	require.Equal(t, s1.PairingSecret, decoded.PairingSecret)
+	require.Equal(t, s1.Label, decoded.Label)
+}
@@ -69,6 +69,40 @@ type Session struct {
 	// group of sessions. If this is the very first session in the group
 	// then this will be the same as ID.
 	GroupID ID
+
+	// MaxStreams is the maximum number of streams that may be active at
+	// the same time for this session. A value of zero means that the
+	// limit configured for litd applies.
+	MaxStreams uint32
+
+	// RevocationReason is a human-readable description of why the session
+	// was revoked. It is only set if the session was revoked automatically.
+	RevocationReason string
+
+	// Metadata is a set of arbitrary key/value pairs that the user attached
+	// to the session, for example to correlate it with external systems.
+	Metadata map[string]string
+
+	// MacaroonExpiry is an optional hard deadline after which the
+	// session's macaroon is no longer valid, regardless of the session's
+	// own expiry. If it is zero, only the session's expiry applies.
+	MacaroonExpiry time.Time
+
+	// IdleTimeout is the duration after which the session is revoked if
+	// it wasn't used in the meantime. If it is zero, the idle timeout
+	// configured for litd applies.
+	IdleTimeout time.Duration
+}
+
+// MacaroonDeadline returns the time after which the session's macaroon is no
+// longer valid, which is the earlier one of the session's expiry and its
+// macaroon expiry.
+func (s *Session) MacaroonDeadline() time.Time {
+	if !s.MacaroonExpiry.IsZero() && s.MacaroonExpiry.Before(s.Expiry) {
+		return s.MacaroonExpiry
+	}
+
+	return s.Expiry
 }
 
 // MacaroonBaker is a function type for baking a super macaroon.
@@ -159,6 +193,31 @@ type Store interface {
 	// public key to be revoked.
 	RevokeSession(*btcec.PublicKey) error
 
+	// RevokeSessionWithReason updates the state of the session with the
+	// given local public key to be revoked and records the reason for the
+	// revocation.
+	RevokeSessionWithReason(key *btcec.PublicKey, reason string) error
+
+	// ExpireSessions revokes all sessions that are still active but
+	// expired before the given time and returns them.
+	ExpireSessions(now time.Time) ([]*Session, error)
+
+	// PurgeSessions removes the sessions that were revoked or expired
+	// before the given time from the store and returns them. The sessions
+	// of a group are only removed together.
+	PurgeSessions(before time.Time) ([]*Session, error)
+
+	// RenewSession sets the expiry of the session with the given local
+	// public key to the given time, which must be later than the current
+	// one, and returns the updated session. Revoked and expired sessions
+	// can't be renewed.
+	RenewSession(key *btcec.PublicKey, expiry time.Time) (*Session, error)
+
+	// ImportSession inserts a session that was exported from another
+	// store. If a session with the same local public key already exists,
+	// ErrSessionExists is returned unless overwrite is set.
+	ImportSession(session *Session, overwrite bool) error
+
 	// UpdateSessionRemotePubKey can be used to add the given remote pub key
 	// to the session with the given local pub key.
 	UpdateSessionRemotePubKey(localPubKey,
@@ -179,5 +238,74 @@ type Store interface {
 	CheckSessionGroupPredicate(groupID ID,
 		fn func(s *Session) bool) (bool, error)
 
+	// CheckConsistency audits the store for index entries without a
+	// session and sessions without index entries. If repair is true, the
+	// found inconsistencies are fixed.
+	CheckConsistency(repair bool) (*ConsistencyReport, error)
+
+	// AddSessionUsage adds the given request counts to the usage of the
+	// sessions.
+	AddSessionUsage(usage map[ID][]UsageDay) error
+
+	// GetSessionUsage returns the recorded usage of the session with the
+	// given ID, sorted by day.
+	GetSessionUsage(id ID) ([]UsageDay, error)
+
+	// PruneSessionUsage removes the usage of all days that started before
+	// the given time.
+	PruneSessionUsage(before time.Time) error
+
+	// AddSessionActivity adds the given request counts to the activity
+	// of the sessions.
+	AddSessionActivity(activity map[ID]Activity) error
+
+	// GetSessionActivity returns when the session with the given ID was
+	// last used and how many requests it made in total.
+	GetSessionActivity(id ID) (Activity, error)
+
+	// SubscribeEvents returns a new subscription that delivers each
+	// session event that happens from now on. The subscription must be
+	// cancelled once it is no longer needed.
+	SubscribeEvents() *EventSubscription
+
+	// ListEvents returns the stored session events with an ID of at least
+	// the given one, sorted by their ID. Only the most recent events are
+	// stored, so older events might be missing.
+	ListEvents(fromID uint64) ([]*Event, error)
+
+	// AddExpiryWarning emits an EventExpiring event for the session with
+	// the given local public key, unless one was already emitted for it or
+	// the session was revoked. True is returned if the event was emitted.
+	AddExpiryWarning(key *btcec.PublicKey) (bool, error)
+
+	// GetSuperMacaroonRootKeys returns the state of the super macaroon
+	// root keys.
+	GetSuperMacaroonRootKeys() (*SuperMacaroonRootKeys, error)
+
+	// RotateSuperMacaroonRootKey makes the root key with the given ID the
+	// current super macaroon root key and marks the given root keys as
+	// stale.
+	RotateSuperMacaroonRootKey(rootKeyID uint64, stale []uint64,
+		rotatedAt time.Time) error
+
+	// RenewRootKey removes the root key with the given ID from the stale
+	// root keys.
+	RenewRootKey(rootKeyID uint64) error
+
+	// AddIssuedRootKey records that LiT baked a super macaroon with the
+	// root key with the given ID that doesn't belong to a session or an
+	// account.
+	AddIssuedRootKey(rootKeyID uint64) error
+
+	// AddAPIKey stores the given API key.
+	AddAPIKey(key *APIKey) error
+
+	// ListAPIKeys returns all stored API keys.
+	ListAPIKeys() ([]*APIKey, error)
+
+	// DeleteAPIKey removes the API key with the given ID.
+	// ErrAPIKeyNotFound is returned if no such key exists.
+	DeleteAPIKey(id APIKeyID) error
+
 	IDToGroupIndex
 }
@@ -0,0 +1,59 @@
+package session
+
+import "time"
+
+// ListFilter describes which sessions should be returned when listing the
+// sessions of the store. Every field that is set must match, the zero value
+// matches all sessions.
+type ListFilter struct {
+	// States is the set of states of which one must be the session's
+	// state.
+	States []State
+
+	// ExpiresBefore, if set, only matches sessions that expire before the
+	// given time.
+	ExpiresBefore time.Time
+
+	// CreatedAfter, if set, only matches sessions that were created after
+	// the given time.
+	CreatedAfter time.Time
+
+	// Metadata is the set of key/value pairs that the session's metadata
+	// must contain.
+	Metadata map[string]string
+
+	// After, if set, only matches sessions that come after the given
+	// cursor.
+	After *PageCursor
+}
+
+// Matches returns true if the given session matches the filter.
+func (f *ListFilter) Matches(s *Session) bool {
+	if len(f.States) > 0 {
+		var stateMatches bool
+		for _, state := range f.States {
+			if s.State == state {
+				stateMatches = true
+				break
+			}
+		}
+
+		if !stateMatches {
+			return false
+		}
+	}
+
+	if !f.ExpiresBefore.IsZero() && !s.Expiry.Before(f.ExpiresBefore) {
+		return false
+	}
+
+	if !f.CreatedAfter.IsZero() && !s.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+
+	if f.After != nil && !f.After.IsBefore(s) {
+		return false
+	}
+
+	return s.MatchesMetadata(f.Metadata)
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestListFilter tests that sessions are matched by their state, expiry,
+// creation time and metadata.
+func TestListFilter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	sess := &Session{
+		State:     StateInUse,
+		Expiry:    now.Add(time.Hour),
+		CreatedAt: now.Add(-time.Hour),
+		Metadata: map[string]string{
+			"team": "payments",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		filter  ListFilter
+		matches bool
+	}{
+		{
+			name:    "empty filter",
+			matches: true,
+		},
+		{
+			name: "matching state",
+			filter: ListFilter{
+				States: []State{StateCreated, StateInUse},
+			},
+			matches: true,
+		},
+		{
+			name: "other state",
+			filter: ListFilter{
+				States: []State{StateRevoked, StateExpired},
+			},
+		},
+		{
+			name: "expires before",
+			filter: ListFilter{
+				ExpiresBefore: now.Add(2 * time.Hour),
+			},
+			matches: true,
+		},
+		{
+			name: "expires later",
+			filter: ListFilter{
+				ExpiresBefore: now.Add(time.Hour),
+			},
+		},
+		{
+			name: "created after",
+			filter: ListFilter{
+				CreatedAfter: now.Add(-2 * time.Hour),
+			},
+			matches: true,
+		},
+		{
+			name: "created earlier",
+			filter: ListFilter{
+				CreatedAfter: now.Add(-time.Hour),
+			},
+		},
+		{
+			name: "all fields match",
+			filter: ListFilter{
+				States:        []State{StateInUse},
+				ExpiresBefore: now.Add(2 * time.Hour),
+				CreatedAfter:  now.Add(-2 * time.Hour),
+				Metadata: map[string]string{
+					"team": "payments",
+				},
+			},
+			matches: true,
+		},
+		{
+			name: "metadata doesn't match",
+			filter: ListFilter{
+				States: []State{StateInUse},
+				Metadata: map[string]string{
+					"team": "routing",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(
+				t, test.matches, test.filter.Matches(sess),
+			)
+		})
+	}
+}
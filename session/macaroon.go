@@ -5,13 +5,16 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
 )
 
@@ -21,6 +24,10 @@ var (
 	SuperMacaroonRootKeyPrefix = [4]byte{0xFF, 0xEE, 0xDD, 0xCC}
 )
 
+// ErrMacaroonExpired is returned if a macaroon carries a time-before caveat
+// that has passed.
+var ErrMacaroonExpired = errors.New("macaroon has expired")
+
 // ID represents the id of a session.
 type ID [4]byte
 
@@ -66,13 +73,13 @@ func IsSuperMacaroon(macHex string) bool {
 		return false
 	}
 
-	return isSuperMacaroonRootKeyID(rootKeyID)
+	return IsSuperMacaroonRootKeyID(rootKeyID)
 }
 
-// isSuperMacaroonRootKeyID returns true if the given macaroon root key ID (also
+// IsSuperMacaroonRootKeyID returns true if the given macaroon root key ID (also
 // known as storage ID) is a super macaroon, which can be identified by its
 // first 4 bytes.
-func isSuperMacaroonRootKeyID(rootKeyID uint64) bool {
+func IsSuperMacaroonRootKeyID(rootKeyID uint64) bool {
 	rootKeyBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(rootKeyBytes, rootKeyID)
 	return bytes.HasPrefix(rootKeyBytes, SuperMacaroonRootKeyPrefix[:])
@@ -153,3 +160,30 @@ func NewSessionPrivKeyAndID() (*btcec.PrivateKey, ID, error) {
 
 	return privateKey, id, nil
 }
+
+// CheckMacaroonExpiry checks all time-before caveats of the given macaroon and
+// returns ErrMacaroonExpired if any of them has passed at the given time.
+func CheckMacaroonExpiry(mac *macaroon.Macaroon, now time.Time) error {
+	for _, caveat := range mac.Caveats() {
+		if caveat.VerificationId != nil {
+			continue
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil || cond != checkers.CondTimeBefore {
+			continue
+		}
+
+		expiry, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return fmt.Errorf("invalid caveat %q: %v",
+				string(caveat.Id), err)
+		}
+
+		if !now.Before(expiry) {
+			return ErrMacaroonExpired
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 )
 
 var (
@@ -28,10 +30,38 @@ var (
 func TestSuperMacaroonRootKeyID(t *testing.T) {
 	someBytes := [4]byte{02, 03, 44, 88}
 	rootKeyID := NewSuperMacaroonRootKeyID(someBytes)
-	require.True(t, isSuperMacaroonRootKeyID(rootKeyID))
-	require.False(t, isSuperMacaroonRootKeyID(123))
+	require.True(t, IsSuperMacaroonRootKeyID(rootKeyID))
+	require.False(t, IsSuperMacaroonRootKeyID(123))
 }
 
 func TestIsSuperMacaroon(t *testing.T) {
 	require.True(t, IsSuperMacaroon(testMacHex))
 }
+
+// TestCheckMacaroonExpiry tests that a macaroon is only reported as expired
+// once the earliest of its time-before caveats has passed.
+func TestCheckMacaroonExpiry(t *testing.T) {
+	mac, err := ParseMacaroon(testMacHex)
+	require.NoError(t, err)
+
+	// A macaroon without any time-before caveat never expires.
+	now := time.Now()
+	require.NoError(t, CheckMacaroonExpiry(mac, now))
+
+	early := now.Add(time.Hour)
+	late := now.Add(2 * time.Hour)
+	for _, expiry := range []time.Time{late, early} {
+		caveat := checkers.TimeBeforeCaveat(expiry)
+		require.NoError(t, mac.AddFirstPartyCaveat(
+			[]byte(caveat.Condition),
+		))
+	}
+
+	require.NoError(t, CheckMacaroonExpiry(mac, now))
+	require.ErrorIs(
+		t, CheckMacaroonExpiry(mac, early), ErrMacaroonExpired,
+	)
+	require.ErrorIs(
+		t, CheckMacaroonExpiry(mac, late), ErrMacaroonExpired,
+	)
+}
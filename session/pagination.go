@@ -0,0 +1,88 @@
+package session
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// pageCursorLen is the length of an encoded page cursor: the creation time as
+// Unix seconds followed by the compressed local public key.
+const pageCursorLen = 8 + btcec.PubKeyBytesLenCompressed
+
+// PageCursor is the position of a session in the list of all sessions when
+// they are ordered by their creation time and then by their local public key.
+// Neither of the two ever changes, so the position of a session is stable
+// even if other sessions are revoked in the meantime.
+type PageCursor struct {
+	// CreatedAt is the creation time of the session.
+	CreatedAt time.Time
+
+	// LocalPublicKey is the compressed local public key of the session.
+	LocalPublicKey [btcec.PubKeyBytesLenCompressed]byte
+}
+
+// NewPageCursor returns the cursor that points to the given session.
+func NewPageCursor(s *Session) *PageCursor {
+	c := &PageCursor{
+		CreatedAt: s.CreatedAt,
+	}
+	copy(c.LocalPublicKey[:], s.LocalPublicKey.SerializeCompressed())
+
+	return c
+}
+
+// DecodePageCursor decodes a cursor that was encoded with Encode.
+func DecodePageCursor(token string) (*PageCursor, error) {
+	b, err := hex.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %v", err)
+	}
+
+	if len(b) != pageCursorLen {
+		return nil, fmt.Errorf("invalid page token length %d, "+
+			"expected %d", len(b), pageCursorLen)
+	}
+
+	c := &PageCursor{
+		CreatedAt: time.Unix(int64(binary.BigEndian.Uint64(b[:8])), 0),
+	}
+	copy(c.LocalPublicKey[:], b[8:])
+
+	return c, nil
+}
+
+// Encode encodes the cursor as an opaque hex string.
+func (c *PageCursor) Encode() string {
+	var b [pageCursorLen]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(c.CreatedAt.Unix()))
+	copy(b[8:], c.LocalPublicKey[:])
+
+	return hex.EncodeToString(b[:])
+}
+
+// IsBefore returns true if the cursor comes before the given session, so the
+// session belongs on a page after the cursor.
+func (c *PageCursor) IsBefore(s *Session) bool {
+	createdAt := s.CreatedAt.Unix()
+	if createdAt != c.CreatedAt.Unix() {
+		return createdAt > c.CreatedAt.Unix()
+	}
+
+	return bytes.Compare(
+		c.LocalPublicKey[:], s.LocalPublicKey.SerializeCompressed(),
+	) < 0
+}
+
+// SortByCreation sorts the given sessions by their creation time and then by
+// their local public key, which is the order that page cursors refer to.
+func SortByCreation(sessions []*Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return NewPageCursor(sessions[i]).IsBefore(sessions[j])
+	})
+}
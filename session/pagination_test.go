@@ -0,0 +1,101 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPageCursorEncoding tests that a page cursor can be encoded and decoded
+// again and that invalid tokens are rejected.
+func TestPageCursorEncoding(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sess := newSession(t, db, "session", nil)
+	cursor := NewPageCursor(sess)
+
+	decoded, err := DecodePageCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor.CreatedAt.Unix(), decoded.CreatedAt.Unix())
+	require.Equal(t, cursor.LocalPublicKey, decoded.LocalPublicKey)
+
+	_, err = DecodePageCursor("not hex")
+	require.ErrorContains(t, err, "invalid page token")
+
+	_, err = DecodePageCursor("aabb")
+	require.ErrorContains(t, err, "invalid page token length")
+}
+
+// TestPagination tests that paging through the sessions of the store returns
+// every session exactly once and in a deterministic order, even if sessions
+// are revoked between the pages.
+func TestPagination(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	// Create a few sessions, some of them within the same second so that
+	// the local public key decides their order.
+	now := time.Unix(1700000000, 0)
+	createdAt := []time.Time{
+		now, now.Add(time.Second), now, now.Add(-time.Second),
+		now.Add(time.Second),
+	}
+	for _, created := range createdAt {
+		sess := newSession(t, db, "session", nil)
+		sess.CreatedAt = created
+		require.NoError(t, db.CreateSession(sess))
+	}
+
+	all, err := db.ListSessions(nil)
+	require.NoError(t, err)
+	SortByCreation(all)
+
+	var (
+		listed  []*Session
+		cursor  *PageCursor
+		revoked bool
+	)
+	for {
+		filter := &ListFilter{
+			After: cursor,
+		}
+		page, err := db.ListSessions(filter.Matches)
+		require.NoError(t, err)
+		SortByCreation(page)
+
+		if len(page) == 0 {
+			break
+		}
+		if len(page) > 2 {
+			page = page[:2]
+		}
+		listed = append(listed, page...)
+		cursor = NewPageCursor(page[len(page)-1])
+
+		// Revoke a session that is on a later page, which must not
+		// change the order of the remaining sessions.
+		if !revoked {
+			require.NoError(t, db.RevokeSession(
+				all[len(all)-1].LocalPublicKey,
+			))
+			revoked = true
+		}
+	}
+
+	require.Len(t, listed, len(all))
+	for idx := range all {
+		require.Equal(t, all[idx].ID, listed[idx].ID)
+	}
+}
@@ -0,0 +1,219 @@
+package session
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// rootKeysBucketKey is the top level bucket that holds the state of
+	// the super macaroon root keys.
+	//
+	// The root keys bucket has the following structure:
+	// super-macaroon-root-keys -> current-root-key-id -> <root-key-id>
+	// super-macaroon-root-keys -> stale-root-keys -> <root-key-id> -> <ts>
+	// super-macaroon-root-keys -> issued-root-keys -> <root-key-id> -> {}
+	//
+	// Both the root key IDs and the rotation timestamps, which are unix
+	// timestamps, are encoded as big endian uint64.
+	rootKeysBucketKey = []byte("super-macaroon-root-keys")
+
+	// currentRootKeyIDKey is the key under which the ID of the current
+	// super macaroon root key is stored.
+	currentRootKeyIDKey = []byte("current-root-key-id")
+
+	// staleRootKeysBucketKey is the sub bucket that holds the IDs of the
+	// root keys that were rotated out and the time they were rotated out
+	// at.
+	staleRootKeysBucketKey = []byte("stale-root-keys")
+
+	// issuedRootKeysBucketKey is the sub bucket that holds the IDs of the
+	// root keys that LiT baked super macaroons with that don't belong to
+	// a session or an account.
+	issuedRootKeysBucketKey = []byte("issued-root-keys")
+)
+
+// DefaultSuperMacaroonRootKeyID is the ID of the root key that super macaroons
+// are baked with by default, as long as it wasn't rotated yet.
+var DefaultSuperMacaroonRootKeyID = NewSuperMacaroonRootKeyID([4]byte{})
+
+// SuperMacaroonRootKeys is the state of the super macaroon root keys.
+type SuperMacaroonRootKeys struct {
+	// Current is the ID of the root key that super macaroons are baked
+	// with by default.
+	Current uint64
+
+	// Stale maps the IDs of the root keys that were rotated out to the
+	// time they were rotated out at. Macaroons baked with any of these
+	// root keys must no longer be accepted.
+	Stale map[uint64]time.Time
+
+	// Issued holds the IDs of the root keys that LiT baked super macaroons
+	// with that don't belong to a session or an account, for example the
+	// bootstrap super macaroon.
+	Issued map[uint64]bool
+}
+
+// GetSuperMacaroonRootKeys returns the state of the super macaroon root keys.
+// If the root key was never rotated, the current root key is the default one
+// and no root key is stale.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) GetSuperMacaroonRootKeys() (*SuperMacaroonRootKeys, error) {
+	keys := &SuperMacaroonRootKeys{
+		Current: DefaultSuperMacaroonRootKeyID,
+		Stale:   make(map[uint64]time.Time),
+		Issued:  make(map[uint64]bool),
+	}
+	err := db.View(func(tx *bbolt.Tx) error {
+		rootKeysBkt := tx.Bucket(rootKeysBucketKey)
+		if rootKeysBkt == nil {
+			return nil
+		}
+
+		if v := rootKeysBkt.Get(currentRootKeyIDKey); len(v) == 8 {
+			keys.Current = byteOrder.Uint64(v)
+		}
+
+		issuedBkt := rootKeysBkt.Bucket(issuedRootKeysBucketKey)
+		if issuedBkt != nil {
+			err := issuedBkt.ForEach(func(k, _ []byte) error {
+				if len(k) == 8 {
+					keys.Issued[byteOrder.Uint64(k)] = true
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		staleBkt := rootKeysBkt.Bucket(staleRootKeysBucketKey)
+		if staleBkt == nil {
+			return nil
+		}
+
+		return staleBkt.ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 8 {
+				return nil
+			}
+
+			keys.Stale[byteOrder.Uint64(k)] = time.Unix(
+				int64(byteOrder.Uint64(v)), 0,
+			)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RotateSuperMacaroonRootKey makes the root key with the given ID the current
+// super macaroon root key and marks the given root keys as stale.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) RotateSuperMacaroonRootKey(rootKeyID uint64, stale []uint64,
+	rotatedAt time.Time) error {
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		rootKeysBkt, err := tx.CreateBucketIfNotExists(
+			rootKeysBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		staleBkt, err := rootKeysBkt.CreateBucketIfNotExists(
+			staleRootKeysBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		var timestamp [8]byte
+		byteOrder.PutUint64(timestamp[:], uint64(rotatedAt.Unix()))
+
+		for _, id := range stale {
+			var key [8]byte
+			byteOrder.PutUint64(key[:], id)
+
+			// A root key that is already stale keeps the time it
+			// was first rotated out at.
+			if staleBkt.Get(key[:]) != nil {
+				continue
+			}
+
+			err := staleBkt.Put(key[:], timestamp[:])
+			if err != nil {
+				return err
+			}
+		}
+
+		var current [8]byte
+		byteOrder.PutUint64(current[:], rootKeyID)
+
+		// The new root key might have been used before and be stale, in
+		// which case it is fresh again now.
+		if err := staleBkt.Delete(current[:]); err != nil {
+			return err
+		}
+
+		return rootKeysBkt.Put(currentRootKeyIDKey, current[:])
+	})
+}
+
+// RenewRootKey removes the root key with the given ID from the stale root
+// keys. This must only be called once the root key itself was replaced.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) RenewRootKey(rootKeyID uint64) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		rootKeysBkt := tx.Bucket(rootKeysBucketKey)
+		if rootKeysBkt == nil {
+			return nil
+		}
+
+		staleBkt := rootKeysBkt.Bucket(staleRootKeysBucketKey)
+		if staleBkt == nil {
+			return nil
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], rootKeyID)
+
+		return staleBkt.Delete(key[:])
+	})
+}
+
+// AddIssuedRootKey records that LiT baked a super macaroon with the root key
+// with the given ID that doesn't belong to a session or an account.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) AddIssuedRootKey(rootKeyID uint64) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		rootKeysBkt, err := tx.CreateBucketIfNotExists(
+			rootKeysBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		issuedBkt, err := rootKeysBkt.CreateBucketIfNotExists(
+			issuedRootKeysBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], rootKeyID)
+
+		return issuedBkt.Put(key[:], []byte{})
+	})
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSuperMacaroonRootKeyRotation tests that rotating the super macaroon root
+// key is persisted and marks the old root keys as stale.
+func TestSuperMacaroonRootKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	db, err := NewDB(dir, "test.db")
+	require.NoError(t, err)
+
+	// Before the first rotation, the default root key is current.
+	keys, err := db.GetSuperMacaroonRootKeys()
+	require.NoError(t, err)
+	require.Equal(t, DefaultSuperMacaroonRootKeyID, keys.Current)
+	require.Empty(t, keys.Stale)
+
+	var (
+		first     = NewSuperMacaroonRootKeyID([4]byte{1})
+		second    = NewSuperMacaroonRootKeyID([4]byte{2})
+		rotatedAt = time.Unix(1700000000, 0)
+	)
+	err = db.RotateSuperMacaroonRootKey(
+		first, []uint64{DefaultSuperMacaroonRootKeyID, second},
+		rotatedAt,
+	)
+	require.NoError(t, err)
+
+	// A second rotation keeps the time at which the root keys were first
+	// rotated out.
+	err = db.RotateSuperMacaroonRootKey(
+		second, []uint64{first, DefaultSuperMacaroonRootKeyID},
+		rotatedAt.Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	// The rotation must survive a restart.
+	require.NoError(t, db.Close())
+	db, err = NewDB(dir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	keys, err = db.GetSuperMacaroonRootKeys()
+	require.NoError(t, err)
+	require.Equal(t, second, keys.Current)
+	require.Equal(t, map[uint64]time.Time{
+		DefaultSuperMacaroonRootKeyID: rotatedAt,
+		first:                         rotatedAt.Add(time.Hour),
+	}, keys.Stale)
+
+	// Renewing a root key removes it from the stale ones.
+	require.NoError(t, db.RenewRootKey(first))
+
+	keys, err = db.GetSuperMacaroonRootKeys()
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]time.Time{
+		DefaultSuperMacaroonRootKeyID: rotatedAt,
+	}, keys.Stale)
+
+	// Root keys that LiT issued super macaroons with are remembered.
+	require.Empty(t, keys.Issued)
+	require.NoError(t, db.AddIssuedRootKey(first))
+	require.NoError(t, db.AddIssuedRootKey(first))
+
+	keys, err = db.GetSuperMacaroonRootKeys()
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]bool{first: true}, keys.Issued)
+}
@@ -92,6 +92,10 @@ type Server struct {
 	activeSessions    map[sessionID]*mailboxSession
 	activeSessionsMtx sync.Mutex
 
+	// draining is set once the server drains its sessions for shutdown,
+	// after which no new sessions are started.
+	draining bool
+
 	quit chan struct{}
 }
 
@@ -110,6 +114,10 @@ func (s *Server) StartSession(session *Session, authData []byte,
 	s.activeSessionsMtx.Lock()
 	defer s.activeSessionsMtx.Unlock()
 
+	if s.draining {
+		return nil, fmt.Errorf("session server is shutting down")
+	}
+
 	var id sessionID
 	copy(id[:], session.LocalPublicKey.SerializeCompressed())
 
@@ -144,6 +152,31 @@ func (s *Server) StopSession(localPublicKey *btcec.PublicKey) error {
 	return nil
 }
 
+// Drain stops the gRPC servers of all active sessions from accepting new calls
+// and blocks until the calls in flight completed. No new sessions are started
+// afterwards. Stop can be called while Drain is blocking to cut off the
+// remaining calls.
+func (s *Server) Drain() {
+	s.activeSessionsMtx.Lock()
+	s.draining = true
+	servers := make([]*grpc.Server, 0, len(s.activeSessions))
+	for _, session := range s.activeSessions {
+		servers = append(servers, session.server)
+	}
+	s.activeSessionsMtx.Unlock()
+
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server *grpc.Server) {
+			defer wg.Done()
+
+			server.GracefulStop()
+		}(server)
+	}
+	wg.Wait()
+}
+
 func (s *Server) Stop() {
 	s.activeSessionsMtx.Lock()
 	defer s.activeSessionsMtx.Unlock()
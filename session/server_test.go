@@ -0,0 +1,42 @@
+package session
+
+import (
+	"net"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestServerDrain tests that draining the server stops the gRPC servers of
+// the active sessions and that no new sessions are started afterwards.
+func TestServerDrain(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	serveErr := make(chan error, 1)
+	sess := newMailboxSession()
+	sess.server = grpc.NewServer()
+	go func() {
+		serveErr <- sess.server.Serve(lis)
+	}()
+
+	s := NewServer(grpc.NewServer)
+	s.activeSessions[sessionID{1}] = sess
+
+	// Serve returns once the session's server is stopped. It may not have
+	// started serving yet, in which case it returns an error right away.
+	s.Drain()
+	<-serveErr
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = s.StartSession(
+		&Session{LocalPublicKey: privKey.PubKey()}, nil, nil, nil,
+	)
+	require.ErrorContains(t, err, "shutting down")
+
+	// Stopping the drained server must not block.
+	s.Stop()
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"fmt"
+)
+
+const (
+	// MaxMetadataEntries is the maximum number of key/value pairs that can
+	// be attached to a session.
+	MaxMetadataEntries = 16
+
+	// MaxMetadataKeyLength is the maximum length in bytes of a metadata
+	// key.
+	MaxMetadataKeyLength = 64
+
+	// MaxMetadataValueLength is the maximum length in bytes of a metadata
+	// value.
+	MaxMetadataValueLength = 256
+)
+
+// ValidateMetadata makes sure the given session metadata is within the size
+// limits.
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataEntries {
+		return fmt.Errorf("too many metadata entries: %d, the maximum "+
+			"is %d", len(metadata), MaxMetadataEntries)
+	}
+
+	for key, value := range metadata {
+		if key == "" {
+			return fmt.Errorf("metadata key must not be empty")
+		}
+
+		if len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("metadata key %q is too long: %d "+
+				"bytes, the maximum is %d", key, len(key),
+				MaxMetadataKeyLength)
+		}
+
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("value of metadata key %q is too "+
+				"long: %d bytes, the maximum is %d", key,
+				len(value), MaxMetadataValueLength)
+		}
+	}
+
+	return nil
+}
+
+// MatchesMetadata returns true if the session's metadata contains all the
+// key/value pairs of the given filter. An empty filter matches all sessions.
+func (s *Session) MatchesMetadata(filter map[string]string) bool {
+	for key, value := range filter {
+		sessValue, ok := s.Metadata[key]
+		if !ok || sessValue != value {
+			return false
+		}
+	}
+
+	return true
+}
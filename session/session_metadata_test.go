@@ -0,0 +1,115 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateMetadata tests that session metadata that exceeds the size
+// limits is rejected.
+func TestValidateMetadata(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxMetadataEntries; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		err      string
+	}{
+		{
+			name: "no metadata",
+		},
+		{
+			name: "valid metadata",
+			metadata: map[string]string{
+				"team":   "payments",
+				"ticket": "JIRA-123",
+				"empty":  "",
+			},
+		},
+		{
+			name:     "too many entries",
+			metadata: tooMany,
+			err:      "too many metadata entries",
+		},
+		{
+			name: "empty key",
+			metadata: map[string]string{
+				"": "value",
+			},
+			err: "must not be empty",
+		},
+		{
+			name: "key too long",
+			metadata: map[string]string{
+				strings.Repeat("k", MaxMetadataKeyLength+1): "v",
+			},
+			err: "is too long",
+		},
+		{
+			name: "value too long",
+			metadata: map[string]string{
+				"key": strings.Repeat(
+					"v", MaxMetadataValueLength+1,
+				),
+			},
+			err: "value of metadata key \"key\" is too long",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateMetadata(test.metadata)
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+// TestMatchesMetadata tests that sessions are matched against a metadata
+// filter correctly.
+func TestMatchesMetadata(t *testing.T) {
+	t.Parallel()
+
+	sess := &Session{
+		Metadata: map[string]string{
+			"team":   "payments",
+			"ticket": "JIRA-123",
+		},
+	}
+
+	require.True(t, sess.MatchesMetadata(nil))
+	require.True(t, sess.MatchesMetadata(map[string]string{
+		"team": "payments",
+	}))
+	require.True(t, sess.MatchesMetadata(map[string]string{
+		"team":   "payments",
+		"ticket": "JIRA-123",
+	}))
+	require.False(t, sess.MatchesMetadata(map[string]string{
+		"team": "treasury",
+	}))
+	require.False(t, sess.MatchesMetadata(map[string]string{
+		"team": "payments",
+		"env":  "prod",
+	}))
+
+	noMetadata := &Session{}
+	require.True(t, noMetadata.MatchesMetadata(nil))
+	require.False(t, noMetadata.MatchesMetadata(map[string]string{
+		"team": "payments",
+	}))
+}
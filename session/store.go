@@ -53,6 +53,14 @@ var (
 	// ErrDBInitErr is returned when a bucket that we expect to have been
 	// set up during DB initialisation is not found.
 	ErrDBInitErr = errors.New("db did not initialise properly")
+
+	// ErrSessionRevoked is returned when we attempt to renew a session
+	// that was revoked.
+	ErrSessionRevoked = errors.New("session is revoked")
+
+	// ErrSessionExpired is returned when we attempt to renew a session
+	// that already expired.
+	ErrSessionExpired = errors.New("session is expired")
 )
 
 // getSessionKey returns the key for a session.
@@ -71,7 +79,8 @@ func (db *DB) CreateSession(session *Session) error {
 	}
 	sessionKey := getSessionKey(session)
 
-	return db.Update(func(tx *bbolt.Tx) error {
+	var event *Event
+	err := db.Update(func(tx *bbolt.Tx) error {
 		sessionBucket, err := getBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
@@ -150,8 +159,22 @@ func (db *DB) CreateSession(session *Session) error {
 			return err
 		}
 
-		return sessionBucket.Put(sessionKey, buf.Bytes())
+		err = sessionBucket.Put(sessionKey, buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		event, err = addEvent(tx, EventCreated, session)
+
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	db.notifyEventSubscribers(event)
+
+	return nil
 }
 
 // UpdateSessionRemotePubKey can be used to add the given remote pub key
@@ -267,8 +290,21 @@ func (db *DB) ListSessions(filterFn func(s *Session) bool) ([]*Session, error) {
 //
 // NOTE: this is part of the Store interface.
 func (db *DB) RevokeSession(key *btcec.PublicKey) error {
-	var session *Session
-	return db.Update(func(tx *bbolt.Tx) error {
+	return db.RevokeSessionWithReason(key, "")
+}
+
+// RevokeSessionWithReason updates the state of the session with the given
+// local public key to be revoked and records the reason for the revocation.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) RevokeSessionWithReason(key *btcec.PublicKey,
+	reason string) error {
+
+	var (
+		session *Session
+		event   *Event
+	)
+	err := db.Update(func(tx *bbolt.Tx) error {
 		sessionBucket, err := getBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
@@ -284,16 +320,124 @@ func (db *DB) RevokeSession(key *btcec.PublicKey) error {
 			return err
 		}
 
+		wasRevoked := session.State == StateRevoked
+
 		session.State = StateRevoked
 		session.RevokedAt = time.Now()
+		session.RevocationReason = reason
+
+		var buf bytes.Buffer
+		if err := SerializeSession(&buf, session); err != nil {
+			return err
+		}
+
+		err = sessionBucket.Put(key.SerializeCompressed(), buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		// Revoking a session again doesn't change its state, so there
+		// is nothing to report. Sessions are revoked once they expire,
+		// which we report as such.
+		if wasRevoked {
+			return nil
+		}
+
+		eventType := EventRevoked
+		if !session.Expiry.After(session.RevokedAt) {
+			eventType = EventExpired
+		}
+		event, err = addEvent(tx, eventType, session)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if event != nil {
+		db.notifyEventSubscribers(event)
+	}
+
+	return nil
+}
+
+// RenewSession sets the expiry of the session with the given local public key
+// to the given time, which must be later than the current one, and returns the
+// updated session. Revoked and expired sessions can't be renewed. The expiry
+// warning of the session is reset, so it is emitted again before the new
+// expiry.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) RenewSession(key *btcec.PublicKey,
+	expiry time.Time) (*Session, error) {
+
+	var (
+		session *Session
+		event   *Event
+	)
+	err := db.Update(func(tx *bbolt.Tx) error {
+		sessionBucket, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		sessionBytes := sessionBucket.Get(key.SerializeCompressed())
+		if len(sessionBytes) == 0 {
+			return ErrSessionNotFound
+		}
+
+		session, err = DeserializeSession(bytes.NewReader(sessionBytes))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case session.State == StateRevoked:
+			return ErrSessionRevoked
+
+		case session.State == StateExpired ||
+			!session.Expiry.After(time.Now()):
+
+			return ErrSessionExpired
+
+		case !expiry.After(session.Expiry):
+			return fmt.Errorf("new expiry %v must be later than "+
+				"the current expiry %v", expiry,
+				session.Expiry)
+		}
+
+		session.Expiry = expiry
 
 		var buf bytes.Buffer
 		if err := SerializeSession(&buf, session); err != nil {
 			return err
 		}
 
-		return sessionBucket.Put(key.SerializeCompressed(), buf.Bytes())
+		err = sessionBucket.Put(key.SerializeCompressed(), buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		warningsBkt := tx.Bucket(expiryWarningsBucketKey)
+		if warningsBkt != nil {
+			err := warningsBkt.Delete(key.SerializeCompressed())
+			if err != nil {
+				return err
+			}
+		}
+
+		event, err = addEvent(tx, EventRenewed, session)
+
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	db.notifyEventSubscribers(event)
+
+	return session, nil
 }
 
 // GetSessionByID fetches the session with the given ID.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
 )
 
 // TestBasicSessionStore tests the basic getters and setters of the session
@@ -84,6 +85,17 @@ func TestBasicSessionStore(t *testing.T) {
 	session1, err = db.GetSession(s1.LocalPublicKey)
 	require.NoError(t, err)
 	require.Equal(t, session1.State, StateRevoked)
+	require.Empty(t, session1.RevocationReason)
+
+	// Revoke another session with a reason and assert that the reason is
+	// persisted.
+	reason := "too many authentication failures"
+	err = db.RevokeSessionWithReason(s2.LocalPublicKey, reason)
+	require.NoError(t, err)
+	session2, err := db.GetSession(s2.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, StateRevoked, session2.State)
+	require.Equal(t, reason, session2.RevocationReason)
 }
 
 // TestLinkingSessions tests that session linking works as expected.
@@ -279,6 +291,145 @@ func TestCheckSessionGroupPredicate(t *testing.T) {
 	require.True(t, ok)
 }
 
+// TestCheckConsistency tests that inconsistencies between the sessions and
+// their indexes are detected and repaired.
+func TestCheckConsistency(t *testing.T) {
+	// Set up a new DB.
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	// Create a few sessions.
+	s1 := newSession(t, db, "session 1", nil)
+	s2 := newSession(t, db, "session 2", nil)
+	s3 := newSession(t, db, "session 3", nil)
+	require.NoError(t, db.CreateSession(s1))
+	require.NoError(t, db.CreateSession(s2))
+	require.NoError(t, db.CreateSession(s3))
+
+	// A freshly populated store should be consistent.
+	report, err := db.CheckConsistency(false)
+	require.NoError(t, err)
+	require.Empty(t, report.OrphanedIndexEntries)
+	require.Empty(t, report.UnindexedSessions)
+	require.Empty(t, report.DanglingGroupEntries)
+	require.Empty(t, report.CorruptSessions)
+
+	// Now we remove session 1 while leaving its index entries in place
+	// and remove the ID index entry of session 2.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		sessionBkt, err := getBucket(tx, sessionBucketKey)
+		require.NoError(t, err)
+
+		err = sessionBkt.Delete(getSessionKey(s1))
+		require.NoError(t, err)
+
+		return sessionBkt.Bucket(idIndexKey).DeleteBucket(s2.ID[:])
+	})
+	require.NoError(t, err)
+
+	// Session 2 can't be found by its ID anymore.
+	_, err = db.GetSessionByID(s2.ID)
+	require.Error(t, err)
+
+	// Without repairing, the inconsistencies should be reported but not
+	// fixed.
+	report, err = db.CheckConsistency(false)
+	require.NoError(t, err)
+	require.Equal(t, []ID{s1.ID}, report.OrphanedIndexEntries)
+	require.Equal(t, []ID{s2.ID}, report.UnindexedSessions)
+	require.Equal(t, []ID{s1.ID}, report.DanglingGroupEntries)
+	require.False(t, report.Repaired)
+
+	// Now repair the store.
+	report, err = db.CheckConsistency(true)
+	require.NoError(t, err)
+	require.Equal(t, []ID{s1.ID}, report.OrphanedIndexEntries)
+	require.Equal(t, []ID{s2.ID}, report.UnindexedSessions)
+	require.True(t, report.Repaired)
+
+	// The store should now be consistent again and session 2 should be
+	// found by its ID and group ID again.
+	report, err = db.CheckConsistency(false)
+	require.NoError(t, err)
+	require.Empty(t, report.OrphanedIndexEntries)
+	require.Empty(t, report.UnindexedSessions)
+	require.Empty(t, report.DanglingGroupEntries)
+
+	sess, err := db.GetSessionByID(s2.ID)
+	require.NoError(t, err)
+	require.Equal(t, s2.Label, sess.Label)
+
+	groupID, err := db.GetGroupID(s2.ID)
+	require.NoError(t, err)
+	require.Equal(t, s2.GroupID, groupID)
+
+	ids, err := db.GetSessionIDs(s2.GroupID)
+	require.NoError(t, err)
+	require.Equal(t, []ID{s2.ID}, ids)
+
+	_, err = db.GetGroupID(s1.ID)
+	require.Error(t, err)
+}
+
+// TestRenewSession tests that only sessions that are neither revoked nor
+// expired can be renewed and that the expiry warning is emitted again after a
+// renewal.
+func TestRenewSession(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	s1 := newSession(t, db, "session 1", nil)
+	s1.Expiry = time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, db.CreateSession(s1))
+
+	emitted, err := db.AddExpiryWarning(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.True(t, emitted)
+
+	// The new expiry must be later than the current one.
+	_, err = db.RenewSession(
+		s1.LocalPublicKey, s1.Expiry.Add(-time.Minute),
+	)
+	require.ErrorContains(t, err, "must be later")
+
+	newExpiry := s1.Expiry.Add(time.Hour)
+	renewed, err := db.RenewSession(s1.LocalPublicKey, newExpiry)
+	require.NoError(t, err)
+	require.Equal(t, newExpiry.Unix(), renewed.Expiry.Unix())
+
+	stored, err := db.GetSession(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, newExpiry.Unix(), stored.Expiry.Unix())
+
+	// The renewal is reported and the expiry warning can be emitted again.
+	events, err := db.ListEvents(0)
+	require.NoError(t, err)
+	require.Equal(t, EventRenewed, events[len(events)-1].Type)
+
+	emitted, err = db.AddExpiryWarning(s1.LocalPublicKey)
+	require.NoError(t, err)
+	require.True(t, emitted)
+
+	// An expired session can't be renewed.
+	s2 := newSession(t, db, "session 2", nil)
+	s2.Expiry = time.Now().Add(-time.Minute)
+	require.NoError(t, db.CreateSession(s2))
+
+	_, err = db.RenewSession(s2.LocalPublicKey, newExpiry)
+	require.ErrorIs(t, err, ErrSessionExpired)
+
+	// Neither can a revoked session.
+	require.NoError(t, db.RevokeSession(s1.LocalPublicKey))
+	_, err = db.RenewSession(s1.LocalPublicKey, newExpiry.Add(time.Hour))
+	require.ErrorIs(t, err, ErrSessionRevoked)
+}
+
 func newSession(t *testing.T, db Store, label string,
 	linkedGroupID *ID) *Session {
 
@@ -13,23 +13,28 @@ import (
 )
 
 const (
-	typeLabel           tlv.Type = 1
-	typeState           tlv.Type = 2
-	typeType            tlv.Type = 3
-	typeExpiry          tlv.Type = 4
-	typeServerAddr      tlv.Type = 5
-	typeDevServer       tlv.Type = 6
-	typeMacaroonRootKey tlv.Type = 7
-	typePairingSecret   tlv.Type = 9
-	typeLocalPrivateKey tlv.Type = 10
-	typeRemotePublicKey tlv.Type = 11
-	typeMacaroonRecipe  tlv.Type = 12
-	typeCreatedAt       tlv.Type = 13
-	typeFeaturesConfig  tlv.Type = 14
-	typeWithPrivacy     tlv.Type = 15
-	typeRevokedAt       tlv.Type = 16
-	typeGroupID         tlv.Type = 17
-	typePrivacyFlags    tlv.Type = 18
+	typeLabel            tlv.Type = 1
+	typeState            tlv.Type = 2
+	typeType             tlv.Type = 3
+	typeExpiry           tlv.Type = 4
+	typeServerAddr       tlv.Type = 5
+	typeDevServer        tlv.Type = 6
+	typeMacaroonRootKey  tlv.Type = 7
+	typePairingSecret    tlv.Type = 9
+	typeLocalPrivateKey  tlv.Type = 10
+	typeRemotePublicKey  tlv.Type = 11
+	typeMacaroonRecipe   tlv.Type = 12
+	typeCreatedAt        tlv.Type = 13
+	typeFeaturesConfig   tlv.Type = 14
+	typeWithPrivacy      tlv.Type = 15
+	typeRevokedAt        tlv.Type = 16
+	typeGroupID          tlv.Type = 17
+	typePrivacyFlags     tlv.Type = 18
+	typeMaxStreams       tlv.Type = 19
+	typeRevocationReason tlv.Type = 20
+	typeMetadata         tlv.Type = 21
+	typeMacaroonExpiry   tlv.Type = 22
+	typeIdleTimeout      tlv.Type = 23
 
 	// typeMacaroon is no longer used, but we leave it defined for backwards
 	// compatibility.
@@ -47,6 +52,9 @@ const (
 
 	typeFeatureName   tlv.Type = 1
 	typeFeatureConfig tlv.Type = 2
+
+	typeMetadataKey   tlv.Type = 1
+	typeMetadataValue tlv.Type = 2
 )
 
 // SerializeSession binary serializes the given session to the writer using the
@@ -179,6 +187,45 @@ func constructSessionTLVRecords(session *Session, withGroupID bool) (
 		tlv.MakePrimitiveRecord(typePrivacyFlags, &privacyFlags),
 	)
 
+	if session.MaxStreams != 0 {
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMaxStreams, &session.MaxStreams,
+		))
+	}
+
+	if session.RevocationReason != "" {
+		revocationReason := []byte(session.RevocationReason)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeRevocationReason, &revocationReason,
+		))
+	}
+
+	if len(session.Metadata) != 0 {
+		tlvRecords = append(tlvRecords, tlv.MakeDynamicRecord(
+			typeMetadata, &session.Metadata,
+			func() uint64 {
+				return recordSize(
+					metadataEncoder, &session.Metadata,
+				)
+			},
+			metadataEncoder, metadataDecoder,
+		))
+	}
+
+	if !session.MacaroonExpiry.IsZero() {
+		macExpiry := uint64(session.MacaroonExpiry.Unix())
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMacaroonExpiry, &macExpiry,
+		))
+	}
+
+	if session.IdleTimeout != 0 {
+		idleTimeout := uint64(session.IdleTimeout / time.Second)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeIdleTimeout, &idleTimeout,
+		))
+	}
+
 	return tlvRecords, nil
 }
 
@@ -191,9 +238,11 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		pairingSecret, privateKey                  []byte
 		state, typ, devServer, privacy             uint8
 		expiry, createdAt, revokedAt, privacyFlags uint64
+		macExpiry, idleTimeout                     uint64
 		macRecipe                                  MacaroonRecipe
 		featureConfig                              FeaturesConfig
-		groupID                                    []byte
+		metadata                                   map[string]string
+		groupID, revocationReason                  []byte
 	)
 	tlvStream, err := tlv.NewStream(
 		tlv.MakePrimitiveRecord(typeLabel, &label),
@@ -223,6 +272,14 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		tlv.MakePrimitiveRecord(typeRevokedAt, &revokedAt),
 		tlv.MakePrimitiveRecord(typeGroupID, &groupID),
 		tlv.MakePrimitiveRecord(typePrivacyFlags, &privacyFlags),
+		tlv.MakePrimitiveRecord(typeMaxStreams, &session.MaxStreams),
+		tlv.MakePrimitiveRecord(typeRevocationReason, &revocationReason),
+		tlv.MakeDynamicRecord(
+			typeMetadata, &metadata, nil, metadataEncoder,
+			metadataDecoder,
+		),
+		tlv.MakePrimitiveRecord(typeMacaroonExpiry, &macExpiry),
+		tlv.MakePrimitiveRecord(typeIdleTimeout, &idleTimeout),
 	)
 	if err != nil {
 		return nil, err
@@ -241,6 +298,8 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 	session.CreatedAt = time.Unix(int64(createdAt), 0)
 	session.ServerAddr = string(serverAddr)
 	session.DevServer = devServer == 1
+	session.RevocationReason = string(revocationReason)
+	session.IdleTimeout = time.Duration(idleTimeout) * time.Second
 	session.WithPrivacyMapper = privacy == 1
 	session.PrivacyFlags, err = Deserialize(privacyFlags)
 	if err != nil {
@@ -251,6 +310,10 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		session.RevokedAt = time.Unix(int64(revokedAt), 0)
 	}
 
+	if macExpiry != 0 {
+		session.MacaroonExpiry = time.Unix(int64(macExpiry), 0)
+	}
+
 	if t, ok := parsedTypes[typeMacaroonRecipe]; ok && t == nil {
 		session.MacaroonRecipe = &macRecipe
 	}
@@ -269,6 +332,10 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		session.FeatureConfig = &featureConfig
 	}
 
+	if t, ok := parsedTypes[typeMetadata]; ok && t == nil {
+		session.Metadata = metadata
+	}
+
 	// The GroupID field might not be set for older sessions. So we attempt
 	// to read it from the DB, otherwise we set the group ID to the session
 	// ID.
@@ -383,6 +450,112 @@ func featureConfigDecoder(r io.Reader, val interface{}, buf *[8]byte,
 	return tlv.NewTypeForEncodingErr(val, "FeaturesConfig")
 }
 
+// metadataEncoder is a custom TLV encoder for the metadata record of a
+// session.
+func metadataEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*map[string]string); ok {
+		for k, v := range *v {
+			key := []byte(k)
+			value := []byte(v)
+
+			var entryTLVBytes bytes.Buffer
+			tlvStream, err := tlv.NewStream(
+				tlv.MakePrimitiveRecord(typeMetadataKey, &key),
+				tlv.MakePrimitiveRecord(
+					typeMetadataValue, &value,
+				),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = tlvStream.Encode(&entryTLVBytes)
+			if err != nil {
+				return err
+			}
+
+			// We encode the record with a varint length followed by
+			// the _raw_ TLV bytes.
+			tlvLen := uint64(len(entryTLVBytes.Bytes()))
+			if err := tlv.WriteVarInt(w, tlvLen, buf); err != nil {
+				return err
+			}
+
+			_, err = w.Write(entryTLVBytes.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "Metadata")
+}
+
+// metadataDecoder is a custom TLV decoder for the metadata record of a
+// session.
+func metadataDecoder(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if v, ok := val.(*map[string]string); ok {
+		metadata := make(map[string]string)
+
+		// Using this information, we'll create a new limited
+		// reader that'll return an EOF once the end has been
+		// reached so the stream stops consuming bytes.
+		innerTlvReader := io.LimitedReader{
+			R: r,
+			N: int64(l),
+		}
+
+		for {
+			// Read out the varint that encodes the size of this
+			// inner TLV record.
+			blobSize, err := tlv.ReadVarInt(&innerTlvReader, buf)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			innerInnerTlvReader := io.LimitedReader{
+				R: &innerTlvReader,
+				N: int64(blobSize),
+			}
+
+			var (
+				key   []byte
+				value []byte
+			)
+			tlvStream, err := tlv.NewStream(
+				tlv.MakePrimitiveRecord(
+					typeMetadataKey, &key,
+				),
+				tlv.MakePrimitiveRecord(
+					typeMetadataValue, &value,
+				),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = tlvStream.Decode(&innerInnerTlvReader)
+			if err != nil {
+				return err
+			}
+
+			metadata[string(key)] = string(value)
+		}
+
+		*v = metadata
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "Metadata", l, l)
+}
+
 // macaroonRecipeEncoder is a custom TLV encoder for a MacaroonRecipe record.
 func macaroonRecipeEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
 	if v, ok := val.(*MacaroonRecipe); ok {
@@ -62,6 +62,11 @@ func TestSerializeDeserializeSession(t *testing.T) {
 		caveats       []macaroon.Caveat
 		featureConfig map[string][]byte
 		linkedGroupID *ID
+		maxStreams    uint32
+		revokeReason  string
+		metadata      map[string]string
+		macExpiry     time.Time
+		idleTimeout   time.Duration
 	}{
 		{
 			name:     "revoked-at field",
@@ -102,6 +107,36 @@ func TestSerializeDeserializeSession(t *testing.T) {
 			},
 			linkedGroupID: &groupID,
 		},
+		{
+			name:       "max streams",
+			sessType:   TypeMacaroonCustom,
+			maxStreams: 10,
+		},
+		{
+			name:     "revocation reason",
+			sessType: TypeMacaroonCustom,
+			revokedAt: time.Date(
+				2023, 1, 10, 10, 10, 0, 0, time.UTC,
+			),
+			revokeReason: "too many authentication failures",
+		},
+		{
+			name:     "metadata",
+			sessType: TypeMacaroonCustom,
+			metadata: map[string]string{
+				"team":   "payments",
+				"ticket": "JIRA-123",
+				"empty":  "",
+			},
+		},
+		{
+			name:     "macaroon expiry",
+			sessType: TypeMacaroonAdmin,
+			macExpiry: time.Date(
+				2030, 1, 10, 10, 10, 0, 0, time.UTC,
+			),
+			idleTimeout: 7 * 24 * time.Hour,
+		},
 		{
 			name:     "session with no optional fields",
 			sessType: TypeMacaroonCustom,
@@ -118,6 +153,14 @@ func TestSerializeDeserializeSession(t *testing.T) {
 				"AutoFees":      {1, 2, 3, 4},
 				"AutoSomething": {4, 3, 4, 5, 6, 6},
 			},
+			maxStreams:   10,
+			revokeReason: "too many authentication failures",
+			metadata: map[string]string{
+				"team": "payments",
+			},
+			macExpiry: time.Date(
+				2030, 1, 10, 10, 10, 0, 0, time.UTC,
+			),
 		},
 	}
 
@@ -140,6 +183,11 @@ func TestSerializeDeserializeSession(t *testing.T) {
 			require.NoError(t, err)
 
 			session.RevokedAt = test.revokedAt
+			session.MaxStreams = test.maxStreams
+			session.RevocationReason = test.revokeReason
+			session.Metadata = test.metadata
+			session.MacaroonExpiry = test.macExpiry
+			session.IdleTimeout = test.idleTimeout
 
 			_, remotePubKey := btcec.PrivKeyFromBytes(testRootKey)
 			session.RemotePublicKey = remotePubKey
@@ -169,6 +217,13 @@ func TestSerializeDeserializeSession(t *testing.T) {
 			session.RevokedAt = time.Time{}
 			deserializedSession.RevokedAt = time.Time{}
 
+			require.Equal(
+				t, session.MacaroonExpiry.Unix(),
+				deserializedSession.MacaroonExpiry.Unix(),
+			)
+			session.MacaroonExpiry = time.Time{}
+			deserializedSession.MacaroonExpiry = time.Time{}
+
 			require.Equal(t, session, deserializedSession)
 		})
 	}
@@ -0,0 +1,262 @@
+package session
+
+import (
+	"bytes"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// usageBucketKey is the top level bucket that holds the number of
+	// requests each session made per day.
+	//
+	// The usage bucket has the following structure:
+	// session-usage -> <session-id> -> <day> -> <request count>
+	//
+	// The day is the unix timestamp of the start of the day in UTC, both
+	// the day and the count are encoded as big endian uint64.
+	usageBucketKey = []byte("session-usage")
+
+	// activityBucketKey is the top level bucket that holds when each
+	// session was last used and how many requests it made in total.
+	//
+	// The activity bucket has the following structure:
+	// session-activity -> <session-id> -> <last used> <request count>
+	//
+	// The last used time is a unix timestamp. Both it and the count are
+	// encoded as big endian uint64.
+	activityBucketKey = []byte("session-activity")
+)
+
+// UsageDay is the number of requests a session made within one day.
+type UsageDay struct {
+	// Day is the start of the day in UTC.
+	Day time.Time
+
+	// Requests is the number of requests that were made within the day.
+	Requests uint64
+}
+
+// Activity is the total number of requests a session made and the time of
+// the last one.
+type Activity struct {
+	// LastUsed is the time of the last request. It is the zero time if
+	// the session was never used.
+	LastUsed time.Time
+
+	// Requests is the total number of requests the session made.
+	Requests uint64
+}
+
+// UsageDayStart returns the start of the UTC day the given time falls into.
+func UsageDayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// AddSessionUsage adds the given request counts to the usage of the sessions.
+// The days of the counts are truncated to the start of their UTC day.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) AddSessionUsage(usage map[ID][]UsageDay) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		usageBkt, err := tx.CreateBucketIfNotExists(usageBucketKey)
+		if err != nil {
+			return err
+		}
+
+		for id, days := range usage {
+			sessionBkt, err := usageBkt.CreateBucketIfNotExists(
+				id[:],
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, day := range days {
+				var key [8]byte
+				byteOrder.PutUint64(
+					key[:], uint64(
+						UsageDayStart(day.Day).Unix(),
+					),
+				)
+
+				var count uint64
+				if v := sessionBkt.Get(key[:]); len(v) == 8 {
+					count = byteOrder.Uint64(v)
+				}
+
+				var value [8]byte
+				byteOrder.PutUint64(
+					value[:], count+day.Requests,
+				)
+
+				err := sessionBkt.Put(key[:], value[:])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetSessionUsage returns the recorded usage of the session with the given ID,
+// sorted by day. Days without requests are not included.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) GetSessionUsage(id ID) ([]UsageDay, error) {
+	var usage []UsageDay
+	err := db.View(func(tx *bbolt.Tx) error {
+		usageBkt := tx.Bucket(usageBucketKey)
+		if usageBkt == nil {
+			return nil
+		}
+
+		sessionBkt := usageBkt.Bucket(id[:])
+		if sessionBkt == nil {
+			return nil
+		}
+
+		return sessionBkt.ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 8 {
+				return nil
+			}
+
+			usage = append(usage, UsageDay{
+				Day: time.Unix(
+					int64(byteOrder.Uint64(k)), 0,
+				).UTC(),
+				Requests: byteOrder.Uint64(v),
+			})
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// PruneSessionUsage removes the usage of all days that started before the
+// given time. Sessions that have no usage left are removed completely.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) PruneSessionUsage(before time.Time) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		usageBkt := tx.Bucket(usageBucketKey)
+		if usageBkt == nil {
+			return nil
+		}
+
+		var cutoff [8]byte
+		byteOrder.PutUint64(cutoff[:], uint64(before.Unix()))
+
+		var emptySessions [][]byte
+		err := usageBkt.ForEachBucket(func(id []byte) error {
+			sessionBkt := usageBkt.Bucket(id)
+
+			// The keys are big endian timestamps, so the cursor
+			// visits the days in order.
+			c := sessionBkt.Cursor()
+			for k, _ := c.First(); k != nil; k, _ = c.First() {
+				if bytes.Compare(k, cutoff[:]) >= 0 {
+					break
+				}
+
+				if err := c.Delete(); err != nil {
+					return err
+				}
+			}
+
+			if k, _ := c.First(); k == nil {
+				emptySessions = append(emptySessions, id)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range emptySessions {
+			if err := usageBkt.DeleteBucket(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AddSessionActivity adds the given request counts to the activity of the
+// sessions. The last used time of a session is only moved forward.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) AddSessionActivity(activity map[ID]Activity) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		activityBkt, err := tx.CreateBucketIfNotExists(
+			activityBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		for id, add := range activity {
+			current := getActivity(activityBkt, id)
+
+			current.Requests += add.Requests
+			if add.LastUsed.After(current.LastUsed) {
+				current.LastUsed = add.LastUsed
+			}
+
+			var value [16]byte
+			byteOrder.PutUint64(
+				value[:8], uint64(current.LastUsed.Unix()),
+			)
+			byteOrder.PutUint64(value[8:], current.Requests)
+
+			if err := activityBkt.Put(id[:], value[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetSessionActivity returns the activity of the session with the given ID.
+// A session that was never used has a zero activity.
+//
+// NOTE: this is part of the Store interface.
+func (db *DB) GetSessionActivity(id ID) (Activity, error) {
+	var activity Activity
+	err := db.View(func(tx *bbolt.Tx) error {
+		activity = getActivity(tx.Bucket(activityBucketKey), id)
+
+		return nil
+	})
+
+	return activity, err
+}
+
+// getActivity reads the activity of the session with the given ID from the
+// given bucket, which may be nil.
+func getActivity(activityBkt *bbolt.Bucket, id ID) Activity {
+	if activityBkt == nil {
+		return Activity{}
+	}
+
+	v := activityBkt.Get(id[:])
+	if len(v) != 16 {
+		return Activity{}
+	}
+
+	return Activity{
+		LastUsed: time.Unix(int64(byteOrder.Uint64(v[:8])), 0),
+		Requests: byteOrder.Uint64(v[8:]),
+	}
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionUsage tests that the usage of sessions is added up per day and
+// that old days are pruned.
+func TestSessionUsage(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var (
+		id1  = ID{1}
+		id2  = ID{2}
+		day1 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		day2 = day1.Add(24 * time.Hour)
+	)
+
+	// A session without usage returns an empty result.
+	usage, err := db.GetSessionUsage(id1)
+	require.NoError(t, err)
+	require.Empty(t, usage)
+
+	// Counts within the same day are added up.
+	require.NoError(t, db.AddSessionUsage(map[ID][]UsageDay{
+		id1: {
+			{Day: day1.Add(time.Hour), Requests: 2},
+			{Day: day2.Add(5 * time.Hour), Requests: 1},
+		},
+		id2: {
+			{Day: day1, Requests: 7},
+		},
+	}))
+	require.NoError(t, db.AddSessionUsage(map[ID][]UsageDay{
+		id1: {
+			{Day: day1.Add(20 * time.Hour), Requests: 3},
+		},
+	}))
+
+	usage, err = db.GetSessionUsage(id1)
+	require.NoError(t, err)
+	require.Equal(t, []UsageDay{
+		{Day: day1, Requests: 5},
+		{Day: day2, Requests: 1},
+	}, usage)
+
+	// Pruning removes the days before the cutoff and the sessions that
+	// have no usage left.
+	require.NoError(t, db.PruneSessionUsage(day2))
+
+	usage, err = db.GetSessionUsage(id1)
+	require.NoError(t, err)
+	require.Equal(t, []UsageDay{{Day: day2, Requests: 1}}, usage)
+
+	usage, err = db.GetSessionUsage(id2)
+	require.NoError(t, err)
+	require.Empty(t, usage)
+}
+
+// TestSessionActivity tests that the request counts of sessions are added up
+// and that the last used time only moves forward.
+func TestSessionActivity(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var (
+		id1 = ID{1}
+		id2 = ID{2}
+		t1  = time.Unix(1709251200, 0)
+		t2  = t1.Add(time.Hour)
+	)
+
+	// A session that was never used has a zero activity.
+	activity, err := db.GetSessionActivity(id1)
+	require.NoError(t, err)
+	require.Equal(t, Activity{}, activity)
+
+	require.NoError(t, db.AddSessionActivity(map[ID]Activity{
+		id1: {LastUsed: t2, Requests: 3},
+		id2: {LastUsed: t1, Requests: 1},
+	}))
+
+	// An older last used time doesn't replace a newer one.
+	require.NoError(t, db.AddSessionActivity(map[ID]Activity{
+		id1: {LastUsed: t1, Requests: 2},
+	}))
+
+	activity, err = db.GetSessionActivity(id1)
+	require.NoError(t, err)
+	require.Equal(t, Activity{LastUsed: t2, Requests: 5}, activity)
+
+	activity, err = db.GetSessionActivity(id2)
+	require.NoError(t, err)
+	require.Equal(t, Activity{LastUsed: t1, Requests: 1}, activity)
+}
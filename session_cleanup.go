@@ -0,0 +1,201 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+)
+
+const (
+	// defaultSessionCleanupInterval is the default interval at which
+	// expired sessions are revoked and old sessions are purged.
+	defaultSessionCleanupInterval = time.Hour
+)
+
+// SessionCleanupConfig holds the options for cleaning up the session store.
+type SessionCleanupConfig struct {
+	CleanupInterval time.Duration `long:"cleanupinterval" description:"The interval at which sessions that expired are revoked and, if a retention is set, old sessions are removed from the session store."`
+	IdleTimeout     time.Duration `long:"idletimeout" description:"If set, active sessions that weren't used for this long, for example 720h, are revoked by the session cleanup. Sessions that were never used count from when they were created. A session can set its own idle timeout when it is created, which takes precedence. Set to 0 to only revoke the sessions that have their own idle timeout."`
+	Retention       time.Duration `long:"retention" description:"If set, sessions that were revoked or expired longer than this ago, for example 720h, are removed from the session store. They then no longer show up in ListSessions and their macaroon root keys are deleted. The sessions of a linked group are only removed once all of them are old enough. Set to 0 to keep all sessions."`
+}
+
+// validate checks the session cleanup options.
+func (c *SessionCleanupConfig) validate() error {
+	if c.CleanupInterval <= 0 {
+		return fmt.Errorf("cleanupinterval must be positive")
+	}
+
+	if c.Retention < 0 {
+		return fmt.Errorf("retention must not be negative")
+	}
+
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("idletimeout must not be negative")
+	}
+
+	return nil
+}
+
+// startCleanup periodically revokes the sessions that expired and purges the
+// ones that ended longer than the retention ago.
+func (s *sessionRpcServer) startCleanup() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.cleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := s.cleanupSessions(time.Now())
+				if err != nil {
+					log.Errorf("Unable to clean up "+
+						"sessions: %v", err)
+				}
+
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+}
+
+// cleanupSessions revokes the sessions that expired before the given time and
+// purges the ones that ended longer than the retention ago.
+//
+// Running sessions are revoked by their own timer once they expire, so this
+// mainly catches sessions that aren't running, for example because their type
+// isn't resumed.
+func (s *sessionRpcServer) cleanupSessions(now time.Time) error {
+	expired, err := s.cfg.db.ExpireSessions(now)
+	if err != nil {
+		return fmt.Errorf("error expiring sessions: %v", err)
+	}
+
+	for _, sess := range expired {
+		pubKey := sess.LocalPublicKey
+
+		log.Debugf("Revoked expired session %x",
+			pubKey.SerializeCompressed())
+
+		if s.cfg.autopilot != nil {
+			ctx, cancel := context.WithTimeout(
+				context.Background(), defaultConnectTimeout,
+			)
+			s.cfg.autopilot.SessionRevoked(ctx, pubKey)
+			cancel()
+		}
+
+		if err := s.sessionServer.StopSession(pubKey); err != nil {
+			log.Debugf("Error stopping session: %v", err)
+		}
+	}
+
+	if err := s.revokeIdleSessions(now); err != nil {
+		return fmt.Errorf("error revoking idle sessions: %v", err)
+	}
+
+	if s.cfg.sessionRetention == 0 {
+		return nil
+	}
+
+	// A purged session's ID and root key ID become available again. We
+	// hold the registration lock until the root keys are deleted, so a new
+	// session that happens to get the same ID can't end up with a root
+	// key that is deleted right after.
+	s.sessRegMu.Lock()
+	defer s.sessRegMu.Unlock()
+
+	purged, err := s.cfg.db.PurgeSessions(now.Add(-s.cfg.sessionRetention))
+	if err != nil {
+		return fmt.Errorf("error purging sessions: %v", err)
+	}
+
+	for _, sess := range purged {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), defaultConnectTimeout,
+		)
+		err := s.cfg.deleteMacRootKey(ctx, sess.MacaroonRootKey)
+		cancel()
+		if err != nil {
+			log.Warnf("Unable to delete root key of purged "+
+				"session %x: %v",
+				sess.LocalPublicKey.SerializeCompressed(), err)
+		}
+	}
+
+	if len(purged) > 0 {
+		log.Infof("Purged %d sessions that ended before %v",
+			len(purged), now.Add(-s.cfg.sessionRetention))
+	}
+
+	return nil
+}
+
+// revokeIdleSessions revokes the active sessions that weren't used within
+// their idle timeout before the given time. Sessions that were never used are
+// idle since they were created.
+func (s *sessionRpcServer) revokeIdleSessions(now time.Time) error {
+	sessions, err := s.cfg.db.ListSessions(func(sess *session.Session) bool {
+		if sess.State != session.StateCreated &&
+			sess.State != session.StateInUse {
+
+			return false
+		}
+
+		return sess.IdleTimeout != 0 || s.cfg.idleTimeout != 0
+	})
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	// The requests of the last minute might not be written to the store
+	// yet, so we write them first to not revoke a session that was just
+	// used.
+	if err := s.usage.flush(now); err != nil {
+		return err
+	}
+
+	for _, sess := range sessions {
+		idleTimeout := sess.IdleTimeout
+		if idleTimeout == 0 {
+			idleTimeout = s.cfg.idleTimeout
+		}
+
+		activity, err := s.cfg.db.GetSessionActivity(sess.ID)
+		if err != nil {
+			return err
+		}
+
+		idleSince := sess.CreatedAt
+		if activity.LastUsed.After(idleSince) {
+			idleSince = activity.LastUsed
+		}
+		if now.Sub(idleSince) < idleTimeout {
+			continue
+		}
+
+		log.Infof("Revoking session %x (label=%s) that wasn't used "+
+			"since %v", sess.ID[:], sess.Label, idleSince)
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(), defaultConnectTimeout,
+		)
+		err = s.revokeSession(
+			ctx, sess.LocalPublicKey, session.IdleSessionReason,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
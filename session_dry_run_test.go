@@ -0,0 +1,72 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestAddSessionDryRun tests that a dry run of AddSession returns the resolved
+// permissions of the session's macaroon without creating the session.
+func TestAddSessionDryRun(t *testing.T) {
+	permsMgr, err := perms.NewManager(false)
+	require.NoError(t, err)
+
+	permsMgr.RegisterSubServer(subservers.LOOP, map[string][]bakery.Op{
+		"/looprpc.SwapClient/LoopOut": {{
+			Entity: "swap", Action: "execute",
+		}},
+		"/looprpc.SwapClient/ListSwaps": {{
+			Entity: "swap", Action: "read",
+		}},
+	}, nil)
+
+	// The session store isn't set, so the test would fail if the session
+	// was created.
+	s := &sessionRpcServer{
+		cfg: &sessionRpcServerConfig{permMgr: permsMgr},
+	}
+	ctx := context.Background()
+	expiry := time.Now().Add(time.Hour)
+
+	resp, err := s.AddSession(ctx, &litrpc.AddSessionRequest{
+		SessionType:            litrpc.SessionType_TYPE_MACAROON_CUSTOM,
+		ExpiryTimestampSeconds: uint64(expiry.Unix()),
+		MacaroonCustomPermissions: []*litrpc.MacaroonPermission{{
+			Entity: "uri",
+			Action: "/looprpc.SwapClient/.*",
+		}},
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Session)
+	require.Equal(t, []*litrpc.MacaroonPermission{{
+		Entity: "uri", Action: "/looprpc.SwapClient/ListSwaps",
+	}, {
+		Entity: "uri", Action: "/looprpc.SwapClient/LoopOut",
+	}}, resp.MacaroonRecipe.Permissions)
+	require.Len(t, resp.MacaroonRecipe.Caveats, 1)
+	require.Contains(t, resp.MacaroonRecipe.Caveats[0], "time-before")
+
+	// The permissions of a scoped admin session are resolved like when
+	// the session is started.
+	resp, err = s.AddSession(ctx, &litrpc.AddSessionRequest{
+		SessionType:            litrpc.SessionType_TYPE_MACAROON_ADMIN,
+		ExpiryTimestampSeconds: uint64(expiry.Unix()),
+		SessionScope:           []string{"loop"},
+		DryRun:                 true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*litrpc.MacaroonPermission{{
+		Entity: "swap", Action: "execute",
+	}, {
+		Entity: "swap", Action: "read",
+	}}, resp.MacaroonRecipe.Permissions)
+	require.Len(t, resp.MacaroonRecipe.Caveats, 2)
+}
@@ -0,0 +1,92 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRevokeIdleSessions tests that sessions are revoked once they weren't
+// used within their own or the global idle timeout.
+func TestRevokeIdleSessions(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	s := &sessionRpcServer{
+		cfg: &sessionRpcServerConfig{
+			db:          db,
+			idleTimeout: 48 * time.Hour,
+		},
+		sessionServer: session.NewServer(nil),
+		usage:         newSessionUsageTracker(db, 0),
+	}
+
+	now := time.Now()
+	newSession := func(label string, idleTimeout time.Duration,
+		lastUsed time.Time) *session.Session {
+
+		id, privKey, err := db.GetUnusedIDAndKeyPair()
+		require.NoError(t, err)
+
+		sess, err := session.NewSession(
+			id, privKey, label, session.TypeMacaroonReadonly,
+			now.Add(365*24*time.Hour), "", false, nil, nil, nil,
+			false, nil, session.PrivacyFlags{},
+		)
+		require.NoError(t, err)
+		sess.CreatedAt = now.Add(-72 * time.Hour)
+		sess.IdleTimeout = idleTimeout
+		require.NoError(t, db.CreateSession(sess))
+
+		if !lastUsed.IsZero() {
+			s.usage.record(sess.ID, lastUsed)
+		}
+
+		return sess
+	}
+
+	// The first session was never used and is idle for longer than the
+	// global timeout. The second one was used recently. The third one was
+	// used within the global timeout, but not within its own timeout.
+	neverUsed := newSession("never used", 0, time.Time{})
+	recent := newSession("recent", 0, now.Add(-time.Hour))
+	ownTimeout := newSession("own timeout", time.Hour, now.Add(-2*time.Hour))
+
+	require.NoError(t, s.revokeIdleSessions(now))
+
+	for _, test := range []struct {
+		sess    *session.Session
+		revoked bool
+	}{
+		{neverUsed, true},
+		{recent, false},
+		{ownTimeout, true},
+	} {
+		stored, err := db.GetSession(test.sess.LocalPublicKey)
+		require.NoError(t, err)
+
+		if !test.revoked {
+			require.Equal(t, session.StateCreated, stored.State)
+			continue
+		}
+
+		require.Equal(t, session.StateRevoked, stored.State)
+		require.Equal(
+			t, session.IdleSessionReason, stored.RevocationReason,
+		)
+	}
+
+	// Without a global timeout, only sessions with their own timeout are
+	// revoked.
+	s.cfg.idleTimeout = 0
+	require.NoError(t, s.revokeIdleSessions(now.Add(24*time.Hour)))
+
+	stored, err := db.GetSession(recent.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, session.StateCreated, stored.State)
+}
@@ -0,0 +1,111 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+// newAccountSession creates an exported account session that is bound to the
+// account with the given ID.
+func newAccountSession(t *testing.T, db *session.DB,
+	accountID accounts.AccountID) (*session.Session, []byte) {
+
+	id, privKey, err := db.GetUnusedIDAndKeyPair()
+	require.NoError(t, err)
+
+	caveat := checkers.Condition(
+		macaroons.CondLndCustom,
+		fmt.Sprintf("%s %x", accounts.CondAccount, accountID[:]),
+	)
+	sess, err := session.NewSession(
+		id, privKey, "account", session.TypeMacaroonAccount,
+		time.Now().Add(time.Hour), "", false, nil,
+		[]macaroon.Caveat{{Id: []byte(caveat)}}, nil, false, nil,
+		session.PrivacyFlags{},
+	)
+	require.NoError(t, err)
+
+	// Revoked sessions aren't started when they are imported.
+	sess.State = session.StateRevoked
+
+	export, err := session.EncodeExport(sess, nil)
+	require.NoError(t, err)
+
+	return sess, export
+}
+
+// TestImportAccountSession tests that an account session can only be imported
+// if its account exists on the node.
+func TestImportAccountSession(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	accountService, err := accounts.NewService(
+		t.TempDir(), func(error) {},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = accountService.Stop()
+	})
+
+	account, err := accountService.NewAccount(1000, time.Time{}, "")
+	require.NoError(t, err)
+
+	rootKeys, err := newSuperMacRootKeys(db)
+	require.NoError(t, err)
+
+	s := &sessionRpcServer{
+		cfg: &sessionRpcServerConfig{
+			db:       db,
+			rootKeys: rootKeys,
+			activeStreams: func(*session.Session) uint32 {
+				return 0
+			},
+			getAccount: accountService.Account,
+		},
+	}
+	ctx := context.Background()
+
+	// A session of an account that doesn't exist here is rejected and not
+	// stored.
+	missing, export := newAccountSession(t, db, accounts.AccountID{1})
+	_, err = s.ImportSession(ctx, &litrpc.ImportSessionRequest{
+		Session: export,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.ErrorContains(t, err, "doesn't exist on this node")
+
+	_, err = db.GetSession(missing.LocalPublicKey)
+	require.ErrorIs(t, err, session.ErrSessionNotFound)
+
+	// A session of an existing account is imported.
+	sess, export := newAccountSession(t, db, account.ID)
+	_, err = s.ImportSession(ctx, &litrpc.ImportSessionRequest{
+		Session: export,
+	})
+	require.NoError(t, err)
+
+	_, err = db.GetSession(sess.LocalPublicKey)
+	require.NoError(t, err)
+
+	// An account session without an account caveat is invalid.
+	sess.MacaroonRecipe.Caveats = nil
+	err = s.checkSessionAccount(sess)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.ErrorContains(t, err, "no account caveat")
+}
@@ -0,0 +1,278 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestCheckRootKeys tests that root keys without an active session and active
+// sessions without a root key are reported, and that the orphaned root keys
+// are only deleted if requested.
+func TestCheckRootKeys(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	newSession := func(label string) *session.Session {
+		id, privKey, err := db.GetUnusedIDAndKeyPair()
+		require.NoError(t, err)
+
+		sess, err := session.NewSession(
+			id, privKey, label, session.TypeMacaroonReadonly,
+			time.Now().Add(time.Hour), "", false, nil, nil, nil,
+			false, nil, session.PrivacyFlags{},
+		)
+		require.NoError(t, err)
+		require.NoError(t, db.CreateSession(sess))
+
+		return sess
+	}
+
+	withKey := newSession("with key")
+	withoutKey := newSession("without key")
+	revoked := newSession("revoked")
+	require.NoError(t, db.RevokeSession(revoked.LocalPublicKey))
+
+	// lnd knows the root keys of the first and the revoked session, and
+	// one that doesn't belong to any session.
+	orphan := session.NewSuperMacaroonRootKeyID([4]byte{1, 2, 3, 4})
+	var deleted []uint64
+	s := &sessionRpcServer{
+		cfg: &sessionRpcServerConfig{
+			db: db,
+			listMacRootKeys: func(context.Context) ([]uint64,
+				error) {
+
+				return []uint64{
+					withKey.MacaroonRootKey,
+					revoked.MacaroonRootKey, orphan,
+				}, nil
+			},
+			deleteMacRootKey: func(_ context.Context,
+				id uint64) error {
+
+				deleted = append(deleted, id)
+
+				return nil
+			},
+		},
+	}
+
+	report, err := s.checkRootKeys(context.Background(), false)
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t, []uint64{revoked.MacaroonRootKey, orphan}, report.orphaned,
+	)
+	require.Equal(t, []session.ID{withoutKey.ID}, report.missing)
+	require.False(t, report.deleted)
+	require.Empty(t, deleted)
+
+	report, err = s.checkRootKeys(context.Background(), true)
+	require.NoError(t, err)
+	require.True(t, report.deleted)
+	require.ElementsMatch(t, report.orphaned, deleted)
+}
+
+// mockRootKeyLnd is a lnrpc.LightningClient that bakes and checks macaroons
+// with an in-memory root key store, like lnd does.
+type mockRootKeyLnd struct {
+	lnrpc.LightningClient
+
+	mu       sync.Mutex
+	rootKeys map[uint64][]byte
+}
+
+func newMockRootKeyLnd() *mockRootKeyLnd {
+	return &mockRootKeyLnd{
+		rootKeys: make(map[uint64][]byte),
+	}
+}
+
+func (m *mockRootKeyLnd) BakeMacaroon(_ context.Context,
+	req *lnrpc.BakeMacaroonRequest,
+	_ ...grpc.CallOption) (*lnrpc.BakeMacaroonResponse, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Like lnd, a new root key is created for an unknown root key ID.
+	rootKey, ok := m.rootKeys[req.RootKeyId]
+	if !ok {
+		rootKey = make([]byte, 32)
+		if _, err := rand.Read(rootKey); err != nil {
+			return nil, err
+		}
+		m.rootKeys[req.RootKeyId] = rootKey
+	}
+
+	idProto, err := proto.Marshal(&lnrpc.MacaroonId{
+		Nonce:     []byte("nonce"),
+		StorageId: []byte(strconv.FormatUint(req.RootKeyId, 10)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	id := append([]byte{byte(bakery.LatestVersion)}, idProto...)
+
+	mac, err := macaroon.New(rootKey, id, "lnd", macaroon.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BakeMacaroonResponse{
+		Macaroon: hex.EncodeToString(macBytes),
+	}, nil
+}
+
+func (m *mockRootKeyLnd) ListMacaroonIDs(context.Context,
+	*lnrpc.ListMacaroonIDsRequest,
+	...grpc.CallOption) (*lnrpc.ListMacaroonIDsResponse, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp := &lnrpc.ListMacaroonIDsResponse{}
+	for id := range m.rootKeys {
+		resp.RootKeyIds = append(resp.RootKeyIds, id)
+	}
+
+	return resp, nil
+}
+
+func (m *mockRootKeyLnd) DeleteMacaroonID(_ context.Context,
+	req *lnrpc.DeleteMacaroonIDRequest,
+	_ ...grpc.CallOption) (*lnrpc.DeleteMacaroonIDResponse, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.rootKeys[req.RootKeyId]
+	delete(m.rootKeys, req.RootKeyId)
+
+	return &lnrpc.DeleteMacaroonIDResponse{Deleted: ok}, nil
+}
+
+// checkMacaroon makes sure the given macaroon was baked with a root key that
+// is still in the root key store.
+func (m *mockRootKeyLnd) checkMacaroon(mac *macaroon.Macaroon) error {
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	rootKey, ok := m.rootKeys[rootKeyID]
+	m.mu.Unlock()
+	if !ok {
+		return errors.New("root key not found")
+	}
+
+	_, err = mac.VerifySignature(rootKey, nil)
+
+	return err
+}
+
+// TestRepairRootKeysKeepsIssuedKeys makes sure that deleting the orphaned
+// root keys doesn't delete the root keys of the super macaroons LiT issued
+// itself, like the bootstrap super macaroon.
+func TestRepairRootKeysKeepsIssuedKeys(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	superMacKeys, err := newSuperMacRootKeys(db)
+	require.NoError(t, err)
+
+	accountService, err := accounts.NewService(
+		t.TempDir(), func(error) {},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = accountService.Stop()
+	})
+
+	lnd := newMockRootKeyLnd()
+	path := filepath.Join(t.TempDir(), "super.macaroon")
+	g := &LightningTerminal{
+		cfg: &Config{
+			SuperMacaroon: &SuperMacaroonConfig{
+				Path:          path,
+				rootKeySuffix: [4]byte{1, 2, 3, 4},
+				permissions: []bakery.Op{{
+					Entity: "info", Action: "read",
+				}},
+			},
+		},
+		basicClient:    lnd,
+		accountService: accountService,
+		superMacKeys:   superMacKeys,
+	}
+	ctx := context.Background()
+	require.NoError(t, g.bakeBootstrapSuperMacaroon(ctx))
+
+	// The root key of a deleted session is orphaned.
+	orphan := session.NewSuperMacaroonRootKeyID([4]byte{5, 6, 7, 8})
+	_, err = BakeSuperMacaroon(ctx, lnd, orphan, nil, nil)
+	require.NoError(t, err)
+
+	s := &sessionRpcServer{
+		cfg: &sessionRpcServerConfig{
+			db:              db,
+			listMacRootKeys: g.listSessionRootKeyCandidates,
+			deleteMacRootKey: func(ctx context.Context,
+				id uint64) error {
+
+				_, err := lnd.DeleteMacaroonID(
+					ctx, &lnrpc.DeleteMacaroonIDRequest{
+						RootKeyId: id,
+					},
+				)
+
+				return err
+			},
+		},
+	}
+	report, err := s.checkRootKeys(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{orphan}, report.orphaned)
+
+	// The bootstrap super macaroon is still valid after the repair.
+	macBytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	mac := &macaroon.Macaroon{}
+	require.NoError(t, mac.UnmarshalBinary(macBytes))
+	require.NoError(t, lnd.checkMacaroon(mac))
+
+	// The issued root key is also remembered across restarts, so a super
+	// macaroon that already exists keeps its root key too.
+	superMacKeys, err = newSuperMacRootKeys(db)
+	require.NoError(t, err)
+	require.True(t, superMacKeys.isIssued(
+		session.NewSuperMacaroonRootKeyID([4]byte{1, 2, 3, 4}),
+	))
+}
@@ -1,10 +1,14 @@
 package terminal
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -22,7 +26,10 @@ import (
 	"github.com/lightninglabs/lightning-terminal/rules"
 	"github.com/lightninglabs/lightning-terminal/session"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
@@ -50,6 +57,13 @@ type sessionRpcServer struct {
 	// new session.
 	sessRegMu sync.Mutex
 
+	// createLimiter limits the rate at which sessions are created. It is
+	// nil if the rate is not limited.
+	createLimiter *rate.Limiter
+
+	// usage counts the requests of each session.
+	usage *sessionUsageTracker
+
 	quit     chan struct{}
 	wg       sync.WaitGroup
 	stopOnce sync.Once
@@ -63,12 +77,65 @@ type sessionRpcServerConfig struct {
 	grpcOptions             []grpc.ServerOption
 	registerGrpcServers     func(server *grpc.Server)
 	superMacBaker           session.MacaroonBaker
+	deleteMacRootKey        func(ctx context.Context, rootKeyID uint64) error
+	listMacRootKeys         func(ctx context.Context) ([]uint64, error)
+	rootKeys                *superMacRootKeys
 	firstConnectionDeadline time.Duration
 	permMgr                 *perms.Manager
 	actionsDB               *firewalldb.DB
 	autopilot               autopilotserver.Autopilot
 	ruleMgrs                rules.ManagerSet
 	privMap                 firewalldb.NewPrivacyMapDB
+
+	// activeStreams returns the number of streams that are currently
+	// active for the given session.
+	activeStreams func(sess *session.Session) uint32
+
+	// getAccount looks up the account with the given ID in the local
+	// accounts store.
+	getAccount func(id accounts.AccountID) (
+		*accounts.OffChainBalanceAccount, error)
+
+	// maxActiveSessions is the maximum number of sessions that may be
+	// active at the same time. Zero means that there is no limit.
+	maxActiveSessions uint32
+
+	// sessionCreateRate is the maximum number of sessions that may be
+	// created per minute. Zero means that there is no limit.
+	sessionCreateRate uint32
+
+	// usageRetention is the duration for which the daily request counts
+	// of sessions are kept. Zero means that only the total request counts
+	// are kept.
+	usageRetention time.Duration
+
+	// expiryWarningLead is the time before a session's macaroon expires
+	// at which an expiry warning is emitted. Zero means that no warnings
+	// are emitted.
+	expiryWarningLead time.Duration
+
+	// expiryWebhookURL is the URL of the webhook that expiry warnings are
+	// sent to, if set, signed with expiryWebhookSecret.
+	expiryWebhookURL    string
+	expiryWebhookSecret []byte
+
+	// cleanupInterval is the interval at which expired sessions are
+	// revoked and old sessions are purged.
+	cleanupInterval time.Duration
+
+	// sessionRetention is the time after which sessions that were revoked
+	// or expired are purged from the store. Zero means that sessions are
+	// never purged.
+	sessionRetention time.Duration
+
+	// idleTimeout is the time after which sessions that weren't used are
+	// revoked, unless a session has its own idle timeout. Zero means that
+	// only sessions with their own idle timeout are revoked when idle.
+	idleTimeout time.Duration
+
+	// thirdPartyAuth holds the external authorization servers that may
+	// discharge the third party caveats of session macaroons.
+	thirdPartyAuth *ThirdPartyAuthConfig
 }
 
 // newSessionRPCServer creates a new sessionRpcServer using the passed config.
@@ -80,7 +147,12 @@ func newSessionRPCServer(cfg *sessionRpcServerConfig) (*sessionRpcServer,
 	// interface.
 	server := session.NewServer(
 		func(opts ...grpc.ServerOption) *grpc.Server {
-			allOpts := append(cfg.grpcOptions, opts...)
+			// The options are copied, since servers may be
+			// created for several sessions concurrently.
+			allOpts := append(
+				append([]grpc.ServerOption(nil),
+					cfg.grpcOptions...), opts...,
+			)
 			grpcServer := grpc.NewServer(allOpts...)
 
 			cfg.registerGrpcServers(grpcServer)
@@ -89,9 +161,25 @@ func newSessionRPCServer(cfg *sessionRpcServerConfig) (*sessionRpcServer,
 		},
 	)
 
+	// The whole minute's worth of sessions may be created at once, which
+	// allows provisioning a batch of sessions without running into the
+	// limit.
+	var createLimiter *rate.Limiter
+	if cfg.sessionCreateRate > 0 {
+		createLimiter = rate.NewLimiter(
+			rate.Every(time.Minute/time.Duration(
+				cfg.sessionCreateRate,
+			)), int(cfg.sessionCreateRate),
+		)
+	}
+
+	usage := newSessionUsageTracker(cfg.db, cfg.usageRetention)
+
 	return &sessionRpcServer{
 		cfg:           cfg,
 		sessionServer: server,
+		createLimiter: createLimiter,
+		usage:         usage,
 		quit:          make(chan struct{}),
 	}, nil
 }
@@ -169,6 +257,12 @@ func (s *sessionRpcServer) start() error {
 		}
 	}
 
+	s.usage.start()
+
+	if s.cfg.cleanupInterval > 0 {
+		s.startCleanup()
+	}
+
 	return nil
 }
 
@@ -176,14 +270,20 @@ func (s *sessionRpcServer) start() error {
 func (s *sessionRpcServer) stop() error {
 	var returnErr error
 	s.stopOnce.Do(func() {
+		// The session cleanup uses the DB, so our goroutines need to
+		// be stopped before the DB is closed.
+		close(s.quit)
+		s.wg.Wait()
+
+		// The usage tracker writes its last counts to the DB, so it
+		// needs to be stopped before the DB is closed.
+		s.usage.stop()
+
 		if err := s.cfg.db.Close(); err != nil {
 			log.Errorf("Error closing session DB: %v", err)
 			returnErr = err
 		}
 		s.sessionServer.Stop()
-
-		close(s.quit)
-		s.wg.Wait()
 	})
 
 	return returnErr
@@ -203,6 +303,15 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		return nil, err
 	}
 
+	var macExpiry time.Time
+	if req.MacExpiry != 0 {
+		macExpiry = time.Unix(int64(req.MacExpiry), 0)
+		if time.Now().After(macExpiry) {
+			return nil, status.Error(codes.InvalidArgument,
+				"macaroon expiry must be in the future")
+		}
+	}
+
 	// Store the entity-action permission pairs in a map in order to
 	// de-dup any repeat perms.
 	permissions := make(map[string]map[string]struct{})
@@ -218,6 +327,52 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		permissions[entity][action] = struct{}{}
 	}
 
+	if req.IdleTimeoutSeconds > uint64(math.MaxInt64/time.Second) {
+		return nil, status.Error(codes.InvalidArgument,
+			"idle timeout is too long")
+	}
+	idleTimeout := time.Duration(req.IdleTimeoutSeconds) * time.Second
+
+	if err := session.ValidateMetadata(req.Metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+
+	if len(req.AllowedOperations) > 0 && typ != session.TypeMacaroonCustom {
+		return nil, fmt.Errorf("allowed operations can only be set " +
+			"for the custom macaroon session type")
+	}
+
+	// If requested, restrict the sub-servers the session can reach. The
+	// scope is kept as a caveat, which both limits the permissions the
+	// session's macaroon is baked with and is checked for each request.
+	var (
+		scope       map[string]bool
+		scopeCaveat string
+	)
+	if len(req.SessionScope) > 0 {
+		names, err := parseSessionScope(req.SessionScope)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+
+		scopeCaveat = firewall.ScopeToCaveat(names)
+		scope, _, err = sessionScopeFromCaveats([]macaroon.Caveat{{
+			Id: []byte(scopeCaveat),
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// inScope returns true if the given permission may be added to the
+	// session's macaroon.
+	inScope := func(entity, action string) bool {
+		return scope == nil || permissionInScope(
+			s.cfg.permMgr, scope, entity, action,
+		)
+	}
+
 	var caveats []macaroon.Caveat
 	switch typ {
 	// For the default session types we use empty caveats and permissions,
@@ -251,6 +406,22 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 
 		for _, op := range req.MacaroonCustomPermissions {
 			if op.Entity != macaroons.PermissionEntityCustomURI {
+				err := validatePermission(
+					s.cfg.permMgr, op.Entity, op.Action,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				if !inScope(op.Entity, op.Action) {
+					return nil, status.Errorf(
+						codes.InvalidArgument,
+						"permission %s:%s is outside "+
+							"of the session scope",
+						op.Entity, op.Action,
+					)
+				}
+
 				addPerm(op.Entity, op.Action)
 
 				continue
@@ -266,7 +437,9 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 				)
 
 				for _, p := range readPerms {
-					addPerm(p.Entity, p.Action)
+					if inScope(p.Entity, p.Action) {
+						addPerm(p.Entity, p.Action)
+					}
 				}
 
 				continue
@@ -279,7 +452,9 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 				// the matching URIs returned from the
 				// permissions' manager.
 				for _, uri := range uris {
-					addPerm(op.Entity, uri)
+					if inScope(op.Entity, uri) {
+						addPerm(op.Entity, uri)
+					}
 				}
 				continue
 			}
@@ -292,9 +467,35 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 					"LiT", op.Action)
 			}
 
+			if !inScope(op.Entity, op.Action) {
+				return nil, status.Errorf(codes.InvalidArgument,
+					"URI %s is outside of the session "+
+						"scope", op.Action)
+			}
+
 			addPerm(op.Entity, op.Action)
 		}
 
+		// If requested, restrict the loop and pool operations the
+		// session may execute.
+		if len(req.AllowedOperations) > 0 {
+			ops, err := firewall.ParseOperations(
+				req.AllowedOperations,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			cav, err := firewall.OperationsToCaveat(ops)
+			if err != nil {
+				return nil, err
+			}
+
+			caveats = append(caveats, macaroon.Caveat{
+				Id: []byte(cav),
+			})
+		}
+
 	// No other types are currently supported.
 	default:
 		return nil, fmt.Errorf("invalid session type, only admin, " +
@@ -303,6 +504,36 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 			"AddAutoPilotSession method")
 	}
 
+	if scopeCaveat != "" {
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(scopeCaveat),
+		})
+	}
+
+	// If requested, restrict the client IPs the session's macaroon may be
+	// used from. This applies to all session types.
+	if len(req.AllowedIpRanges) > 0 {
+		ranges, err := firewall.ParseIPRanges(req.AllowedIpRanges)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(firewall.IPRangesToCaveat(ranges)),
+		})
+	}
+
+	// If requested, an external authorization server must discharge the
+	// session's macaroon before it can be used.
+	thirdPartyCaveats, err := s.cfg.thirdPartyAuth.thirdPartyCaveats(
+		req.ThirdPartyCaveats,
+	)
+	if err != nil {
+		return nil, err
+	}
+	caveats = append(caveats, thirdPartyCaveats...)
+
 	// Collect the de-duped permissions.
 	var uniquePermissions []bakery.Op
 	for entity, actions := range permissions {
@@ -314,9 +545,23 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		}
 	}
 
+	if req.DryRun {
+		return s.previewSessionMacaroon(
+			typ, expiry, macExpiry, uniquePermissions, caveats,
+		)
+	}
+
 	s.sessRegMu.Lock()
 	defer s.sessRegMu.Unlock()
 
+	if err := s.checkSessionCreateRate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkActiveSessionLimit(); err != nil {
+		return nil, err
+	}
+
 	id, localPrivKey, err := s.cfg.db.GetUnusedIDAndKeyPair()
 	if err != nil {
 		return nil, err
@@ -330,6 +575,12 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("error creating new session: %v", err)
 	}
+	sess.MaxStreams = req.MaxStreams
+	sess.MacaroonExpiry = macExpiry
+	sess.IdleTimeout = idleTimeout
+	if len(req.Metadata) > 0 {
+		sess.Metadata = req.Metadata
+	}
 
 	if err := s.cfg.db.CreateSession(sess); err != nil {
 		return nil, fmt.Errorf("error storing session: %v", err)
@@ -349,6 +600,160 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 	}, nil
 }
 
+// previewSessionMacaroon returns the permissions and caveats that the macaroon
+// of a session with the given parameters would be baked with, without creating
+// the session. The permissions of the admin, read-only and account session
+// types are resolved the same way as when the session is started.
+func (s *sessionRpcServer) previewSessionMacaroon(typ session.Type,
+	expiry, macExpiry time.Time, perms []bakery.Op,
+	caveats []macaroon.Caveat) (*litrpc.AddSessionResponse, error) {
+
+	sess := &session.Session{
+		Type:           typ,
+		Expiry:         expiry,
+		MacaroonExpiry: macExpiry,
+	}
+	if perms != nil || caveats != nil {
+		sess.MacaroonRecipe = &session.MacaroonRecipe{
+			Permissions: perms,
+			Caveats:     caveats,
+		}
+	}
+
+	recipe, err := s.sessionMacaroonRecipe(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	// The permissions are collected from maps, so we sort them to always
+	// return them in the same order. They might be shared with the
+	// permission manager, so we sort a copy.
+	sorted := append([]bakery.Op(nil), recipe.Permissions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Entity != sorted[j].Entity {
+			return sorted[i].Entity < sorted[j].Entity
+		}
+
+		return sorted[i].Action < sorted[j].Action
+	})
+	recipe.Permissions = sorted
+
+	return &litrpc.AddSessionResponse{
+		MacaroonRecipe: marshalRPCMacaroonRecipe(recipe),
+	}, nil
+}
+
+// validatePermission checks that the given entity-action pair is one of the
+// permissions of the methods known to LiT. An InvalidArgument error is returned
+// otherwise, since a macaroon with an unknown permission would not grant access
+// to any method.
+func validatePermission(permMgr *perms.Manager, entity, action string) error {
+	var knownEntity bool
+	for _, op := range permMgr.ActivePermissions(false) {
+		if op.Entity != entity {
+			continue
+		}
+		knownEntity = true
+
+		if op.Action == action {
+			return nil
+		}
+	}
+
+	if !knownEntity {
+		return status.Errorf(codes.InvalidArgument, "unknown "+
+			"permission entity %s", entity)
+	}
+
+	return status.Errorf(codes.InvalidArgument, "unknown action %s for "+
+		"permission entity %s", action, entity)
+}
+
+// TestWebhook sends a synthetic, signed test event to the given webhook URL
+// and reports the HTTP status code of the response and the round trip time.
+func (s *sessionRpcServer) TestWebhook(ctx context.Context,
+	req *litrpc.TestWebhookRequest) (*litrpc.TestWebhookResponse, error) {
+
+	if len(req.Secret) == 0 {
+		return nil, fmt.Errorf("secret must be set")
+	}
+
+	timeout := defaultWebhookTimeout
+	if req.TimeoutSeconds != 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	if timeout > maxWebhookTimeout {
+		return nil, fmt.Errorf("timeout must not exceed %v",
+			maxWebhookTimeout)
+	}
+
+	event, err := newWebhookEvent(webhookEventTest, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating test event: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statusCode, rtt, err := sendWebhookEvent(
+		ctx, req.Url, req.Secret, event,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.TestWebhookResponse{
+		StatusCode:      uint32(statusCode),
+		RoundTripTimeMs: uint64(rtt.Milliseconds()),
+	}, nil
+}
+
+// checkActiveSessionLimit returns an error with the RESOURCE_EXHAUSTED code if
+// the configured maximum number of active sessions has been reached. Sessions
+// that are revoked or expired don't count towards the limit.
+//
+// NOTE: the sessRegMu mutex must be held when calling this method.
+func (s *sessionRpcServer) checkActiveSessionLimit() error {
+	if s.cfg.maxActiveSessions == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	active, err := s.cfg.db.ListSessions(func(sess *session.Session) bool {
+		if sess.State != session.StateCreated &&
+			sess.State != session.StateInUse {
+
+			return false
+		}
+
+		return sess.Expiry.After(now)
+	})
+	if err != nil {
+		return fmt.Errorf("error listing sessions: %v", err)
+	}
+
+	if uint32(len(active)) >= s.cfg.maxActiveSessions {
+		return status.Errorf(codes.ResourceExhausted, "maximum number "+
+			"of active sessions reached: %d of %d sessions are "+
+			"active", len(active), s.cfg.maxActiveSessions)
+	}
+
+	return nil
+}
+
+// checkSessionCreateRate returns an error with the RESOURCE_EXHAUSTED code if
+// more sessions were created within the last minute than the configured rate
+// allows.
+func (s *sessionRpcServer) checkSessionCreateRate() error {
+	if s.createLimiter == nil || s.createLimiter.Allow() {
+		return nil
+	}
+
+	return status.Errorf(codes.ResourceExhausted, "session creation rate "+
+		"limit reached: at most %d sessions can be created per minute",
+		s.cfg.sessionCreateRate)
+}
+
 // resumeSession tries to start an existing session if it is not expired, not
 // revoked and a LiT session.
 func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
@@ -377,216 +782,1195 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		return nil
 	}
 
+	recipe, err := s.sessionMacaroonRecipe(sess)
+	if err != nil {
+		return err
+	}
+
+	// No other types are currently supported.
+	if recipe == nil {
+		log.Debugf("Not resuming session %x with type %d", pubKeyBytes,
+			sess.Type)
+		return nil
+	}
+
+	mac, err := s.cfg.superMacBaker(
+		context.Background(), sess.MacaroonRootKey, recipe,
+	)
+	if err != nil {
+		log.Debugf("Not resuming session %x. Could not bake "+
+			"the necessary macaroon: %w", pubKeyBytes, err)
+		return nil
+	}
+
 	var (
-		caveats     []macaroon.Caveat
-		permissions []bakery.Op
-		readOnly    = sess.Type == session.TypeMacaroonReadonly
+		onNewStatus     func(s mailbox.ServerStatus)
+		firstConnTimout = make(chan struct{})
 	)
-	switch sess.Type {
-	// For the default session types we use empty caveats and permissions,
-	// the macaroons are baked correctly when creating the session.
-	case session.TypeMacaroonAdmin, session.TypeMacaroonReadonly:
-		permissions = s.cfg.permMgr.ActivePermissions(readOnly)
 
-	// For account based sessions we just add the account ID caveat, the
-	// permissions are added dynamically when creating the session.
-	case session.TypeMacaroonAccount:
-		if sess.MacaroonRecipe == nil {
-			return fmt.Errorf("invalid account session, expected " +
-				"recipe to be set")
+	// If this is the first time the session is being spun up then we will
+	// kick off a timer to revoke the session after a timeout unless an
+	// initial connection is made. We identify such a session as one that
+	// we do not yet have a static remote pub key for.
+	if sess.RemotePublicKey == nil {
+		deadline := sess.CreatedAt.Add(s.cfg.firstConnectionDeadline)
+		if deadline.Before(time.Now()) {
+			log.Debugf("Deadline for session %x has already "+
+				"passed. Revoking session", pubKeyBytes)
+
+			return s.cfg.db.RevokeSession(pubKey)
 		}
 
-		caveats = sess.MacaroonRecipe.Caveats
-		permissions = accounts.MacaroonPermissions
+		// Start the deadline timer.
+		deadlineDuration := time.Until(deadline)
+		deadlineTimer := time.AfterFunc(deadlineDuration, func() {
+			close(firstConnTimout)
+		})
 
-	// For custom session types, we use the caveats and permissions that
-	// were persisted on session creation.
-	case session.TypeMacaroonCustom, session.TypeAutopilot:
-		if sess.MacaroonRecipe == nil {
-			break
+		log.Warnf("Kicking off deadline timer for first connection "+
+			"for session %x. A successful connection must be "+
+			"made in the next %s", pubKeyBytes, deadlineDuration)
+
+		var stopTimerOnce sync.Once
+		onNewStatus = func(s mailbox.ServerStatus) {
+			// We will only stop the timer if the server status
+			// indicates that the client has successfully connected.
+			if s != mailbox.ServerStatusInUse {
+				return
+			}
+
+			// Stop the deadline timer.
+			stopTimerOnce.Do(func() {
+				log.Debugf("First connection for session %x "+
+					"made in a timely manner",
+					sess.LocalPublicKey.
+						SerializeCompressed())
+
+				deadlineTimer.Stop()
+			})
 		}
+	}
 
-		permissions = sess.MacaroonRecipe.Permissions
-		caveats = append(caveats, sess.MacaroonRecipe.Caveats...)
+	authData := []byte(fmt.Sprintf("%s: %s", HeaderMacaroon, mac))
+	sessionClosedSub, err := s.sessionServer.StartSession(
+		sess, authData, s.cfg.db.UpdateSessionRemotePubKey, onNewStatus,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTimer(time.Until(sess.Expiry))
+		defer ticker.Stop()
+
+		// If configured, we warn once before the session's macaroon
+		// expires.
+		var expiryWarning <-chan time.Time
+		if s.cfg.expiryWarningLead > 0 {
+			warnAt := sess.MacaroonDeadline().Add(
+				-s.cfg.expiryWarningLead,
+			)
+			warningTimer := time.NewTimer(time.Until(warnAt))
+			defer warningTimer.Stop()
+
+			expiryWarning = warningTimer.C
+		}
+
+		for {
+			select {
+			case <-s.quit:
+				return
+
+			case <-sessionClosedSub:
+				return
+
+			case <-expiryWarning:
+				expiryWarning = nil
+				s.warnSessionExpiry(sess)
+
+				continue
+
+			case <-ticker.C:
+				log.Debugf("Stopping expired session %x with "+
+					"type %d", pubKeyBytes, sess.Type)
+
+			case <-firstConnTimout:
+				log.Debugf("Deadline exceeded for first "+
+					"connection for session %x. Stopping "+
+					"and revoking.", pubKeyBytes)
+			}
+
+			break
+		}
+
+		if s.cfg.autopilot != nil {
+			ctx := context.Background()
+			ctxc, cancel := context.WithTimeout(
+				ctx, defaultConnectTimeout,
+			)
+
+			s.cfg.autopilot.SessionRevoked(ctxc, pubKey)
+			cancel()
+		}
+
+		err = s.sessionServer.StopSession(pubKey)
+		if err != nil {
+			log.Debugf("Error stopping session: %v", err)
+		}
+
+		err = s.cfg.db.RevokeSession(pubKey)
+		if err != nil {
+			log.Debugf("error revoking session: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// warnSessionExpiry emits the expiry warning of the given session, unless it
+// was already emitted before, and sends it to the configured webhook.
+func (s *sessionRpcServer) warnSessionExpiry(sess *session.Session) {
+	pubKeyBytes := sess.LocalPublicKey.SerializeCompressed()
+
+	emitted, err := s.cfg.db.AddExpiryWarning(sess.LocalPublicKey)
+	if err != nil {
+		log.Errorf("Error adding expiry warning for session %x: %v",
+			pubKeyBytes, err)
+		return
+	}
+	if !emitted {
+		return
+	}
+
+	deadline := sess.MacaroonDeadline()
+	log.Infof("Session %x expires at %v", pubKeyBytes, deadline)
+
+	if s.cfg.expiryWebhookURL == "" {
+		return
+	}
+
+	event, err := newWebhookEvent(
+		webhookEventSessionExpiring, &sessionExpiringData{
+			LocalPublicKey: hex.EncodeToString(pubKeyBytes),
+			Label:          sess.Label,
+			Expiry:         deadline.Unix(),
+		},
+	)
+	if err != nil {
+		log.Errorf("Error creating expiry warning event: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), defaultWebhookTimeout,
+	)
+	defer cancel()
+
+	statusCode, _, err := sendWebhookEvent(
+		ctx, s.cfg.expiryWebhookURL, s.cfg.expiryWebhookSecret, event,
+	)
+	switch {
+	case err != nil:
+		log.Warnf("Error sending expiry warning of session %x to "+
+			"webhook: %v", pubKeyBytes, err)
+
+	case statusCode < 200 || statusCode >= 300:
+		log.Warnf("Webhook responded with status %d to expiry "+
+			"warning of session %x", statusCode, pubKeyBytes)
+	}
+}
+
+// sessionMacaroonRecipe returns the permissions and caveats of the macaroon
+// that belongs to the given session. If the session's type doesn't use a
+// macaroon, nil is returned.
+func (s *sessionRpcServer) sessionMacaroonRecipe(
+	sess *session.Session) (*session.MacaroonRecipe, error) {
+
+	var (
+		caveats     []macaroon.Caveat
+		permissions []bakery.Op
+		readOnly    = sess.Type == session.TypeMacaroonReadonly
+	)
+	switch sess.Type {
+	// For the default session types we use all active permissions and
+	// only the caveats that were persisted on session creation, if any.
+	// If the session is scoped to some sub-servers, only their
+	// permissions are used.
+	case session.TypeMacaroonAdmin, session.TypeMacaroonReadonly:
+		permissions = s.cfg.permMgr.ActivePermissions(readOnly)
+		if sess.MacaroonRecipe != nil {
+			caveats = append(caveats, sess.MacaroonRecipe.Caveats...)
+		}
+
+		scope, scoped, err := sessionScopeFromCaveats(caveats)
+		if err != nil {
+			return nil, err
+		}
+		if scoped {
+			permissions = scopedPermissions(
+				s.cfg.permMgr, scope, readOnly,
+			)
+		}
+
+	// For account based sessions we just add the account ID caveat, the
+	// permissions are added dynamically when creating the session.
+	case session.TypeMacaroonAccount:
+		if sess.MacaroonRecipe == nil {
+			return nil, fmt.Errorf("invalid account session, " +
+				"expected recipe to be set")
+		}
+
+		caveats = sess.MacaroonRecipe.Caveats
+		permissions = accounts.MacaroonPermissions
+
+	// For custom session types, we use the caveats and permissions that
+	// were persisted on session creation.
+	case session.TypeMacaroonCustom, session.TypeAutopilot:
+		if sess.MacaroonRecipe == nil {
+			break
+		}
+
+		permissions = sess.MacaroonRecipe.Permissions
+		caveats = append(caveats, sess.MacaroonRecipe.Caveats...)
+
+	// No other types are currently supported.
+	default:
+		return nil, nil
+	}
+
+	// Add the session expiry, or the macaroon expiry if it is earlier, as
+	// a macaroon caveat.
+	macExpiry := checkers.TimeBeforeCaveat(sess.MacaroonDeadline())
+	caveats = append(caveats, macaroon.Caveat{
+		Id: []byte(macExpiry.Condition),
+	})
+
+	return &session.MacaroonRecipe{
+		Permissions: permissions,
+		Caveats:     caveats,
+	}, nil
+}
+
+// ListSessions returns all sessions known to the session store.
+func (s *sessionRpcServer) ListSessions(_ context.Context,
+	req *litrpc.ListSessionsRequest) (*litrpc.ListSessionsResponse, error) {
+
+	filter := &session.ListFilter{
+		States:   make([]session.State, 0, len(req.StateFilter)),
+		Metadata: req.MetadataFilter,
+	}
+	for _, rpcState := range req.StateFilter {
+		state, err := unmarshalRPCState(rpcState)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+		filter.States = append(filter.States, state)
+	}
+	if req.ExpiresBefore != 0 {
+		filter.ExpiresBefore = time.Unix(int64(req.ExpiresBefore), 0)
+	}
+	if req.CreatedAfter != 0 {
+		filter.CreatedAfter = time.Unix(int64(req.CreatedAfter), 0)
+	}
+	if req.PageToken != "" {
+		cursor, err := session.DecodePageCursor(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument,
+				err.Error())
+		}
+		filter.After = cursor
+	}
+
+	// Include the requests that were counted since the last write to the
+	// store in the activity of the sessions.
+	if err := s.usage.flush(time.Now()); err != nil {
+		return nil, fmt.Errorf("error storing session usage: %v", err)
+	}
+
+	// The filter is applied by the store, so the sessions that don't
+	// match are never marshaled.
+	sessions, err := s.cfg.db.ListSessions(filter.Matches)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sessions: %v", err)
+	}
+
+	response := &litrpc.ListSessionsResponse{}
+
+	// Pages are cut from the sessions in the order the page cursors refer
+	// to. That order only depends on the creation time and key of the
+	// sessions, which never change, so it is the same across calls even if
+	// sessions are revoked in between.
+	if req.PageSize > 0 {
+		session.SortByCreation(sessions)
+
+		if len(sessions) > int(req.PageSize) {
+			sessions = sessions[:req.PageSize]
+			response.NextPageToken = session.NewPageCursor(
+				sessions[len(sessions)-1],
+			).Encode()
+		}
+	}
+
+	response.Sessions = make([]*litrpc.Session, len(sessions))
+	for idx, sess := range sessions {
+		response.Sessions[idx], err = s.marshalRPCSession(sess)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling session: %v",
+				err)
+		}
+	}
+
+	return response, nil
+}
+
+// SubscribeSessionEvents streams an event whenever a session is created,
+// revoked or expires. If a start ID is given, the stored events from that ID
+// on are replayed first.
+func (s *sessionRpcServer) SubscribeSessionEvents(
+	req *litrpc.SubscribeSessionEventsRequest,
+	stream litrpc.Sessions_SubscribeSessionEventsServer) error {
+
+	// We subscribe before we read the stored events so that no event can
+	// fall between the two. Events that are both replayed and delivered by
+	// the subscription are only sent once.
+	sub := s.cfg.db.SubscribeEvents()
+	defer sub.Cancel()
+
+	var lastID uint64
+	if req.FromEventId != 0 {
+		events, err := s.cfg.db.ListEvents(req.FromEventId)
+		if err != nil {
+			return fmt.Errorf("error fetching session events: %v",
+				err)
+		}
+
+		for _, event := range events {
+			if err := sendSessionEvent(stream, event); err != nil {
+				return err
+			}
+			lastID = event.ID
+		}
+	}
+
+	for {
+		select {
+		case event := <-sub.Updates():
+			if event.ID <= lastID {
+				continue
+			}
+
+			if err := sendSessionEvent(stream, event); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case <-s.quit:
+			return fmt.Errorf("server shutting down")
+		}
+	}
+}
+
+// sendSessionEvent converts the given session event into its RPC counterpart
+// and sends it on the given stream.
+func sendSessionEvent(stream litrpc.Sessions_SubscribeSessionEventsServer,
+	event *session.Event) error {
+
+	var eventType litrpc.SessionEventType
+	switch event.Type {
+	case session.EventCreated:
+		eventType = litrpc.SessionEventType_SESSION_EVENT_CREATED
+
+	case session.EventRevoked:
+		eventType = litrpc.SessionEventType_SESSION_EVENT_REVOKED
+
+	case session.EventExpired:
+		eventType = litrpc.SessionEventType_SESSION_EVENT_EXPIRED
+
+	case session.EventExpiring:
+		eventType = litrpc.SessionEventType_SESSION_EVENT_EXPIRING
+
+	case session.EventRenewed:
+		eventType = litrpc.SessionEventType_SESSION_EVENT_RENEWED
+
+	default:
+		return fmt.Errorf("unknown session event type <%d>", event.Type)
+	}
+
+	state, err := marshalRPCState(event.State)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&litrpc.SessionEvent{
+		EventId:        event.ID,
+		EventType:      eventType,
+		LocalPublicKey: event.LocalPublicKey.SerializeCompressed(),
+		Label:          event.Label,
+		SessionState:   state,
+		Timestamp:      uint64(event.Timestamp.Unix()),
+	})
+}
+
+// RevokeSession revokes a single session and also stops it if it is currently
+// active.
+func (s *sessionRpcServer) RevokeSession(ctx context.Context,
+	req *litrpc.RevokeSessionRequest) (*litrpc.RevokeSessionResponse, error) {
+
+	var (
+		pubKey *btcec.PublicKey
+		err    error
+	)
+	switch {
+	case len(req.LocalPublicKey) > 0 && req.Label != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of "+
+			"local_public_key and label can be set")
+
+	case req.Label != "":
+		pubKey, err = s.sessionKeyByLabel(req.Label)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		pubKey, err = btcec.ParsePubKey(req.LocalPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key: %v",
+				err)
+		}
+	}
+
+	if err := s.revokeSession(ctx, pubKey, ""); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RevokeSessionResponse{}, nil
+}
+
+// sessionKeyByLabel returns the local public key of the session with the given
+// label that isn't revoked yet. Revoked sessions are skipped, so a label can be
+// reused once its session is revoked.
+func (s *sessionRpcServer) sessionKeyByLabel(label string) (*btcec.PublicKey,
+	error) {
+
+	sessions, err := s.cfg.db.ListSessions(func(sess *session.Session) bool {
+		return sess.Label == label &&
+			sess.State != session.StateRevoked
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %v", err)
+	}
+
+	switch len(sessions) {
+	case 0:
+		return nil, status.Errorf(codes.NotFound, "no session with "+
+			"label %q found", label)
+
+	case 1:
+		return sessions[0].LocalPublicKey, nil
+	}
+
+	pubKeys := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		pubKeys = append(pubKeys, fmt.Sprintf(
+			"%x", sess.LocalPublicKey.SerializeCompressed(),
+		))
+	}
+
+	return nil, status.Errorf(codes.FailedPrecondition, "%d sessions "+
+		"have the label %q, revoke by local public key instead: %s",
+		len(sessions), label, strings.Join(pubKeys, ", "))
+}
+
+// revokeSession revokes the session with the given local public key, records
+// the given reason for the revocation and stops the session if it is running.
+func (s *sessionRpcServer) revokeSession(ctx context.Context,
+	pubKey *btcec.PublicKey, reason string) error {
+
+	err := s.cfg.db.RevokeSessionWithReason(pubKey, reason)
+	if err != nil {
+		return fmt.Errorf("error revoking session: %v", err)
+	}
+
+	if s.cfg.autopilot != nil {
+		s.cfg.autopilot.SessionRevoked(ctx, pubKey)
+	}
+
+	// If the session expired already it might not be running anymore. So we
+	// only log possible errors here.
+	if err := s.sessionServer.StopSession(pubKey); err != nil {
+		log.Debugf("Error stopping session: %v", err)
+	}
+
+	return nil
+}
+
+// RotateSessionKey replaces the macaroon root key of a session with a new one
+// and returns the session's macaroon baked with the new root key. Macaroons
+// that were baked with the old root key are invalid from then on. If the
+// session is active, it is restarted with the new macaroon.
+func (s *sessionRpcServer) RotateSessionKey(ctx context.Context,
+	req *litrpc.RotateSessionKeyRequest) (*litrpc.RotateSessionKeyResponse,
+	error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.cfg.db.GetSession(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	if sess.State != session.StateCreated &&
+		sess.State != session.StateInUse {
+
+		return nil, fmt.Errorf("cannot rotate the root key of a "+
+			"session in state %d", sess.State)
+	}
+
+	recipe, err := s.sessionMacaroonRecipe(sess)
+	if err != nil {
+		return nil, err
+	}
+	if recipe == nil {
+		return nil, fmt.Errorf("cannot rotate the root key of a "+
+			"session of type %d", sess.Type)
+	}
+
+	// We stop the session first so the remote client can't use the old
+	// macaroon anymore. If the session isn't running, there's nothing to
+	// stop.
+	if err := s.sessionServer.StopSession(pubKey); err != nil {
+		log.Debugf("Error stopping session: %v", err)
+	}
+
+	// Once the old root key is deleted, lnd generates a new one for the
+	// same root key ID the next time a macaroon is baked for it. That way
+	// the session keeps its ID.
+	err = s.cfg.deleteMacRootKey(ctx, sess.MacaroonRootKey)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting root key: %v", err)
+	}
+
+	// If the root key was rotated out with the super macaroon root key,
+	// the new one replaces it.
+	if err := s.cfg.rootKeys.renew(sess.MacaroonRootKey); err != nil {
+		return nil, err
+	}
+
+	mac, err := s.cfg.superMacBaker(ctx, sess.MacaroonRootKey, recipe)
+	if err != nil {
+		return nil, fmt.Errorf("error baking macaroon: %v", err)
+	}
+
+	log.Infof("Rotated macaroon root key of session %x",
+		pubKey.SerializeCompressed())
+
+	if err := s.resumeSession(sess); err != nil {
+		return nil, fmt.Errorf("error restarting session: %v", err)
+	}
+
+	return &litrpc.RotateSessionKeyResponse{
+		Macaroon: mac,
+	}, nil
+}
+
+// RenewSession sets a new expiry for a session that is neither revoked nor
+// expired. The session keeps its pairing, so the remote client doesn't need to
+// pair again. If the session is active, it is restarted with a macaroon that
+// expires with the new expiry.
+func (s *sessionRpcServer) RenewSession(_ context.Context,
+	req *litrpc.RenewSessionRequest) (*litrpc.RenewSessionResponse,
+	error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.cfg.db.GetSession(pubKey)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	// The autopilot was told about the expiry of its sessions when they
+	// were registered, so it wouldn't know about the new one.
+	if sess.Type == session.TypeAutopilot {
+		return nil, status.Error(codes.FailedPrecondition,
+			"autopilot sessions can't be renewed")
+	}
+
+	expiry := time.Unix(int64(req.ExpiryTimestampSeconds), 0)
+	if !expiry.After(sess.Expiry) {
+		return nil, status.Errorf(codes.InvalidArgument, "new expiry "+
+			"must be later than the current expiry %d",
+			sess.Expiry.Unix())
+	}
+
+	sess, err = s.cfg.db.RenewSession(pubKey, expiry)
+	switch {
+	case errors.Is(err, session.ErrSessionRevoked),
+		errors.Is(err, session.ErrSessionExpired):
+
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot "+
+			"renew session: %v", err)
+
+	case err != nil:
+		return nil, fmt.Errorf("error renewing session: %v", err)
+	}
+
+	log.Infof("Renewed session %x until %v", pubKey.SerializeCompressed(),
+		sess.Expiry)
+
+	// A running session still uses a macaroon that expires with the old
+	// expiry and would be stopped at that time, so we restart it. If the
+	// session isn't running, there's nothing to stop.
+	if err := s.sessionServer.StopSession(pubKey); err != nil {
+		log.Debugf("Error stopping session: %v", err)
+	}
+
+	if err := s.resumeSession(sess); err != nil {
+		return nil, fmt.Errorf("error restarting session: %v", err)
+	}
+
+	rpcSession, err := s.marshalRPCSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RenewSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
+
+// ImportSession adds a session that was exported from another node to the
+// session store and starts it if it is active.
+func (s *sessionRpcServer) ImportSession(_ context.Context,
+	req *litrpc.ImportSessionRequest) (*litrpc.ImportSessionResponse,
+	error) {
+
+	sess, err := session.DecodeExport(
+		req.Session, []byte(req.Passphrase),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Autopilot sessions are registered with the autopilot server by the
+	// node that created them, so they can't be moved to another node.
+	if sess.Type == session.TypeAutopilot {
+		return nil, status.Error(codes.FailedPrecondition,
+			"autopilot sessions can't be imported")
+	}
+
+	// The account of an account session is only known to the node that
+	// created it, so it must exist here already, for example because the
+	// session is restored from a backup of the same node.
+	if sess.Type == session.TypeMacaroonAccount {
+		if err := s.checkSessionAccount(sess); err != nil {
+			return nil, err
+		}
+	}
+
+	pubKey := sess.LocalPublicKey
+
+	// The ID of the imported session must not be handed out to a new
+	// session at the same time.
+	s.sessRegMu.Lock()
+	defer s.sessRegMu.Unlock()
+
+	// A running session that is replaced must be stopped first, it is
+	// started again with the imported state below.
+	if req.Overwrite {
+		if err := s.sessionServer.StopSession(pubKey); err != nil {
+			log.Debugf("Error stopping session: %v", err)
+		}
+	}
+
+	err = s.cfg.db.ImportSession(sess, req.Overwrite)
+	switch {
+	case errors.Is(err, session.ErrSessionExists):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+
+	case err != nil:
+		return nil, fmt.Errorf("error importing session: %v", err)
+	}
+
+	log.Infof("Imported session %x", pubKey.SerializeCompressed())
+
+	if err := s.resumeSession(sess); err != nil {
+		return nil, fmt.Errorf("error starting session: %v", err)
+	}
+
+	rpcSession, err := s.marshalRPCSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.ImportSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
+
+// checkSessionAccount makes sure the account that the given account session is
+// bound to exists in the local accounts store.
+func (s *sessionRpcServer) checkSessionAccount(sess *session.Session) error {
+	id, err := sessionAccountID(sess)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = s.cfg.getAccount(*id)
+	switch {
+	case errors.Is(err, accounts.ErrAccNotFound):
+		return status.Errorf(codes.FailedPrecondition, "account %x of "+
+			"the session doesn't exist on this node", id[:])
+
+	case err != nil:
+		return fmt.Errorf("error looking up account: %v", err)
+	}
+
+	return nil
+}
+
+// sessionAccountID returns the ID of the account that the given account session
+// is bound to by its account caveat.
+func sessionAccountID(sess *session.Session) (*accounts.AccountID, error) {
+	if sess.MacaroonRecipe == nil {
+		return nil, errors.New("invalid account session, expected " +
+			"recipe to be set")
+	}
+
+	prefix := fmt.Sprintf("%s %s ", macaroons.CondLndCustom,
+		accounts.CondAccount)
+	for _, caveat := range sess.MacaroonRecipe.Caveats {
+		idStr, ok := strings.CutPrefix(string(caveat.Id), prefix)
+		if !ok {
+			continue
+		}
+
+		return accounts.ParseAccountID(idStr)
+	}
+
+	return nil, errors.New("account session has no account caveat")
+}
+
+// ExportSession serializes a session so that it can be imported on another
+// node, optionally encrypted with a passphrase.
+func (s *sessionRpcServer) ExportSession(_ context.Context,
+	req *litrpc.ExportSessionRequest) (*litrpc.ExportSessionResponse,
+	error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.cfg.db.GetSession(pubKey)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	if sess.Type == session.TypeAutopilot {
+		return nil, status.Error(codes.FailedPrecondition,
+			"autopilot sessions can't be exported")
+	}
+
+	export, err := session.EncodeExport(sess, []byte(req.Passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("error exporting session: %v", err)
+	}
+
+	log.Infof("Exported session %x (encrypted=%v)",
+		pubKey.SerializeCompressed(), req.Passphrase != "")
+
+	return &litrpc.ExportSessionResponse{
+		Session: export,
+	}, nil
+}
+
+// CheckSessionPermissions reports whether the macaroon of a session grants the
+// permissions the given method requires and lists the missing ones.
+func (s *sessionRpcServer) CheckSessionPermissions(_ context.Context,
+	req *litrpc.CheckSessionPermissionsRequest) (
+	*litrpc.CheckSessionPermissionsResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.cfg.db.GetSession(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	required, ok := s.cfg.permMgr.URIPermissions(req.Method)
+	if !ok {
+		return nil, fmt.Errorf("unknown method %s", req.Method)
+	}
+
+	resp := &litrpc.CheckSessionPermissionsResponse{
+		RequiredPermissions: marshalRPCPermissions(required),
+	}
+
+	switch {
+	case s.cfg.permMgr.IsWhiteListedURL(req.Method):
+		resp.Permitted = true
+		resp.Reason = fmt.Sprintf("%s doesn't require any "+
+			"permissions", req.Method)
+
+		return resp, nil
+
+	case sess.State == session.StateRevoked ||
+		sess.State == session.StateExpired:
+
+		resp.MissingPermissions = resp.RequiredPermissions
+		resp.Reason = fmt.Sprintf("session is in state %d and can't "+
+			"be used anymore", sess.State)
+
+		return resp, nil
+	}
+
+	recipe, err := s.sessionMacaroonRecipe(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions without a macaroon, like UI password sessions, are
+	// authenticated with the UI password which grants full access.
+	if recipe == nil {
+		resp.Permitted = true
+		resp.Reason = fmt.Sprintf("sessions of type %d have full "+
+			"access", sess.Type)
+
+		return resp, nil
+	}
+
+	missing, byURI := missingPermissions(
+		recipe.Permissions, required, req.Method,
+	)
+	switch {
+	case byURI:
+		resp.Permitted = true
+		resp.Reason = fmt.Sprintf("session grants access to %s by URI",
+			req.Method)
+
+	case len(missing) > 0:
+		resp.MissingPermissions = marshalRPCPermissions(missing)
+		resp.Reason = fmt.Sprintf("session is missing the "+
+			"permissions %s", formatOps(missing))
 
-	// No other types are currently supported.
 	default:
-		log.Debugf("Not resuming session %x with type %d", pubKeyBytes,
-			sess.Type)
-		return nil
+		resp.Permitted = true
+		resp.Reason = "session has all required permissions"
 	}
 
-	// Add the session expiry as a macaroon caveat.
-	macExpiry := checkers.TimeBeforeCaveat(sess.Expiry)
-	caveats = append(caveats, macaroon.Caveat{
-		Id: []byte(macExpiry.Condition),
-	})
+	return resp, nil
+}
 
-	mac, err := s.cfg.superMacBaker(
-		context.Background(), sess.MacaroonRootKey,
-		&session.MacaroonRecipe{
-			Permissions: permissions,
-			Caveats:     caveats,
-		},
-	)
+// PreviewMethodPolicy reports which of the currently active sessions would be
+// affected by denying or allowing the given methods. Nothing is changed.
+func (s *sessionRpcServer) PreviewMethodPolicy(_ context.Context,
+	req *litrpc.PreviewMethodPolicyRequest) (
+	*litrpc.PreviewMethodPolicyResponse, error) {
+
+	denied, err := s.expandPolicyMethods(req.DenyMethods)
 	if err != nil {
-		log.Debugf("Not resuming session %x. Could not bake "+
-			"the necessary macaroon: %w", pubKeyBytes, err)
-		return nil
+		return nil, err
 	}
 
-	var (
-		onNewStatus     func(s mailbox.ServerStatus)
-		firstConnTimout = make(chan struct{})
-	)
-
-	// If this is the first time the session is being spun up then we will
-	// kick off a timer to revoke the session after a timeout unless an
-	// initial connection is made. We identify such a session as one that
-	// we do not yet have a static remote pub key for.
-	if sess.RemotePublicKey == nil {
-		deadline := sess.CreatedAt.Add(s.cfg.firstConnectionDeadline)
-		if deadline.Before(time.Now()) {
-			log.Debugf("Deadline for session %x has already "+
-				"passed. Revoking session", pubKeyBytes)
+	allowed, err := s.expandPolicyMethods(req.AllowMethods)
+	if err != nil {
+		return nil, err
+	}
 
-			return s.cfg.db.RevokeSession(pubKey)
+	deniedSet := make(map[string]struct{}, len(denied))
+	for _, method := range denied {
+		deniedSet[method] = struct{}{}
+	}
+	for _, method := range allowed {
+		if _, ok := deniedSet[method]; ok {
+			return nil, fmt.Errorf("method %s is both denied and "+
+				"allowed", method)
 		}
+	}
 
-		// Start the deadline timer.
-		deadlineDuration := time.Until(deadline)
-		deadlineTimer := time.AfterFunc(deadlineDuration, func() {
-			close(firstConnTimout)
-		})
+	now := time.Now()
+	sessions, err := s.cfg.db.ListSessions(func(sess *session.Session) bool {
+		return (sess.State == session.StateCreated ||
+			sess.State == session.StateInUse) &&
+			now.Before(sess.Expiry)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %v", err)
+	}
 
-		log.Warnf("Kicking off deadline timer for first connection "+
-			"for session %x. A successful connection must be "+
-			"made in the next %s", pubKeyBytes, deadlineDuration)
+	// A denied method blocks the sessions that can call it today, an
+	// allowed method unblocks the ones that can't.
+	effects := func(methods []string,
+		affected bool) ([]*litrpc.MethodPolicyEffect, error) {
 
-		var stopTimerOnce sync.Once
-		onNewStatus = func(s mailbox.ServerStatus) {
-			// We will only stop the timer if the server status
-			// indicates that the client has successfully connected.
-			if s != mailbox.ServerStatusInUse {
-				return
+		result := make([]*litrpc.MethodPolicyEffect, 0, len(methods))
+		for _, method := range methods {
+			effect := &litrpc.MethodPolicyEffect{
+				Method: method,
 			}
 
-			// Stop the deadline timer.
-			stopTimerOnce.Do(func() {
-				log.Debugf("First connection for session %x "+
-					"made in a timely manner",
-					sess.LocalPublicKey.
-						SerializeCompressed())
+			for _, sess := range sessions {
+				permitted, err := s.sessionPermits(sess, method)
+				if err != nil {
+					return nil, err
+				}
 
-				deadlineTimer.Stop()
-			})
+				if permitted != affected {
+					continue
+				}
+
+				effect.Sessions = append(
+					effect.Sessions,
+					&litrpc.PolicyAffectedSession{
+						LocalPublicKey: sess.LocalPublicKey.
+							SerializeCompressed(),
+						Label: sess.Label,
+					},
+				)
+			}
+
+			result = append(result, effect)
 		}
+
+		return result, nil
 	}
 
-	authData := []byte(fmt.Sprintf("%s: %s", HeaderMacaroon, mac))
-	sessionClosedSub, err := s.sessionServer.StartSession(
-		sess, authData, s.cfg.db.UpdateSessionRemotePubKey, onNewStatus,
-	)
+	newlyBlocked, err := effects(denied, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-
-		ticker := time.NewTimer(time.Until(sess.Expiry))
-		defer ticker.Stop()
+	newlyAllowed, err := effects(allowed, false)
+	if err != nil {
+		return nil, err
+	}
 
-		select {
-		case <-s.quit:
-			return
+	return &litrpc.PreviewMethodPolicyResponse{
+		NewlyBlocked: newlyBlocked,
+		NewlyAllowed: newlyAllowed,
+	}, nil
+}
 
-		case <-sessionClosedSub:
-			return
+// expandPolicyMethods converts the methods of a policy, which can be full URIs
+// or regular expressions, into the sorted list of known URIs they match.
+func (s *sessionRpcServer) expandPolicyMethods(methods []string) ([]string,
+	error) {
 
-		case <-ticker.C:
-			log.Debugf("Stopping expired session %x with "+
-				"type %d", pubKeyBytes, sess.Type)
+	uris := make(map[string]struct{})
+	for _, method := range methods {
+		matches, isRegex := s.cfg.permMgr.MatchRegexURI(method)
+		if !isRegex {
+			if _, ok := s.cfg.permMgr.URIPermissions(method); !ok {
+				return nil, fmt.Errorf("unknown method %s",
+					method)
+			}
 
-		case <-firstConnTimout:
-			log.Debugf("Deadline exceeded for first connection "+
-				"for session %x. Stopping and revoking.",
-				pubKeyBytes)
+			matches = []string{method}
 		}
 
-		if s.cfg.autopilot != nil {
-			ctx := context.Background()
-			ctxc, cancel := context.WithTimeout(
-				ctx, defaultConnectTimeout,
-			)
-
-			s.cfg.autopilot.SessionRevoked(ctxc, pubKey)
-			cancel()
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s matches no known method",
+				method)
 		}
 
-		err = s.sessionServer.StopSession(pubKey)
-		if err != nil {
-			log.Debugf("Error stopping session: %v", err)
+		for _, uri := range matches {
+			uris[uri] = struct{}{}
 		}
+	}
 
-		err = s.cfg.db.RevokeSession(pubKey)
-		if err != nil {
-			log.Debugf("error revoking session: %v", err)
-		}
-	}()
+	result := make([]string, 0, len(uris))
+	for uri := range uris {
+		result = append(result, uri)
+	}
+	sort.Strings(result)
 
-	return nil
+	return result, nil
 }
 
-// ListSessions returns all sessions known to the session store.
-func (s *sessionRpcServer) ListSessions(_ context.Context,
-	_ *litrpc.ListSessionsRequest) (*litrpc.ListSessionsResponse, error) {
+// sessionPermits returns true if the macaroon of the given session grants the
+// permissions the method requires. Like CheckSessionPermissions, only the
+// permissions are checked, not the caveats.
+func (s *sessionRpcServer) sessionPermits(sess *session.Session,
+	method string) (bool, error) {
 
-	sessions, err := s.cfg.db.ListSessions(nil)
+	if s.cfg.permMgr.IsWhiteListedURL(method) {
+		return true, nil
+	}
+
+	required, ok := s.cfg.permMgr.URIPermissions(method)
+	if !ok {
+		return false, fmt.Errorf("unknown method %s", method)
+	}
+
+	recipe, err := s.sessionMacaroonRecipe(sess)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching sessions: %v", err)
+		return false, err
 	}
 
-	response := &litrpc.ListSessionsResponse{
-		Sessions: make([]*litrpc.Session, len(sessions)),
+	// Sessions without a macaroon are authenticated with the UI password
+	// which grants full access.
+	if recipe == nil {
+		return true, nil
 	}
-	for idx, sess := range sessions {
-		response.Sessions[idx], err = s.marshalRPCSession(sess)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling session: %v",
-				err)
+
+	missing, byURI := missingPermissions(
+		recipe.Permissions, required, method,
+	)
+
+	return byURI || len(missing) == 0, nil
+}
+
+// CheckSessionStore audits the session store for inconsistencies and repairs
+// them if requested.
+func (s *sessionRpcServer) CheckSessionStore(ctx context.Context,
+	req *litrpc.CheckSessionStoreRequest) (*litrpc.CheckSessionStoreResponse,
+	error) {
+
+	// Make sure no new session is registered while the store is being
+	// checked.
+	s.sessRegMu.Lock()
+	defer s.sessRegMu.Unlock()
+
+	report, err := s.cfg.db.CheckConsistency(req.Repair)
+	if err != nil {
+		return nil, fmt.Errorf("error checking session store: %v", err)
+	}
+
+	if report.Repaired {
+		log.Infof("Repaired session store: removed %d orphaned and "+
+			"%d dangling index entries, re-indexed %d sessions",
+			len(report.OrphanedIndexEntries),
+			len(report.DanglingGroupEntries),
+			len(report.UnindexedSessions))
+	}
+
+	rootKeys, err := s.checkRootKeys(ctx, req.DeleteOrphanedRootKeys)
+	if err != nil {
+		return nil, fmt.Errorf("error checking root keys: %v", err)
+	}
+
+	marshalIDs := func(ids []session.ID) [][]byte {
+		res := make([][]byte, len(ids))
+		for i, id := range ids {
+			id := id
+			res[i] = id[:]
 		}
+
+		return res
 	}
 
-	return response, nil
+	return &litrpc.CheckSessionStoreResponse{
+		OrphanedIndexEntries:   marshalIDs(report.OrphanedIndexEntries),
+		UnindexedSessions:      marshalIDs(report.UnindexedSessions),
+		DanglingGroupEntries:   marshalIDs(report.DanglingGroupEntries),
+		CorruptSessions:        report.CorruptSessions,
+		Repaired:               report.Repaired,
+		OrphanedRootKeys:       rootKeys.orphaned,
+		SessionsMissingRootKey: marshalIDs(rootKeys.missing),
+		RootKeysDeleted:        rootKeys.deleted,
+	}, nil
 }
 
-// RevokeSession revokes a single session and also stops it if it is currently
-// active.
-func (s *sessionRpcServer) RevokeSession(ctx context.Context,
-	req *litrpc.RevokeSessionRequest) (*litrpc.RevokeSessionResponse, error) {
+// rootKeyReport is the result of comparing the super macaroon root keys in
+// lnd with the root keys of the active sessions.
+type rootKeyReport struct {
+	// orphaned are the IDs of the root keys that don't belong to an
+	// active session.
+	orphaned []uint64
 
-	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	// missing are the IDs of the active sessions whose root key doesn't
+	// exist in lnd.
+	missing []session.ID
+
+	// deleted is true if the orphaned root keys were deleted.
+	deleted bool
+}
+
+// checkRootKeys compares the super macaroon root keys in lnd's root key store
+// with the root keys of the active sessions in both directions. If
+// deleteOrphans is set, the root keys without an active session are deleted
+// from lnd.
+func (s *sessionRpcServer) checkRootKeys(ctx context.Context,
+	deleteOrphans bool) (*rootKeyReport, error) {
+
+	rootKeyIDs, err := s.cfg.listMacRootKeys(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing public key: %v", err)
+		return nil, err
+	}
+
+	// The root keys of revoked sessions are deleted on revocation, so
+	// only active sessions need to have one.
+	sessions, err := s.cfg.db.ListSessions(func(sess *session.Session) bool {
+		return sess.State == session.StateCreated ||
+			sess.State == session.StateInUse
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.cfg.db.RevokeSession(pubKey); err != nil {
-		return nil, fmt.Errorf("error revoking session: %v", err)
+	sessionKeys := make(map[uint64]session.ID, len(sessions))
+	for _, sess := range sessions {
+		sessionKeys[sess.MacaroonRootKey] = sess.ID
 	}
 
-	if s.cfg.autopilot != nil {
-		s.cfg.autopilot.SessionRevoked(ctx, pubKey)
+	report := &rootKeyReport{}
+	inLnd := make(map[uint64]bool, len(rootKeyIDs))
+	for _, id := range rootKeyIDs {
+		inLnd[id] = true
+
+		if _, ok := sessionKeys[id]; !ok {
+			report.orphaned = append(report.orphaned, id)
+		}
 	}
+	for rootKeyID, id := range sessionKeys {
+		if !inLnd[rootKeyID] {
+			report.missing = append(report.missing, id)
+		}
+	}
+	sort.Slice(report.orphaned, func(i, j int) bool {
+		return report.orphaned[i] < report.orphaned[j]
+	})
+	sort.Slice(report.missing, func(i, j int) bool {
+		return bytes.Compare(
+			report.missing[i][:], report.missing[j][:],
+		) < 0
+	})
 
-	// If the session expired already it might not be running anymore. So we
-	// only log possible errors here.
-	if err := s.sessionServer.StopSession(pubKey); err != nil {
-		log.Debugf("Error stopping session: %v", err)
+	if !deleteOrphans {
+		return report, nil
 	}
 
-	return &litrpc.RevokeSessionResponse{}, nil
+	for _, id := range report.orphaned {
+		if err := s.cfg.deleteMacRootKey(ctx, id); err != nil {
+			return nil, fmt.Errorf("error deleting root key %d: %v",
+				id, err)
+		}
+	}
+	report.deleted = true
+
+	log.Infof("Deleted %d orphaned macaroon root keys",
+		len(report.orphaned))
+
+	return report, nil
 }
 
 // PrivacyMapConversion can be used map real values to their pseudo counterpart
@@ -764,24 +2148,10 @@ func (s *sessionRpcServer) ListActions(_ context.Context,
 	}
 	resp := make([]*litrpc.Action, len(actions))
 	for i, a := range actions {
-		state, err := marshalActionState(a.State)
+		resp[i], err = marshalAction(a)
 		if err != nil {
 			return nil, err
 		}
-
-		resp[i] = &litrpc.Action{
-			SessionId:          a.SessionID[:],
-			ActorName:          a.ActorName,
-			FeatureName:        a.FeatureName,
-			Trigger:            a.Trigger,
-			Intent:             a.Intent,
-			StructuredJsonData: a.StructuredJsonData,
-			RpcMethod:          a.RPCMethod,
-			RpcParamsJson:      string(a.RPCParamsJson),
-			Timestamp:          uint64(a.AttemptedAt.Unix()),
-			State:              state,
-			ErrorReason:        a.ErrorReason,
-		}
 	}
 
 	return &litrpc.ListActionsResponse{
@@ -791,6 +2161,84 @@ func (s *sessionRpcServer) ListActions(_ context.Context,
 	}, nil
 }
 
+// SubscribeActions streams the actions that are added to the action log as
+// well as the actions whose state changes. If a session ID is given, only the
+// actions of that session are streamed.
+func (s *sessionRpcServer) SubscribeActions(req *litrpc.SubscribeActionsRequest,
+	stream litrpc.Firewall_SubscribeActionsServer) error {
+
+	var (
+		sessionID session.ID
+		filter    bool
+	)
+	if len(req.SessionId) != 0 {
+		var err error
+		sessionID, err = session.IDFromBytes(req.SessionId)
+		if err != nil {
+			return err
+		}
+		filter = true
+	}
+
+	sub := s.cfg.actionsDB.SubscribeActions()
+	defer sub.Cancel()
+
+	for {
+		select {
+		case a := <-sub.Updates():
+			if filter && a.SessionID != sessionID {
+				continue
+			}
+
+			action, err := marshalAction(a)
+			if err != nil {
+				return err
+			}
+
+			if err := stream.Send(action); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case <-s.quit:
+			return fmt.Errorf("server shutting down")
+		}
+	}
+}
+
+// marshalAction converts an action from the action log into its RPC
+// counterpart.
+func marshalAction(a *firewalldb.Action) (*litrpc.Action, error) {
+	state, err := marshalActionState(a.State)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &litrpc.Action{
+		SessionId:          a.SessionID[:],
+		ActorName:          a.ActorName,
+		FeatureName:        a.FeatureName,
+		Trigger:            a.Trigger,
+		Intent:             a.Intent,
+		StructuredJsonData: a.StructuredJsonData,
+		RpcMethod:          a.RPCMethod,
+		RpcParamsJson:      string(a.RPCParamsJson),
+		Timestamp:          uint64(a.AttemptedAt.Unix()),
+		State:              state,
+		ErrorReason:        a.ErrorReason,
+	}
+
+	if a.MacaroonInfo != nil {
+		action.HasMacaroonInfo = true
+		action.MacaroonRootKeyId = a.MacaroonInfo.RootKeyID
+		action.MacaroonCaveats = a.MacaroonInfo.Caveats
+	}
+
+	return action, nil
+}
+
 // ListAutopilotFeatures fetches all the features supported by the autopilot
 // server along with the rules that we need to support in order to subscribe
 // to those features.
@@ -1150,6 +2598,14 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 	s.sessRegMu.Lock()
 	defer s.sessRegMu.Unlock()
 
+	if err := s.checkSessionCreateRate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkActiveSessionLimit(); err != nil {
+		return nil, err
+	}
+
 	id, localPrivKey, err := s.cfg.db.GetUnusedIDAndKeyPair()
 	if err != nil {
 		return nil, err
@@ -1436,6 +2892,11 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		revokedAt = uint64(sess.RevokedAt.Unix())
 	}
 
+	var macExpiry uint64
+	if !sess.MacaroonExpiry.IsZero() {
+		macExpiry = uint64(sess.MacaroonExpiry.Unix())
+	}
+
 	var (
 		featureInfo    = make(map[string]*litrpc.RulesMap)
 		initRuleValues = s.cfg.ruleMgrs.InitRuleValues
@@ -1488,6 +2949,17 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		}
 	}
 
+	activity, err := s.cfg.db.GetSessionActivity(sess.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching session activity: %v",
+			err)
+	}
+
+	var lastUsed uint64
+	if !activity.LastUsed.IsZero() {
+		lastUsed = uint64(activity.LastUsed.Unix())
+	}
+
 	return &litrpc.Session{
 		Id:                     sess.ID[:],
 		Label:                  sess.Label,
@@ -1507,6 +2979,17 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		GroupId:                sess.GroupID[:],
 		FeatureConfigs:         clientConfig,
 		PrivacyFlags:           sess.PrivacyFlags.Serialize(),
+		MaxStreams:             sess.MaxStreams,
+		ActiveStreams:          s.cfg.activeStreams(sess),
+		RevocationReason:       sess.RevocationReason,
+		Metadata:               sess.Metadata,
+		MacExpiry:              macExpiry,
+		MacaroonRootKeyStale: s.cfg.rootKeys.isStale(
+			sess.MacaroonRootKey,
+		),
+		LastUsed:           lastUsed,
+		RequestCount:       activity.Requests,
+		IdleTimeoutSeconds: uint64(sess.IdleTimeout / time.Second),
 	}, nil
 }
 
@@ -1519,13 +3002,7 @@ func marshalRPCMacaroonRecipe(
 		return nil
 	}
 
-	perms := make([]*litrpc.MacaroonPermission, len(recipe.Permissions))
-	for i, op := range recipe.Permissions {
-		perms[i] = &litrpc.MacaroonPermission{
-			Entity: op.Entity,
-			Action: op.Action,
-		}
-	}
+	perms := marshalRPCPermissions(recipe.Permissions)
 
 	caveats := make([]string, len(recipe.Caveats))
 	for i, cav := range recipe.Caveats {
@@ -1558,6 +3035,26 @@ func marshalRPCState(state session.State) (litrpc.SessionState, error) {
 	}
 }
 
+// unmarshalRPCState converts an RPC session state to its session counterpart.
+func unmarshalRPCState(state litrpc.SessionState) (session.State, error) {
+	switch state {
+	case litrpc.SessionState_STATE_CREATED:
+		return session.StateCreated, nil
+
+	case litrpc.SessionState_STATE_IN_USE:
+		return session.StateInUse, nil
+
+	case litrpc.SessionState_STATE_REVOKED:
+		return session.StateRevoked, nil
+
+	case litrpc.SessionState_STATE_EXPIRED:
+		return session.StateExpired, nil
+
+	default:
+		return 0, fmt.Errorf("unknown state <%d>", state)
+	}
+}
+
 // marshalRPCType converts a session type to its RPC counterpart.
 func marshalRPCType(typ session.Type) (litrpc.SessionType, error) {
 	switch typ {
@@ -1627,3 +3124,17 @@ func marshalActionState(state firewalldb.ActionState) (litrpc.ActionState,
 		return 0, fmt.Errorf("unknown state <%d>", state)
 	}
 }
+
+// marshalRPCPermissions converts a list of permissions into its RPC
+// counterpart.
+func marshalRPCPermissions(ops []bakery.Op) []*litrpc.MacaroonPermission {
+	perms := make([]*litrpc.MacaroonPermission, len(ops))
+	for i, op := range ops {
+		perms[i] = &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		}
+	}
+
+	return perms
+}
@@ -0,0 +1,166 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// sessionScopeSubServers maps the names that can be used in a session scope
+// to the names of the sub-servers in the permissions manager.
+var sessionScopeSubServers = map[string]string{
+	"lnd":     "lnd",
+	"loop":    subservers.LOOP,
+	"pool":    subservers.POOL,
+	"faraday": subservers.FARADAY,
+	"litrpc":  "lit",
+}
+
+// parseSessionScope validates the given session scope names and returns them
+// de-duplicated and sorted.
+func parseSessionScope(names []string) ([]string, error) {
+	unique := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := sessionScopeSubServers[name]; !ok {
+			return nil, fmt.Errorf("unknown session scope %q, "+
+				"must be one of lnd, loop, pool, faraday or "+
+				"litrpc", name)
+		}
+
+		unique[name] = struct{}{}
+	}
+
+	scope := make([]string, 0, len(unique))
+	for name := range unique {
+		scope = append(scope, name)
+	}
+	sort.Strings(scope)
+
+	return scope, nil
+}
+
+// sessionScopeFromCaveats returns the sub-servers of the permissions manager
+// that the given caveats scope a session to. If there is more than one scope
+// caveat, only the sub-servers that are in all of them are returned. False is
+// returned if the caveats don't restrict the scope.
+func sessionScopeFromCaveats(caveats []macaroon.Caveat) (map[string]bool,
+	bool, error) {
+
+	var scope map[string]bool
+	for _, caveat := range caveats {
+		names, err := firewall.ParseScopeCaveat(string(caveat.Id))
+		if errors.Is(err, firewall.ErrNoScopeCaveat) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		caveatScope := make(map[string]bool, len(names))
+		for _, name := range names {
+			subServer, ok := sessionScopeSubServers[name]
+			if !ok {
+				return nil, false, fmt.Errorf("unknown "+
+					"session scope %q", name)
+			}
+
+			if scope == nil || scope[subServer] {
+				caveatScope[subServer] = true
+			}
+		}
+
+		scope = caveatScope
+	}
+
+	return scope, scope != nil, nil
+}
+
+// scopedPermissions returns the active permissions of the sub-servers in the
+// given scope. Optionally, readOnly can be set to true if only the read-only
+// permissions should be returned.
+func scopedPermissions(permMgr *perms.Manager, scope map[string]bool,
+	readOnly bool) []bakery.Op {
+
+	var (
+		unique = make(map[bakery.Op]struct{})
+		result []bakery.Op
+	)
+	for subServer, ops := range permMgr.ActivePermissionsBySubServer() {
+		if !scope[subServer] {
+			continue
+		}
+
+		for _, op := range ops {
+			if readOnly && op.Action != "read" {
+				continue
+			}
+
+			if _, ok := unique[op]; ok {
+				continue
+			}
+			unique[op] = struct{}{}
+
+			result = append(result, op)
+		}
+	}
+
+	return result
+}
+
+// permissionInScope returns true if the given entity-action pair is one of the
+// permissions of the sub-servers in the given scope. For the URI entity, the
+// URI must belong to one of the sub-servers.
+func permissionInScope(permMgr *perms.Manager, scope map[string]bool,
+	entity, action string) bool {
+
+	if entity == macaroons.PermissionEntityCustomURI {
+		return scope[permMgr.SubServerForURI(action)]
+	}
+
+	for _, op := range scopedPermissions(permMgr, scope, false) {
+		if op.Entity == entity && op.Action == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSessionScope makes sure the given method belongs to a sub-server that
+// all session scope caveats of the request's macaroon allow. Requests that
+// aren't allowed are rejected with PermissionDenied.
+func (p *rpcProxy) checkSessionScope(ctx context.Context,
+	requestURI string) error {
+
+	mac, err := macaroonFromContext(ctx)
+	if err != nil || mac == nil {
+		// An invalid macaroon is rejected by the authentication, so
+		// there is nothing to check here.
+		return nil
+	}
+
+	scope, scoped, err := sessionScopeFromCaveats(mac.Caveats())
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "invalid session "+
+			"scope caveat: %v", err)
+	}
+
+	if !scoped || scope[p.permsMgr.SubServerForURI(requestURI)] {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "permission denied: %s "+
+		"is outside of the session's scope", requestURI)
+}
@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestSessionScope tests that a session scope only grants the permissions of
+// the sub-servers in it and that calls to other sub-servers are rejected.
+func TestSessionScope(t *testing.T) {
+	scope, err := parseSessionScope([]string{"Loop", "litrpc", "loop"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"litrpc", "loop"}, scope)
+
+	_, err = parseSessionScope([]string{"tapd"})
+	require.ErrorContains(t, err, `unknown session scope "tapd"`)
+
+	permsMgr, err := perms.NewManager(false)
+	require.NoError(t, err)
+
+	loopOp := bakery.Op{Entity: "swap", Action: "execute"}
+	permsMgr.RegisterSubServer(subservers.LOOP, map[string][]bakery.Op{
+		"/looprpc.SwapClient/LoopOut": {loopOp},
+	}, nil)
+
+	loopOnly := []macaroon.Caveat{{
+		Id: []byte(firewall.ScopeToCaveat([]string{"loop"})),
+	}}
+	subServers, scoped, err := sessionScopeFromCaveats(loopOnly)
+	require.NoError(t, err)
+	require.True(t, scoped)
+	require.Equal(t, map[string]bool{subservers.LOOP: true}, subServers)
+
+	// Only the permissions of the sub-servers in the scope are granted.
+	require.Equal(
+		t, []bakery.Op{loopOp},
+		scopedPermissions(permsMgr, subServers, false),
+	)
+	require.Empty(t, scopedPermissions(permsMgr, subServers, true))
+	require.True(t, permissionInScope(
+		permsMgr, subServers, "uri", "/looprpc.SwapClient/LoopOut",
+	))
+	require.False(t, permissionInScope(
+		permsMgr, subServers, "uri", "/lnrpc.Lightning/GetInfo",
+	))
+	require.False(t, permissionInScope(
+		permsMgr, subServers, "info", "read",
+	))
+
+	// Multiple scope caveats can only narrow the scope down.
+	_, scoped, err = sessionScopeFromCaveats(nil)
+	require.NoError(t, err)
+	require.False(t, scoped)
+
+	subServers, scoped, err = sessionScopeFromCaveats(append(
+		[]macaroon.Caveat{{Id: []byte(firewall.ScopeToCaveat(
+			[]string{"lnd", "loop"},
+		))}}, loopOnly...,
+	))
+	require.NoError(t, err)
+	require.True(t, scoped)
+	require.Equal(t, map[string]bool{subservers.LOOP: true}, subServers)
+
+	// Requests with the macaroon of a scoped session are only allowed to
+	// reach the sub-servers in the scope.
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+	require.NoError(t, mac.AddFirstPartyCaveat(loopOnly[0].Id))
+
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs(
+			HeaderMacaroon, hex.EncodeToString(macBytes),
+		),
+	)
+
+	p := &rpcProxy{permsMgr: permsMgr}
+	require.NoError(t, p.checkSessionScope(
+		ctx, "/looprpc.SwapClient/LoopOut",
+	))
+
+	err = p.checkSessionScope(ctx, "/lnrpc.Lightning/GetInfo")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Requests without a scope caveat are not restricted.
+	require.NoError(t, p.checkSessionScope(
+		context.Background(), "/lnrpc.Lightning/GetInfo",
+	))
+}
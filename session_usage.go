@@ -0,0 +1,316 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+)
+
+const (
+	// defaultSessionUsageRetention is the default duration for which the
+	// daily request counts of sessions are kept.
+	defaultSessionUsageRetention = 90 * 24 * time.Hour
+
+	// sessionUsageFlushInterval is the interval at which the request
+	// counts that were collected in memory are written to the session
+	// store.
+	sessionUsageFlushInterval = time.Minute
+)
+
+// sessionUsageTracker counts the requests of each session in total and, if a
+// retention is set, per day. The counts are collected in memory and
+// periodically added to the session store, which keeps the daily counts for
+// the configured retention.
+type sessionUsageTracker struct {
+	db        session.Store
+	retention time.Duration
+
+	// pending holds the request counts that are not yet written to the
+	// store, by session and start of the day.
+	pending map[session.ID]map[time.Time]uint64
+
+	// pendingActivity holds the request counts and the time of the last
+	// request that are not yet written to the store, by session.
+	pendingActivity map[session.ID]session.Activity
+	mu              sync.Mutex
+
+	// flushMu makes sure only one flush runs at a time.
+	flushMu sync.Mutex
+
+	quit     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newSessionUsageTracker creates a new tracker that keeps the usage of the
+// sessions in the given store for the given retention. A zero retention
+// means that only the total request counts are kept.
+func newSessionUsageTracker(db session.Store,
+	retention time.Duration) *sessionUsageTracker {
+
+	return &sessionUsageTracker{
+		db:              db,
+		retention:       retention,
+		pending:         make(map[session.ID]map[time.Time]uint64),
+		pendingActivity: make(map[session.ID]session.Activity),
+		quit:            make(chan struct{}),
+	}
+}
+
+// start starts writing the collected request counts to the store
+// periodically.
+func (t *sessionUsageTracker) start() {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		ticker := time.NewTicker(sessionUsageFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := t.flush(time.Now()); err != nil {
+					log.Errorf("Unable to store session "+
+						"usage: %v", err)
+				}
+
+			case <-t.quit:
+				return
+			}
+		}
+	}()
+}
+
+// stop stops the periodic writes and writes the request counts that were
+// collected since the last write. It must be called before the store is
+// closed.
+func (t *sessionUsageTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.quit)
+		t.wg.Wait()
+
+		if err := t.flush(time.Now()); err != nil {
+			log.Errorf("Unable to store session usage: %v", err)
+		}
+	})
+}
+
+// record counts a request of the session with the given ID.
+func (t *sessionUsageTracker) record(id session.ID, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	days, ok := t.pending[id]
+	if !ok {
+		days = make(map[time.Time]uint64)
+		t.pending[id] = days
+	}
+
+	days[session.UsageDayStart(now)]++
+
+	activity := t.pendingActivity[id]
+	activity.Requests++
+	if now.After(activity.LastUsed) {
+		activity.LastUsed = now
+	}
+	t.pendingActivity[id] = activity
+}
+
+// flush writes the collected request counts to the store and removes the
+// usage that is older than the retention.
+func (t *sessionUsageTracker) flush(now time.Time) error {
+	t.flushMu.Lock()
+	defer t.flushMu.Unlock()
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[session.ID]map[time.Time]uint64)
+	pendingActivity := t.pendingActivity
+	t.pendingActivity = make(map[session.ID]session.Activity)
+	t.mu.Unlock()
+
+	// Not every super macaroon belongs to a session, so we only keep the
+	// counts of the IDs that we know.
+	usage := make(map[session.ID][]session.UsageDay, len(pending))
+	activity := make(map[session.ID]session.Activity, len(pending))
+	for id, days := range pending {
+		if _, err := t.db.GetSessionByID(id); err != nil {
+			continue
+		}
+
+		activity[id] = pendingActivity[id]
+
+		// Without a retention, the daily counts aren't kept.
+		if t.retention == 0 {
+			continue
+		}
+
+		for day, requests := range days {
+			usage[id] = append(usage[id], session.UsageDay{
+				Day:      day,
+				Requests: requests,
+			})
+		}
+	}
+
+	if len(activity) > 0 {
+		if err := t.db.AddSessionActivity(activity); err != nil {
+			return err
+		}
+	}
+
+	if t.retention == 0 {
+		return nil
+	}
+
+	if len(usage) > 0 {
+		if err := t.db.AddSessionUsage(usage); err != nil {
+			return err
+		}
+	}
+
+	return t.db.PruneSessionUsage(
+		session.UsageDayStart(now.Add(-t.retention)),
+	)
+}
+
+// usagePeriodStart returns the start of the bucket of the given period that the
+// given day falls into. Weeks start on Monday.
+func usagePeriodStart(day time.Time, period litrpc.UsagePeriod) time.Time {
+	day = session.UsageDayStart(day)
+	if period != litrpc.UsagePeriod_USAGE_PERIOD_WEEK {
+		return day
+	}
+
+	daysSinceMonday := (int(day.Weekday()) + 6) % 7
+
+	return day.AddDate(0, 0, -daysSinceMonday)
+}
+
+// usagePeriodEnd returns the end of the bucket of the given period that starts
+// at the given time.
+func usagePeriodEnd(start time.Time, period litrpc.UsagePeriod) time.Time {
+	if period == litrpc.UsagePeriod_USAGE_PERIOD_WEEK {
+		return start.AddDate(0, 0, 7)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+// GetSessionUsage returns the number of requests sessions made, bucketed by
+// day or by week.
+func (s *sessionRpcServer) GetSessionUsage(_ context.Context,
+	req *litrpc.GetSessionUsageRequest) (*litrpc.GetSessionUsageResponse,
+	error) {
+
+	if s.cfg.usageRetention == 0 {
+		return nil, fmt.Errorf("the usage of sessions is not tracked, " +
+			"set lit-session-usage-retention to enable it")
+	}
+
+	if _, ok := litrpc.UsagePeriod_name[int32(req.Period)]; !ok {
+		return nil, fmt.Errorf("unknown usage period %v",
+			req.Period)
+	}
+
+	// Include the requests that were counted since the last write to the
+	// store.
+	if err := s.usage.flush(time.Now()); err != nil {
+		return nil, fmt.Errorf("error storing session usage: %v", err)
+	}
+
+	var sessions []*session.Session
+	if len(req.LocalPublicKey) > 0 {
+		pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key: %v",
+				err)
+		}
+
+		sess, err := s.cfg.db.GetSession(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching session: %v",
+				err)
+		}
+		sessions = append(sessions, sess)
+	} else {
+		var err error
+		sessions, err = s.cfg.db.ListSessions(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing sessions: %v",
+				err)
+		}
+	}
+
+	var (
+		start = time.Unix(int64(req.StartTimestamp), 0)
+		end   = time.Unix(int64(req.EndTimestamp), 0)
+	)
+
+	resp := &litrpc.GetSessionUsageResponse{
+		Sessions: make([]*litrpc.SessionUsage, 0, len(sessions)),
+	}
+	for _, sess := range sessions {
+		state, err := marshalRPCState(sess.State)
+		if err != nil {
+			return nil, err
+		}
+
+		days, err := s.cfg.db.GetSessionUsage(sess.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching usage of "+
+				"session %x: %v", sess.ID[:], err)
+		}
+
+		pubKey := sess.LocalPublicKey.SerializeCompressed()
+		usage := &litrpc.SessionUsage{
+			LocalPublicKey: pubKey,
+			Label:          sess.Label,
+			SessionState:   state,
+		}
+
+		// The days are sorted, so the days of a bucket are next to
+		// each other.
+		for _, day := range days {
+			usage.LastUsedDay = uint64(day.Day.Unix())
+
+			bucketStart := usagePeriodStart(day.Day, req.Period)
+			bucketEnd := usagePeriodEnd(bucketStart, req.Period)
+			if req.StartTimestamp != 0 && !bucketEnd.After(start) {
+				continue
+			}
+			if req.EndTimestamp != 0 && !bucketStart.Before(end) {
+				continue
+			}
+
+			usage.TotalRequests += day.Requests
+
+			n := len(usage.Buckets)
+			if n > 0 && usage.Buckets[n-1].StartTimestamp ==
+				uint64(bucketStart.Unix()) {
+
+				usage.Buckets[n-1].Requests += day.Requests
+				continue
+			}
+
+			usage.Buckets = append(
+				usage.Buckets, &litrpc.UsageBucket{
+					StartTimestamp: uint64(
+						bucketStart.Unix(),
+					),
+					Requests: day.Requests,
+				},
+			)
+		}
+
+		resp.Sessions = append(resp.Sessions, usage)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// defaultShutdownTimeout is the default duration LiT waits for the
+	// requests in flight to complete when it is shut down.
+	defaultShutdownTimeout = 10 * time.Second
+
+	// drainPollInterval is the interval at which the number of active
+	// requests is checked while they are drained.
+	drainPollInterval = 100 * time.Millisecond
+)
+
+// ShutdownConfig holds the settings for shutting down LiT gracefully.
+type ShutdownConfig struct {
+	Timeout time.Duration `long:"timeout" description:"The maximum duration LiT waits for active requests and streams to complete when it is shut down. New connections are no longer accepted in the meantime. Requests that are still active afterwards are cut off. Set to 0 to cut off all requests right away."`
+}
+
+// validate checks that the shutdown config is sane.
+func (c *ShutdownConfig) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+
+	return nil
+}
+
+// activeRequestsUnaryInterceptor is a gRPC interceptor that keeps track of
+// the number of unary requests that are currently being handled.
+func (p *rpcProxy) activeRequestsUnaryInterceptor(ctx context.Context,
+	req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	atomic.AddInt64(&p.activeRequests, 1)
+	defer atomic.AddInt64(&p.activeRequests, -1)
+
+	return handler(ctx, req)
+}
+
+// activeRequestsStreamInterceptor is a gRPC interceptor that keeps track of
+// the number of streams that are currently active.
+func (p *rpcProxy) activeRequestsStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	atomic.AddInt64(&p.activeRequests, 1)
+	defer atomic.AddInt64(&p.activeRequests, -1)
+
+	return handler(srv, ss)
+}
+
+// waitForRequests blocks until no requests are active anymore or the given
+// context is done. The number of requests that are still active is returned.
+func (p *rpcProxy) waitForRequests(ctx context.Context) int64 {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		active := atomic.LoadInt64(&p.activeRequests)
+		if active == 0 {
+			return 0
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return active
+		}
+	}
+}
+
+// drainRequests stops accepting new connections on LiT's HTTP(S) listeners
+// and new calls of LNC sessions and waits up to the shutdown timeout for the
+// active requests to complete. Requests that are still active afterwards are
+// cut off once the servers are closed.
+func (g *LightningTerminal) drainRequests() {
+	timeout := g.cfg.Shutdown.Timeout
+	if g.httpServer == nil || g.rpcProxy == nil || timeout == 0 {
+		return
+	}
+
+	log.Infof("Waiting up to %v for %d active requests to complete",
+		timeout, atomic.LoadInt64(&g.rpcProxy.activeRequests))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// The LNC sessions are served by their own gRPC servers. Draining them
+	// blocks until their calls completed, which are counted as active
+	// requests as well. If the timeout is reached first, the remaining
+	// calls are cut off once the session servers are stopped.
+	if g.sessionRpcServerStarted {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+
+			g.sessionRpcServer.sessionServer.Drain()
+		}()
+	}
+
+	// Shutdown closes the listeners and then waits for all connections to
+	// become idle. It doesn't wait for connections that were hijacked for
+	// WebSockets, so we also wait for the requests themselves.
+	if err := g.httpServer.Shutdown(ctx); err != nil {
+		log.Debugf("Error shutting down HTTP server: %v", err)
+	}
+
+	active := g.rpcProxy.waitForRequests(ctx)
+	if active > 0 {
+		log.Warnf("Shutdown timeout of %v reached with %d requests "+
+			"still active, cutting them off", timeout, active)
+	}
+}
@@ -0,0 +1,50 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestWaitForRequests tests that the active requests are counted and that
+// draining them waits until they completed or the timeout is reached.
+func TestWaitForRequests(t *testing.T) {
+	p := &rpcProxy{}
+
+	// Start a request that only completes once it is told to.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.activeRequestsUnaryInterceptor(
+			context.Background(), nil, &grpc.UnaryServerInfo{},
+			func(context.Context, interface{}) (interface{}, error) {
+				close(started)
+				<-release
+
+				return nil, nil
+			},
+		)
+		done <- err
+	}()
+	<-started
+
+	// The request is reported as still active once the timeout is
+	// reached.
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 2*drainPollInterval,
+	)
+	defer cancel()
+	require.EqualValues(t, 1, p.waitForRequests(ctx))
+
+	// Once the request completes, waiting returns right away.
+	close(release)
+	require.NoError(t, <-done)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.Zero(t, p.waitForRequests(ctx))
+}
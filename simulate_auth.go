@@ -0,0 +1,491 @@
+package terminal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// The names of the steps of the authentication pipeline as reported by
+	// the SimulateAuth RPC.
+	authStepMethod       = "method"
+	authStepDaemon       = "daemon"
+	authStepCredential   = "credential"
+	authStepMacaroon     = "macaroon"
+	authStepRootKey      = "root_key"
+	authStepCaveats      = "caveats"
+	authStepSessionMeta  = "session_metadata"
+	authStepPermissions  = "permissions"
+	authStepVerification = "verification"
+)
+
+// authTrace records the results of the steps of a simulated authentication.
+type authTrace struct {
+	resp *litrpc.SimulateAuthResponse
+}
+
+// newAuthTrace creates a new, empty authTrace.
+func newAuthTrace() *authTrace {
+	return &authTrace{
+		resp: &litrpc.SimulateAuthResponse{
+			Authorized: true,
+		},
+	}
+}
+
+// add records the result of a step.
+func (t *authTrace) add(name string, result litrpc.AuthStepResult,
+	format string, args ...interface{}) {
+
+	t.resp.Steps = append(t.resp.Steps, &litrpc.AuthStep{
+		Name:    name,
+		Result:  result,
+		Details: fmt.Sprintf(format, args...),
+	})
+}
+
+// pass records a step that passed.
+func (t *authTrace) pass(name, format string, args ...interface{}) {
+	t.add(name, litrpc.AuthStepResult_AUTH_STEP_PASSED, format, args...)
+}
+
+// fail records a step that failed. A single failed step means the credential
+// is not accepted.
+func (t *authTrace) fail(name, format string, args ...interface{}) {
+	t.resp.Authorized = false
+	t.add(name, litrpc.AuthStepResult_AUTH_STEP_FAILED, format, args...)
+}
+
+// skip records a step that could not be run.
+func (t *authTrace) skip(name, format string, args ...interface{}) {
+	t.add(name, litrpc.AuthStepResult_AUTH_STEP_SKIPPED, format, args...)
+}
+
+// simulateAuth runs the same checks the RPC proxy's interceptors run for a
+// request to the given method with the given credential, without calling the
+// method itself. Steps that depend on a step that failed are not run.
+func (p *rpcProxy) simulateAuth(ctx context.Context,
+	req *litrpc.SimulateAuthRequest) (*litrpc.SimulateAuthResponse,
+	error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	switch {
+	case req.Method == "":
+		return nil, status.Error(
+			codes.InvalidArgument, "method must be set",
+		)
+
+	case (req.Macaroon == "") == (req.Password == ""):
+		return nil, status.Error(
+			codes.InvalidArgument, "exactly one of macaroon and "+
+				"password must be set",
+		)
+	}
+
+	trace := newAuthTrace()
+
+	requiredPerms, ok := p.permsMgr.URIPermissions(req.Method)
+	if !ok {
+		if err := p.unavailableURIError(req.Method); err != nil {
+			trace.fail(authStepMethod, "%v", err)
+		} else {
+			trace.fail(authStepMethod, "unknown method %s",
+				req.Method)
+		}
+
+		return trace.resp, nil
+	}
+
+	if p.permsMgr.IsWhiteListedURL(req.Method) {
+		trace.pass(authStepMethod, "%s doesn't require "+
+			"authentication", req.Method)
+
+		return trace.resp, nil
+	}
+
+	trace.pass(authStepMethod, "%s requires the permissions %s",
+		req.Method, formatOps(requiredPerms))
+
+	daemon, err := p.subSystemForURI(req.Method)
+	if err != nil {
+		daemon = "unknown"
+	}
+	if err := p.checkSubSystemStarted(req.Method); err != nil {
+		trace.fail(authStepDaemon, "%v", err)
+	} else {
+		trace.pass(authStepDaemon, "%s is ready to handle the "+
+			"request", daemon)
+	}
+
+	macBytes, ok := p.simulateCredential(trace, req)
+	if !ok {
+		return trace.resp, nil
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		trace.fail(authStepMacaroon, "unable to decode macaroon: %v",
+			err)
+
+		return trace.resp, nil
+	}
+	trace.pass(authStepMacaroon, "macaroon with %d caveat(s) decoded",
+		len(mac.Caveats()))
+
+	macHex := hex.EncodeToString(macBytes)
+	isSuperMac := session.IsSuperMacaroon(macHex)
+	p.simulateRootKey(trace, mac, isSuperMac)
+	simulateCaveats(trace, mac)
+	p.simulateSessionMetadata(trace, mac)
+	simulatePermissions(trace, mac, requiredPerms, req.Method)
+
+	// Only the signature of macaroons that are verified by LiT itself can
+	// be checked here. Other macaroons are verified by the daemon that
+	// handles the request once it is forwarded.
+	remote, _, _ := p.subServerMgr.GetRemoteConn(req.Method)
+	switch {
+	case !isSuperMac && daemon == subservers.LND:
+		trace.skip(authStepVerification, "macaroons for lnd are "+
+			"verified by lnd itself")
+
+	case !isSuperMac && remote:
+		trace.skip(authStepVerification, "macaroons for %s are "+
+			"verified by the remote %s daemon", daemon, daemon)
+
+	default:
+		valCtx := metadata.NewIncomingContext(
+			ctx, metadata.Pairs(HeaderMacaroon, macHex),
+		)
+		err := p.macValidator.ValidateMacaroon(
+			valCtx, requiredPerms, req.Method,
+		)
+		if err != nil {
+			trace.fail(authStepVerification, "%v", err)
+		} else {
+			trace.pass(authStepVerification, "macaroon signature, "+
+				"root key and caveats verified")
+		}
+	}
+
+	return trace.resp, nil
+}
+
+// simulateCredential converts the credential of the request into the
+// macaroon that would be used to authenticate the request. False is returned
+// if no macaroon can be obtained.
+func (p *rpcProxy) simulateCredential(trace *authTrace,
+	req *litrpc.SimulateAuthRequest) ([]byte, bool) {
+
+	if req.Macaroon != "" {
+		macBytes, err := hex.DecodeString(req.Macaroon)
+		if err != nil {
+			trace.fail(authStepCredential, "macaroon is not hex "+
+				"encoded: %v", err)
+
+			return nil, false
+		}
+
+		trace.pass(authStepCredential, "macaroon provided")
+
+		return macBytes, true
+	}
+
+	if p.cfg.DisableUI {
+		trace.fail(authStepCredential, "password authentication is "+
+			"not available because the UI is disabled")
+
+		return nil, false
+	}
+
+	basicAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(
+		"%s:%s", req.Password, req.Password,
+	)))
+	macBytes, err := p.basicAuthToMacaroon(
+		"Basic "+basicAuth, req.Method, nil,
+	)
+	switch {
+	case err != nil:
+		trace.fail(authStepCredential, "unable to convert password "+
+			"to macaroon: %v", err)
+
+		return nil, false
+
+	case len(macBytes) == 0:
+		trace.fail(authStepCredential, "password is incorrect")
+
+		return nil, false
+	}
+
+	trace.pass(authStepCredential, "password is correct and was "+
+		"converted to a macaroon")
+
+	return macBytes, true
+}
+
+// simulateRootKey checks that the root key ID of the macaroon can be read and,
+// for super macaroons, that the session they belong to is still active.
+func (p *rpcProxy) simulateRootKey(trace *authTrace, mac *macaroon.Macaroon,
+	isSuperMac bool) {
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		trace.fail(authStepRootKey, "unable to read root key ID: %v",
+			err)
+
+		return
+	}
+
+	if !isSuperMac {
+		trace.pass(authStepRootKey, "root key ID %d", rootKeyID)
+
+		return
+	}
+
+	sess, err := p.sessionDB.GetSessionByID(
+		session.IDFromMacRootKeyID(rootKeyID),
+	)
+	if err != nil {
+		trace.pass(authStepRootKey, "super macaroon root key ID %d, "+
+			"not bound to a session", rootKeyID)
+
+		return
+	}
+
+	switch sess.State {
+	case session.StateRevoked:
+		trace.fail(authStepRootKey, "super macaroon root key ID %d "+
+			"belongs to session %x (label=%s) which is revoked",
+			rootKeyID, sess.ID[:], sess.Label)
+
+	case session.StateExpired:
+		trace.fail(authStepRootKey, "super macaroon root key ID %d "+
+			"belongs to session %x (label=%s) which is expired",
+			rootKeyID, sess.ID[:], sess.Label)
+
+	default:
+		trace.pass(authStepRootKey, "super macaroon root key ID %d "+
+			"belongs to session %x (label=%s)", rootKeyID,
+			sess.ID[:], sess.Label)
+	}
+}
+
+// simulateCaveats lists the first party caveats of the macaroon and checks
+// that it hasn't expired. All other caveats can only be checked by the
+// verification step.
+func simulateCaveats(trace *authTrace, mac *macaroon.Macaroon) {
+	var conditions []string
+	for _, caveat := range mac.Caveats() {
+		if caveat.VerificationId != nil {
+			trace.fail(authStepCaveats, "third party caveats are "+
+				"not supported")
+
+			return
+		}
+
+		condition := string(caveat.Id)
+		conditions = append(conditions, condition)
+
+		cond, arg, err := checkers.ParseCaveat(condition)
+		if err != nil || cond != checkers.CondTimeBefore {
+			continue
+		}
+
+		expiry, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			trace.fail(authStepCaveats, "invalid caveat %q: %v",
+				condition, err)
+
+			return
+		}
+
+		if !time.Now().Before(expiry) {
+			trace.fail(authStepCaveats, "macaroon expired at %v",
+				expiry)
+
+			return
+		}
+	}
+
+	if len(conditions) == 0 {
+		trace.pass(authStepCaveats, "macaroon has no caveats")
+
+		return
+	}
+
+	trace.pass(authStepCaveats, "macaroon hasn't expired, caveats: %s",
+		strings.Join(conditions, ", "))
+}
+
+// simulateSessionMetadata checks the session metadata caveats of the macaroon
+// against the metadata of the session the macaroon belongs to. No step is
+// recorded if the macaroon has no such caveat.
+func (p *rpcProxy) simulateSessionMetadata(trace *authTrace,
+	mac *macaroon.Macaroon) {
+
+	var required []map[string]string
+	for _, caveat := range mac.Caveats() {
+		meta, err := firewall.ParseSessionMetadataCaveat(
+			string(caveat.Id),
+		)
+		switch {
+		case errors.Is(err, firewall.ErrNoSessionMetadataCaveat):
+			continue
+
+		case err != nil:
+			trace.fail(authStepSessionMeta, "%v", err)
+
+			return
+		}
+
+		required = append(required, meta)
+	}
+
+	if len(required) == 0 {
+		return
+	}
+
+	sessionID, err := session.IDFromMacaroon(mac)
+	if err != nil {
+		trace.fail(authStepSessionMeta, "unable to read session ID: %v",
+			err)
+
+		return
+	}
+
+	sess, err := p.sessionDB.GetSessionByID(sessionID)
+	if err != nil {
+		trace.fail(authStepSessionMeta, "macaroon requires session "+
+			"metadata but is not bound to a session")
+
+		return
+	}
+
+	for _, meta := range required {
+		err := firewall.CheckSessionMetadata(meta, sess.Metadata)
+		if err != nil {
+			trace.fail(authStepSessionMeta, "session %x: %v",
+				sess.ID[:], err)
+
+			return
+		}
+	}
+
+	trace.pass(authStepSessionMeta, "session %x carries the required "+
+		"metadata", sess.ID[:])
+}
+
+// simulatePermissions checks that the permissions in the macaroon's ID cover
+// the permissions required by the method. Like lnd, we also accept a macaroon
+// that explicitly allows calling the method by its URI.
+func simulatePermissions(trace *authTrace, mac *macaroon.Macaroon,
+	requiredPerms []bakery.Op, fullMethod string) {
+
+	ops, err := macaroonOps(mac)
+	if err != nil {
+		trace.fail(authStepPermissions, "unable to read macaroon "+
+			"permissions: %v", err)
+
+		return
+	}
+
+	missing, byURI := missingPermissions(ops, requiredPerms, fullMethod)
+	if byURI {
+		trace.pass(authStepPermissions, "macaroon grants access to "+
+			"%s by URI", fullMethod)
+
+		return
+	}
+
+	if len(missing) > 0 {
+		trace.fail(authStepPermissions, "macaroon is missing the "+
+			"permissions %s", formatOps(missing))
+
+		return
+	}
+
+	trace.pass(authStepPermissions, "macaroon has all required "+
+		"permissions")
+}
+
+// missingPermissions returns the required permissions that are not among the
+// granted ones. Like lnd, we also accept permissions that explicitly allow
+// calling the method by its URI, in which case true is returned and nothing is
+// missing.
+func missingPermissions(granted, required []bakery.Op,
+	fullMethod string) ([]bakery.Op, bool) {
+
+	grantedSet := make(map[bakery.Op]bool, len(granted))
+	for _, op := range granted {
+		grantedSet[op] = true
+	}
+
+	if grantedSet[bakery.Op{Entity: "uri", Action: fullMethod}] {
+		return nil, true
+	}
+
+	var missing []bakery.Op
+	for _, op := range required {
+		if !grantedSet[op] {
+			missing = append(missing, op)
+		}
+	}
+
+	return missing, false
+}
+
+// macaroonOps returns the permissions that are encoded in the ID of the given
+// macaroon.
+func macaroonOps(mac *macaroon.Macaroon) ([]bakery.Op, error) {
+	rawID := mac.Id()
+	if len(rawID) == 0 || rawID[0] != byte(bakery.LatestVersion) {
+		return nil, fmt.Errorf("mac id is not on the latest version")
+	}
+
+	decodedID := &lnrpc.MacaroonId{}
+	if err := proto.Unmarshal(rawID[1:], decodedID); err != nil {
+		return nil, err
+	}
+
+	var ops []bakery.Op
+	for _, op := range decodedID.Ops {
+		for _, action := range op.Actions {
+			ops = append(ops, bakery.Op{
+				Entity: op.Entity,
+				Action: action,
+			})
+		}
+	}
+
+	return ops, nil
+}
+
+// formatOps returns the given permissions in the entity:action notation.
+func formatOps(ops []bakery.Op) string {
+	perms := make([]string, len(ops))
+	for idx, op := range ops {
+		perms[idx] = fmt.Sprintf("%s:%s", op.Entity, op.Action)
+	}
+
+	return strings.Join(perms, ", ")
+}
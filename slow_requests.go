@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// defaultSlowRequestThreshold is the default duration after which a
+	// request handled by the RPC proxy is logged as slow.
+	defaultSlowRequestThreshold = 30 * time.Second
+)
+
+// methodDescriptor looks up the descriptor of the method with the given gRPC
+// URI in the global proto registry.
+func methodDescriptor(requestURI string) (protoreflect.MethodDescriptor,
+	bool) {
+
+	name := strings.Replace(strings.TrimPrefix(requestURI, "/"), "/", ".", 1)
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(
+		protoreflect.FullName(name),
+	)
+	if err != nil {
+		return nil, false
+	}
+
+	method, ok := desc.(protoreflect.MethodDescriptor)
+
+	return method, ok
+}
+
+// isStreamingMethod returns true if the given gRPC URI belongs to a known
+// method that streams requests or responses. The duration of such calls isn't
+// a meaningful latency, so they are excluded from the slow request log.
+func isStreamingMethod(requestURI string) bool {
+	method, ok := methodDescriptor(requestURI)
+	if !ok {
+		return false
+	}
+
+	return method.IsStreamingClient() || method.IsStreamingServer()
+}
+
+// logSlowRequest logs a warning if the request with the given URI took longer
+// than the configured threshold.
+func (p *rpcProxy) logSlowRequest(ctx context.Context, requestURI string,
+	duration time.Duration) {
+
+	if duration < p.cfg.SlowRequestThreshold {
+		return
+	}
+
+	daemon, err := p.subSystemForURI(requestURI)
+	if err != nil {
+		daemon = "unknown"
+	}
+
+	// The request ID is added to the entry by the request's logger.
+	p.requestLog(ctx, requestURI).Warnf("Slow request: method=%s, "+
+		"daemon=%s, duration=%v", requestURI, daemon, duration)
+}
+
+// slowRequestUnaryInterceptor is a gRPC interceptor that logs unary requests
+// that take longer than the configured threshold.
+func (p *rpcProxy) slowRequestUnaryInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	p.logSlowRequest(ctx, info.FullMethod, time.Since(start))
+
+	return resp, err
+}
+
+// slowRequestStreamInterceptor is a gRPC interceptor that logs requests that
+// take longer than the configured threshold. Since all calls that are
+// forwarded to a backend daemon are handled as streams by the proxy, we use
+// the method descriptor to skip calls that are actually streaming.
+func (p *rpcProxy) slowRequestStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if isStreamingMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	start := time.Now()
+	err := handler(srv, ss)
+	p.logSlowRequest(ss.Context(), info.FullMethod, time.Since(start))
+
+	return err
+}
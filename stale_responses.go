@@ -0,0 +1,269 @@
+package terminal
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"strconv"
+	"sync"
+	"time"
+
+	grpcProxy "github.com/mwitkow/grpc-proxy/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// HeaderStaleResponse is the header that is set on a response that was
+	// served from the stale response cache because the backend daemon
+	// failed. Its value is the unix timestamp in seconds at which the
+	// response was received from the backend.
+	HeaderStaleResponse = "lit-stale-response"
+
+	// defaultMaxStaleness is the default maximum age of a cached response
+	// that is served if the backend daemon fails.
+	defaultMaxStaleness = 5 * time.Minute
+
+	// maxStaleResponses is the maximum number of responses that are kept
+	// in the stale response cache.
+	maxStaleResponses = 1000
+)
+
+// staleCodec is the codec of the proxy's gRPC server. It serializes both the
+// raw frames of forwarded calls and regular proto messages.
+var staleCodec = grpcProxy.Codec()
+
+// staleCredentialHeaders are the headers that identify the credential of a
+// request. They are part of the cache key, so a cached response is only ever
+// served to the credential it was originally returned to.
+var staleCredentialHeaders = []string{
+	HeaderMacaroon, "authorization", apiKeyMetadataKey,
+}
+
+// staleKey identifies a cached response by the method, the credential and
+// the serialized request.
+type staleKey [sha256.Size]byte
+
+// staleResponse is a serialized response that was returned by a backend
+// daemon.
+type staleResponse struct {
+	key      staleKey
+	resp     []byte
+	received time.Time
+}
+
+// staleResponseCache keeps the last successful response of read-only calls,
+// so they can be served if the backend daemon is unavailable. The least
+// recently stored responses are evicted once the cache is full.
+type staleResponseCache struct {
+	maxAge time.Duration
+
+	entries map[staleKey]*list.Element
+	order   *list.List
+	mu      sync.Mutex
+}
+
+// newStaleResponseCache creates a new, empty staleResponseCache.
+func newStaleResponseCache(maxAge time.Duration) *staleResponseCache {
+	return &staleResponseCache{
+		maxAge:  maxAge,
+		entries: make(map[staleKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// put stores the given response, replacing any older response for the same
+// key.
+func (c *staleResponseCache) put(key staleKey, resp []byte, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+
+	for c.order.Len() >= maxStaleResponses {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*staleResponse).key)
+	}
+
+	c.entries[key] = c.order.PushFront(&staleResponse{
+		key:      key,
+		resp:     resp,
+		received: now,
+	})
+}
+
+// get returns the response for the given key if there is one that isn't
+// older than the maximum age.
+func (c *staleResponseCache) get(key staleKey,
+	now time.Time) (*staleResponse, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*staleResponse)
+	if now.Sub(entry.received) > c.maxAge {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// isStaleCacheable returns true if the given URI belongs to a known unary
+// method that only requires read permissions. Only such calls are idempotent,
+// so serving an older response can't hide a change the caller made.
+func (p *rpcProxy) isStaleCacheable(requestURI string) bool {
+	method, ok := methodDescriptor(requestURI)
+	if !ok || method.IsStreamingClient() || method.IsStreamingServer() {
+		return false
+	}
+
+	ops, ok := p.permsMgr.URIPermissions(requestURI)
+	if !ok || len(ops) == 0 {
+		return false
+	}
+
+	for _, op := range ops {
+		if op.Action != "read" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// staleCaptureStream is a server stream that records the serialized request
+// and response of a unary call that is forwarded to a backend daemon.
+type staleCaptureStream struct {
+	grpc.ServerStream
+
+	req  []byte
+	resp []byte
+	sent bool
+}
+
+// RecvMsg receives the request of the call and records its serialized form.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *staleCaptureStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	req, err := staleCodec.Marshal(m)
+	if err == nil {
+		s.req = req
+	}
+
+	return nil
+}
+
+// SendMsg sends the response of the call and records its serialized form.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *staleCaptureStream) SendMsg(m interface{}) error {
+	s.sent = true
+
+	resp, err := staleCodec.Marshal(m)
+	if err == nil {
+		s.resp = resp
+	}
+
+	return s.ServerStream.SendMsg(m)
+}
+
+// staleResponseStreamInterceptor is a gRPC interceptor that keeps the last
+// successful response of read-only unary calls that are forwarded to a
+// backend daemon. If the backend is unavailable, the cached response is
+// returned instead of the error, with the HeaderStaleResponse header set.
+//
+// NOTE: Calls that are forwarded to a backend daemon are always handled as
+// streams by the proxy, so a stream interceptor is enough to see all of them.
+func (p *rpcProxy) staleResponseStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if !p.isStaleCacheable(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	captureStream := &staleCaptureStream{ServerStream: ss}
+	err := handler(srv, captureStream)
+
+	// The key can only be derived once the request was received.
+	if captureStream.req == nil {
+		return err
+	}
+	key := staleCacheKey(ss, info.FullMethod, captureStream.req)
+
+	switch {
+	case err == nil && captureStream.resp != nil:
+		p.staleResponses.put(key, captureStream.resp, time.Now())
+
+		return nil
+
+	case status.Code(err) != codes.Unavailable || captureStream.sent:
+		return err
+	}
+
+	entry, ok := p.staleResponses.get(key, time.Now())
+	if !ok {
+		return err
+	}
+
+	log.Debugf("Serving stale response for %s from %v: %v",
+		info.FullMethod, entry.received, err)
+
+	// We don't know the response type of the call, so we pass on the raw
+	// bytes as unknown fields of an empty message.
+	resp := &emptypb.Empty{}
+	resp.ProtoReflect().SetUnknown(protoreflect.RawFields(entry.resp))
+
+	err = ss.SetHeader(metadata.Pairs(
+		HeaderStaleResponse,
+		strconv.FormatInt(entry.received.Unix(), 10),
+	))
+	if err != nil {
+		return err
+	}
+
+	return ss.SendMsg(resp)
+}
+
+// staleCacheKey derives the cache key of a call from its method, the
+// credential it was made with and its serialized request.
+func staleCacheKey(ss grpc.ServerStream, requestURI string,
+	req []byte) staleKey {
+
+	md, _ := metadata.FromIncomingContext(ss.Context())
+
+	h := sha256.New()
+	_, _ = h.Write([]byte(requestURI))
+	for _, header := range staleCredentialHeaders {
+		for _, value := range md.Get(header) {
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write([]byte(value))
+		}
+		_, _ = h.Write([]byte{0})
+	}
+	_, _ = h.Write(req)
+
+	var key staleKey
+	copy(key[:], h.Sum(nil))
+
+	return key
+}
@@ -0,0 +1,176 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	litstatus "github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+)
+
+// statusServer is LiT's implementation of the litrpc.StatusServer. The status
+// of the sub-servers is served by the status manager, the detected lnd
+// sub-servers and the authentication simulation by the RPC proxy.
+type statusServer struct {
+	*litstatus.Manager
+
+	proxy *rpcProxy
+
+	certChain *tlsCertChain
+
+	subServerMgr *subservers.Manager
+
+	lndMode string
+}
+
+// statusServer returns LiT's implementation of the litrpc.StatusServer.
+func (g *LightningTerminal) statusServer() *statusServer {
+	return &statusServer{
+		Manager:      g.statusMgr,
+		proxy:        g.rpcProxy,
+		certChain:    g.certChain,
+		subServerMgr: g.subServerMgr,
+		lndMode:      g.cfg.LndMode,
+	}
+}
+
+// SubServerStatus queries the current status of all sub-servers and adds the
+// lnd sub-servers that were detected and the state of lnd's wallet recovery.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) SubServerStatus(ctx context.Context,
+	req *litrpc.SubServerStatusReq) (*litrpc.SubServerStatusResp, error) {
+
+	resp, err := s.Manager.SubServerStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.LndSubServers = s.proxy.permsMgr.LndSubServers()
+	resp.LndRecovery = s.proxy.lndRecovery.status()
+
+	return resp, nil
+}
+
+// SimulateAuth runs the authentication pipeline for the given credential and
+// method and returns a trace of all steps.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) SimulateAuth(ctx context.Context,
+	req *litrpc.SimulateAuthRequest) (*litrpc.SimulateAuthResponse,
+	error) {
+
+	return s.proxy.simulateAuth(ctx, req)
+}
+
+// StepUpAuth exchanges the UI password for a one-time token that allows a
+// single call to a sensitive method.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) StepUpAuth(_ context.Context,
+	req *litrpc.StepUpAuthRequest) (*litrpc.StepUpAuthResponse, error) {
+
+	return s.proxy.stepUpAuth(req)
+}
+
+// GetTLSCertChain returns the full TLS certificate chain that LiT presents on
+// its main HTTPS listener.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) GetTLSCertChain(_ context.Context,
+	_ *litrpc.GetTLSCertChainRequest) (*litrpc.GetTLSCertChainResponse,
+	error) {
+
+	certs, pemChain, err := s.certChain.chain()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get TLS cert chain: %v", err)
+	}
+
+	return &litrpc.GetTLSCertChainResponse{
+		Certificates: certs,
+		PemChain:     pemChain,
+	}, nil
+}
+
+// CheckHealth probes lnd, LiT itself and each enabled sub-server and returns
+// whether each of them responded.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) CheckHealth(ctx context.Context,
+	_ *litrpc.CheckHealthRequest) (*litrpc.CheckHealthResponse, error) {
+
+	return s.proxy.checkHealth(ctx)
+}
+
+// GetSubServerState returns the state of lnd and of each sub-server managed by
+// the sub-server manager.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) GetSubServerState(ctx context.Context,
+	_ *litrpc.GetSubServerStateRequest) (*litrpc.GetSubServerStateResponse,
+	error) {
+
+	// lnd isn't managed by the sub-server manager, so its state comes from
+	// the status manager. The error is cleared there once lnd is running.
+	statusResp, err := s.Manager.SubServerStatus(
+		ctx, &litrpc.SubServerStatusReq{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lnd := &litrpc.SubServerState{
+		Name: subservers.LND,
+		Mode: litrpc.SubServerMode_SUB_SERVER_MODE_INTEGRATED,
+	}
+	if s.lndMode == ModeRemote {
+		lnd.Mode = litrpc.SubServerMode_SUB_SERVER_MODE_REMOTE
+	}
+	if lndStatus, ok := statusResp.SubServers[subservers.LND]; ok {
+		lnd.Running = lndStatus.Running
+		lnd.StartError = lndStatus.Error
+	}
+
+	resp := &litrpc.GetSubServerStateResponse{
+		SubServers: []*litrpc.SubServerState{lnd},
+	}
+	for _, state := range s.subServerMgr.States() {
+		resp.SubServers = append(resp.SubServers, &litrpc.SubServerState{
+			Name:       state.Name,
+			Mode:       marshalSubServerMode(state.Mode),
+			Running:    state.Running,
+			StartError: state.StartError,
+		})
+	}
+	sort.Slice(resp.SubServers, func(i, j int) bool {
+		return resp.SubServers[i].Name < resp.SubServers[j].Name
+	})
+
+	return resp, nil
+}
+
+// GetLitStatus returns the startup state of lnd.
+//
+// NOTE: this is part of the litrpc.StatusServer interface.
+func (s *statusServer) GetLitStatus(_ context.Context,
+	_ *litrpc.GetLitStatusRequest) (*litrpc.GetLitStatusResponse, error) {
+
+	return s.proxy.lndStartup.status(), nil
+}
+
+// marshalSubServerMode converts the given sub-server mode to its RPC
+// counterpart.
+func marshalSubServerMode(mode subservers.Mode) litrpc.SubServerMode {
+	switch mode {
+	case subservers.ModeRemote:
+		return litrpc.SubServerMode_SUB_SERVER_MODE_REMOTE
+
+	case subservers.ModeDisabled:
+		return litrpc.SubServerMode_SUB_SERVER_MODE_DISABLED
+
+	default:
+		return litrpc.SubServerMode_SUB_SERVER_MODE_INTEGRATED
+	}
+}
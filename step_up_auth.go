@@ -0,0 +1,165 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeaderStepUpToken is the header field name that is used to send the
+	// step-up token with a request to a sensitive method.
+	HeaderStepUpToken = "lit-stepup-token"
+
+	// HeaderStepUpRequired is the trailer that is set on the response of a
+	// sensitive method that was called without a valid step-up token. Its
+	// value is the method that requires the step-up.
+	HeaderStepUpRequired = "lit-stepup-required"
+
+	// defaultStepUpTokenTTL is the default duration for which a step-up
+	// token is valid.
+	defaultStepUpTokenTTL = 5 * time.Minute
+
+	// maxStepUpTokens is the maximum number of unused step-up tokens that
+	// are kept at the same time.
+	maxStepUpTokens = 1000
+)
+
+// stepUpToken is a one-time token that allows a single call to a sensitive
+// method.
+type stepUpToken struct {
+	method string
+	expiry time.Time
+}
+
+// stepUpTracker keeps track of the step-up tokens that were issued and not yet
+// used.
+type stepUpTracker struct {
+	tokens map[string]stepUpToken
+	mu     sync.Mutex
+}
+
+// newStepUpTracker creates a new, empty stepUpTracker.
+func newStepUpTracker() *stepUpTracker {
+	return &stepUpTracker{
+		tokens: make(map[string]stepUpToken),
+	}
+}
+
+// issue creates a new token for the given method that is valid until the
+// given expiry.
+func (t *stepUpTracker) issue(method string, now,
+	expiry time.Time) (string, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for token, info := range t.tokens {
+		if !now.Before(info.expiry) {
+			delete(t.tokens, token)
+		}
+	}
+
+	if len(t.tokens) >= maxStepUpTokens {
+		return "", status.Errorf(codes.ResourceExhausted, "too many "+
+			"unused step-up tokens, at most %d are kept",
+			maxStepUpTokens)
+	}
+
+	var tokenBytes [32]byte
+	if _, err := rand.Read(tokenBytes[:]); err != nil {
+		return "", fmt.Errorf("unable to create token: %v", err)
+	}
+	token := hex.EncodeToString(tokenBytes[:])
+
+	t.tokens[token] = stepUpToken{
+		method: method,
+		expiry: expiry,
+	}
+
+	return token, nil
+}
+
+// consume returns true if the given token is valid for the given method. A
+// token can only be presented once, it is removed even if it isn't valid for
+// the method.
+func (t *stepUpTracker) consume(token, method string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, ok := t.tokens[token]
+	if !ok {
+		return false
+	}
+	delete(t.tokens, token)
+
+	return info.method == method && now.Before(info.expiry)
+}
+
+// stepUpAuth checks the UI password of the request and issues a one-time
+// token for the requested sensitive method.
+func (p *rpcProxy) stepUpAuth(req *litrpc.StepUpAuthRequest) (
+	*litrpc.StepUpAuthResponse, error) {
+
+	if _, ok := p.cfg.StepUp.methods[req.Method]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s doesn't "+
+			"require step-up authentication", req.Method)
+	}
+
+	if p.cfg.DisableUI || !p.isUIPassword(req.Password) {
+		return nil, status.Error(codes.PermissionDenied, "invalid "+
+			"password")
+	}
+
+	now := time.Now()
+	expiry := now.Add(p.cfg.StepUp.TokenTTL)
+	token, err := p.stepUpTokens.issue(req.Method, now, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Issued step-up token for %s", req.Method)
+
+	return &litrpc.StepUpAuthResponse{
+		Token:     token,
+		ExpiresAt: expiry.Unix(),
+	}, nil
+}
+
+// checkStepUp makes sure a request to a sensitive method carries a valid
+// step-up token. This is checked in addition to the regular authentication,
+// so a hijacked session or credential alone can't call the method.
+func (p *rpcProxy) checkStepUp(ctx context.Context, requestURI string) error {
+	if _, ok := p.cfg.StepUp.methods[requestURI]; !ok {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(HeaderStepUpToken)
+	if len(tokens) == 1 &&
+		p.stepUpTokens.consume(tokens[0], requestURI, time.Now()) {
+
+		log.Infof("Step-up token accepted for %s", requestURI)
+
+		return nil
+	}
+
+	// The trailer lets clients recognize the error without parsing the
+	// message.
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(
+		HeaderStepUpRequired, requestURI,
+	))
+
+	return status.Errorf(codes.Unauthenticated, "step-up authentication "+
+		"required: %s needs a token from litrpc.Status/StepUpAuth in "+
+		"the %s header", requestURI, HeaderStepUpToken)
+}
@@ -0,0 +1,143 @@
+package terminal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultWebsocketPingInterval is the default interval at which pings
+	// are sent to the clients of gRPC web streams over WebSockets.
+	defaultWebsocketPingInterval = 2 * time.Minute
+
+	// disconnectWatchBufferSize is the size of the buffer that is used to
+	// read from a WebSocket after the client finished sending.
+	disconnectWatchBufferSize = 512
+)
+
+// websocketRequestKey is the context key that marks a request as a gRPC web
+// call over a WebSocket.
+type websocketRequestKey struct{}
+
+// markWebsocketRequest returns a copy of the request that is marked as a gRPC
+// web call over a WebSocket.
+func markWebsocketRequest(req *http.Request) *http.Request {
+	return req.WithContext(
+		context.WithValue(req.Context(), websocketRequestKey{}, true),
+	)
+}
+
+// isWebsocketRequest returns true if the request was marked as a gRPC web call
+// over a WebSocket.
+func isWebsocketRequest(req *http.Request) bool {
+	isWebsocket, _ := req.Context().Value(websocketRequestKey{}).(bool)
+
+	return isWebsocket
+}
+
+// serveGrpcWeb serves a gRPC web call that was converted into a native gRPC
+// call by the gRPC web proxy.
+//
+// A WebSocket is hijacked from the HTTP server, so the context of its request
+// isn't cancelled if the client disconnects. The client's messages are only
+// read until it finished sending, which for server streams is right after the
+// request. Without any further reads, a client that goes away isn't noticed
+// and the stream to the backend daemon stays open until the daemon ends it.
+// We therefore keep reading from the WebSocket once the client finished
+// sending and cancel the call as soon as the connection breaks.
+func (p *rpcProxy) serveGrpcWeb(resp http.ResponseWriter, req *http.Request) {
+	if !isWebsocketRequest(req) {
+		p.grpcServer.ServeHTTP(resp, req)
+
+		return
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	requestURI := req.URL.Path
+	body := &disconnectWatchBody{
+		ReadCloser: req.Body,
+		onDisconnect: func() {
+			log.Debugf("Client of stream %s disconnected, "+
+				"cancelling stream", requestURI)
+
+			if p.metrics != nil {
+				daemon, err := p.subSystemForURI(requestURI)
+				if err != nil {
+					daemon = "unknown"
+				}
+				p.metrics.observeOrphanedStream(daemon)
+			}
+
+			cancel()
+		},
+	}
+
+	req = req.WithContext(ctx)
+	req.Body = body
+
+	p.grpcServer.ServeHTTP(resp, req)
+}
+
+// disconnectWatchBody is the body of a gRPC web call over a WebSocket. Once the
+// client finished sending, it keeps reading from the WebSocket in the
+// background to notice if the client disconnects while the call is still
+// running.
+type disconnectWatchBody struct {
+	io.ReadCloser
+
+	// onDisconnect is called if the WebSocket breaks before the call is
+	// done.
+	onDisconnect func()
+
+	watchOnce sync.Once
+
+	// closed is set once the call is done and the body is closed.
+	closed atomic.Bool
+}
+
+// Read reads the client's messages from the WebSocket. Once the client
+// finished sending, the background read is started.
+//
+// NOTE: this is part of the io.Reader interface.
+func (b *disconnectWatchBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.watchOnce.Do(func() {
+			go b.watch()
+		})
+	}
+
+	return n, err
+}
+
+// watch reads from the WebSocket until it breaks or is closed. This only runs
+// after the reader of the call stopped reading, so there is only ever one
+// reader. The client isn't supposed to send anything after it finished
+// sending, so anything that is read is discarded.
+func (b *disconnectWatchBody) watch() {
+	buf := make([]byte, disconnectWatchBufferSize)
+	for {
+		if _, err := b.ReadCloser.Read(buf); err != nil {
+			break
+		}
+	}
+
+	if !b.closed.Load() {
+		b.onDisconnect()
+	}
+}
+
+// Close marks the call as done and closes the WebSocket.
+//
+// NOTE: this is part of the io.Closer interface.
+func (b *disconnectWatchBody) Close() error {
+	b.closed.Store(true)
+
+	return b.ReadCloser.Close()
+}
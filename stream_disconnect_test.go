@@ -0,0 +1,169 @@
+package terminal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// blockingHealthServer is a health server whose Watch stream stays open until
+// it is cancelled.
+type blockingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+// Watch sends a single update and then blocks until the stream is cancelled.
+func (s *blockingHealthServer) Watch(_ *grpc_health_v1.HealthCheckRequest,
+	stream grpc_health_v1.Health_WatchServer) error {
+
+	err := stream.Send(&grpc_health_v1.HealthCheckResponse{
+		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+	})
+	if err != nil {
+		return err
+	}
+	close(s.started)
+
+	<-stream.Context().Done()
+	close(s.cancelled)
+
+	return stream.Context().Err()
+}
+
+// streamTestTimeout is the maximum time the tests wait for a stream to change
+// its state.
+const streamTestTimeout = 5 * time.Second
+
+// TestWebsocketStreamDisconnect tests that a gRPC web stream over a WebSocket
+// is cancelled once its client abruptly closes the connection.
+func TestWebsocketStreamDisconnect(t *testing.T) {
+	healthServer := &blockingHealthServer{
+		started:   make(chan struct{}),
+		cancelled: make(chan struct{}),
+	}
+
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	p := &rpcProxy{
+		grpcServer: grpcServer,
+	}
+	p.grpcWebProxy = grpcweb.WrapHandler(
+		http.HandlerFunc(p.serveGrpcWeb),
+		grpcweb.WithWebsockets(true),
+		grpcweb.WithCorsForRegisteredEndpointsOnly(false),
+		grpcweb.WithWebsocketOriginFunc(func(*http.Request) bool {
+			return true
+		}),
+	)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(
+		func(resp http.ResponseWriter, req *http.Request) {
+			require.True(t, p.isHandling(resp, req))
+		},
+	))
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") +
+		"/grpc.health.v1.Health/Watch"
+	dialer := websocket.Dialer{
+		Subprotocols: []string{"grpc-websockets"},
+	}
+	conn, _, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+
+	// The first frame carries the headers of the call, followed by the
+	// request message and the frame that marks the end of the client's
+	// messages. The first byte of the data frames is a control byte.
+	require.NoError(t, conn.WriteMessage(
+		websocket.BinaryMessage,
+		[]byte("content-type: application/grpc-web+proto\r\n"),
+	))
+	require.NoError(t, conn.WriteMessage(
+		websocket.BinaryMessage, []byte{0, 0, 0, 0, 0, 0},
+	))
+	require.NoError(t, conn.WriteMessage(
+		websocket.BinaryMessage, []byte{1},
+	))
+
+	select {
+	case <-healthServer.started:
+	case <-time.After(streamTestTimeout):
+		t.Fatalf("stream not started")
+	}
+
+	// Closing the connection without a close frame simulates a client
+	// that goes away.
+	require.NoError(t, conn.UnderlyingConn().Close())
+
+	select {
+	case <-healthServer.cancelled:
+	case <-time.After(streamTestTimeout):
+		t.Fatalf("stream not cancelled after the client disconnected")
+	}
+}
+
+// fakeWebsocketBody is a body that reports the end of the client's messages on
+// the first read and fails all further reads once it is closed.
+type fakeWebsocketBody struct {
+	reads  int
+	closed chan struct{}
+}
+
+// Read returns io.EOF on the first call and blocks further calls until the
+// body is closed.
+func (b *fakeWebsocketBody) Read([]byte) (int, error) {
+	b.reads++
+	if b.reads == 1 {
+		return 0, io.EOF
+	}
+
+	<-b.closed
+
+	return 0, io.ErrClosedPipe
+}
+
+// Close closes the body.
+func (b *fakeWebsocketBody) Close() error {
+	close(b.closed)
+
+	return nil
+}
+
+// TestDisconnectWatchBodyClosed tests that a call that is done isn't reported
+// as disconnected when its WebSocket is closed.
+func TestDisconnectWatchBodyClosed(t *testing.T) {
+	disconnected := make(chan struct{})
+	body := &disconnectWatchBody{
+		ReadCloser: &fakeWebsocketBody{
+			closed: make(chan struct{}),
+		},
+		onDisconnect: func() {
+			close(disconnected)
+		},
+	}
+
+	// The client finished sending, which starts the background read.
+	_, err := body.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+
+	require.NoError(t, body.Close())
+
+	select {
+	case <-disconnected:
+		t.Fatalf("disconnect of a finished call reported")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
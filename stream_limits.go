@@ -0,0 +1,139 @@
+package terminal
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultStreamMaxMessages is the default maximum number of messages
+	// that are sent to the client of a single server-streaming call.
+	defaultStreamMaxMessages = 10_000_000
+
+	// defaultStreamMaxBytes is the default maximum number of bytes that
+	// are sent to the client of a single server-streaming call.
+	defaultStreamMaxBytes = 10 << 30
+)
+
+// streamLimit is the maximum number of messages and bytes that are sent to the
+// client of a single server-streaming call. A value of 0 means no limit.
+type streamLimit struct {
+	maxMessages uint64
+	maxBytes    uint64
+}
+
+// unlimited returns true if neither the number of messages nor the number of
+// bytes is limited.
+func (l streamLimit) unlimited() bool {
+	return l.maxMessages == 0 && l.maxBytes == 0
+}
+
+// enabled returns true if any stream is limited.
+func (c *StreamLimitsConfig) enabled() bool {
+	if c.MaxMessages != 0 || c.MaxBytes != 0 {
+		return true
+	}
+
+	for _, limit := range c.methods {
+		if !limit.unlimited() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limitFor returns the stream limit of the given gRPC URI.
+func (c *StreamLimitsConfig) limitFor(requestURI string) streamLimit {
+	if limit, ok := c.methods[requestURI]; ok {
+		return limit
+	}
+
+	return streamLimit{
+		maxMessages: c.MaxMessages,
+		maxBytes:    c.MaxBytes,
+	}
+}
+
+// isServerStreamingMethod returns true if the given gRPC URI belongs to a known
+// method that streams responses.
+func isServerStreamingMethod(requestURI string) bool {
+	method, ok := methodDescriptor(requestURI)
+	if !ok {
+		return false
+	}
+
+	return method.IsStreamingServer()
+}
+
+// limitedServerStream is a grpc.ServerStream that terminates the stream once
+// more messages or bytes than allowed are sent to the client.
+type limitedServerStream struct {
+	grpc.ServerStream
+
+	requestURI string
+	limit      streamLimit
+
+	messages uint64
+	bytes    uint64
+}
+
+// SendMsg counts the messages and bytes sent to the client and returns an
+// error instead of sending the message if the stream's limit is exceeded.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *limitedServerStream) SendMsg(m interface{}) error {
+	// All messages, including the raw frames of the calls that are
+	// forwarded to a backend daemon, are proto messages.
+	if msg, ok := m.(proto.Message); ok {
+		s.bytes += uint64(proto.Size(msg))
+	}
+	s.messages++
+
+	if s.limit.maxMessages != 0 && s.messages > s.limit.maxMessages {
+		log.Warnf("Terminating stream %s after exceeding the limit of "+
+			"%d messages", s.requestURI, s.limit.maxMessages)
+
+		return status.Errorf(codes.ResourceExhausted, "stream limit "+
+			"exceeded: more than %d messages sent for %s",
+			s.limit.maxMessages, s.requestURI)
+	}
+
+	if s.limit.maxBytes != 0 && s.bytes > s.limit.maxBytes {
+		log.Warnf("Terminating stream %s after exceeding the limit of "+
+			"%d bytes", s.requestURI, s.limit.maxBytes)
+
+		return status.Errorf(codes.ResourceExhausted, "stream limit "+
+			"exceeded: more than %d bytes sent for %s",
+			s.limit.maxBytes, s.requestURI)
+	}
+
+	return s.ServerStream.SendMsg(m)
+}
+
+// streamLimitStreamInterceptor is a gRPC interceptor that terminates
+// server-streaming calls that send more messages or bytes to the client than
+// the configured limits allow. Since all calls that are forwarded to a backend
+// daemon are handled as streams by the proxy, we use the method descriptor to
+// only limit calls that actually stream responses.
+func (p *rpcProxy) streamLimitStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if !isServerStreamingMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	limit := p.cfg.StreamLimits.limitFor(info.FullMethod)
+	if limit.unlimited() {
+		return handler(srv, ss)
+	}
+
+	return handler(srv, &limitedServerStream{
+		ServerStream: ss,
+		requestURI:   info.FullMethod,
+		limit:        limit,
+	})
+}
@@ -0,0 +1,96 @@
+package terminal
+
+import (
+	"sync"
+)
+
+// streamTracker keeps track of the number of streams that are currently
+// active for each macaroon root key.
+type streamTracker struct {
+	active map[uint64]uint32
+
+	// limits caches the stream limits of the sessions, keyed by their
+	// macaroon root key ID. The limit of a session can't be changed once
+	// it was created, so the cached limits never go stale.
+	limits map[uint64]uint32
+
+	mu sync.Mutex
+}
+
+// newStreamTracker creates a new, empty streamTracker.
+func newStreamTracker() *streamTracker {
+	return &streamTracker{
+		active: make(map[uint64]uint32),
+		limits: make(map[uint64]uint32),
+	}
+}
+
+// sessionLimit returns the stream limit of the session with the given root key
+// ID. The limit is only looked up with the given function if it isn't cached
+// yet. False is returned if the root key doesn't belong to a session. That
+// isn't cached, since a session might still be created with the root key.
+func (t *streamTracker) sessionLimit(rootKeyID uint64,
+	lookup func(uint64) (uint32, bool)) (uint32, bool) {
+
+	t.mu.Lock()
+	limit, ok := t.limits[rootKeyID]
+	t.mu.Unlock()
+	if ok {
+		return limit, true
+	}
+
+	limit, ok = lookup(rootKeyID)
+	if !ok {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	t.limits[rootKeyID] = limit
+	t.mu.Unlock()
+
+	return limit, true
+}
+
+// acquire registers a new active stream for the given root key ID. If the
+// limit is non-zero and the number of active streams has already reached it,
+// false is returned and the stream is not registered.
+func (t *streamTracker) acquire(rootKeyID uint64, limit uint32) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit != 0 && t.active[rootKeyID] >= limit {
+		return false
+	}
+
+	t.active[rootKeyID]++
+
+	return true
+}
+
+// release removes an active stream for the given root key ID that was
+// previously registered with acquire.
+func (t *streamTracker) release(rootKeyID uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count, ok := t.active[rootKeyID]
+	if !ok {
+		return
+	}
+
+	if count <= 1 {
+		delete(t.active, rootKeyID)
+		return
+	}
+
+	t.active[rootKeyID] = count - 1
+}
+
+// activeStreams returns the number of streams that are currently active for
+// the given root key ID.
+func (t *streamTracker) activeStreams(rootKeyID uint64) uint32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.active[rootKeyID]
+}
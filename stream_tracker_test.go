@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// countingSessionStore is a session.Store that counts the session lookups.
+type countingSessionStore struct {
+	session.Store
+
+	lookups int
+}
+
+func (s *countingSessionStore) GetSessionByID(
+	id session.ID) (*session.Session, error) {
+
+	s.lookups++
+
+	return s.Store.GetSessionByID(id)
+}
+
+// TestSessionStreamLimit tests that the streams of a session are limited, and
+// that the limit of the session is only looked up once.
+func TestSessionStreamLimit(t *testing.T) {
+	db, err := session.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	id, privKey, err := db.GetUnusedIDAndKeyPair()
+	require.NoError(t, err)
+	sess, err := session.NewSession(
+		id, privKey, "streams", session.TypeMacaroonReadonly,
+		time.Now().Add(time.Hour), "", false, nil, nil, nil, false,
+		nil, session.PrivacyFlags{},
+	)
+	require.NoError(t, err)
+	sess.MaxStreams = 2
+	require.NoError(t, db.CreateSession(sess))
+
+	superMac, err := BakeSuperMacaroon(
+		context.Background(), newMockRootKeyLnd(), sess.MacaroonRootKey,
+		nil, nil,
+	)
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs(HeaderMacaroon, superMac),
+	)
+
+	store := &countingSessionStore{Store: db}
+	p := &rpcProxy{
+		cfg:       &Config{},
+		started:   1,
+		sessionDB: store,
+		streams:   newStreamTracker(),
+	}
+
+	release1, err := p.acquireSessionStream(ctx)
+	require.NoError(t, err)
+	release2, err := p.acquireSessionStream(ctx)
+	require.NoError(t, err)
+
+	_, err = p.acquireSessionStream(ctx)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Once a stream is done, a new one can be started.
+	release1()
+	release3, err := p.acquireSessionStream(ctx)
+	require.NoError(t, err)
+	release2()
+	release3()
+	require.Zero(t, p.activeSessionStreams(sess))
+
+	// The limit of the session is cached.
+	require.Equal(t, 1, store.lookups)
+
+	// Requests without a super macaroon aren't limited.
+	release, err := p.acquireSessionStream(context.Background())
+	require.NoError(t, err)
+	release()
+
+	// Only methods that actually stream count towards the limit.
+	require.False(t, isStreamingMethod("/lnrpc.Lightning/GetInfo"))
+	require.True(t, isStreamingMethod("/lnrpc.Lightning/SubscribeInvoices"))
+}
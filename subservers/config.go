@@ -1,5 +1,17 @@
 package subservers
 
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+// UnixSocketScheme is the scheme of an RPC server address that points to a
+// Unix domain socket instead of a TCP host:port.
+const UnixSocketScheme = "unix://"
+
 // RemoteConfig holds the configuration parameters that are needed when running
 // LiT in the "remote" lnd mode.
 type RemoteConfig struct {
@@ -20,8 +32,9 @@ type RemoteConfig struct {
 // connect to a remote daemon like lnd for example.
 type RemoteDaemonConfig struct {
 	// RPCServer is host:port that the remote daemon's RPC server is
-	// listening on.
-	RPCServer string `long:"rpcserver" description:"The host:port that the remote daemon is listening for RPC connections on."`
+	// listening on. For lnd, it can also be the path of a Unix domain
+	// socket in the form unix:///path/to/socket.
+	RPCServer string `long:"rpcserver" description:"The host:port that the remote daemon is listening for RPC connections on. For lnd, unix:///path/to/socket connects to lnd over a Unix domain socket instead."`
 
 	// MacaroonPath is the path to the single macaroon that should be used
 	// instead of needing to specify the macaroon directory that contains
@@ -33,4 +46,41 @@ type RemoteDaemonConfig struct {
 	// TLSCertPath is the path to the tls cert of the remote daemon that
 	// should be used to verify the TLS identity of the remote RPC server.
 	TLSCertPath string `long:"tlscertpath" description:"The full path to the remote daemon's TLS cert to use for RPC connection verification."`
+
+	// TLSVerify is the mode in which the TLS identity of the remote daemon
+	// is verified. See the TLSVerify* constants for the available modes.
+	TLSVerify string `long:"tlsverify" description:"How the remote daemon's TLS identity is verified. 'cert' trusts only the cert at tlscertpath, which must be valid for the dialed host. 'ca' verifies the cert chain and host against the CA bundle at tlscapath. 'pinned' only accepts a server cert identical to the one at tlscertpath, without checking its host or expiry. 'insecure' skips the verification entirely: the connection is still encrypted, but anyone able to intercept it can impersonate the daemon and capture its macaroon, so only use it on trusted networks." choice:"cert" choice:"ca" choice:"pinned" choice:"insecure"`
+
+	// TLSCAPath is the path to the CA bundle that is used to verify the
+	// TLS identity of the remote RPC server if TLSVerify is set to
+	// TLSVerifyCA.
+	TLSCAPath string `long:"tlscapath" description:"The full path to a PEM encoded CA bundle to verify the remote daemon's TLS cert chain with. Only used if tlsverify=ca."`
+}
+
+// UnixSocketPath returns the path of the Unix domain socket that the remote
+// daemon's RPC server is reached through. False is returned if the RPC server
+// is a TCP host:port.
+func (c *RemoteDaemonConfig) UnixSocketPath() (string, bool) {
+	return strings.CutPrefix(c.RPCServer, UnixSocketScheme)
+}
+
+// ValidateUnixSocket makes sure that the path of a Unix domain socket RPC
+// server is absolute after expanding it, so gRPC dials the right socket, and
+// rewrites the RPC server address with the expanded path. Nothing is done for
+// a TCP host:port.
+func (c *RemoteDaemonConfig) ValidateUnixSocket(name string) error {
+	path, ok := c.UnixSocketPath()
+	if !ok {
+		return nil
+	}
+
+	path = lncfg.CleanAndExpandPath(path)
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%s: the path of the unix socket in "+
+			"rpcserver must be absolute, got %q", name, path)
+	}
+
+	c.RPCServer = UnixSocketScheme + path
+
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,7 +17,7 @@ import (
 	grpcProxy "github.com/mwitkow/grpc-proxy/proxy"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/connectivity"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
 )
@@ -34,9 +35,15 @@ var (
 // Manager manages a set of subServer objects.
 type Manager struct {
 	servers      []*subServerWrapper
+	disabled     []SubServer
 	permsMgr     *perms.Manager
 	statusServer *status.Manager
 	mu           sync.RWMutex
+
+	retryWg   sync.WaitGroup
+	monitorWg sync.WaitGroup
+	quit      chan struct{}
+	closeQuit sync.Once
 }
 
 // NewManager constructs a new Manager.
@@ -46,6 +53,7 @@ func NewManager(permsMgr *perms.Manager,
 	return &Manager{
 		permsMgr:     permsMgr,
 		statusServer: statusServer,
+		quit:         make(chan struct{}),
 	}
 }
 
@@ -54,15 +62,21 @@ func (s *Manager) AddServer(ss SubServer, enable bool) {
 	// Register all sub-servers with the status server.
 	s.statusServer.RegisterSubServer(ss.Name())
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// If the sub-server has explicitly been disabled, then we don't add it
-	// to the set of servers tracked by the Manager.
+	// to the set of servers tracked by the Manager. We only remember it so
+	// we can tell calls to it apart from calls to unknown methods.
 	if !enable {
+		log.Infof("%s sub-server is disabled, not starting it and not "+
+			"registering its gRPC and REST endpoints", ss.Name())
+
+		s.disabled = append(s.disabled, ss)
+
 		return
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	// Add the enabled server to the set of servers tracked by the Manager.
 	s.servers = append(s.servers, &subServerWrapper{
 		SubServer: ss,
@@ -79,41 +93,67 @@ func (s *Manager) AddServer(ss SubServer, enable bool) {
 }
 
 // StartIntegratedServers starts all the manager's sub-servers that should be
-// started in integrated mode.
+// started in integrated mode. A sub-server that fails to start is marked as
+// errored, the others are started regardless. An error listing all failed
+// sub-servers is returned.
 func (s *Manager) StartIntegratedServers(lndClient lnrpc.LightningClient,
-	lndGrpc *lndclient.GrpcLndServices, withMacaroonService bool) {
+	lndGrpc *lndclient.GrpcLndServices, withMacaroonService bool) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	var failures []string
 	for _, ss := range s.servers {
 		if ss.Remote() {
 			continue
 		}
 
-		err := ss.startIntegrated(
-			lndClient, lndGrpc, withMacaroonService,
-			func(err error) {
-				s.statusServer.SetErrored(
-					ss.Name(), err.Error(),
-				)
-			},
+		err := s.startIntegrated(
+			ss, lndClient, lndGrpc, withMacaroonService,
 		)
 		if err != nil {
-			s.statusServer.SetErrored(ss.Name(), err.Error())
-			continue
+			ss.startFailed = true
+			failures = append(failures, fmt.Sprintf("%s: %v",
+				ss.Name(), err))
 		}
+	}
 
-		s.statusServer.SetRunning(ss.Name())
+	return startFailuresError(failures)
+}
+
+// startIntegrated starts the given integrated sub-server and updates its
+// status accordingly.
+func (s *Manager) startIntegrated(ss *subServerWrapper,
+	lndClient lnrpc.LightningClient, lndGrpc *lndclient.GrpcLndServices,
+	withMacaroonService bool) error {
+
+	err := ss.startIntegrated(
+		lndClient, lndGrpc, withMacaroonService,
+		func(err error) {
+			s.statusServer.SetErrored(ss.Name(), err.Error())
+		},
+	)
+	if err != nil {
+		s.statusServer.SetErrored(ss.Name(), err.Error())
+		ss.setStartError(err)
+
+		return err
 	}
+
+	s.statusServer.SetRunning(ss.Name())
+
+	return nil
 }
 
 // ConnectRemoteSubServers creates connections to all the manager's sub-servers
-// that are running remotely.
-func (s *Manager) ConnectRemoteSubServers() {
+// that are running remotely. A sub-server that can't be connected to is
+// marked as errored, the others are connected regardless. An error listing
+// all failed sub-servers is returned.
+func (s *Manager) ConnectRemoteSubServers() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	var failures []string
 	for _, ss := range s.servers {
 		if !ss.Remote() {
 			continue
@@ -122,11 +162,177 @@ func (s *Manager) ConnectRemoteSubServers() {
 		err := ss.connectRemote()
 		if err != nil {
 			s.statusServer.SetErrored(ss.Name(), err.Error())
+			ss.setStartError(err)
+			ss.startFailed = true
+			failures = append(failures, fmt.Sprintf("%s: %v",
+				ss.Name(), err))
+
 			continue
 		}
 
 		s.statusServer.SetRunning(ss.Name())
+		s.monitorRemoteConn(ss.Name(), ss.remoteConn)
+	}
+
+	return startFailuresError(failures)
+}
+
+// monitorRemoteConn keeps the status of the remote sub-server with the given
+// name in line with the state of its connection until the manager is stopped.
+// The sub-server is reported as errored while the connection is failing and
+// as running again once it is re-established.
+func (s *Manager) monitorRemoteConn(name string, conn *grpc.ClientConn) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.monitorWg.Add(2)
+	go func() {
+		defer s.monitorWg.Done()
+		defer cancel()
+
+		select {
+		case <-s.quit:
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		defer s.monitorWg.Done()
+		defer cancel()
+
+		state := conn.GetState()
+		for {
+			switch state {
+			case connectivity.Ready:
+				s.statusServer.SetRunning(name)
+
+			// An idle connection is only re-established with the
+			// next call. We reconnect right away instead, so a
+			// remote that went away is noticed without a call.
+			case connectivity.Idle:
+				conn.Connect()
+
+			case connectivity.TransientFailure:
+				s.statusServer.SetErrored(
+					name, "connection to remote %s is "+
+						"failing", name,
+				)
+
+			case connectivity.Shutdown:
+				return
+			}
+
+			if !conn.WaitForStateChange(ctx, state) {
+				return
+			}
+			state = conn.GetState()
+		}
+	}()
+}
+
+// startFailuresError combines the given sub-server start failures into a
+// single error. Nil is returned if there are none.
+func startFailuresError(failures []string) error {
+	if len(failures) == 0 {
+		return nil
 	}
+
+	return fmt.Errorf("sub-servers failed to start: %s",
+		strings.Join(failures, ", "))
+}
+
+// RetryFailedServers keeps retrying to start or connect to the sub-servers
+// that failed to do so at startup, every interval, until all of them are
+// running or the manager is stopped. Sub-servers that stopped because of an
+// error after they were started successfully are not retried.
+func (s *Manager) RetryFailedServers(interval time.Duration,
+	lndClient lnrpc.LightningClient, lndGrpc *lndclient.GrpcLndServices,
+	withMacaroonService bool) {
+
+	s.retryWg.Add(1)
+	go func() {
+		defer s.retryWg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.quit:
+				return
+			}
+
+			remaining := s.retryFailedServers(
+				lndClient, lndGrpc, withMacaroonService,
+			)
+			if remaining == 0 {
+				return
+			}
+		}
+	}()
+}
+
+// retryFailedServers makes one attempt to start or connect to each sub-server
+// that failed to do so before. The number of sub-servers that still failed is
+// returned.
+func (s *Manager) retryFailedServers(lndClient lnrpc.LightningClient,
+	lndGrpc *lndclient.GrpcLndServices, withMacaroonService bool) int {
+
+	s.mu.RLock()
+	var failed []*subServerWrapper
+	for _, ss := range s.servers {
+		if ss.startFailed {
+			failed = append(failed, ss)
+		}
+	}
+	s.mu.RUnlock()
+
+	remaining := 0
+	for _, ss := range failed {
+		log.Infof("Retrying to start %s sub-server", ss.Name())
+
+		var err error
+		if ss.Remote() {
+			// The remote connection is read by calls in flight,
+			// so it may only be set while holding the lock.
+			s.mu.Lock()
+			err = ss.connectRemote()
+			s.mu.Unlock()
+
+			if err != nil {
+				s.statusServer.SetErrored(
+					ss.Name(), err.Error(),
+				)
+				ss.setStartError(err)
+			} else {
+				s.statusServer.SetRunning(ss.Name())
+				s.monitorRemoteConn(ss.Name(), ss.remoteConn)
+			}
+		} else {
+			// Starting an integrated sub-server can take a while,
+			// so we don't block calls to the other sub-servers by
+			// holding the lock.
+			err = s.startIntegrated(
+				ss, lndClient, lndGrpc, withMacaroonService,
+			)
+		}
+
+		if err != nil {
+			log.Warnf("Retrying to start %s sub-server failed: %v",
+				ss.Name(), err)
+			remaining++
+
+			continue
+		}
+
+		log.Infof("%s sub-server started after retrying", ss.Name())
+
+		s.mu.Lock()
+		ss.startFailed = false
+		s.mu.Unlock()
+	}
+
+	return remaining
 }
 
 // RegisterRPCServices registers all the manager's sub-servers with the given
@@ -317,10 +523,128 @@ func (s *Manager) Handles(uri string) (bool, string) {
 	return false, ""
 }
 
+// HandlesDisabled returns true if the given URI belongs to one of the
+// sub-servers that were explicitly disabled, along with the name of the
+// sub-server.
+func (s *Manager) HandlesDisabled(uri string) (bool, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ss := range s.disabled {
+		if _, ok := ss.Permissions()[uri]; !ok {
+			continue
+		}
+
+		return true, ss.Name()
+	}
+
+	return false, ""
+}
+
+// DisabledURIs returns the URIs of all sub-servers that were explicitly
+// disabled.
+func (s *Manager) DisabledURIs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var uris []string
+	for _, ss := range s.disabled {
+		for uri := range ss.Permissions() {
+			uris = append(uris, uri)
+		}
+	}
+
+	return uris
+}
+
+// Mode is the mode a sub-server is run in.
+type Mode uint8
+
+const (
+	// ModeIntegrated means the sub-server runs in the same process as
+	// LiT.
+	ModeIntegrated Mode = iota
+
+	// ModeRemote means the sub-server runs as a separate daemon that LiT
+	// connects to.
+	ModeRemote
+
+	// ModeDisabled means the sub-server was explicitly disabled and is
+	// neither started nor connected to.
+	ModeDisabled
+)
+
+// State is the state of a sub-server as known by the Manager.
+type State struct {
+	// Name is the name of the sub-server.
+	Name string
+
+	// Mode is the mode the sub-server is run in.
+	Mode Mode
+
+	// Running is true if an integrated sub-server is started or if the
+	// connection to a remote sub-server is up.
+	Running bool
+
+	// StartError is the error of the last failed attempt to start or
+	// connect to the sub-server. It is kept after a later attempt
+	// succeeded, so it can still be inspected.
+	StartError string
+}
+
+// States returns the state of all the manager's sub-servers, including the
+// disabled ones.
+func (s *Manager) States() []State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make([]State, 0, len(s.servers)+len(s.disabled))
+	for _, ss := range s.servers {
+		state := State{
+			Name:       ss.Name(),
+			Mode:       ModeIntegrated,
+			Running:    ss.started(),
+			StartError: ss.startError(),
+		}
+
+		if ss.Remote() {
+			state.Mode = ModeRemote
+			state.Running = false
+
+			// An idle connection is re-established with the next
+			// call, so it counts as up.
+			if ss.remoteConn != nil {
+				switch ss.remoteConn.GetState() {
+				case connectivity.Ready, connectivity.Idle:
+					state.Running = true
+				}
+			}
+		}
+
+		states = append(states, state)
+	}
+
+	for _, ss := range s.disabled {
+		states = append(states, State{
+			Name: ss.Name(),
+			Mode: ModeDisabled,
+		})
+	}
+
+	return states
+}
+
 // Stop stops all the manager's sub-servers
 func (s *Manager) Stop() error {
 	var returnErr error
 
+	// Make sure no sub-server is started while we stop them.
+	s.closeQuit.Do(func() {
+		close(s.quit)
+	})
+	s.retryWg.Wait()
+	s.monitorWg.Wait()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -341,11 +665,13 @@ func (s *Manager) Stop() error {
 	return returnErr
 }
 
-func dialBackend(name, dialAddr, tlsCertPath string) (*grpc.ClientConn, error) {
-	tlsConfig, err := credentials.NewClientTLSFromFile(tlsCertPath, "")
+func dialBackend(name string, cfg *RemoteDaemonConfig) (*grpc.ClientConn,
+	error) {
+
+	tlsConfig, err := cfg.TransportCredentials()
 	if err != nil {
-		return nil, fmt.Errorf("could not read %s TLS cert %s: %v",
-			name, tlsCertPath, err)
+		return nil, fmt.Errorf("could not set up %s TLS verification: "+
+			"%v", name, err)
 	}
 
 	opts := []grpc.DialOption{
@@ -360,8 +686,8 @@ func dialBackend(name, dialAddr, tlsCertPath string) (*grpc.ClientConn, error) {
 		}),
 	}
 
-	log.Infof("Dialing %s gRPC server at %s", name, dialAddr)
-	cc, err := grpc.Dial(dialAddr, opts...)
+	log.Infof("Dialing %s gRPC server at %s", name, cfg.RPCServer)
+	cc, err := grpc.Dial(cfg.RPCServer, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed dialing %s backend: %v", name,
 			err)
@@ -5,7 +5,6 @@ import (
 	"sync"
 
 	"github.com/lightninglabs/lndclient"
-	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"google.golang.org/grpc"
 )
@@ -26,12 +25,18 @@ type subServerWrapper struct {
 	SubServer
 
 	integratedStarted bool
+	startErr          string
 	startedMu         sync.RWMutex
 
 	stopped sync.Once
 
 	remoteConn *grpc.ClientConn
 
+	// startFailed is true if the sub-server couldn't be started or
+	// connected to at startup and wasn't successfully retried since. It
+	// is guarded by the manager's mutex.
+	startFailed bool
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -54,6 +59,24 @@ func (s *subServerWrapper) setStarted(started bool) {
 	s.integratedStarted = started
 }
 
+// startError returns the error of the last failed attempt to start or connect
+// to the subServer. An empty string is returned if no attempt failed.
+func (s *subServerWrapper) startError() string {
+	s.startedMu.RLock()
+	defer s.startedMu.RUnlock()
+
+	return s.startErr
+}
+
+// setStartError records the error of a failed attempt to start or connect to
+// the subServer.
+func (s *subServerWrapper) setStartError(err error) {
+	s.startedMu.Lock()
+	defer s.startedMu.Unlock()
+
+	s.startErr = err.Error()
+}
+
 // stop the subServer by closing the connection to it if it is remote or by
 // stopping the integrated process.
 func (s *subServerWrapper) stop() error {
@@ -139,9 +162,8 @@ func (s *subServerWrapper) startIntegrated(lndClient lnrpc.LightningClient,
 // connectRemote attempts to make a connection to the remote sub-server.
 func (s *subServerWrapper) connectRemote() error {
 	cfg := s.RemoteConfig()
-	certPath := lncfg.CleanAndExpandPath(cfg.TLSCertPath)
 	name := s.Name()
-	conn, err := dialBackend(name, cfg.RPCServer, certPath)
+	conn, err := dialBackend(name, cfg)
 	if err != nil {
 		return fmt.Errorf("remote dial error: %v", err)
 	}
@@ -0,0 +1,148 @@
+package subservers
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// TLSVerifyCert verifies the remote daemon's TLS cert against the cert
+	// at the configured TLS cert path, which must also be valid for the
+	// dialed host name. This is the default.
+	TLSVerifyCert = "cert"
+
+	// TLSVerifyCA verifies the remote daemon's TLS cert chain and host
+	// name against the CA bundle at the configured TLS CA path.
+	TLSVerifyCA = "ca"
+
+	// TLSVerifyPinned only accepts a remote daemon's TLS cert that is
+	// byte-for-byte identical to the cert at the configured TLS cert path.
+	// The host name and the validity period of the cert aren't checked.
+	TLSVerifyPinned = "pinned"
+
+	// TLSVerifyInsecure skips the verification of the remote daemon's TLS
+	// cert entirely. The connection is still encrypted, but anyone that
+	// can intercept it can impersonate the daemon and obtain the macaroon
+	// that is sent along with each request.
+	TLSVerifyInsecure = "insecure"
+)
+
+// ValidateTLS makes sure the TLS verification options of the remote daemon
+// with the given name are consistent.
+func (c *RemoteDaemonConfig) ValidateTLS(name string) error {
+	switch c.TLSVerify {
+	case "", TLSVerifyCert, TLSVerifyPinned:
+		if c.TLSCertPath == "" {
+			return fmt.Errorf("%s: tlscertpath must be set for "+
+				"tlsverify=%s", name, c.TLSVerifyMode())
+		}
+
+	case TLSVerifyCA:
+		if c.TLSCAPath == "" {
+			return fmt.Errorf("%s: tlscapath must be set for "+
+				"tlsverify=%s", name, TLSVerifyCA)
+		}
+
+	case TLSVerifyInsecure:
+
+	default:
+		return fmt.Errorf("%s: invalid tlsverify mode %q", name,
+			c.TLSVerify)
+	}
+
+	return nil
+}
+
+// TransportCredentials returns the credentials that verify the TLS identity of
+// the remote daemon according to the configured verification mode.
+func (c *RemoteDaemonConfig) TransportCredentials() (
+	credentials.TransportCredentials, error) {
+
+	switch c.TLSVerifyMode() {
+	case TLSVerifyCert:
+		certPath := lncfg.CleanAndExpandPath(c.TLSCertPath)
+		return credentials.NewClientTLSFromFile(certPath, "")
+
+	case TLSVerifyCA:
+		caPath := lncfg.CleanAndExpandPath(c.TLSCAPath)
+		caBundle, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no CA certs found in %s",
+				caPath)
+		}
+
+		return credentials.NewTLS(&tls.Config{RootCAs: pool}), nil
+
+	case TLSVerifyPinned:
+		certPath := lncfg.CleanAndExpandPath(c.TLSCertPath)
+		pinned, err := readCert(certPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// We skip the default verification and instead compare the
+		// leaf cert the daemon presents with the pinned one.
+		return credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true, // nolint:gosec
+			VerifyPeerCertificate: func(rawCerts [][]byte,
+				_ [][]*x509.Certificate) error {
+
+				if len(rawCerts) == 0 ||
+					!bytes.Equal(rawCerts[0], pinned.Raw) {
+
+					return errors.New("remote TLS cert " +
+						"doesn't match the pinned cert")
+				}
+
+				return nil
+			},
+		}), nil
+
+	case TLSVerifyInsecure:
+		return credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true, // nolint:gosec
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("invalid tlsverify mode %q",
+			c.TLSVerify)
+	}
+}
+
+// TLSVerifyMode returns the configured TLS verification mode, defaulting to
+// TLSVerifyCert if none is set.
+func (c *RemoteDaemonConfig) TLSVerifyMode() string {
+	if c.TLSVerify == "" {
+		return TLSVerifyCert
+	}
+
+	return c.TLSVerify
+}
+
+// readCert reads the first PEM encoded certificate from the given file.
+func readCert(path string) (*x509.Certificate, error) {
+	certPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded cert found in %s", path)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
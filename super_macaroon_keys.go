@@ -0,0 +1,310 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// superMacRootKeys keeps track of the current and the stale super macaroon
+// root keys. The state is persisted in the session store and kept in memory
+// as well, since it is consulted for every request made with a super macaroon.
+type superMacRootKeys struct {
+	db session.Store
+
+	mu      sync.RWMutex
+	current uint64
+	stale   map[uint64]time.Time
+	issued  map[uint64]bool
+}
+
+// newSuperMacRootKeys loads the state of the super macaroon root keys from the
+// given store.
+func newSuperMacRootKeys(db session.Store) (*superMacRootKeys, error) {
+	keys, err := db.GetSuperMacaroonRootKeys()
+	if err != nil {
+		return nil, fmt.Errorf("error loading super macaroon root "+
+			"keys: %v", err)
+	}
+
+	return &superMacRootKeys{
+		db:      db,
+		current: keys.Current,
+		stale:   keys.Stale,
+		issued:  keys.Issued,
+	}, nil
+}
+
+// currentID returns the ID of the root key that super macaroons are baked with
+// by default.
+func (k *superMacRootKeys) currentID() uint64 {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.current
+}
+
+// isStale returns true if the root key with the given ID was rotated out.
+func (k *superMacRootKeys) isStale(rootKeyID uint64) bool {
+	_, ok := k.staleSince(rootKeyID)
+
+	return ok
+}
+
+// staleSince returns the time at which the root key with the given ID was
+// rotated out. False is returned if the root key isn't stale.
+func (k *superMacRootKeys) staleSince(rootKeyID uint64) (time.Time, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	rotatedAt, ok := k.stale[rootKeyID]
+
+	return rotatedAt, ok
+}
+
+// checkRootKey returns an error if the root key with the given ID was rotated
+// out, so macaroons baked with it must not be accepted.
+func (k *superMacRootKeys) checkRootKey(rootKeyID uint64) error {
+	rotatedAt, ok := k.staleSince(rootKeyID)
+	if !ok {
+		return nil
+	}
+
+	return fmt.Errorf("macaroon root key %d was rotated out at %v",
+		rootKeyID, rotatedAt)
+}
+
+// markIssued persists that a super macaroon was baked with the root key with
+// the given ID, so the root key isn't mistaken for the one of a deleted
+// session.
+func (k *superMacRootKeys) markIssued(rootKeyID uint64) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.issued[rootKeyID] {
+		return nil
+	}
+
+	if err := k.db.AddIssuedRootKey(rootKeyID); err != nil {
+		return fmt.Errorf("error storing issued root key: %v", err)
+	}
+	k.issued[rootKeyID] = true
+
+	return nil
+}
+
+// isIssued returns true if LiT baked a super macaroon with the root key with
+// the given ID that doesn't belong to a session or an account.
+func (k *superMacRootKeys) isIssued(rootKeyID uint64) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.issued[rootKeyID]
+}
+
+// rotate persists the rotation to the root key with the given ID and marks the
+// given root keys as stale.
+func (k *superMacRootKeys) rotate(rootKeyID uint64, stale []uint64) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	err := k.db.RotateSuperMacaroonRootKey(rootKeyID, stale, now)
+	if err != nil {
+		return fmt.Errorf("error storing root key rotation: %v", err)
+	}
+
+	for _, id := range stale {
+		if _, ok := k.stale[id]; !ok {
+			k.stale[id] = time.Unix(now.Unix(), 0)
+		}
+	}
+	delete(k.stale, rootKeyID)
+	k.current = rootKeyID
+
+	return nil
+}
+
+// renew removes the root key with the given ID from the stale root keys. This
+// must only be called once the root key itself was replaced in lnd.
+func (k *superMacRootKeys) renew(rootKeyID uint64) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.stale[rootKeyID]; !ok {
+		return nil
+	}
+
+	if err := k.db.RenewRootKey(rootKeyID); err != nil {
+		return fmt.Errorf("error renewing root key: %v", err)
+	}
+
+	delete(k.stale, rootKeyID)
+
+	return nil
+}
+
+// rotateSuperMacRootKey rotates the super macaroon root key. Every super
+// macaroon root key that lnd knows, except for the ones of accounts, is marked
+// as stale and deleted from lnd, so all super macaroons baked so far are
+// rejected. A new root key with a fresh ID then becomes the current one. The
+// new root key ID and the IDs of the stale root keys are returned.
+func (g *LightningTerminal) rotateSuperMacRootKey(ctx context.Context) (uint64,
+	[]uint64, error) {
+
+	if g.basicClient == nil {
+		return 0, nil, errors.New("lnd not yet connected")
+	}
+
+	resp, err := g.basicClient.ListMacaroonIDs(
+		ctx, &lnrpc.ListMacaroonIDsRequest{},
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error listing root keys: %v", err)
+	}
+
+	// The macaroons of accounts are only bound to the account itself and
+	// are invalidated by removing the account, so we leave their root
+	// keys alone.
+	used, err := g.accountRootKeyIDs()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The current root key might not have been used to bake a macaroon
+	// yet, so it is rotated out even if lnd doesn't know it.
+	currentID := g.superMacKeys.currentID()
+	rootKeyIDs := append(resp.RootKeyIds, currentID)
+
+	var stale []uint64
+	for _, id := range rootKeyIDs {
+		if !session.IsSuperMacaroonRootKeyID(id) || used[id] {
+			continue
+		}
+
+		used[id] = true
+		stale = append(stale, id)
+	}
+
+	// The new root key ID must not have been used before. Macaroons baked
+	// with it would otherwise become valid again.
+	var newID uint64
+	for {
+		var suffix [4]byte
+		if _, err := rand.Read(suffix[:]); err != nil {
+			return 0, nil, err
+		}
+
+		newID = session.NewSuperMacaroonRootKeyID(suffix)
+		if binary.BigEndian.Uint32(suffix[:]) != 0 && !used[newID] &&
+			!g.superMacKeys.isStale(newID) {
+
+			break
+		}
+	}
+
+	// We persist the rotation before deleting the root keys from lnd. If
+	// deleting them fails, the stale root keys are still rejected by LiT.
+	if err := g.superMacKeys.rotate(newID, stale); err != nil {
+		return 0, nil, err
+	}
+	if err := g.superMacKeys.markIssued(newID); err != nil {
+		return 0, nil, err
+	}
+
+	for _, id := range stale {
+		_, err := g.basicClient.DeleteMacaroonID(
+			ctx, &lnrpc.DeleteMacaroonIDRequest{
+				RootKeyId: id,
+			},
+		)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error deleting root key "+
+				"%d: %v", id, err)
+		}
+	}
+
+	log.Infof("Rotated super macaroon root key to %d, %d root keys are "+
+		"stale now", newID, len(stale))
+
+	// Our own super macaroon was baked with the old root key, so we need
+	// to replace it.
+	if g.cfg.LndMode == ModeIntegrated {
+		superMacaroon, err := BakeSuperMacaroon(
+			ctx, g.basicClient, newID,
+			g.permsMgr.ActivePermissions(false), nil,
+		)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error baking internal "+
+				"super macaroon: %v", err)
+		}
+
+		g.rpcProxy.setSuperMacaroon(superMacaroon)
+	}
+
+	return newID, stale, nil
+}
+
+// accountRootKeyIDs returns the IDs of the super macaroon root keys that the
+// macaroons of the accounts are baked with.
+func (g *LightningTerminal) accountRootKeyIDs() (map[uint64]bool, error) {
+	accounts, err := g.accountService.Accounts()
+	if err != nil {
+		return nil, fmt.Errorf("error listing accounts: %v", err)
+	}
+
+	ids := make(map[uint64]bool, len(accounts))
+	for _, acct := range accounts {
+		var suffix [4]byte
+		copy(suffix[:], acct.ID[0:4])
+
+		ids[session.NewSuperMacaroonRootKeyID(suffix)] = true
+	}
+
+	return ids, nil
+}
+
+// listSessionRootKeyCandidates returns the IDs of the super macaroon root keys
+// in lnd's root key store that may belong to a session. The root keys of the
+// accounts, the current root key of LiT's own super macaroon and the root keys
+// of the super macaroons LiT issued otherwise are left out.
+func (g *LightningTerminal) listSessionRootKeyCandidates(
+	ctx context.Context) ([]uint64, error) {
+
+	if g.basicClient == nil {
+		return nil, errors.New("lnd not yet connected")
+	}
+
+	resp, err := g.basicClient.ListMacaroonIDs(
+		ctx, &lnrpc.ListMacaroonIDsRequest{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing root keys: %v", err)
+	}
+
+	notSession, err := g.accountRootKeyIDs()
+	if err != nil {
+		return nil, err
+	}
+	notSession[g.superMacKeys.currentID()] = true
+
+	var ids []uint64
+	for _, id := range resp.RootKeyIds {
+		if !session.IsSuperMacaroonRootKeyID(id) || notSession[id] ||
+			g.superMacKeys.isIssued(id) {
+
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -54,10 +54,10 @@ import (
 	"github.com/lightningnetwork/lnd/rpcperms"
 	"github.com/lightningnetwork/lnd/signal"
 	grpcProxy "github.com/mwitkow/grpc-proxy/proxy"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/test/bufconn"
-	"google.golang.org/protobuf/encoding/protojson"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
 )
@@ -165,8 +165,11 @@ type LightningTerminal struct {
 
 	lndConnID   string
 	lndConn     *grpc.ClientConn
+	lndReplicas *lndReplicaSet
 	lndClient   *lndclient.GrpcLndServices
 	basicClient lnrpc.LightningClient
+	lndRecovery *lndRecoveryMonitor
+	lndStartup  *lndStartupTracker
 
 	subServerMgr *subservers.Manager
 	statusMgr    *status.Manager
@@ -178,6 +181,8 @@ type LightningTerminal struct {
 	rpcProxy   *rpcProxy
 	httpServer *http.Server
 
+	metricsServer *http.Server
+
 	sessionRpcServer        *sessionRpcServer
 	sessionRpcServerStarted bool
 
@@ -196,14 +201,28 @@ type LightningTerminal struct {
 	firewallDB *firewalldb.DB
 	sessionDB  *session.DB
 
+	// superMacKeys keeps track of the current and the stale super
+	// macaroon root keys.
+	superMacKeys *superMacRootKeys
+
 	restHandler http.Handler
 	restCancel  func()
+
+	// restClientCert is the client certificate that the REST proxy
+	// presents to the main HTTPS listener. It is only set if the listener
+	// requires a client certificate.
+	restClientCert *tls.Certificate
+
+	tracerProvider *sdktrace.TracerProvider
+
+	certChain *tlsCertChain
 }
 
 // New creates a new instance of the lightning-terminal daemon.
 func New() *LightningTerminal {
 	return &LightningTerminal{
 		statusMgr: status.NewStatusManager(),
+		certChain: &tlsCertChain{},
 	}
 }
 
@@ -245,7 +264,15 @@ func (g *LightningTerminal) Run() error {
 	// set up, we need to override the isReady check for this specific
 	// URI as soon as LND can accept the call, i.e. when the lnd sub-server
 	// is in the "Wallet Ready" state.
+	// While lnd is recovering its wallet, the recovery monitor decides
+	// which calls are let through.
+	g.lndRecovery = newLndRecoveryMonitor(g.cfg.LndRecovery, g.statusMgr)
+	g.lndStartup = newLndStartupTracker()
 	lndOverride := func(uri, manualStatus string) (bool, bool) {
+		if manualStatus == lndRecoveringStatus {
+			return g.lndRecovery.allowsCall(uri), true
+		}
+
 		if uri != "/lnrpc.State/GetState" {
 			return false, false
 		}
@@ -273,17 +300,42 @@ func (g *LightningTerminal) Run() error {
 	// set up so that the correct REST handlers are registered.
 	g.initSubServers()
 
+	// Set up the OpenTelemetry exporter before the rpcProxy is created so
+	// that the proxy's interceptors use the configured tracer provider.
+	if g.cfg.isTracingEnabled() {
+		g.tracerProvider, err = newTracerProvider(
+			context.Background(), g.cfg.OtelEndpoint,
+			g.cfg.OtelInsecure,
+		)
+		if err != nil {
+			return fmt.Errorf("could not set up tracing: %v", err)
+		}
+	}
+
 	// Construct the rpcProxy. It must be initialised before the main web
 	// server is started.
-	g.rpcProxy = newRpcProxy(
+	g.rpcProxy, err = newRpcProxy(
 		g.cfg, g, g.validateSuperMacaroon, g.permsMgr, g.subServerMgr,
-		g.statusMgr,
+		g.statusMgr, g.lndRecovery, g.lndStartup,
 	)
+	if err != nil {
+		return fmt.Errorf("could not create RPC proxy: %v", err)
+	}
+
+	// Serve the RPC metrics to Prometheus if enabled.
+	if g.cfg.isMetricsEnabled() {
+		err = g.startMetricsServer(g.rpcProxy.metrics.registry)
+		if err != nil {
+			return fmt.Errorf("could not start metrics server: %v",
+				err)
+		}
+	}
 
 	// Register any gRPC services that should be served using LiT's
 	// gRPC server regardless of the LND mode being used.
 	litrpc.RegisterProxyServer(g.rpcProxy.grpcServer, g.rpcProxy)
-	litrpc.RegisterStatusServer(g.rpcProxy.grpcServer, g.statusMgr)
+	litrpc.RegisterStatusServer(g.rpcProxy.grpcServer, g.statusServer())
+	litrpc.RegisterApiKeysServer(g.rpcProxy.grpcServer, g.rpcProxy)
 
 	// Start the main web server that dispatches requests either to the
 	// static UI file server or the RPC proxy. This makes it possible to
@@ -293,6 +345,11 @@ func (g *LightningTerminal) Run() error {
 			err)
 	}
 
+	// A renewed TLS certificate of the main web server can be loaded by
+	// sending SIGHUP.
+	stopTLSReload := watchTLSReloadSignal(g.rpcProxy.tlsReloader)
+	defer stopTLSReload()
+
 	// We'll also create a REST proxy that'll convert any REST calls to gRPC
 	// calls and forward them to the internal listener.
 	if g.cfg.EnableREST {
@@ -316,6 +373,10 @@ func (g *LightningTerminal) Run() error {
 	<-shutdownInterceptor.ShutdownChannel()
 	log.Infof("Shutdown signal received")
 
+	// Give the requests in flight a chance to complete before everything
+	// they depend on is shut down.
+	g.drainRequests()
+
 	err = g.shutdownSubServers()
 	if err != nil {
 		log.Errorf("Error shutting down: %v", err)
@@ -355,12 +416,32 @@ func (g *LightningTerminal) start() error {
 	superMacBaker := func(ctx context.Context, rootKeyID uint64,
 		recipe *session.MacaroonRecipe) (string, error) {
 
+		// lnd would create a new root key for a stale root key ID,
+		// but LiT would still reject the macaroon.
+		if err := g.superMacKeys.checkRootKey(rootKeyID); err != nil {
+			return "", err
+		}
+
 		return BakeSuperMacaroon(
 			ctx, g.basicClient, rootKeyID,
 			recipe.Permissions, recipe.Caveats,
 		)
 	}
 
+	deleteMacRootKey := func(ctx context.Context, rootKeyID uint64) error {
+		if g.basicClient == nil {
+			return errors.New("lnd not yet connected")
+		}
+
+		_, err := g.basicClient.DeleteMacaroonID(
+			ctx, &lnrpc.DeleteMacaroonIDRequest{
+				RootKeyId: rootKeyID,
+			},
+		)
+
+		return err
+	}
+
 	g.accountRpcServer = accounts.NewRPCServer(
 		g.accountService, superMacBaker,
 	)
@@ -374,6 +455,11 @@ func (g *LightningTerminal) start() error {
 		return fmt.Errorf("error creating session DB: %v", err)
 	}
 
+	g.superMacKeys, err = newSuperMacRootKeys(g.sessionDB)
+	if err != nil {
+		return err
+	}
+
 	g.firewallDB, err = firewalldb.NewDB(
 		networkDir, firewalldb.DBFilename, g.sessionDB,
 	)
@@ -410,15 +496,32 @@ func (g *LightningTerminal) start() error {
 		}
 	}
 
+	// The calls of LNC sessions are counted as active requests, so they
+	// are drained on shutdown, and are subject to the same stream limits
+	// as the calls that reach LiT directly.
+	sessionStreamInterceptors := []grpc.StreamServerInterceptor{
+		g.rpcProxy.activeRequestsStreamInterceptor,
+	}
+	if g.cfg.StreamLimits.enabled() {
+		sessionStreamInterceptors = append(
+			sessionStreamInterceptors,
+			g.rpcProxy.streamLimitStreamInterceptor,
+		)
+	}
+	sessionStreamInterceptors = append(
+		sessionStreamInterceptors, g.rpcProxy.StreamServerInterceptor,
+	)
+
 	g.sessionRpcServer, err = newSessionRPCServer(&sessionRpcServerConfig{
 		db:        g.sessionDB,
 		basicAuth: g.rpcProxy.basicAuth,
-		grpcOptions: []grpc.ServerOption{
+		grpcOptions: append([]grpc.ServerOption{
 			grpc.CustomCodec(grpcProxy.Codec()), // nolint: staticcheck,
 			grpc.ChainStreamInterceptor(
-				g.rpcProxy.StreamServerInterceptor,
+				sessionStreamInterceptors...,
 			),
 			grpc.ChainUnaryInterceptor(
+				g.rpcProxy.activeRequestsUnaryInterceptor,
 				g.rpcProxy.UnaryServerInterceptor,
 			),
 			grpc.UnknownServiceHandler(
@@ -427,17 +530,34 @@ func (g *LightningTerminal) start() error {
 					g.rpcProxy.makeDirector(false),
 				),
 			),
-		},
+		}, g.cfg.Keepalive.serverOpts()...),
 		registerGrpcServers: func(server *grpc.Server) {
 			g.registerSubDaemonGrpcServers(server, true)
 		},
 		superMacBaker:           superMacBaker,
+		deleteMacRootKey:        deleteMacRootKey,
+		listMacRootKeys:         g.listSessionRootKeyCandidates,
+		rootKeys:                g.superMacKeys,
 		firstConnectionDeadline: g.cfg.FirstLNCConnDeadline,
+		activeStreams:           g.rpcProxy.activeSessionStreams,
+		getAccount:              g.accountService.Account,
+		maxActiveSessions:       g.cfg.MaxActiveSessions,
+		sessionCreateRate:       g.cfg.SessionCreateRate,
+		usageRetention:          g.cfg.SessionUsageRetention,
 		permMgr:                 g.permsMgr,
 		actionsDB:               g.firewallDB,
 		autopilot:               g.autopilotClient,
 		ruleMgrs:                g.ruleMgrs,
 		privMap:                 g.firewallDB.PrivacyDB,
+		expiryWarningLead:       g.cfg.SessionExpiryWarning.LeadTime,
+		expiryWebhookURL:        g.cfg.SessionExpiryWarning.WebhookURL,
+		expiryWebhookSecret: []byte(
+			g.cfg.SessionExpiryWarning.WebhookSecret,
+		),
+		cleanupInterval:  g.cfg.Session.CleanupInterval,
+		sessionRetention: g.cfg.Session.Retention,
+		idleTimeout:      g.cfg.Session.IdleTimeout,
+		thirdPartyAuth:   g.cfg.ThirdPartyAuth,
 	})
 	if err != nil {
 		return fmt.Errorf("could not create new session rpc "+
@@ -468,6 +588,17 @@ func (g *LightningTerminal) start() error {
 			}},
 		}
 
+		g.rpcProxy.listeners.add(newListenerInfo(
+			"lnd-grpc", g.cfg.Lnd.RPCListeners[0],
+			[]string{"grpc"}, true, lndAuthPolicy(g.cfg),
+		))
+		for _, restListener := range g.cfg.Lnd.RESTListeners {
+			g.rpcProxy.listeners.add(newListenerInfo(
+				"lnd-rest", restListener, []string{"rest"},
+				!g.cfg.Lnd.DisableRestTLS, lndAuthPolicy(g.cfg),
+			))
+		}
+
 		implCfg := &lnd.ImplementationCfg{
 			GrpcRegistrar:       g,
 			RestRegistrar:       g,
@@ -544,6 +675,29 @@ func (g *LightningTerminal) start() error {
 		return fmt.Errorf("could not connect to LND")
 	}
 
+	// Track the startup state of lnd, so clients can be told what we are
+	// waiting for until lnd is ready. If a wallet password file is
+	// configured, we unlock lnd ourselves once it reports that its wallet
+	// is locked.
+	var unlockLnd func(context.Context) error
+	if g.cfg.LitWalletUnlockPasswordFile != "" {
+		unlocker := lnrpc.NewWalletUnlockerClient(g.lndConn)
+		unlockLnd = func(ctx context.Context) error {
+			return unlockLndWallet(
+				ctx, unlocker,
+				g.cfg.LitWalletUnlockPasswordFile,
+			)
+		}
+	}
+	g.lndStartup.start(lnrpc.NewStateClient(g.lndConn), unlockLnd)
+	defer g.lndStartup.stop()
+
+	// Connect to the lnd read replicas, if any are configured.
+	g.lndReplicas, err = connectLndReplicas(g.cfg)
+	if err != nil {
+		return fmt.Errorf("could not connect to lnd replicas: %v", err)
+	}
+
 	// In order to be able to create unique middleware request identifiers,
 	// we set a new unique connection ID. This should be refreshed every
 	// time we (re)connect to LND.
@@ -555,27 +709,56 @@ func (g *LightningTerminal) start() error {
 
 	// Initialise any connections to sub-servers that we are running in
 	// remote mode.
-	g.subServerMgr.ConnectRemoteSubServers()
+	err = g.subServerMgr.ConnectRemoteSubServers()
+	if err != nil {
+		if g.cfg.SubServerStartup.Strict {
+			return err
+		}
+
+		log.Warnf("Continuing without failed sub-servers: %v", err)
+	}
 
 	// bakeSuperMac is a closure that can be used to bake a new super
-	// macaroon that contains all active permissions.
-	bakeSuperMac := func(ctx context.Context, rootKeyIDSuffix uint32) (
-		string, error) {
+	// macaroon that contains the given permissions.
+	bakeSuperMac := func(ctx context.Context, rootKeyIDSuffix uint32,
+		permissions []bakery.Op, caveats []macaroon.Caveat) (string,
+		error) {
 
 		var suffixBytes [4]byte
 		binary.BigEndian.PutUint32(suffixBytes[:], rootKeyIDSuffix)
 
-		rootKeyID := session.NewSuperMacaroonRootKeyID(suffixBytes)
+		// Without a suffix, the current super macaroon root key is
+		// used.
+		rootKeyID := g.superMacKeys.currentID()
+		if rootKeyIDSuffix != 0 {
+			rootKeyID = session.NewSuperMacaroonRootKeyID(
+				suffixBytes,
+			)
+		}
+
+		if err := g.superMacKeys.checkRootKey(rootKeyID); err != nil {
+			return "", err
+		}
+
+		// The root key must not be deleted when the root keys of
+		// deleted sessions are cleaned up.
+		if err := g.superMacKeys.markIssued(rootKeyID); err != nil {
+			return "", err
+		}
 
 		return BakeSuperMacaroon(
-			ctx, g.basicClient, rootKeyID,
-			g.permsMgr.ActivePermissions(false), nil,
+			ctx, g.basicClient, rootKeyID, permissions, caveats,
 		)
 	}
 
 	// Now start the RPC proxy that will handle all incoming gRPC, grpc-web
 	// and REST requests.
-	if err := g.rpcProxy.Start(g.lndConn, bakeSuperMac); err != nil {
+	err = g.rpcProxy.Start(
+		g.lndConn, bakeSuperMac, g.rotateSuperMacRootKey, g.sessionDB,
+		g.sessionRpcServer.revokeSession, g.lndReplicas,
+		g.sessionRpcServer.usage,
+	)
+	if err != nil {
 		return fmt.Errorf("error starting lnd gRPC proxy server: %v",
 			err)
 	}
@@ -656,6 +839,7 @@ func (g *LightningTerminal) start() error {
 	// Mark that lnd is now completely running after connecting the
 	// lnd clients.
 	g.statusMgr.SetRunning(subservers.LND)
+	g.lndStartup.setReady()
 
 	// If we're in integrated and stateless init mode, we won't create
 	// macaroon files in any of the subserver daemons.
@@ -674,15 +858,45 @@ func (g *LightningTerminal) start() error {
 
 	// Both connection types are ready now, let's start our sub-servers if
 	// they should be started locally as an integrated service.
-	g.subServerMgr.StartIntegratedServers(
+	err = g.subServerMgr.StartIntegratedServers(
 		g.basicClient, g.lndClient, createDefaultMacaroons,
 	)
+	if err != nil {
+		if g.cfg.SubServerStartup.Strict {
+			return err
+		}
+
+		log.Warnf("Continuing without failed sub-servers: %v", err)
+	}
+
+	// Sub-servers that failed to start are retried in the background,
+	// until then calls to them fail with UNAVAILABLE.
+	if g.cfg.SubServerStartup.RetryInterval > 0 {
+		g.subServerMgr.RetryFailedServers(
+			g.cfg.SubServerStartup.RetryInterval, g.basicClient,
+			g.lndClient, createDefaultMacaroons,
+		)
+	}
 
 	err = g.startInternalSubServers(createDefaultMacaroons)
 	if err != nil {
 		return fmt.Errorf("could not start litd sub-servers: %v", err)
 	}
 
+	// If configured, bake a super macaroon for bootstrapping external
+	// tools now that all daemons and their permissions are known.
+	err = g.bakeBootstrapSuperMacaroon(context.Background())
+	if err != nil {
+		return fmt.Errorf("could not bake bootstrap super macaroon: %v",
+			err)
+	}
+
+	// If configured, create the default session on the first startup.
+	err = g.createDefaultSession(context.Background())
+	if err != nil {
+		return fmt.Errorf("could not create default session: %v", err)
+	}
+
 	// We can now set the status of LiT as running.
 	g.statusMgr.SetRunning(subservers.LIT)
 
@@ -733,6 +947,15 @@ func (g *LightningTerminal) setUpLNDClients(lndQuit chan struct{}) error {
 		clientOptions = append(clientOptions, lndclient.Insecure())
 	}
 
+	// In remote mode, the user might have chosen to skip the verification
+	// of lnd's TLS cert.
+	if g.cfg.LndMode == ModeRemote && g.cfg.Remote.Lnd.TLSVerifyMode() ==
+		subservers.TLSVerifyInsecure {
+
+		insecure = true
+		clientOptions = append(clientOptions, lndclient.Insecure())
+	}
+
 	// checkRunning checks if we should continue running for the duration of
 	// the defaultStartupTimeout, or else returns an error indicating why
 	// a shut-down is needed.
@@ -786,6 +1009,13 @@ func (g *LightningTerminal) setUpLNDClients(lndQuit chan struct{}) error {
 		log.Infof("Retrying to connect basic lnd client")
 	}
 
+	// If lnd is recovering its wallet, the wait for the wallet sync below
+	// only returns once the recovery is done. Until then, we track the
+	// recovery so its progress can be reported. The monitor must be
+	// stopped before lnd is marked as running.
+	g.lndRecovery.start(g.basicClient)
+	defer g.lndRecovery.stop()
+
 	// Now we know that the connection itself is ready. But we also need to
 	// wait for two things: The chain notifier to be ready and the lnd
 	// wallet being fully synced to its chain backend. The chain notifier
@@ -859,16 +1089,109 @@ func (g *LightningTerminal) setUpLNDClients(lndQuit chan struct{}) error {
 		log.Infof("Baking internal super macaroon")
 		ctx := context.Background()
 		superMacaroon, err := BakeSuperMacaroon(
-			ctx, g.basicClient, session.NewSuperMacaroonRootKeyID(
-				[4]byte{},
-			),
+			ctx, g.basicClient, g.superMacKeys.currentID(),
 			g.permsMgr.ActivePermissions(false), nil,
 		)
 		if err != nil {
 			return err
 		}
 
-		g.rpcProxy.superMacaroon = superMacaroon
+		g.rpcProxy.setSuperMacaroon(superMacaroon)
+	}
+
+	return nil
+}
+
+// bakeBootstrapSuperMacaroon bakes a super macaroon with the configured
+// permissions and writes it to the configured path. If no path is configured
+// or the file already exists, nothing is done.
+func (g *LightningTerminal) bakeBootstrapSuperMacaroon(
+	ctx context.Context) error {
+
+	cfg := g.cfg.SuperMacaroon
+	if cfg.Path == "" {
+		return nil
+	}
+
+	if lnrpc.FileExists(cfg.Path) {
+		log.Infof("Super macaroon already exists at %v, not baking a "+
+			"new one", cfg.Path)
+
+		return g.checkExistingSuperMacaroon(cfg.Path)
+	}
+
+	rootKeyID := session.NewSuperMacaroonRootKeyID(cfg.rootKeySuffix)
+	if err := g.superMacKeys.checkRootKey(rootKeyID); err != nil {
+		return fmt.Errorf("cannot bake bootstrap super macaroon, "+
+			"choose a different root key suffix: %v", err)
+	}
+
+	perms := cfg.permissions
+	if len(perms) == 0 {
+		perms = g.permsMgr.ActivePermissions(cfg.ReadOnly)
+	}
+
+	if err := g.superMacKeys.markIssued(rootKeyID); err != nil {
+		return err
+	}
+
+	log.Infof("Baking bootstrap super macaroon")
+	superMacaroon, err := BakeSuperMacaroon(
+		ctx, g.basicClient, rootKeyID, perms, nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	macBytes, err := hex.DecodeString(superMacaroon)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(cfg.Path), 0700)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Writing super macaroon to %v", cfg.Path)
+
+	return os.WriteFile(cfg.Path, macBytes, 0600)
+}
+
+// checkExistingSuperMacaroon remembers the root key of the super macaroon
+// stored at the given path as issued by LiT, since it might have been baked
+// before issued root keys were tracked. A warning is logged if the super
+// macaroon was baked with a root key that was rotated out since.
+func (g *LightningTerminal) checkExistingSuperMacaroon(path string) error {
+	macBytes, err := os.ReadFile(path)
+	if err != nil {
+		log.Warnf("Unable to read super macaroon at %v: %v", path, err)
+		return nil
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		log.Warnf("Unable to decode super macaroon at %v: %v", path,
+			err)
+		return nil
+	}
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		log.Warnf("Unable to read root key ID of super macaroon at "+
+			"%v: %v", path, err)
+		return nil
+	}
+
+	if err := g.superMacKeys.markIssued(rootKeyID); err != nil {
+		return err
+	}
+
+	if rotatedAt, ok := g.superMacKeys.staleSince(rootKeyID); ok {
+		log.Warnf("Super macaroon at %v was baked with root key %d "+
+			"that was rotated out at %v, it is no longer valid. "+
+			"Delete it to bake a new one on the next start.", path,
+			rootKeyID, rotatedAt)
 	}
 
 	return nil
@@ -993,6 +1316,8 @@ func (g *LightningTerminal) startInternalSubServers(
 		privacyMapper,
 		g.accountService,
 		requestLogger,
+		firewall.NewSessionMetadataEnforcer(g.sessionDB),
+		firewall.NewThirdPartyEnforcer(g.rpcProxy.thirdPartyProofKey),
 	}
 
 	if !g.cfg.Autopilot.Disable {
@@ -1059,7 +1384,7 @@ func (g *LightningTerminal) registerSubDaemonGrpcServers(server *grpc.Server,
 	g.subServerMgr.RegisterRPCServices(server)
 
 	if forLNCSession {
-		litrpc.RegisterStatusServer(server, g.statusMgr)
+		litrpc.RegisterStatusServer(server, g.statusServer())
 	} else {
 		litrpc.RegisterSessionsServer(server, g.sessionRpcServer)
 
@@ -1138,6 +1463,13 @@ func (g *LightningTerminal) RegisterRestSubserver(ctx context.Context,
 		return err
 	}
 
+	err = litrpc.RegisterApiKeysHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
 	return g.subServerMgr.RegisterRestServices(ctx, mux, endpoint, dialOpts)
 }
 
@@ -1315,6 +1647,10 @@ func (g *LightningTerminal) shutdownSubServers() error {
 		}
 	}
 
+	if g.lndReplicas != nil {
+		g.lndReplicas.close()
+	}
+
 	if g.rpcProxy != nil {
 		if err := g.rpcProxy.Stop(); err != nil {
 			log.Errorf("Error stopping rpc proxy: %v", err)
@@ -1322,13 +1658,35 @@ func (g *LightningTerminal) shutdownSubServers() error {
 		}
 	}
 
+	// The listeners may already have been closed while the requests were
+	// drained, so we don't report that as an error.
 	if g.httpServer != nil {
-		if err := g.httpServer.Close(); err != nil {
+		err := g.httpServer.Close()
+		if err != nil && !errors.Is(err, net.ErrClosed) {
 			log.Errorf("Error stopping UI server: %v", err)
 			returnErr = err
 		}
 	}
 
+	if g.metricsServer != nil {
+		if err := g.metricsServer.Close(); err != nil {
+			log.Errorf("Error stopping metrics server: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.tracerProvider != nil {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), defaultServerTimeout,
+		)
+		if err := g.tracerProvider.Shutdown(ctx); err != nil {
+			log.Errorf("Error shutting down tracer provider: %v",
+				err)
+			returnErr = err
+		}
+		cancel()
+	}
+
 	// Do we have any last errors to display? We use an anonymous function,
 	// so we can use return instead of breaking to a label in the default
 	// case.
@@ -1398,14 +1756,60 @@ func (g *LightningTerminal) startMainWebServer() error {
 	if err != nil {
 		return err
 	}
-	staticFileServer := http.FileServer(&ClientRouteWrapper{
-		assets: http.FS(buildDir),
-	})
+	// The embedded assets can only change with a new binary, so the time
+	// LiT started is used as their modification time.
+	routeWrapper := &ClientRouteWrapper{
+		assets:           http.FS(buildDir),
+		fallbackPrefixes: g.cfg.UIFallback.Prefixes,
+		modTime:          time.Now().UTC().Truncate(time.Second),
+	}
+	var fileServer http.Handler = http.FileServer(routeWrapper)
+
+	// Everything is served under the configured path prefix. Without one,
+	// we still accept requests under the prefix the UI was built with, but
+	// don't reject any others as that's how it always worked.
+	pathPrefix, strictPrefix := g.cfg.HTTPPathPrefix, true
+	if pathPrefix == "" {
+		pathPrefix, strictPrefix = strings.TrimSuffix(
+			appFilesPrefix, "/",
+		), false
+	}
+	if g.cfg.UICSP.Enable {
+		fileServer = withCSPNonce(
+			fileServer, routeWrapper, g.cfg.UICSP.Policy,
+		)
+	}
+	staticFileServer := withFallbackStatus(
+		fileServer, routeWrapper, g.cfg.UIFallback.Status,
+	)
+
+	// Browsers can fetch the entry point chunks of the UI while they are
+	// still parsing the index page if we tell them about the chunks early.
+	preload, err := preloadLink(routeWrapper.assets, pathPrefix)
+	if err != nil {
+		log.Debugf("Not sending UI preload hints: %v", err)
+	}
+	staticFileHandler := makeCompressionHandler(withPreloadHints(
+		withAssetETags(staticFileServer, routeWrapper), routeWrapper,
+		preload,
+	))
 
 	// Both gRPC (web) and static file requests will come into through the
 	// main UI HTTP server. We use this simple switching handler to send the
 	// requests to the correct implementation.
 	httpHandler := func(resp http.ResponseWriter, req *http.Request) {
+		// Browser based clients can store their macaroon in a cookie
+		// that can't be accessed by JavaScript. We turn it into the
+		// header the proxy expects before doing anything else.
+		g.applyMacaroonCookie(req)
+
+		// The health report is served without any credentials, so
+		// load balancers can poll it.
+		if req.URL.Path == healthPath {
+			g.rpcProxy.serveHealth(resp, req)
+			return
+		}
+
 		// If this is some kind of gRPC, gRPC Web or REST call that
 		// should go to lnd or one of the daemons, pass it to the proxy
 		// that handles all those calls.
@@ -1446,10 +1850,9 @@ func (g *LightningTerminal) startMainWebServer() error {
 			resp.Header().Set("Cache-Control", "max-age=31536000")
 		}
 
-		// Transfer static files using gzip to save up to 70% of
+		// Transfer static files compressed to save up to 70% of
 		// bandwidth.
-		gzipHandler := makeGzipHandler(staticFileServer.ServeHTTP)
-		gzipHandler(resp, req)
+		staticFileHandler.ServeHTTP(resp, req)
 	}
 
 	// Create and start our HTTPS server now that will handle both gRPC web
@@ -1468,18 +1871,68 @@ func (g *LightningTerminal) startMainWebServer() error {
 		IdleTimeout:       0,
 		ReadTimeout:       0,
 		ReadHeaderTimeout: defaultServerTimeout,
-		Handler:           http.HandlerFunc(httpHandler),
+		Handler: withPathPrefix(
+			withMaxRequestSize(
+				http.HandlerFunc(httpHandler),
+				g.cfg.RestMaxRequestSize,
+			), pathPrefix, strictPrefix,
+		),
+	}
+	if g.cfg.HTTP2.enabled() {
+		g.cfg.HTTP2.configureServer(g.httpServer, func(reason string) {
+			if g.rpcProxy.metrics != nil {
+				g.rpcProxy.metrics.observeHTTP2Abuse(reason)
+			}
+		})
 	}
 	httpListener, err := net.Listen("tcp", g.cfg.HTTPSListen)
 	if err != nil {
 		return fmt.Errorf("unable to listen on %v: %v",
 			g.cfg.HTTPSListen, err)
 	}
-	tlsConfig, err := buildTLSConfigForHttp2(g.cfg)
+
+	// The connection limits are shared by both listeners, so a client
+	// can't get around them by using the other one.
+	var counter *connCounter
+	if g.cfg.MaxConnsPerIP != 0 {
+		counter = newConnCounter(
+			g.cfg.MaxConnsPerIP, g.cfg.trustedProxies,
+		)
+		httpListener = newConnLimitListener(httpListener, counter)
+	}
+
+	tlsConfig, tlsReloader, err := buildTLSConfigForHttp2(g.cfg)
 	if err != nil {
 		return fmt.Errorf("unable to create TLS config: %v", err)
 	}
+	g.rpcProxy.tlsReloader = tlsReloader
+
+	// The REST proxy forwards its requests to this listener, so it needs
+	// a trusted client certificate if one is required.
+	if g.cfg.LitClientCAPath != "" {
+		g.restClientCert, err = newRESTClientCert()
+		if err != nil {
+			return fmt.Errorf("unable to create REST proxy client "+
+				"certificate: %v", err)
+		}
+		tlsConfig.ClientCAs.AddCert(g.restClientCert.Leaf)
+	}
+	g.certChain.setTLSConfig(tlsConfig, g.cfg.LetsEncryptHost)
 	tlsListener := tls.NewListener(httpListener, tlsConfig)
+	g.rpcProxy.listeners.add(newListenerInfo(
+		"https", httpListener.Addr(), webProtocols(g.cfg), true,
+		webAuthPolicy(g.cfg, true),
+	))
+
+	if g.cfg.LetsEncrypt {
+		g.rpcProxy.listeners.add(&listenerInfo{
+			name:       "letsencrypt",
+			network:    "tcp",
+			addr:       g.cfg.LetsEncryptListen,
+			protocols:  []string{"acme-http-01"},
+			authPolicy: "none",
+		})
+	}
 
 	g.wg.Add(1)
 	go func() {
@@ -1500,6 +1953,15 @@ func (g *LightningTerminal) startMainWebServer() error {
 			return fmt.Errorf("unable to listen on %v: %v",
 				g.cfg.HTTPListen, err)
 		}
+		if counter != nil {
+			insecureListener = newConnLimitListener(
+				insecureListener, counter,
+			)
+		}
+		g.rpcProxy.listeners.add(newListenerInfo(
+			"http", insecureListener.Addr(), webProtocols(g.cfg),
+			false, webAuthPolicy(g.cfg, false),
+		))
 
 		g.wg.Add(1)
 		go func() {
@@ -1524,13 +1986,11 @@ func (g *LightningTerminal) createRESTProxy() error {
 	// The default JSON marshaler of the REST proxy only sets OrigName to
 	// true, which instructs it to use the same field names as specified in
 	// the proto file and not switch to camel case. What we also want is
-	// that the marshaler prints all values, even if they are falsey.
+	// that the marshaler prints all values, even if they are falsey. Both
+	// can be changed in the config to match the expectations of clients.
 	customMarshalerOption := restProxy.WithMarshalerOption(
 		restProxy.MIMEWildcard, &restProxy.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				UseProtoNames:   true,
-				EmitUnpopulated: true,
-			},
+			MarshalOptions: g.cfg.RESTJSON.marshalOptions(),
 		},
 	)
 
@@ -1538,6 +1998,12 @@ func (g *LightningTerminal) createRESTProxy() error {
 	// we'll decode to allow clients to hit endpoints which return more data
 	// such as the DescribeGraph call. We set this to 200MiB atm. Should be
 	// the same value as maxMsgRecvSize in lnd/cmd/lncli/main.go.
+	restTLSConfig := &tls.Config{InsecureSkipVerify: true}
+	if g.restClientCert != nil {
+		restTLSConfig.Certificates = []tls.Certificate{
+			*g.restClientCert,
+		}
+	}
 	restDialOpts := []grpc.DialOption{
 		// We are forwarding the requests directly to the address of our
 		// own local listener. To not need to mess with the TLS
@@ -1546,7 +2012,7 @@ func (g *LightningTerminal) createRESTProxy() error {
 		// Injecting a malicious hostname into the listener address will
 		// result in an error on startup so this should be quite safe.
 		grpc.WithTransportCredentials(credentials.NewTLS(
-			&tls.Config{InsecureSkipVerify: true},
+			restTLSConfig,
 		)),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200),
@@ -1562,7 +2028,14 @@ func (g *LightningTerminal) createRESTProxy() error {
 	// we direct LND to connect to its loopback address rather than a
 	// wildcard to prevent certificate issues when accessing the proxy
 	// externally.
-	restMux := restProxy.NewServeMux(customMarshalerOption)
+	// The gRPC peer of a REST request is the REST proxy itself, so the IP
+	// of the HTTP client is passed on for the allowed IP ranges caveat.
+	restMux := restProxy.NewServeMux(
+		customMarshalerOption,
+		restProxy.WithMetadata(g.rpcProxy.clientAddrs.restMetadata),
+		restProxy.WithIncomingHeaderMatcher(restHeaderMatcher),
+		restProxy.WithOutgoingHeaderMatcher(restOutgoingHeaderMatcher),
+	)
 	ctx, cancel := context.WithCancel(context.Background())
 	g.restCancel = cancel
 
@@ -1570,11 +2043,13 @@ func (g *LightningTerminal) createRESTProxy() error {
 	// through the following chain:
 	// req ---> CORS handler --> WS proxy ---> REST proxy --> gRPC endpoint
 	// where gRPC endpoint is our main HTTP(S) listener again.
-	restHandler := lnrpc.NewWebSocketProxy(
-		restMux, log, g.cfg.Lnd.WSPingInterval, g.cfg.Lnd.WSPongWait,
-		lnrpc.LndClientStreamingURIs,
+	restHandler := newRESTWebSocketProxy(
+		restMux, g.cfg.RestCORS, g.cfg.Lnd.WSPingInterval,
+		g.cfg.Lnd.WSPongWait, lnrpc.LndClientStreamingURIs,
+	)
+	g.restHandler = allowCORS(
+		makeCompressionHandler(restHandler), g.cfg.RestCORS,
 	)
-	g.restHandler = allowCORS(restHandler, g.cfg.RestCORS)
 
 	// First register all lnd handlers. This will make it possible to speak
 	// REST over the main RPC listener port in both remote and integrated
@@ -1615,6 +2090,27 @@ func (g *LightningTerminal) validateSuperMacaroon(ctx context.Context,
 			"connected to lnd, please wait")
 	}
 
+	// We check the expiry of the macaroon ourselves first, so an expired
+	// session macaroon is rejected with a clear error instead of the
+	// generic validation failure returned by lnd.
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(superMacaroon); err != nil {
+		return fmt.Errorf("unable to decode macaroon: %v", err)
+	}
+	if err := session.CheckMacaroonExpiry(mac, time.Now()); err != nil {
+		return err
+	}
+
+	// lnd doesn't know about root keys that were rotated out but
+	// recreated since, so we reject macaroons baked with them ourselves.
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return err
+	}
+	if err := g.superMacKeys.checkRootKey(rootKeyID); err != nil {
+		return err
+	}
+
 	// Convert permissions to the form that lndClient will accept.
 	permissions := make(
 		[]lndclient.MacaroonPermission, len(requiredPermissions),
@@ -1740,22 +2236,22 @@ func allowCORS(handler http.Handler, origins []string) http.Handler {
 			return
 		}
 
-		// Set the static header fields first.
+		// Set the static header fields first. The allowed origin
+		// depends on the request, so caches must tell them apart.
 		w.Header().Set(
-			allowHeaders,
-			"Content-Type, Accept, Grpc-Metadata-Macaroon",
+			allowHeaders, "Content-Type, Accept, "+
+				"Grpc-Metadata-Macaroon, Authorization, "+
+				restHeaderRequestID,
+		)
+		w.Header().Set(
+			"Access-Control-Expose-Headers", restHeaderRequestID,
 		)
 		w.Header().Set(allowMethods, "GET, POST, DELETE")
+		w.Header().Add("Vary", "Origin")
 
-		// Either we allow all origins or the incoming request matches
-		// a specific origin in our list of allowed origins.
-		for _, allowedOrigin := range origins {
-			if allowedOrigin == "*" || origin == allowedOrigin {
-				// Only set allowed origin to requested origin.
-				w.Header().Set(allowOrigin, origin)
-
-				break
-			}
+		// Only set allowed origin to requested origin.
+		if isAllowedOrigin(origins, origin) {
+			w.Header().Set(allowOrigin, origin)
 		}
 
 		// For a pre-flight request we only need to send the headers
@@ -1770,6 +2266,18 @@ func allowCORS(handler http.Handler, origins []string) http.Handler {
 	})
 }
 
+// isAllowedOrigin returns true if either all origins are allowed or the given
+// origin matches a specific origin in the list of allowed origins.
+func isAllowedOrigin(origins []string, origin string) bool {
+	for _, allowedOrigin := range origins {
+		if allowedOrigin == "*" || origin == allowedOrigin {
+			return true
+		}
+	}
+
+	return false
+}
+
 // showStartupInfo shows useful information to the user to easily access the
 // web UI that was just started.
 func (g *LightningTerminal) showStartupInfo() error {
@@ -1796,9 +2304,20 @@ func (g *LightningTerminal) showStartupInfo() error {
 		// We try to query GetInfo on the remote node to find out the
 		// alias. But the wallet might be locked.
 		host, network, tlsPath, macPath, _ := g.cfg.lndConnectParams()
+		clientOptions := []lndclient.BasicClientOption{
+			lndclient.MacFilename(filepath.Base(macPath)),
+		}
+		if g.cfg.Remote.Lnd.TLSVerifyMode() ==
+			subservers.TLSVerifyInsecure {
+
+			clientOptions = append(
+				clientOptions, lndclient.Insecure(),
+			)
+		}
+
 		basicClient, err := lndclient.NewBasicClient(
 			host, tlsPath, filepath.Dir(macPath), string(network),
-			lndclient.MacFilename(filepath.Base(macPath)),
+			clientOptions...,
 		)
 		if err != nil {
 			return fmt.Errorf("error querying remote node: %v", err)
@@ -1869,25 +2388,157 @@ func (g *LightningTerminal) showStartupInfo() error {
 // http server
 type ClientRouteWrapper struct {
 	assets http.FileSystem
+
+	// fallbackPrefixes are the path prefixes of the client side routes. If
+	// set, only unknown paths with one of these prefixes are answered with
+	// index.html, all other unknown paths result in a 404.
+	fallbackPrefixes []string
+
+	// modTime is reported as the modification time of all assets except
+	// index.html, so the file server can answer If-Modified-Since
+	// requests. It is ignored if it is zero.
+	modTime time.Time
 }
 
 // Open intercepts requests to open files. If the file does not exist and there
 // is no file extension, then assume this is a client side route and return the
 // contents of index.html
 func (i *ClientRouteWrapper) Open(name string) (http.File, error) {
+	ret, fallback, err := i.open(name)
+	if fallback {
+		return i.assets.Open("/index.html")
+	}
+
+	// The index page is the entry point of the UI, so it must always be
+	// fetched fresh.
+	switch toLocalName(name) {
+	case "/", "/index.html":
+		return ret, err
+	}
+
+	if err != nil || i.modTime.IsZero() {
+		return ret, err
+	}
+
+	return &assetFile{File: ret, modTime: i.modTime}, nil
+}
+
+// isFallback returns true if a request for the given path is answered with
+// index.html because the path is a client side route.
+func (i *ClientRouteWrapper) isFallback(name string) bool {
+	ret, fallback, _ := i.open(name)
+	if ret != nil {
+		_ = ret.Close()
+	}
+
+	return fallback
+}
+
+// open opens the file with the given name. If the file doesn't exist and the
+// name is a client side route, true is returned to signal that index.html
+// should be served instead.
+func (i *ClientRouteWrapper) open(name string) (http.File, bool, error) {
+	localName := toLocalName(name)
+	ret, err := i.assets.Open(localName)
+	if !os.IsNotExist(err) || filepath.Ext(localName) != "" {
+		return ret, false, err
+	}
+
+	if len(i.fallbackPrefixes) == 0 {
+		return nil, true, err
+	}
+
+	for _, prefix := range i.fallbackPrefixes {
+		if strings.HasPrefix(localName, prefix) {
+			return nil, true, err
+		}
+	}
+
+	return nil, false, err
+}
+
+// servesIndex returns true if a request for the given path is answered with
+// index.html, either because the index itself is requested or because the
+// path is a client side route.
+func (i *ClientRouteWrapper) servesIndex(name string) bool {
+	switch toLocalName(name) {
+	case "/", "/index.html":
+		return true
+
+	default:
+		return i.isFallback(name)
+	}
+}
+
+// toLocalName converts the path of a request into the name of the file in the
+// embedded UI assets.
+func toLocalName(name string) string {
 	localName := name
 
 	// The file prefix can be overwritten during build time.
 	if appFilesPrefix != "" {
 		localName = strings.Replace(name, appFilesPrefix, "/", 1)
 	}
-	localName = strings.ReplaceAll(localName, "//", "/")
-	ret, err := i.assets.Open(localName)
-	if !os.IsNotExist(err) || filepath.Ext(localName) != "" {
-		return ret, err
+
+	return strings.ReplaceAll(localName, "//", "/")
+}
+
+// fallbackStatusWriter is a http.ResponseWriter that replaces the 200 status
+// code of a response with another status code.
+type fallbackStatusWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader writes the header with the replaced status code.
+//
+// NOTE: this is part of the http.ResponseWriter interface.
+func (w *fallbackStatusWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if code == http.StatusOK {
+		code = w.status
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the body of the response, with the replaced status code if the
+// header wasn't written yet.
+//
+// NOTE: this is part of the http.ResponseWriter interface.
+func (w *fallbackStatusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
 	}
 
-	return i.assets.Open("/index.html")
+	return w.ResponseWriter.Write(b)
+}
+
+// withFallbackStatus returns a handler that answers requests for client side
+// routes, which are served with index.html, with the given status code instead
+// of 200.
+func withFallbackStatus(next http.Handler, routes *ClientRouteWrapper,
+	status int) http.Handler {
+
+	if status == http.StatusOK {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if routes.isFallback(r.URL.Path) {
+			w = &fallbackStatusWriter{
+				ResponseWriter: w,
+				status:         status,
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
 }
 
 // toLocalAddress converts an address that is meant as a wildcard listening
@@ -1905,6 +2556,38 @@ func isRESTRequest(req *http.Request) bool {
 	return patternRESTRequest.MatchString(req.URL.Path)
 }
 
+// applyMacaroonCookie copies the macaroon from the configured cookie into the
+// header that is used for gRPC web or REST requests. The cookie is only used
+// for requests that were received over TLS and that don't already carry a
+// macaroon, basic auth or API key header.
+func (g *LightningTerminal) applyMacaroonCookie(req *http.Request) {
+	if g.cfg.MacaroonCookie == "" || req.TLS == nil {
+		return
+	}
+
+	// The grpc-gateway only forwards headers with the metadata prefix to
+	// the gRPC server, while gRPC web forwards all headers as they are.
+	header := HeaderMacaroon
+	if isRESTRequest(req) {
+		header = restMetadataPrefix + HeaderMacaroon
+	}
+
+	if req.Header.Get(HeaderMacaroon) != "" ||
+		req.Header.Get(restMetadataPrefix+HeaderMacaroon) != "" ||
+		req.Header.Get("Authorization") != "" ||
+		req.Header.Get(HeaderAPIKey) != "" {
+
+		return
+	}
+
+	cookie, err := req.Cookie(g.cfg.MacaroonCookie)
+	if err != nil || cookie.Value == "" {
+		return
+	}
+
+	req.Header.Set(header, cookie.Value)
+}
+
 // randId generates a random character string of length n.
 func randId(n int) string {
 	var letters = []rune(
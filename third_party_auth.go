@@ -0,0 +1,329 @@
+package terminal
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// HeaderDischarge is the metadata field name that is used to send the
+	// hex encoded discharge macaroons of the third party caveats of the
+	// request's macaroon. It can be set multiple times.
+	HeaderDischarge = "Discharge"
+
+	// thirdPartyProofKeyLen is the length of the key that signs the
+	// proof caveats of macaroons with verified third party caveats.
+	thirdPartyProofKeyLen = 32
+
+	// thirdPartyProofTTL is how long a proof caveat is valid for. The
+	// macaroon with the proof is only sent to the backend of the request,
+	// so it only needs to be valid until the backend checked it.
+	thirdPartyProofTTL = time.Minute
+
+	// minThirdPartyRootKeyLen is the minimum length of the root key of an
+	// external authorization server.
+	minThirdPartyRootKeyLen = 32
+)
+
+// ThirdPartyAuthConfig holds the external authorization servers that may
+// discharge the third party caveats of session and super macaroons.
+type ThirdPartyAuthConfig struct {
+	Servers []string `long:"server" description:"An external authorization server that may discharge third party caveats, in the form <hex_root_key>@<location>, for example 00ff...@https://auth.example.com. The server must bake its discharge macaroons with the root key, which must be at least 32 bytes long. Can be specified multiple times."`
+
+	// rootKeys are the parsed root keys of the servers, by location.
+	rootKeys map[string][]byte
+}
+
+// validate checks and parses the external authorization servers.
+func (c *ThirdPartyAuthConfig) validate() error {
+	c.rootKeys = make(map[string][]byte, len(c.Servers))
+	for _, server := range c.Servers {
+		keyHex, location, ok := strings.Cut(server, "@")
+		if !ok {
+			return errors.New("servers must be in the form " +
+				"<hex_root_key>@<location>")
+		}
+
+		rootKey, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return fmt.Errorf("invalid root key of server %s: %v",
+				location, err)
+		}
+		if len(rootKey) < minThirdPartyRootKeyLen {
+			return fmt.Errorf("root key of server %s must be at "+
+				"least %d bytes long", location,
+				minThirdPartyRootKeyLen)
+		}
+
+		if location == "" ||
+			strings.IndexFunc(location, unicode.IsSpace) >= 0 {
+
+			return fmt.Errorf("location of server %q must be set "+
+				"and must not contain white space", location)
+		}
+
+		if _, ok := c.rootKeys[location]; ok {
+			return fmt.Errorf("server %s is configured more than "+
+				"once", location)
+		}
+		c.rootKeys[location] = rootKey
+	}
+
+	return nil
+}
+
+// thirdPartyCaveats converts the given third party caveats of a request into
+// macaroon caveats. Only caveats of the configured servers are accepted,
+// since LiT couldn't verify the discharges of any other server.
+func (c *ThirdPartyAuthConfig) thirdPartyCaveats(
+	rpcCaveats []*litrpc.ThirdPartyCaveat) ([]macaroon.Caveat, error) {
+
+	caveats := make([]macaroon.Caveat, 0, len(rpcCaveats))
+	for _, rpcCaveat := range rpcCaveats {
+		caveat := &firewall.ThirdPartyCaveat{
+			Location:  rpcCaveat.Location,
+			Predicate: rpcCaveat.Predicate,
+		}
+		if err := caveat.Validate(); err != nil {
+			return nil, status.Error(
+				codes.InvalidArgument, err.Error(),
+			)
+		}
+
+		if _, ok := c.rootKeys[caveat.Location]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"unknown third party location %s",
+				caveat.Location)
+		}
+
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(firewall.ThirdPartyToCaveat(caveat)),
+		})
+	}
+
+	return caveats, nil
+}
+
+// checkThirdPartyCaveats makes sure every third party caveat of the request's
+// macaroon is discharged by one of the discharge macaroons sent with the
+// request. Requests that aren't are rejected with PermissionDenied.
+func (p *rpcProxy) checkThirdPartyCaveats(ctx context.Context) error {
+	mac, err := macaroonFromContext(ctx)
+	if err != nil || mac == nil {
+		// An invalid macaroon is rejected by the authentication, so
+		// there is nothing to check here.
+		return nil
+	}
+
+	var caveats []*firewall.ThirdPartyCaveat
+	for _, caveat := range mac.Caveats() {
+		c, err := firewall.ParseThirdPartyCaveat(string(caveat.Id))
+		if errors.Is(err, firewall.ErrNoThirdPartyCaveat) {
+			continue
+		} else if err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		caveats = append(caveats, c)
+	}
+	if len(caveats) == 0 {
+		return nil
+	}
+
+	discharges, err := dischargesFromContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "invalid "+
+			"discharge macaroon: %v", err)
+	}
+
+	for _, caveat := range caveats {
+		err := verifyDischarge(
+			p.cfg.ThirdPartyAuth.rootKeys, mac, caveat, discharges,
+			time.Now(),
+		)
+		if err != nil {
+			return status.Errorf(codes.PermissionDenied, "third "+
+				"party caveat of %s not discharged: %v",
+				caveat.Location, err)
+		}
+	}
+
+	return nil
+}
+
+// newThirdPartyProofKey creates a new random key to sign proof caveats with.
+func newThirdPartyProofKey() ([]byte, error) {
+	key := make([]byte, thirdPartyProofKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// addThirdPartyProof adds a proof caveat to the macaroon in the outgoing
+// metadata if it is the macaroon the client sent and it has third party
+// caveats. The discharges of those were already verified by
+// checkThirdPartyCaveats, and the proof lets lnd's third party middleware
+// accept the macaroon. Macaroons that LiT itself put into the outgoing
+// metadata don't get a proof, since their caveats weren't checked.
+func addThirdPartyProof(in, out metadata.MD, key []byte) error {
+	inMacs, outMacs := in.Get(HeaderMacaroon), out.Get(HeaderMacaroon)
+	if len(inMacs) != 1 || len(outMacs) != 1 || inMacs[0] != outMacs[0] {
+		return nil
+	}
+
+	mac, err := session.ParseMacaroon(outMacs[0])
+	if err != nil {
+		// An invalid macaroon is rejected by the authentication, so
+		// there is nothing to prove here.
+		return nil
+	}
+
+	hasThirdParty := false
+	for _, caveat := range mac.Caveats() {
+		_, err := firewall.ParseThirdPartyCaveat(string(caveat.Id))
+		if err == nil {
+			hasThirdParty = true
+			break
+		}
+	}
+	if !hasThirdParty {
+		return nil
+	}
+
+	err = firewall.AddThirdPartyProof(
+		mac, key, time.Now().Add(thirdPartyProofTTL),
+	)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to add third "+
+			"party proof: %v", err)
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to encode "+
+			"macaroon: %v", err)
+	}
+	out.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+
+	return nil
+}
+
+// dischargesFromContext returns the discharge macaroons in the discharge
+// header of the request in the given context.
+func dischargesFromContext(ctx context.Context) ([]*macaroon.Macaroon,
+	error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(HeaderDischarge)
+	discharges := make([]*macaroon.Macaroon, 0, len(values))
+	for _, value := range values {
+		discharge, err := session.ParseMacaroon(value)
+		if err != nil {
+			return nil, err
+		}
+
+		discharges = append(discharges, discharge)
+	}
+
+	return discharges, nil
+}
+
+// verifyDischarge makes sure one of the given discharge macaroons discharges
+// the third party caveat of the primary macaroon. The discharge must be baked
+// with the root key of the caveat's location, be bound to the primary
+// macaroon and not be expired at the given time.
+func verifyDischarge(rootKeys map[string][]byte, primary *macaroon.Macaroon,
+	caveat *firewall.ThirdPartyCaveat, discharges []*macaroon.Macaroon,
+	now time.Time) error {
+
+	rootKey, ok := rootKeys[caveat.Location]
+	if !ok {
+		return errors.New("unknown third party location")
+	}
+
+	for _, discharge := range discharges {
+		if discharge.Location() != caveat.Location ||
+			string(discharge.Id()) != caveat.Predicate {
+
+			continue
+		}
+
+		return checkDischarge(rootKey, primary, discharge, now)
+	}
+
+	return errors.New("no discharge macaroon found")
+}
+
+// checkDischarge verifies the signature of the given discharge macaroon, which
+// must be bound to the primary macaroon, and its caveats. Only time-before
+// caveats are supported in discharge macaroons.
+func checkDischarge(rootKey []byte, primary, discharge *macaroon.Macaroon,
+	now time.Time) error {
+
+	// We bake the discharge macaroon again from its root key and caveats
+	// and bind it to the primary macaroon. Its signature must then be the
+	// same as the one we received.
+	expected, err := macaroon.New(
+		rootKey, discharge.Id(), discharge.Location(),
+		discharge.Version(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, caveat := range discharge.Caveats() {
+		if caveat.VerificationId != nil {
+			return errors.New("discharge macaroons must not have " +
+				"third party caveats")
+		}
+
+		err := expected.AddFirstPartyCaveat(caveat.Id)
+		if err != nil {
+			return err
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil || cond != checkers.CondTimeBefore {
+			return fmt.Errorf("unsupported discharge caveat %q",
+				string(caveat.Id))
+		}
+
+		expiry, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return fmt.Errorf("invalid caveat %q: %v",
+				string(caveat.Id), err)
+		}
+		if !now.Before(expiry) {
+			return errors.New("discharge macaroon expired")
+		}
+	}
+
+	expected.Bind(primary.Signature())
+	if !hmac.Equal(expected.Signature(), discharge.Signature()) {
+		return errors.New("invalid discharge macaroon signature")
+	}
+
+	return nil
+}
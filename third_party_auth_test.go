@@ -0,0 +1,141 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestThirdPartyCaveats tests that a macaroon with a third party caveat is only
+// accepted together with a valid discharge macaroon that is bound to it.
+func TestThirdPartyCaveats(t *testing.T) {
+	const location = "https://auth.example.com"
+	rootKey := bytes.Repeat([]byte{1}, minThirdPartyRootKeyLen)
+
+	cfg := &ThirdPartyAuthConfig{
+		Servers: []string{hex.EncodeToString(rootKey) + "@" + location},
+	}
+	require.NoError(t, cfg.validate())
+
+	// Only caveats of configured servers can be added to a macaroon.
+	_, err := cfg.thirdPartyCaveats([]*litrpc.ThirdPartyCaveat{{
+		Location: "https://other.example.com", Predicate: "user = bob",
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	caveats, err := cfg.thirdPartyCaveats([]*litrpc.ThirdPartyCaveat{{
+		Location: location, Predicate: "user = alice",
+	}})
+	require.NoError(t, err)
+
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+	require.NoError(t, mac.AddFirstPartyCaveat(caveats[0].Id))
+
+	// discharge bakes a discharge macaroon with the given key and
+	// expiry, optionally bound to the macaroon.
+	discharge := func(key []byte, expiry time.Time,
+		bind bool) *macaroon.Macaroon {
+
+		d, err := macaroon.New(
+			key, []byte("user = alice"), location,
+			macaroon.LatestVersion,
+		)
+		require.NoError(t, err)
+
+		caveat := checkers.TimeBeforeCaveat(expiry).Condition
+		require.NoError(t, d.AddFirstPartyCaveat([]byte(caveat)))
+
+		if bind {
+			d.Bind(mac.Signature())
+		}
+
+		return d
+	}
+
+	p := &rpcProxy{cfg: &Config{ThirdPartyAuth: cfg}}
+	check := func(discharges ...*macaroon.Macaroon) error {
+		macBytes, err := mac.MarshalBinary()
+		require.NoError(t, err)
+
+		md := metadata.Pairs(
+			HeaderMacaroon, hex.EncodeToString(macBytes),
+		)
+		for _, d := range discharges {
+			dBytes, err := d.MarshalBinary()
+			require.NoError(t, err)
+
+			md.Append(HeaderDischarge, hex.EncodeToString(dBytes))
+		}
+
+		return p.checkThirdPartyCaveats(
+			metadata.NewIncomingContext(context.Background(), md),
+		)
+	}
+
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, check(discharge(rootKey, future, true)))
+
+	// A missing, unbound, expired or wrongly signed discharge is
+	// rejected.
+	otherKey := bytes.Repeat([]byte{2}, minThirdPartyRootKeyLen)
+	for _, discharges := range [][]*macaroon.Macaroon{
+		nil,
+		{discharge(rootKey, future, false)},
+		{discharge(rootKey, time.Now().Add(-time.Hour), true)},
+		{discharge(otherKey, future, true)},
+	} {
+		err := check(discharges...)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+
+	// Requests with macaroons without third party caveats don't need any
+	// discharge.
+	require.NoError(t, p.checkThirdPartyCaveats(context.Background()))
+
+	// Once the discharge was verified, the macaroon that is forwarded to
+	// the backend gets a proof that lnd's third party middleware accepts.
+	proofKey, err := newThirdPartyProofKey()
+	require.NoError(t, err)
+
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+	in := metadata.Pairs(HeaderMacaroon, hex.EncodeToString(macBytes))
+	out := in.Copy()
+	require.NoError(t, addThirdPartyProof(in, out, proofKey))
+
+	proofMac, err := session.ParseMacaroon(out.Get(HeaderMacaroon)[0])
+	require.NoError(t, err)
+	require.Len(t, proofMac.Caveats(), len(mac.Caveats())+1)
+	require.NoError(t, firewall.CheckThirdPartyProof(
+		proofMac, proofKey, time.Now(),
+	))
+	require.Error(t, firewall.CheckThirdPartyProof(mac, proofKey,
+		time.Now()))
+
+	// A macaroon that LiT put into the outgoing metadata itself doesn't
+	// get a proof.
+	out = metadata.Pairs(HeaderMacaroon, hex.EncodeToString(macBytes))
+	require.NoError(t, addThirdPartyProof(metadata.MD{}, out, proofKey))
+	require.Equal(
+		t, hex.EncodeToString(macBytes), out.Get(HeaderMacaroon)[0],
+	)
+
+	// The root keys of the servers must be long enough.
+	cfg = &ThirdPartyAuthConfig{Servers: []string{"00ff@" + location}}
+	require.ErrorContains(t, cfg.validate(), "must be at least 32 bytes")
+}
@@ -0,0 +1,120 @@
+package terminal
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"sync"
+)
+
+var (
+	// errTLSNotReady is returned if the TLS cert chain is queried before
+	// the main web server has set up its TLS config.
+	errTLSNotReady = errors.New("TLS config of the main web server not " +
+		"yet initialized")
+
+	// ecdsaHello is the client hello that is used to look up the
+	// certificate served by a TLS config that selects its certificate
+	// dynamically (for example Let's Encrypt). It advertises ECDSA support
+	// to make sure we look up the same certificate that modern clients
+	// are served and don't cause a new certificate to be requested.
+	ecdsaHello = tls.ClientHelloInfo{
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		},
+		SupportedCurves: []tls.CurveID{tls.CurveP256},
+		SignatureSchemes: []tls.SignatureScheme{
+			tls.ECDSAWithP256AndSHA256,
+		},
+	}
+)
+
+// tlsCertChain keeps track of the TLS certificate chain that LiT presents on
+// its main HTTPS listener. The encoded chain is cached and only re-encoded if
+// the leaf certificate changes, for example because it was renewed.
+type tlsCertChain struct {
+	mu sync.Mutex
+
+	tlsConfig  *tls.Config
+	serverName string
+
+	// leaf is the DER encoded leaf certificate of the cached chain.
+	leaf []byte
+
+	// certs is the cached DER encoded chain, leaf first.
+	certs [][]byte
+
+	// pemChain is the cached PEM encoded chain, leaf first.
+	pemChain string
+}
+
+// setTLSConfig sets the TLS config that the main HTTPS listener uses and the
+// server name that clients are expected to connect to.
+func (c *tlsCertChain) setTLSConfig(tlsConfig *tls.Config,
+	serverName string) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tlsConfig = tlsConfig
+	c.serverName = serverName
+}
+
+// chain returns the DER and PEM encoded certificate chain that is currently
+// presented to clients, leaf first.
+func (c *tlsCertChain) chain() ([][]byte, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tlsConfig == nil {
+		return nil, "", errTLSNotReady
+	}
+
+	var certs [][]byte
+	switch {
+	case c.tlsConfig.GetCertificate != nil:
+		hello := ecdsaHello
+		hello.ServerName = c.serverName
+
+		tlsCert, err := c.tlsConfig.GetCertificate(&hello)
+		if err != nil {
+			return nil, "", err
+		}
+		certs = tlsCert.Certificate
+
+	case len(c.tlsConfig.Certificates) > 0:
+		certs = c.tlsConfig.Certificates[0].Certificate
+	}
+
+	if len(certs) == 0 {
+		return nil, "", errors.New("no TLS certificate configured")
+	}
+
+	// The chain only changes together with the leaf, so there's nothing
+	// to do if we already encoded the chain of this leaf.
+	if bytes.Equal(c.leaf, certs[0]) {
+		return c.certs, c.pemChain, nil
+	}
+
+	var pemChain bytes.Buffer
+	for _, der := range certs {
+		err := pem.Encode(&pemChain, &pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: der,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if c.leaf != nil {
+		log.Infof("TLS certificate changed, updating cached cert chain")
+	}
+
+	c.leaf = certs[0]
+	c.certs = certs
+	c.pemChain = pemChain.String()
+
+	return c.certs, c.pemChain, nil
+}
@@ -0,0 +1,163 @@
+package terminal
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/cert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tlsCertReloader serves the TLS certificate of the main HTTPS listener from
+// the configured files and can re-read them while the listener is running.
+// Only new TLS handshakes pick up a reloaded certificate, existing connections
+// keep the one they were established with.
+type tlsCertReloader struct {
+	certPath string
+	keyPath  string
+
+	// reloadMu makes sure only one reload reads the files at a time.
+	reloadMu sync.Mutex
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newTLSCertReloader creates a reloader for the given certificate and key
+// files and loads them for the first time.
+func newTLSCertReloader(certPath, keyPath string) (*tlsCertReloader, error) {
+	r := &tlsCertReloader{
+		certPath: certPath,
+		keyPath:  keyPath,
+	}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// reload reads the certificate and key files again and serves the result to
+// all new TLS handshakes. If the files can't be loaded, the current
+// certificate stays in use.
+func (r *tlsCertReloader) reload() (*tls.Certificate, error) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	tlsCert, x509Cert, err := cert.LoadCert(r.certPath, r.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading TLS server keys: %v",
+			err)
+	}
+	tlsCert.Leaf = x509Cert
+
+	r.mu.Lock()
+	reloaded := r.cert != nil
+	r.cert = &tlsCert
+	r.mu.Unlock()
+
+	if reloaded {
+		log.Infof("Reloaded TLS certificate, serving certificate "+
+			"with fingerprint %x valid until %v",
+			sha256.Sum256(x509Cert.Raw), x509Cert.NotAfter)
+	}
+
+	return &tlsCert, nil
+}
+
+// getCertificate returns the certificate that is currently served.
+//
+// NOTE: this has the signature of tls.Config.GetCertificate.
+func (r *tlsCertReloader) getCertificate(
+	_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// watchTLSReloadSignal reloads the TLS certificate of the main HTTPS listener
+// with the given reloader whenever SIGHUP is received. If the certificate
+// isn't loaded from files, the reloader is nil and the signal is ignored. The
+// returned function stops watching.
+func watchTLSReloadSignal(reloader *tlsCertReloader) func() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-hup:
+			case <-quit:
+				return
+			}
+
+			if reloader == nil {
+				log.Infof("Received SIGHUP, but the TLS " +
+					"certificate is not loaded from files")
+
+				continue
+			}
+
+			log.Infof("Received SIGHUP, reloading TLS certificate")
+
+			if _, err := reloader.reload(); err != nil {
+				log.Errorf("Unable to reload TLS certificate: "+
+					"%v", err)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(hup)
+		close(quit)
+		wg.Wait()
+	}
+}
+
+// errTLSNotReloadable is returned if the TLS certificate of the main HTTPS
+// listener isn't loaded from files.
+var errTLSNotReloadable = status.Error(
+	codes.FailedPrecondition, "TLS certificate is not loaded from files, "+
+		"Let's Encrypt certificates are renewed automatically",
+)
+
+// ReloadTLS re-reads the TLS certificate and key of the main HTTPS listener
+// from the configured files.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) ReloadTLS(_ context.Context,
+	_ *litrpc.ReloadTLSRequest) (*litrpc.ReloadTLSResponse, error) {
+
+	if p.tlsReloader == nil {
+		return nil, errTLSNotReloadable
+	}
+
+	tlsCert, err := p.tlsReloader.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := sha256.Sum256(tlsCert.Leaf.Raw)
+
+	return &litrpc.ReloadTLSResponse{
+		Sha256Fingerprint: hex.EncodeToString(fingerprint[:]),
+		NotAfter:          tlsCert.Leaf.NotAfter.Unix(),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package terminal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/cert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertPair creates a new self-signed certificate and key and writes
+// them to the given paths, replacing any existing files.
+func writeTestCertPair(t *testing.T, certPath, keyPath string) {
+	certBytes, keyBytes, err := cert.GenCertPair(
+		defaultSelfSignedCertOrganization, nil, nil, false,
+		DefaultAutogenValidity,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, cert.WriteCertPair(
+		certPath, keyPath, certBytes, keyBytes,
+	))
+}
+
+// getServerCertificates completes a TLS handshake with the given address and
+// returns the certificates the server presented.
+func getServerCertificates(t *testing.T,
+	addr string) ([]*x509.Certificate, *tls.Conn) {
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		InsecureSkipVerify: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn.ConnectionState().PeerCertificates, conn
+}
+
+// TestTLSCertReload tests that a TLS certificate that is swapped on disk is
+// only served to new handshakes once it was reloaded, and that a certificate
+// that can't be loaded doesn't replace the current one.
+func TestTLSCertReload(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, DefaultTLSCertFilename)
+	keyPath := filepath.Join(dir, DefaultTLSKeyFilename)
+
+	writeTestCertPair(t, certPath, keyPath)
+
+	reloader, err := newTLSCertReloader(certPath, keyPath)
+	require.NoError(t, err)
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		GetCertificate: reloader.getCertificate,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
+
+	// Complete the handshake of every connection, so the clients can
+	// inspect the server's certificate.
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Read(make([]byte, 1))
+			}(conn)
+		}
+	}()
+
+	addr := lis.Addr().String()
+	oldCerts, oldConn := getServerCertificates(t, addr)
+	require.Len(t, oldCerts, 1)
+
+	// Swapping the files alone doesn't change the served certificate.
+	writeTestCertPair(t, certPath, keyPath)
+
+	certs, _ := getServerCertificates(t, addr)
+	require.Equal(t, oldCerts[0].Raw, certs[0].Raw)
+
+	// Once reloaded, new handshakes are served the new certificate, while
+	// the existing connection keeps the old one.
+	newCert, err := reloader.reload()
+	require.NoError(t, err)
+	require.NotEqual(t, oldCerts[0].Raw, newCert.Leaf.Raw)
+
+	certs, _ = getServerCertificates(t, addr)
+	require.Equal(t, newCert.Leaf.Raw, certs[0].Raw)
+	require.Equal(
+		t, oldCerts[0].Raw,
+		oldConn.ConnectionState().PeerCertificates[0].Raw,
+	)
+
+	// A broken certificate file is rejected and the current certificate
+	// stays in use.
+	require.NoError(t, os.WriteFile(certPath, []byte("broken"), 0600))
+
+	_, err = reloader.reload()
+	require.Error(t, err)
+
+	certs, _ = getServerCertificates(t, addr)
+	require.Equal(t, newCert.Leaf.Raw, certs[0].Raw)
+}
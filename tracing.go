@@ -0,0 +1,224 @@
+package terminal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// tracerName is the name of the OpenTelemetry tracer that is used to
+	// create the spans of the RPC proxy.
+	tracerName = "github.com/lightninglabs/lightning-terminal"
+
+	// tracingServiceName is the service name litd reports its spans
+	// under.
+	tracingServiceName = "litd"
+)
+
+// newTracerProvider creates an OpenTelemetry tracer provider that exports all
+// spans to the OTLP gRPC collector at the given endpoint. The provider and a
+// W3C trace context propagator are also registered as the global ones.
+func newTracerProvider(ctx context.Context, endpoint string,
+	insecure bool) (*sdktrace.TracerProvider, error) {
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(endpoint),
+	}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", tracingServiceName),
+			attribute.String("service.version", Version()),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider, nil
+}
+
+// metadataCarrier adapts gRPC metadata so it can be used as a carrier for the
+// OpenTelemetry trace context propagation.
+type metadataCarrier metadata.MD
+
+// A compile-time check to ensure that metadataCarrier implements the
+// propagation.TextMapCarrier interface.
+var _ propagation.TextMapCarrier = metadataCarrier(nil)
+
+// Get returns the first value associated with the passed key.
+//
+// NOTE: this is part of the propagation.TextMapCarrier interface.
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Set stores the key-value pair.
+//
+// NOTE: this is part of the propagation.TextMapCarrier interface.
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+// Keys lists the keys stored in this carrier.
+//
+// NOTE: this is part of the propagation.TextMapCarrier interface.
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// tracedServerStream wraps a grpc.ServerStream so that its context carries
+// the span of the request.
+type tracedServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context of the stream, including the request's span.
+//
+// NOTE: this is part of the grpc.ServerStream interface.
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// startSpan starts a new span for the request with the given URI. If the
+// client sent a trace context along with the request, the span is created as
+// a child of the remote span.
+func (p *rpcProxy) startSpan(ctx context.Context,
+	requestURI string) (context.Context, trace.Span) {
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(
+			ctx, metadataCarrier(md),
+		)
+	}
+
+	daemon, err := p.subSystemForURI(requestURI)
+	if err != nil {
+		daemon = "unknown"
+	}
+
+	return otel.Tracer(tracerName).Start(
+		ctx, requestURI, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.method", requestURI),
+			attribute.String("lit.daemon", daemon),
+		),
+	)
+}
+
+// endSpan records the gRPC status of the request and ends the span.
+func endSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(
+		attribute.String("rpc.grpc.status_code", code.String()),
+	)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// tracingUnaryInterceptor is a gRPC interceptor that creates a span for each
+// unary request handled by the proxy.
+func (p *rpcProxy) tracingUnaryInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	ctx, span := p.startSpan(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	endSpan(span, err)
+
+	return resp, err
+}
+
+// tracingStreamInterceptor is a gRPC interceptor that creates a span for each
+// streaming request handled by the proxy. This includes all requests that are
+// forwarded to a backend daemon by the director.
+func (p *rpcProxy) tracingStreamInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	ctx, span := p.startSpan(ss.Context(), info.FullMethod)
+	err := handler(srv, &tracedServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+	endSpan(span, err)
+
+	return err
+}
+
+// recordAuthDecision adds the outcome of a request's authentication as an
+// event to the request's span. If the request was authenticated, the name of
+// the backend that accepted it is recorded, otherwise the reason it was
+// rejected for. Nothing is done if the request isn't traced.
+func recordAuthDecision(ctx context.Context, backend, failureReason string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.Bool("lit.auth.allowed", failureReason == ""),
+	}
+	if backend != "" {
+		attrs = append(
+			attrs, attribute.String("lit.auth.backend", backend),
+		)
+	}
+	if failureReason != "" {
+		attrs = append(
+			attrs, attribute.String(
+				"lit.auth.reason", failureReason,
+			),
+		)
+	}
+
+	span.AddEvent("authentication", trace.WithAttributes(attrs...))
+}
+
+// injectTraceContext adds the trace context of the span in the given context
+// to the outgoing metadata so the backend daemon can continue the trace.
+func injectTraceContext(ctx context.Context, md metadata.MD) {
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+}
+
+// isTracingEnabled returns true if spans should be exported to an
+// OpenTelemetry collector.
+func (c *Config) isTracingEnabled() bool {
+	return c.OtelEndpoint != ""
+}
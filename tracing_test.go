@@ -0,0 +1,50 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestRecordAuthDecision tests that the outcome of the authentication is added
+// to the span of a traced request.
+func TestRecordAuthDecision(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+	)
+
+	// Requests that aren't traced are left alone.
+	recordAuthDecision(context.Background(), AuthBackendMacaroon, "")
+
+	ctx, span := provider.Tracer(tracerName).Start(
+		context.Background(), "/looprpc.SwapClient/ListSwaps",
+	)
+	recordAuthDecision(ctx, "", authFailureBadMacaroon)
+	recordAuthDecision(ctx, AuthBackendMacaroon, "")
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	require.Equal(t, "authentication", events[0].Name)
+	require.Equal(t, []attribute.KeyValue{
+		attribute.Bool("lit.auth.allowed", false),
+		attribute.String("lit.auth.reason", authFailureBadMacaroon),
+	}, events[0].Attributes)
+	require.Equal(t, []attribute.KeyValue{
+		attribute.Bool("lit.auth.allowed", true),
+		attribute.String("lit.auth.backend", AuthBackendMacaroon),
+	}, events[1].Attributes)
+
+	// The trace context of REST requests is passed on to the gRPC server.
+	key, ok := restHeaderMatcher("Traceparent")
+	require.True(t, ok)
+	require.Equal(t, "traceparent", key)
+}
@@ -0,0 +1,132 @@
+package terminal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// assetFile is an embedded UI asset that reports the given modification time.
+// The files of the embedded file system don't have one, so without it no
+// Last-Modified header would be sent and If-Modified-Since would be ignored.
+type assetFile struct {
+	http.File
+
+	modTime time.Time
+}
+
+// Stat returns the file info of the asset with the replaced modification time.
+//
+// NOTE: this is part of the http.File interface.
+func (f *assetFile) Stat() (fs.FileInfo, error) {
+	info, err := f.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return &assetFileInfo{FileInfo: info, modTime: f.modTime}, nil
+}
+
+// assetFileInfo is the file info of an embedded UI asset with a replaced
+// modification time.
+type assetFileInfo struct {
+	fs.FileInfo
+
+	modTime time.Time
+}
+
+// ModTime returns the replaced modification time.
+//
+// NOTE: this is part of the fs.FileInfo interface.
+func (i *assetFileInfo) ModTime() time.Time {
+	return i.modTime
+}
+
+// assetETags computes and caches the ETags of the embedded UI assets. Since
+// the assets can't change while LiT is running, each one is only hashed once.
+type assetETags struct {
+	routes *ClientRouteWrapper
+
+	etags map[string]string
+	mu    sync.Mutex
+}
+
+// etag returns the ETag of the asset that is served for the given path. False
+// is returned if the path isn't a cacheable asset, which is the case for the
+// index page and the client side routes. Their content is the entry point of
+// the UI, so it must always be fetched fresh.
+func (e *assetETags) etag(name string) (string, bool) {
+	if e.routes.servesIndex(name) {
+		return "", false
+	}
+
+	localName := toLocalName(name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if etag, ok := e.etags[localName]; ok {
+		return etag, etag != ""
+	}
+
+	etag, err := e.hash(localName)
+	if err != nil {
+		return "", false
+	}
+	e.etags[localName] = etag
+
+	return etag, etag != ""
+}
+
+// hash returns the ETag of the asset with the given name, or an empty string
+// if the name is a directory.
+func (e *assetETags) hash(localName string) (string, error) {
+	f, err := e.routes.assets.Open(localName)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", nil
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	// The ETag is weak since the same content can be sent with different
+	// content encodings.
+	return fmt.Sprintf(`W/"%s"`, hex.EncodeToString(h.Sum(nil)[:16])),
+		nil
+}
+
+// withAssetETags returns a handler that adds an ETag, computed from the
+// content, to the responses for the embedded UI assets. The file server then
+// answers requests with a matching If-None-Match header with 304.
+func withAssetETags(next http.Handler,
+	routes *ClientRouteWrapper) http.Handler {
+
+	etags := &assetETags{
+		routes: routes,
+		etags:  make(map[string]string),
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if etag, ok := etags.etag(r.URL.Path); ok {
+			w.Header().Set("ETag", etag)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssetCaching tests that the UI assets are served with validators and
+// conditional requests for them are answered with 304, while the index page
+// is always served fresh.
+func TestAssetCaching(t *testing.T) {
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	routes := &ClientRouteWrapper{
+		assets: http.FS(fstest.MapFS{
+			"index.html": {Data: []byte("<html></html>")},
+			"static/js/main.js": {
+				Data: []byte("console.log('lit')"),
+			},
+		}),
+		fallbackPrefixes: []string{"/loop"},
+		modTime:          modTime,
+	}
+	handler := withAssetETags(http.FileServer(routes), routes)
+
+	serve := func(path string, header http.Header) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Result()
+	}
+
+	// An asset is served with an ETag and its modification time.
+	resp := serve("/static/js/main.js", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	require.Equal(
+		t, modTime.Format(http.TimeFormat),
+		resp.Header.Get("Last-Modified"),
+	)
+
+	// Both validators lead to a 304 if the asset didn't change.
+	resp = serve("/static/js/main.js", http.Header{
+		"If-None-Match": {etag},
+	})
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp = serve("/static/js/main.js", http.Header{
+		"If-Modified-Since": {modTime.Format(http.TimeFormat)},
+	})
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// A different ETag means the asset changed.
+	resp = serve("/static/js/main.js", http.Header{
+		"If-None-Match": {`W/"other"`},
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The index page and the client side routes never get validators and
+	// are always served in full.
+	for _, path := range []string{"/", "/loop"} {
+		resp = serve(path, http.Header{
+			"If-None-Match":     {etag},
+			"If-Modified-Since": {modTime.Format(http.TimeFormat)},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("ETag"))
+		require.Empty(t, resp.Header.Get("Last-Modified"))
+	}
+}
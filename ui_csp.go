@@ -0,0 +1,105 @@
+package terminal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// cspNoncePlaceholder is the placeholder in the configured
+	// Content-Security-Policy that is replaced with the nonce of each
+	// response.
+	cspNoncePlaceholder = "{nonce}"
+
+	// defaultUICSPPolicy is the default Content-Security-Policy of the UI.
+	// It only allows the scripts served by LiT itself and the ones that
+	// carry the nonce of the response.
+	defaultUICSPPolicy = "script-src 'self' 'nonce-" + cspNoncePlaceholder +
+		"'; object-src 'none'; base-uri 'self'"
+
+	// cspNonceLen is the number of random bytes of a nonce.
+	cspNonceLen = 16
+)
+
+// newCSPNonce returns a new random, base64 encoded nonce.
+func newCSPNonce() (string, error) {
+	var nonce [cspNonceLen]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(nonce[:]), nil
+}
+
+// injectCSPNonce adds the given nonce to all script and style tags of the
+// given HTML document.
+func injectCSPNonce(html []byte, nonce string) []byte {
+	attr := []byte(` nonce="` + nonce + `"`)
+	for _, tag := range []string{"<script", "<style"} {
+		html = bytes.ReplaceAll(
+			html, []byte(tag), append([]byte(tag), attr...),
+		)
+	}
+
+	return html
+}
+
+// readIndex returns the content of the UI's index.html.
+func (i *ClientRouteWrapper) readIndex() ([]byte, error) {
+	f, err := i.assets.Open("/index.html")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// withCSPNonce returns a handler that serves index.html, both for requests of
+// the index itself and for client side routes, with a fresh nonce that is
+// added to its script and style tags and to the Content-Security-Policy
+// header built from the given policy. All other requests are passed to the
+// next handler.
+func withCSPNonce(next http.Handler, routes *ClientRouteWrapper,
+	policy string) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !routes.servesIndex(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// If there is no index.html, we let the file server answer
+		// with the usual 404.
+		index, err := routes.readIndex()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		nonce, err := newCSPNonce()
+		if err != nil {
+			log.Errorf("Unable to create CSP nonce: %v", err)
+			code := http.StatusInternalServerError
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		// The nonce must be different for every response, so the
+		// response must never be cached.
+		header := w.Header()
+		header.Set(
+			"Content-Security-Policy",
+			strings.ReplaceAll(policy, cspNoncePlaceholder, nonce),
+		)
+		header.Set("Content-Type", "text/html; charset=utf-8")
+		header.Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = w.Write(injectCSPNonce(index, nonce))
+	})
+}
@@ -0,0 +1,190 @@
+package terminal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// uiPasswordHashKey is the key under which the verified UI password is
+// remembered by the password hash verifier.
+const uiPasswordHashKey = "uipassword"
+
+// validateUIPassword makes sure the UI password meets the configured strength
+// requirements. The error lists all requirements that aren't met, so the
+// operator doesn't have to find them out one by one.
+func (c *Config) validateUIPassword() error {
+	if c.UIPasswordMinLength < uiPasswordMinLength {
+		return fmt.Errorf("the minimum UI password length can't be "+
+			"lower than %d", uiPasswordMinLength)
+	}
+
+	var (
+		hasUpper, hasLower, hasDigit, hasSymbol bool
+		length                                  int
+	)
+	for _, r := range c.UIPassword {
+		length++
+
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+
+		case unicode.IsLower(r):
+			hasLower = true
+
+		case unicode.IsDigit(r):
+			hasDigit = true
+
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+
+	var missing []string
+	if length < c.UIPasswordMinLength {
+		missing = append(missing, fmt.Sprintf("be at least %d "+
+			"characters long", c.UIPasswordMinLength))
+	}
+	if c.UIPasswordRequireMixedCase && !(hasUpper && hasLower) {
+		missing = append(
+			missing, "contain upper and lower case letters",
+		)
+	}
+	if c.UIPasswordRequireDigit && !hasDigit {
+		missing = append(missing, "contain a digit")
+	}
+	if c.UIPasswordRequireSymbol && !hasSymbol {
+		missing = append(missing, "contain a symbol")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("please set a strong password for the UI, "+
+			"it must %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// passwordHashVerifier checks passwords against bcrypt hashes. Since bcrypt is
+// deliberately slow and the password is sent with every request, the digest
+// of the last password that was verified for each key is remembered, so the
+// hash only needs to be computed again once a different password is sent.
+// The digest is an HMAC under a random key that only lives in memory, so it
+// can't be used to brute force the password any faster than the bcrypt hash.
+type passwordHashVerifier struct {
+	digestKey [32]byte
+	verified  map[string][sha256.Size]byte
+	mu        sync.Mutex
+}
+
+// newPasswordHashVerifier creates a new, empty passwordHashVerifier with a
+// random digest key.
+func newPasswordHashVerifier() (*passwordHashVerifier, error) {
+	v := &passwordHashVerifier{
+		verified: make(map[string][sha256.Size]byte),
+	}
+	if _, err := rand.Read(v.digestKey[:]); err != nil {
+		return nil, fmt.Errorf("unable to create password digest "+
+			"key: %v", err)
+	}
+
+	return v, nil
+}
+
+// digest returns the HMAC of the given password under the digest key.
+func (v *passwordHashVerifier) digest(password string) [sha256.Size]byte {
+	mac := hmac.New(sha256.New, v.digestKey[:])
+	_, _ = mac.Write([]byte(password))
+
+	var digest [sha256.Size]byte
+	copy(digest[:], mac.Sum(nil))
+
+	return digest
+}
+
+// verify returns true if the given password matches the given bcrypt hash.
+// The key identifies the hash, for example the name of its user.
+func (v *passwordHashVerifier) verify(key string, hash []byte,
+	password string) bool {
+
+	digest := v.digest(password)
+
+	v.mu.Lock()
+	last, ok := v.verified[key]
+	v.mu.Unlock()
+
+	if ok && subtle.ConstantTimeCompare(last[:], digest[:]) == 1 {
+		return true
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil {
+		return false
+	}
+
+	v.mu.Lock()
+	v.verified[key] = digest
+	v.mu.Unlock()
+
+	return true
+}
+
+// decodeBasicAuth decodes the given base64 encoded basic auth credential into
+// its user name and password.
+func decodeBasicAuth(basicAuth string) (string, string, bool) {
+	credential, err := base64.StdEncoding.DecodeString(basicAuth)
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(credential), ":")
+}
+
+// isUIPasswordBasicAuth returns true if the given base64 encoded basic auth
+// credential carries the UI password. Because there is only a password, the
+// UI sends it as both the user name and the password.
+func (p *rpcProxy) isUIPasswordBasicAuth(basicAuth string) bool {
+	if p.basicAuth != "" {
+		return subtle.ConstantTimeCompare(
+			[]byte(basicAuth), []byte(p.basicAuth),
+		) == 1
+	}
+
+	name, password, ok := decodeBasicAuth(basicAuth)
+	if !ok || name != password {
+		return false
+	}
+
+	return p.isUIPassword(password)
+}
+
+// isUIPassword returns true if the given password is the UI password. If only
+// the hash of the UI password is configured, the password is checked against
+// the hash.
+func (p *rpcProxy) isUIPassword(password string) bool {
+	if p.basicAuth != "" {
+		basicAuth := base64.StdEncoding.EncodeToString([]byte(
+			fmt.Sprintf("%s:%s", password, password),
+		))
+
+		return subtle.ConstantTimeCompare(
+			[]byte(basicAuth), []byte(p.basicAuth),
+		) == 1
+	}
+
+	if len(p.cfg.UIPasswordHash) == 0 {
+		return false
+	}
+
+	return p.uiPasswordHashes.verify(
+		uiPasswordHashKey, []byte(p.cfg.UIPasswordHash), password,
+	)
+}
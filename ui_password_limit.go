@@ -0,0 +1,178 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPasswordMaxFailures is the default number of consecutive
+	// failed UI password attempts of a client after which the client is
+	// locked out.
+	defaultPasswordMaxFailures = 10
+
+	// defaultPasswordFailureWindow is the default time window in which the
+	// failed UI password attempts of a client are counted.
+	defaultPasswordFailureWindow = 10 * time.Minute
+
+	// defaultPasswordLockout is the default duration for which a client
+	// is locked out of UI password authentication.
+	defaultPasswordLockout = 15 * time.Minute
+)
+
+// UIPasswordLimitConfig holds the options for throttling the UI password
+// attempts of each client IP.
+type UIPasswordLimitConfig struct {
+	MaxFailures uint32        `long:"maxfailures" description:"The number of consecutive failed UI password attempts from the same client IP within the window after which further password attempts from that IP are rejected with RESOURCE_EXHAUSTED until the lockout ends. Requests authenticated with a macaroon are not affected. Set to 0 to disable the limit."`
+	Window      time.Duration `long:"window" description:"The time window in which the failed UI password attempts of a client IP are counted."`
+	Lockout     time.Duration `long:"lockout" description:"The duration for which a client IP is locked out of UI password authentication once it reached the maximum number of failures."`
+}
+
+// validate checks the UI password limit options.
+func (c *UIPasswordLimitConfig) validate() error {
+	if c.MaxFailures == 0 {
+		return nil
+	}
+
+	if c.Window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+
+	if c.Lockout <= 0 {
+		return fmt.Errorf("lockout must be positive")
+	}
+
+	return nil
+}
+
+// passwordAttempts holds the recent failed UI password attempts of a client.
+type passwordAttempts struct {
+	failures    []time.Time
+	lockedUntil time.Time
+}
+
+// stale returns true if the attempts no longer have any effect at the given
+// time, so they can be forgotten.
+func (a *passwordAttempts) stale(now time.Time, window time.Duration) bool {
+	if now.Before(a.lockedUntil) {
+		return false
+	}
+
+	for _, ts := range a.failures {
+		if now.Sub(ts) < window {
+			return false
+		}
+	}
+
+	return true
+}
+
+// passwordLimiter keeps track of the failed UI password attempts of each
+// client IP and locks out clients that fail too often. A single limiter is
+// shared by all the ways the password can be sent, so gRPC, gRPC web and REST
+// requests all count towards the same limit.
+type passwordLimiter struct {
+	cfg *UIPasswordLimitConfig
+
+	clients map[string]*passwordAttempts
+	mu      sync.Mutex
+}
+
+// newPasswordLimiter creates a new passwordLimiter with the given options.
+func newPasswordLimiter(cfg *UIPasswordLimitConfig) *passwordLimiter {
+	return &passwordLimiter{
+		cfg:     cfg,
+		clients: make(map[string]*passwordAttempts),
+	}
+}
+
+// check returns an error with the RESOURCE_EXHAUSTED code if the given client
+// is currently locked out of password authentication.
+func (l *passwordLimiter) check(client string, now time.Time) error {
+	if l.cfg.MaxFailures == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	attempts, ok := l.clients[client]
+	if !ok || !now.Before(attempts.lockedUntil) {
+		return nil
+	}
+
+	return status.Errorf(codes.ResourceExhausted, "too many failed "+
+		"password attempts, try again in %v",
+		attempts.lockedUntil.Sub(now).Round(time.Second))
+}
+
+// fail records a failed password attempt of the given client. If the client
+// reached the maximum number of failures within the window, it is locked out.
+func (l *passwordLimiter) fail(client string, now time.Time) {
+	if l.cfg.MaxFailures == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Forget the clients whose failures no longer matter, so the map
+	// doesn't grow with every client that ever failed once.
+	for c, attempts := range l.clients {
+		if attempts.stale(now, l.cfg.Window) {
+			delete(l.clients, c)
+		}
+	}
+
+	attempts, ok := l.clients[client]
+	if !ok {
+		attempts = &passwordAttempts{}
+		l.clients[client] = attempts
+	}
+
+	recent := attempts.failures[:0]
+	for _, ts := range attempts.failures {
+		if now.Sub(ts) < l.cfg.Window {
+			recent = append(recent, ts)
+		}
+	}
+	attempts.failures = append(recent, now)
+
+	if len(attempts.failures) < int(l.cfg.MaxFailures) {
+		return
+	}
+
+	attempts.failures = nil
+	attempts.lockedUntil = now.Add(l.cfg.Lockout)
+
+	log.Warnf("Client %s locked out of UI password authentication for "+
+		"%v after %d failed attempts", client, l.cfg.Lockout,
+		l.cfg.MaxFailures)
+}
+
+// succeed resets the failed password attempts of the given client, since
+// only consecutive failures count towards the limit.
+func (l *passwordLimiter) succeed(client string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.clients, client)
+}
+
+// passwordClient returns the key under which the password attempts of the
+// client of the request in the given context are counted. False is returned
+// if the client's IP can't be determined, in which case the attempts aren't
+// limited.
+func (p *rpcProxy) passwordClient(ctx context.Context) (string, bool) {
+	ip, err := p.clientAddrs.clientIP(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	return ip.String(), true
+}
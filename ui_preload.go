@@ -0,0 +1,85 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// assetManifestName is the name of the manifest that the UI build
+	// writes next to index.html.
+	assetManifestName = "/asset-manifest.json"
+)
+
+// assetManifest is the part of the asset manifest of the UI build that lists
+// the chunks the index page loads initially.
+type assetManifest struct {
+	Entrypoints []string `json:"entrypoints"`
+}
+
+// preloadLink returns the value of the Link header that tells browsers to
+// preload the entry point chunks of the UI listed in the asset manifest. An
+// empty string is returned if the manifest lists no chunks that can be
+// preloaded. The URLs of the chunks are relative to the given path prefix.
+func preloadLink(assets http.FileSystem, prefix string) (string, error) {
+	f, err := assets.Open(assetManifestName)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	manifestBytes, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+
+	var manifest assetManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return "", fmt.Errorf("unable to parse asset manifest: %v", err)
+	}
+
+	if prefix == "" {
+		prefix = "/"
+	}
+
+	var links []string
+	for _, entry := range manifest.Entrypoints {
+		var as string
+		switch path.Ext(entry) {
+		case ".js":
+			as = "script"
+		case ".css":
+			as = "style"
+		default:
+			continue
+		}
+
+		links = append(links, fmt.Sprintf("<%s>; rel=preload; as=%s",
+			path.Join(prefix, entry), as))
+	}
+
+	return strings.Join(links, ", "), nil
+}
+
+// withPreloadHints returns a handler that adds the given Link header to the
+// responses that serve the index page, so browsers can start fetching the
+// entry point chunks before they parsed the page.
+func withPreloadHints(next http.Handler, routes *ClientRouteWrapper,
+	link string) http.Handler {
+
+	if link == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if routes.servesIndex(r.URL.Path) {
+			w.Header().Add("Link", link)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
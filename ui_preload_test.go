@@ -0,0 +1,58 @@
+package terminal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreloadHints tests that the entry point chunks of the asset manifest are
+// announced with a Link header on the index page only.
+func TestPreloadHints(t *testing.T) {
+	routes := &ClientRouteWrapper{
+		assets: http.FS(fstest.MapFS{
+			"index.html": {Data: []byte("<html></html>")},
+			"asset-manifest.json": {Data: []byte(`{
+				"files": {"main.js": "/static/js/main.1.js"},
+				"entrypoints": [
+					"static/css/main.2.css",
+					"static/js/main.1.js",
+					"static/media/logo.svg"
+				]
+			}`)},
+			"static/js/main.1.js": {Data: []byte("")},
+		}),
+	}
+
+	link, err := preloadLink(routes.assets, "")
+	require.NoError(t, err)
+	require.Equal(
+		t, "</static/css/main.2.css>; rel=preload; as=style, "+
+			"</static/js/main.1.js>; rel=preload; as=script", link,
+	)
+
+	// With a path prefix, the chunks are announced under it.
+	prefixedLink, err := preloadLink(routes.assets, "/lightning")
+	require.NoError(t, err)
+	require.Equal(
+		t, "</lightning/static/css/main.2.css>; rel=preload; "+
+			"as=style, </lightning/static/js/main.1.js>; "+
+			"rel=preload; as=script", prefixedLink,
+	)
+
+	handler := withPreloadHints(http.FileServer(routes), routes, link)
+	for path, expected := range map[string]string{
+		"/":                    link,
+		"/loop":                link,
+		"/static/js/main.1.js": "",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, expected, rec.Result().Header.Get("Link"))
+	}
+}
@@ -0,0 +1,211 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// UIUserScopeAdmin grants a UI user the same full access as the UI
+	// password.
+	UIUserScopeAdmin = "admin"
+
+	// UIUserScopeReadOnly only grants a UI user the read permissions of
+	// all active daemons.
+	UIUserScopeReadOnly = "readonly"
+)
+
+// uiUser is a named UI user that authenticates with its own password.
+type uiUser struct {
+	name  string
+	scope string
+	hash  []byte
+}
+
+// parseUIUsers parses the configured UI users, each in the form
+// name:scope:hash.
+func parseUIUsers(users []string) (map[string]*uiUser, error) {
+	parsed := make(map[string]*uiUser, len(users))
+	for _, user := range users {
+		parts := strings.SplitN(user, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid UI user %s, must be "+
+				"in the form name:scope:hash", user)
+		}
+		name, scope, hash := parts[0], parts[1], parts[2]
+
+		if name == "" {
+			return nil, fmt.Errorf("UI user name must not be empty")
+		}
+		if _, ok := parsed[name]; ok {
+			return nil, fmt.Errorf("duplicate UI user %s", name)
+		}
+
+		switch scope {
+		case UIUserScopeAdmin, UIUserScopeReadOnly:
+		default:
+			return nil, fmt.Errorf("unknown scope %s of UI user "+
+				"%s, must be %s or %s", scope, name,
+				UIUserScopeAdmin, UIUserScopeReadOnly)
+		}
+
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("invalid password hash of UI "+
+				"user %s, must be a bcrypt hash: %v", name, err)
+		}
+
+		parsed[name] = &uiUser{
+			name:  name,
+			scope: scope,
+			hash:  []byte(hash),
+		}
+	}
+
+	return parsed, nil
+}
+
+// uiUserVerifier checks the passwords of the named UI users.
+type uiUserVerifier struct {
+	users  map[string]*uiUser
+	hashes *passwordHashVerifier
+
+	// dummyHash is a bcrypt hash of a random password that the password
+	// of an unknown user is checked against, so that a request for an
+	// unknown user takes as long as one for a known user and doesn't give
+	// away which user names exist.
+	dummyHash []byte
+}
+
+// newUIUserVerifier creates a new verifier for the given users.
+func newUIUserVerifier(users map[string]*uiUser) (*uiUserVerifier, error) {
+	hashes, err := newPasswordHashVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	v := &uiUserVerifier{
+		users:  users,
+		hashes: hashes,
+	}
+	if len(users) == 0 {
+		return v, nil
+	}
+
+	// The dummy hash uses the highest cost of all users, so checking it
+	// takes at least as long as checking the password of a known user.
+	cost := bcrypt.MinCost
+	for _, user := range users {
+		userCost, err := bcrypt.Cost(user.hash)
+		if err != nil {
+			return nil, err
+		}
+		if userCost > cost {
+			cost = userCost
+		}
+	}
+
+	var password [32]byte
+	if _, err := rand.Read(password[:]); err != nil {
+		return nil, fmt.Errorf("unable to create dummy password: %v",
+			err)
+	}
+	v.dummyHash, err = bcrypt.GenerateFromPassword(password[:], cost)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create dummy password hash: "+
+			"%v", err)
+	}
+
+	return v, nil
+}
+
+// verify returns the UI user that the given base64 encoded basic auth
+// credential belongs to. False is returned if the credential doesn't name a
+// configured user or if its password is wrong.
+func (v *uiUserVerifier) verify(basicAuth string) (*uiUser, bool) {
+	if len(v.users) == 0 {
+		return nil, false
+	}
+
+	name, password, ok := decodeBasicAuth(basicAuth)
+	if !ok {
+		return nil, false
+	}
+
+	user, ok := v.users[name]
+	if !ok {
+		// Spend as much time on an unknown user as on a known one.
+		_ = bcrypt.CompareHashAndPassword(v.dummyHash, []byte(password))
+
+		return nil, false
+	}
+
+	if !v.hashes.verify(name, user.hash, password) {
+		return nil, false
+	}
+
+	return user, true
+}
+
+// uiUserMacaroon returns the macaroon that is attached to requests of the
+// given UI user. Admins get the same macaroon as the UI password, all other
+// users get a super macaroon that only contains the permissions of their
+// scope.
+func (p *rpcProxy) uiUserMacaroon(user *uiUser, requestURI string) ([]byte,
+	error) {
+
+	if user.scope == UIUserScopeAdmin {
+		return p.fullAccessMacaroon(requestURI)
+	}
+
+	return p.scopeMacaroon(user.scope, func() []bakery.Op {
+		return p.permsMgr.ActivePermissions(true)
+	})
+}
+
+// scopeMacaroon returns the super macaroon of the given scope. The macaroon is
+// baked with the permissions that perms returns the first time it is needed
+// and then cached until the super macaroon root key is rotated.
+func (p *rpcProxy) scopeMacaroon(scope string,
+	perms func() []bakery.Op) ([]byte, error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	p.scopeMacaroonsMtx.Lock()
+	defer p.scopeMacaroonsMtx.Unlock()
+
+	if mac, ok := p.scopeMacaroons[scope]; ok {
+		return mac, nil
+	}
+
+	superMac, err := p.bakeSuperMac(context.Background(), 0, perms(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bake %s macaroon: %v",
+			scope, err)
+	}
+
+	mac, err := hex.DecodeString(superMac)
+	if err != nil {
+		return nil, err
+	}
+
+	p.scopeMacaroons[scope] = mac
+
+	return mac, nil
+}
+
+// resetScopeMacaroons forgets the macaroons that were baked for the scopes of
+// the UI users and JWTs, so they are baked again with the current root key.
+func (p *rpcProxy) resetScopeMacaroons() {
+	p.scopeMacaroonsMtx.Lock()
+	defer p.scopeMacaroonsMtx.Unlock()
+
+	p.scopeMacaroons = make(map[string][]byte)
+}
@@ -0,0 +1,54 @@
+package terminal
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestUIUserVerifier tests that UI users are verified by their password, that
+// unknown users are rejected and that the cached password digest isn't a
+// plain hash of the password.
+func TestUIUserVerifier(t *testing.T) {
+	const password = "correct horse"
+	hash, err := bcrypt.GenerateFromPassword(
+		[]byte(password), bcrypt.MinCost,
+	)
+	require.NoError(t, err)
+
+	users, err := parseUIUsers([]string{
+		"alice:" + UIUserScopeReadOnly + ":" + string(hash),
+	})
+	require.NoError(t, err)
+
+	v, err := newUIUserVerifier(users)
+	require.NoError(t, err)
+	require.NotEmpty(t, v.dummyHash)
+
+	basicAuth := func(name, password string) string {
+		return base64.StdEncoding.EncodeToString(
+			[]byte(name + ":" + password),
+		)
+	}
+
+	_, ok := v.verify(basicAuth("bob", password))
+	require.False(t, ok)
+
+	_, ok = v.verify(basicAuth("alice", "wrong"))
+	require.False(t, ok)
+
+	user, ok := v.verify(basicAuth("alice", password))
+	require.True(t, ok)
+	require.Equal(t, "alice", user.name)
+
+	digest, ok := v.hashes.verified["alice"]
+	require.True(t, ok)
+	require.NotEqual(t, sha256.Sum256([]byte(password)), digest)
+
+	// The second check is answered from the cache.
+	_, ok = v.verify(basicAuth("alice", password))
+	require.True(t, ok)
+}
@@ -0,0 +1,140 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderWebhookSignature is the HTTP header field name that carries
+	// the hex encoded HMAC-SHA256 signature of a webhook event.
+	HeaderWebhookSignature = "X-Lit-Signature"
+
+	// HeaderWebhookTimestamp is the HTTP header field name that carries
+	// the unix timestamp at which a webhook event was signed.
+	HeaderWebhookTimestamp = "X-Lit-Timestamp"
+
+	// webhookEventTest is the type of the synthetic event that is sent
+	// to test a webhook endpoint.
+	webhookEventTest = "test"
+
+	// webhookEventSessionExpiring is the type of the event that is sent
+	// when a session's macaroon is about to expire.
+	webhookEventSessionExpiring = "session_expiring"
+
+	// defaultWebhookTimeout is the default time we wait for a webhook
+	// endpoint to respond.
+	defaultWebhookTimeout = 10 * time.Second
+
+	// maxWebhookTimeout is the maximum time we wait for a webhook endpoint
+	// to respond.
+	maxWebhookTimeout = time.Minute
+)
+
+// webhookEvent is the JSON body of an event that is sent to a webhook.
+type webhookEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// sessionExpiringData is the data of a session expiring webhook event.
+type sessionExpiringData struct {
+	LocalPublicKey string `json:"local_public_key"`
+	Label          string `json:"label"`
+	Expiry         int64  `json:"expiry"`
+}
+
+// newWebhookEvent creates a new webhook event of the given type with a random
+// ID.
+func newWebhookEvent(eventType string, data interface{}) (*webhookEvent,
+	error) {
+
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+
+	return &webhookEvent{
+		ID:        hex.EncodeToString(id[:]),
+		Type:      eventType,
+		Timestamp: time.Now().Unix(),
+		Data:      data,
+	}, nil
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 signature of the
+// given timestamp and payload. The timestamp is included in the signature so
+// receivers can reject replayed events.
+func signWebhookPayload(secret []byte, timestamp string,
+	payload []byte) string {
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendWebhookEvent signs the given event with the secret and POSTs it to the
+// webhook URL. The HTTP status code of the response and the round trip time
+// are returned.
+func sendWebhookEvent(ctx context.Context, webhookURL string, secret []byte,
+	event *webhookEvent) (int, time.Duration, error) {
+
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid webhook URL: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return 0, 0, fmt.Errorf("invalid webhook URL %s, must be an "+
+			"absolute http or https URL", webhookURL)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, u.String(), bytes.NewReader(payload),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	timestamp := strconv.FormatInt(event.Timestamp, 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(
+		HeaderWebhookSignature,
+		signWebhookPayload(secret, timestamp, payload),
+	)
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error sending webhook event: %v", err)
+	}
+	rtt := time.Since(start)
+
+	// We don't care about the response body, but we drain (a bounded part
+	// of) it so the connection can be re-used.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, rtt, nil
+}
@@ -0,0 +1,181 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// whoAmIURI is the URI of the WhoAmI method.
+	whoAmIURI = "/litrpc.Proxy/WhoAmI"
+)
+
+// WhoAmI returns what the super macaroon of the call is allowed to do. The
+// method is whitelisted, so the macaroon is verified here against the
+// permissions it carries itself, which any valid macaroon passes.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) WhoAmI(ctx context.Context, _ *litrpc.WhoAmIRequest) (
+	*litrpc.WhoAmIResponse, error) {
+
+	if !p.hasStarted() {
+		return nil, ErrWaitingToStart
+	}
+
+	mac, err := macaroonFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to "+
+			"decode macaroon: %v", err)
+	}
+	if mac == nil {
+		return nil, status.Error(
+			codes.Unauthenticated, "expected 1 macaroon, got 0",
+		)
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsSuperMacaroon(hex.EncodeToString(macBytes)) {
+		return nil, status.Error(codes.InvalidArgument, "only super "+
+			"macaroons are supported")
+	}
+
+	ops, err := macaroonOps(mac)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to "+
+			"read macaroon permissions: %v", err)
+	}
+	if len(ops) == 0 {
+		return nil, status.Error(
+			codes.PermissionDenied, "macaroon has no permissions",
+		)
+	}
+
+	err = p.superMacValidator(ctx, macBytes, ops, whoAmIURI)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "invalid "+
+			"macaroon: %v", err)
+	}
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := macaroonExpiry(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.WhoAmIResponse{
+		RootKeyId:      rootKeyID,
+		Permissions:    make([]*litrpc.MacaroonPermission, len(ops)),
+		AllowedMethods: p.allowedMethods(ctx, ops),
+	}
+	for idx, op := range ops {
+		resp.Permissions[idx] = &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		}
+	}
+	for _, caveat := range mac.Caveats() {
+		if caveat.VerificationId == nil {
+			resp.Caveats = append(resp.Caveats, string(caveat.Id))
+		}
+	}
+	if !expiry.IsZero() {
+		resp.Expiry = expiry.Unix()
+	}
+
+	// Super macaroons that were baked by the user directly aren't bound
+	// to a session.
+	if sess, ok := p.sessionFromContext(ctx); ok {
+		resp.SessionLabel = sess.Label
+		if sess.LocalPublicKey != nil {
+			resp.SessionLocalPublicKey =
+				sess.LocalPublicKey.SerializeCompressed()
+		}
+	}
+
+	return resp, nil
+}
+
+// allowedMethods returns the URIs of all methods of the active daemons that
+// can be called with the given permissions, sorted by the URI. Methods that
+// are outside of the scope of the session macaroon in the given context are
+// left out, as are the methods that don't require a macaroon at all.
+func (p *rpcProxy) allowedMethods(ctx context.Context,
+	ops []bakery.Op) []string {
+
+	var allowed []string
+	for _, uri := range p.permsMgr.URIs() {
+		if p.permsMgr.IsWhiteListedURL(uri) {
+			continue
+		}
+
+		_, disabled := p.permsMgr.DisabledLndSubServer(uri)
+		if disabled {
+			continue
+		}
+
+		required, ok := p.permsMgr.URIPermissions(uri)
+		if !ok {
+			continue
+		}
+
+		missing, byURI := missingPermissions(ops, required, uri)
+		if !byURI && len(missing) > 0 {
+			continue
+		}
+
+		if p.checkSessionScope(ctx, uri) != nil {
+			continue
+		}
+
+		allowed = append(allowed, uri)
+	}
+	sort.Strings(allowed)
+
+	return allowed
+}
+
+// macaroonExpiry returns the earliest time-before caveat of the given
+// macaroon. The zero time is returned if the macaroon doesn't expire.
+func macaroonExpiry(mac *macaroon.Macaroon) (time.Time, error) {
+	var expiry time.Time
+	for _, caveat := range mac.Caveats() {
+		if caveat.VerificationId != nil {
+			continue
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil || cond != checkers.CondTimeBefore {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid caveat %q: %v",
+				string(caveat.Id), err)
+		}
+
+		if expiry.IsZero() || t.Before(expiry) {
+			expiry = t
+		}
+	}
+
+	return expiry, nil
+}
@@ -0,0 +1,76 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/subservers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestWhoAmIHelpers tests that the expiry and the callable methods of a
+// macaroon are derived from its caveats and permissions.
+func TestWhoAmIHelpers(t *testing.T) {
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+
+	// A macaroon without a time-before caveat doesn't expire.
+	expiry, err := macaroonExpiry(mac)
+	require.NoError(t, err)
+	require.True(t, expiry.IsZero())
+
+	// The earliest of multiple time-before caveats is the expiry.
+	early := time.Unix(1_700_000_000, 0).UTC()
+	for _, ts := range []time.Time{early.Add(time.Hour), early} {
+		caveat := checkers.TimeBeforeCaveat(ts).Condition
+		require.NoError(t, mac.AddFirstPartyCaveat([]byte(caveat)))
+	}
+	expiry, err = macaroonExpiry(mac)
+	require.NoError(t, err)
+	require.True(t, early.Equal(expiry))
+
+	permsMgr, err := perms.NewManager(false)
+	require.NoError(t, err)
+
+	permsMgr.RegisterSubServer(subservers.LOOP, map[string][]bakery.Op{
+		"/looprpc.SwapClient/LoopOut": {{
+			Entity: "swap", Action: "execute",
+		}},
+	}, nil)
+	p := &rpcProxy{permsMgr: permsMgr}
+
+	// Methods can be allowed by their permissions or by their URI.
+	ops := []bakery.Op{
+		{Entity: "swap", Action: "execute"},
+		{Entity: "uri", Action: "/lnrpc.Lightning/GetInfo"},
+	}
+	require.Equal(t, []string{
+		"/lnrpc.Lightning/GetInfo", "/looprpc.SwapClient/LoopOut",
+	}, p.allowedMethods(context.Background(), ops))
+
+	// Methods outside of the scope of a session macaroon are left out.
+	require.NoError(t, mac.AddFirstPartyCaveat(
+		[]byte(firewall.ScopeToCaveat([]string{"loop"})),
+	))
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(), metadata.Pairs(
+			HeaderMacaroon, hex.EncodeToString(macBytes),
+		),
+	)
+	require.Equal(t, []string{
+		"/looprpc.SwapClient/LoopOut",
+	}, p.allowedMethods(ctx, ops))
+}